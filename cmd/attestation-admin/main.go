@@ -0,0 +1,212 @@
+// Command attestation-admin runs maintenance tasks of the attestation API with its settings, calling the
+// attestation services directly instead of the API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/app"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/backfill"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/sqlitestore"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/server-garage/pkg/env"
+	"github.com/rs/zerolog"
+)
+
+const usage = `Usage: attestation-admin <command> [flags]
+
+Commands:
+  backfill  issue attestations for a token ID range or a CSV list of token IDs
+
+Run "attestation-admin <command> -h" for the flags of a command.
+`
+
+func main() {
+	logger := zerolog.New(os.Stderr).With().Timestamp().Str("app", "attestation-admin").Logger()
+	zerolog.DefaultContextLogger = &logger
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch os.Args[1] {
+	case "backfill":
+		err = runBackfill(ctx, &logger, os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		cancel()
+		logger.Fatal().Err(err).Msg("Command failed.")
+	}
+}
+
+// runBackfill issues the attestations of the backfill command. It fails when a vehicle failed, so that the
+// backfill can be resumed with the same checkpoint.
+func runBackfill(ctx context.Context, logger *zerolog.Logger, args []string) error {
+	flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+	settingsFile := flags.String("settings", ".env", "settings file")
+	attestationType := flags.String("type", "vin", "type of the attestations: "+strings.Join(app.BackfillTypes, ", "))
+	tokenRange := flags.String("tokens", "", "token ID range, e.g. 1-1000")
+	csvFile := flags.String("csv", "", "CSV file with the token IDs in the first column")
+	dryRun := flags.Bool("dry-run", false, "check whether the attestations would be issued without issuing them")
+	concurrency := flags.Int("concurrency", 4, "number of vehicles processed at once")
+	checkpointFile := flags.String("checkpoint", "", "file recording the results, vehicles already done are skipped when it exists")
+	outputFile := flags.String("output", "", "file the results are written to, stdout when empty")
+	format := flags.String("format", "csv", "format of the results: csv or json")
+	timestamp := flags.String("time", "", "RFC3339 time of vehicle-position and odometer-statement attestations, now when empty")
+	startTime := flags.String("start", "", "RFC3339 start time of vehicle-health and battery-health attestations")
+	endTime := flags.String("end", "", "RFC3339 end time of vehicle-health and battery-health attestations")
+	skipLedger := flags.Bool("skip-ledger", false, "do not record the attestations in the issuance ledger, e.g. when the ledger database is not reachable")
+	_ = flags.Parse(args)
+
+	if (*tokenRange == "") == (*csvFile == "") {
+		return errors.New("exactly one of -tokens and -csv is required")
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	opts := app.BackfillOptions{Type: *attestationType}
+	var err error
+	if opts.Time, err = parseTime("time", *timestamp); err != nil {
+		return err
+	}
+	if opts.StartTime, err = parseTime("start", *startTime); err != nil {
+		return err
+	}
+	if opts.EndTime, err = parseTime("end", *endTime); err != nil {
+		return err
+	}
+
+	tokenIDs, err := readTokenIDs(*tokenRange, *csvFile)
+	if err != nil {
+		return err
+	}
+
+	settings, err := env.LoadSettings[config.Settings](*settingsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	if settings.IssuanceLedger && !*dryRun {
+		if *skipLedger {
+			logger.Warn().Msg("Backfilled attestations are not recorded in the issuance ledger.")
+		} else {
+			store, err := openLedger(settings.LedgerDatabasePath)
+			if err != nil {
+				return err
+			}
+			defer store.Close() //nolint:errcheck
+			opts.LedgerStore = store
+		}
+	}
+	issuer, err := app.NewBackfillIssuer(logger, &settings, opts)
+	if err != nil {
+		return err
+	}
+
+	var checkpoint interface {
+		backfill.Checkpoint
+		Results() []backfill.Result
+	}
+	if *checkpointFile != "" {
+		fileCheckpoint, err := backfill.OpenFileCheckpoint(*checkpointFile)
+		if err != nil {
+			return err
+		}
+		defer fileCheckpoint.Close() //nolint:errcheck
+		checkpoint = fileCheckpoint
+	} else {
+		checkpoint = &backfill.MemoryCheckpoint{}
+	}
+
+	logger.Info().Str("type", opts.Type).Int("vehicles", len(tokenIDs)).Bool("dryRun", *dryRun).Msg("Starting backfill.")
+	summary, runErr := backfill.Run(ctx, logger, issuer, checkpoint, tokenIDs, backfill.Options{
+		Concurrency: *concurrency,
+		DryRun:      *dryRun,
+	})
+	logger.Info().Interface("summary", summary).Msg("Backfill finished.")
+
+	if err := writeResults(*outputFile, *format, checkpoint.Results()); err != nil {
+		return errors.Join(runErr, err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("backfill stopped: %w", runErr)
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("failed to issue %d attestations", summary.Failed)
+	}
+	return nil
+}
+
+// openLedger opens the database of the issuance ledger, which the API may be writing to at the same time.
+func openLedger(path string) (*sqlitestore.Store, error) {
+	if path == "" {
+		return nil, errors.New("LEDGER_DATABASE_PATH is required when ISSUANCE_LEDGER is set, or run with -skip-ledger")
+	}
+	store, err := sqlitestore.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the issuance ledger %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func parseTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -%s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func readTokenIDs(tokenRange, csvFile string) ([]uint32, error) {
+	if tokenRange != "" {
+		return backfill.ParseRange(tokenRange)
+	}
+	file, err := os.Open(csvFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+	return backfill.ReadTokenIDs(file)
+}
+
+func writeResults(path, format string, results []backfill.Result) (err error) {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		w = file
+	}
+	if format == "json" {
+		return backfill.WriteJSON(w, results)
+	}
+	return backfill.WriteCSV(w, results)
+}
@@ -58,16 +58,22 @@ func main() {
 	logger.Info().Str("port", strconv.Itoa(settings.MonPort)).Msgf("Starting monitoring server")
 	runner.RunHandler(runnerCtx, runnerGroup, monApp, ":"+strconv.Itoa(settings.MonPort))
 
-	webServer, rpcServer, err := app.CreateServers(&logger, &settings)
+	servers, err := app.CreateServers(&logger, &settings)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create servers.")
 	}
 
 	logger.Info().Str("port", strconv.Itoa(settings.Port)).Msgf("Starting web server")
-	runner.RunFiber(runnerCtx, runnerGroup, webServer, ":"+strconv.Itoa(settings.Port))
+	runner.RunFiber(runnerCtx, runnerGroup, servers.HTTP, ":"+strconv.Itoa(settings.Port))
 
 	logger.Info().Str("port", strconv.Itoa(settings.GRPCPort)).Msgf("Starting gRPC server")
-	runner.RunGRPC(runnerCtx, runnerGroup, rpcServer, ":"+strconv.Itoa(settings.GRPCPort))
+	runner.RunGRPC(runnerCtx, runnerGroup, servers.RPC, ":"+strconv.Itoa(settings.GRPCPort))
+
+	for _, worker := range servers.Workers {
+		runnerGroup.Go(func() error {
+			return worker(runnerCtx)
+		})
+	}
 
 	err = runnerGroup.Wait()
 	if err != nil && !errors.Is(err, context.Canceled) {
@@ -39,105 +39,316 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/attestation/odometer-statement/{tokenId}": {
-            "post": {
+        "/v2/admin/attestation/ledger": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a new Odometer Statement attestation for a given token Id. If timestamp is not provided, uses the latest odometer reading.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the attestations recorded in the issuance ledger, oldest first. Pass the next value of a page as the after parameter to get the following page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OdometerStatementVC"
+                    "Admin"
                 ],
-                "summary": "Create Odometer Statement Attestation",
+                "summary": "List Issued Attestations",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "token Id of the vehicle NFT",
+                        "description": "token id of the vehicle NFT",
                         "name": "tokenId",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
+                        "type": "string",
+                        "description": "data version of the attestations, e.g. vin/v1.0",
+                        "name": "dataVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "requester of the attestations, e.g. the dev license address",
+                        "name": "requester",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "uploaded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "upload status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time the attestations were recorded at or after",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time the attestations were recorded before",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last attestation of the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 100 by default and at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateOdometerStatementVCRequest"
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.LedgerPage"
                         }
                     }
+                }
+            }
+        },
+        "/v2/admin/attestation/ledger/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export every attestation in the issuance ledger matching the filters as CSV or Parquet, oldest first.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Export Issued Attestations",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "description": "export format, csv by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "token id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "data version of the attestations, e.g. vin/v1.0",
+                        "name": "dataVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "requester of the attestations, e.g. the dev license address",
+                        "name": "requester",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "uploaded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "upload status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time the attestations were recorded at or after",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time the attestations were recorded before",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/v2/attestation/vehicle-health/{tokenId}": {
-            "post": {
+        "/v2/admin/attestation/ledger/{eventId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a new Vehicle Health attestation for a given token Id and time range. The time range cannot exceed 30 days.",
-                "consumes": [
+                "description": "Get the issuance ledger entry of an attestation.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Issued Attestation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the attestation cloud event",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.LedgerEntry"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/recurring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the recurring attestation subscriptions, optionally of a vehicle or owner.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VehicleHealthVC"
+                    "Admin"
                 ],
-                "summary": "Create Vehicle Health Attestation",
+                "summary": "List Recurring Attestations",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "token Id of the vehicle NFT",
+                        "description": "token id of the vehicle NFT",
                         "name": "tokenId",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "owner of the subscriptions",
+                        "name": "owner",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue an attestation of a vehicle on every due date of a schedule. Requires an operator token with the admin role.\nAttestations from telemetry are read with a vehicle token obtained by the service. A failed attestation is retried 3 times, 15 minutes apart, before its due date is skipped.\nThe notify URL receives a signed models.RecurringFailure for every failure.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Recurring Attestation",
+                "parameters": [
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateVehicleHealthVCRequest"
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateRecurringRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
                         }
                     }
                 }
             }
         },
-        "/v2/attestation/vehicle-position/{tokenId}": {
-            "post": {
+        "/v2/admin/attestation/recurring/{recurringId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a new Vehicle Position attestation for a given token Id and timestamp.",
+                "description": "Get a recurring attestation subscription and the outcome of its last run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Recurring Attestation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the recurring subscription",
+                        "name": "recurringId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the interval, parameters and notify URL of a recurring attestation subscription. The next due date is kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -145,14 +356,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "VehiclePositionVC"
+                    "Admin"
                 ],
-                "summary": "Create Vehicle Position Attestation",
+                "summary": "Update Recurring Attestation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "token Id of the vehicle NFT",
-                        "name": "tokenId",
+                        "type": "string",
+                        "description": "id of the recurring subscription",
+                        "name": "recurringId",
                         "in": "path",
                         "required": true
                     },
@@ -162,7 +373,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateVehiclePositionVCRequest"
+                            "$ref": "#/definitions/internal_controllers_httphandlers.UpdateRecurringRequest"
                         }
                     }
                 ],
@@ -170,35 +381,58 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a recurring attestation subscription. Issued attestations are kept.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Recurring Attestation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the recurring subscription",
+                        "name": "recurringId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v2/attestation/vin/{tokenId}": {
+        "/v2/admin/attestation/recurring/{recurringId}/pause": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a new VIN attestation for a given token Id of a vehicle NFT.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stop issuing the attestations of a recurring subscription until it is resumed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VINVC"
+                    "Admin"
                 ],
-                "summary": "Create VIN Attestation",
+                "summary": "Pause Recurring Attestation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "token Id of the vehicle NFT",
-                        "name": "tokenId",
+                        "type": "string",
+                        "description": "id of the recurring subscription",
+                        "name": "recurringId",
                         "in": "path",
                         "required": true
                     }
@@ -207,38 +441,1860 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_httphandlers.getVCResponse"
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_controllers_httphandlers.CreateOdometerStatementVCRequest": {
-            "type": "object",
-            "properties": {
-                "timestamp": {
-                    "description": "Optional timestamp",
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+        },
+        "/v2/admin/attestation/recurring/{recurringId}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue the attestations of a paused recurring subscription again. Due dates that passed while it was paused are skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Resume Recurring Attestation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the recurring subscription",
+                        "name": "recurringId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription"
+                        }
+                    }
                 }
             }
         },
-        "internal_controllers_httphandlers.CreateVehicleHealthVCRequest": {
-            "type": "object",
-            "required": [
-                "endTime",
-                "startTime"
-            ],
-            "properties": {
-                "endTime": {
-                    "type": "string",
-                    "example": "2021-01-15T00:00:00Z"
-                },
-                "startTime": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+        "/v2/admin/attestation/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the attestation requests allowed and rejected by the rate limits for each dev license and attestation type on the UTC days of the last week. Usage is counted by each replica of the service, and survives restarts when DATABASE_PATH is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "dev license address of the requests, every client by default",
+                        "name": "client",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.Usage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/vin/renewals/latest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the progress and outcome of the latest scheduled renewal of the VIN attestations about to expire.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Latest VIN Renewal",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RenewalSummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/vin/requests/{requestId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending manual VIN attestation request of another operator and issue the attestation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Approve Manual VIN Attestation Request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the manual VIN request",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.manualVINResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/vin/requests/{requestId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending manual VIN attestation request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reject Manual VIN Attestation Request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the manual VIN request",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.RejectManualVINRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.ManualVINRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/vin/{tokenId}/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the manual VIN requests, approvals, rejections and issuances for a given token Id of a vehicle NFT, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Manual VIN Audit Log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.AuditEntry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/vin/{tokenId}/manual": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attest a VIN for a given token Id of a vehicle NFT without fingerprint evidence. Requires an operator token with the admin role.\nReturns 201 with the attestation, or 202 when the request must be approved by a second operator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Manual VIN Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateManualVINRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.manualVINResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.manualVINResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the webhook subscriptions without their secrets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Webhook Subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a partner webhook notified of each issued attestation matching the filters. Requires an operator token with the admin role.\nNotifications are signed with an HMAC-SHA256 keyed by the secret of the subscription, which is only returned by this call.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Webhook Subscription",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/webhooks/deliveries/{deliveryId}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the notification of a delivery to its webhook subscription again as a new delivery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replay Webhook Delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook delivery",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookDelivery"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/webhooks/{subscriptionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a webhook subscription without its secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Webhook Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook subscription",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook subscription. Its delivery log is kept until it expires.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Webhook Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook subscription",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v2/admin/attestation/webhooks/{subscriptionId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the notifications sent to a webhook subscription in the last 30 days and their outcome, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook subscription",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/battery-health/{tokenId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new EV battery state of health attestation for a given token Id and time range. The time range cannot exceed 90 days.\nThe attestation covers capacity, state of health, full charge range and DC fast charge share estimated from the charge sessions in the range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BatteryHealthVC"
+                ],
+                "summary": "Create Battery Health Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateBatteryHealthVCRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-async to create the attestation in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPS URL called with the job when an asynchronous request finishes",
+                        "name": "webhookUrl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/jobs/{jobId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the state of an asynchronous attestation job and the signed attestation once it succeeded.\nThe token must carry the privileges of the attestation on the vehicle of the job. Finished jobs are kept for 7 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get Attestation Job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job Id returned when the attestation was requested",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/odometer-statement/{tokenId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new Odometer Statement attestation for a given token Id. If timestamp is not provided, uses the latest odometer reading.\nMiles are converted from kilometers using the international mile (1 mile = 1.609344 km).\nThe us-federal disclosure profile requires an existing VIN attestation for the vehicle.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OdometerStatementVC"
+                ],
+                "summary": "Create Odometer Statement Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateOdometerStatementVCRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-async to create the attestation in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPS URL called with the job when an asynchronous request finishes",
+                        "name": "webhookUrl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/vehicle-health/{tokenId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new Vehicle Health attestation for a given token Id and time range. The time range cannot exceed 366 days; long ranges are evaluated at a coarser sample interval.\nThe optional ruleSet selects the health definition used for the score; its name and version are recorded in the attestation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VehicleHealthVC"
+                ],
+                "summary": "Create Vehicle Health Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateVehicleHealthVCRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-async to create the attestation in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPS URL called with the job when an asynchronous request finishes",
+                        "name": "webhookUrl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/vehicle-position/{tokenId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new Vehicle Position attestation for a given token Id and timestamp.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VehiclePositionVC"
+                ],
+                "summary": "Create Vehicle Position Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateVehiclePositionVCRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-async to create the attestation in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPS URL called with the job when an asynchronous request finishes",
+                        "name": "webhookUrl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.successResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/vin/{tokenId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new VIN attestation for a given token Id of a vehicle NFT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VINVC"
+                ],
+                "summary": "Create VIN Attestation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-async to create the attestation in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPS URL called with the job when an asynchronous request finishes",
+                        "name": "webhookUrl",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.getVCResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.JobStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/vin/{tokenId}/eligibility": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run the VIN attestation checks for a given token Id of a vehicle NFT without creating an attestation.\nReports the fingerprint messages of each paired device, the decoded VIN and the final decision with a reason code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VINVC"
+                ],
+                "summary": "Get VIN Attestation Eligibility",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token Id of the vehicle NFT",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_attestation_vinvc.EligibilityReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the webhook subscriptions of the dev license of the token without their secrets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Dev License Webhook Subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a webhook notified of each attestation requested by the dev license of the token and matching the filters, and of the attestations issued by the service on its own, such as renewals, for vehicles the dev license requested before.\nAny token-exchange token issued to the dev license is accepted. The devLicenses filter may only hold the dev license itself.\nNotifications are signed with an HMAC-SHA256 keyed by the secret of the subscription, which is only returned by this call.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Dev License Webhook Subscription",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_httphandlers.CreateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attestation/webhooks/{subscriptionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a webhook subscription of the dev license of the token without its secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Dev License Webhook Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook subscription",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook subscription of the dev license of the token.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Dev License Webhook Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the webhook subscription",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cloudevent.RawEvent": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data contains domain-specific information about the event.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "datacontenttype": {
+                    "description": "DataContentType is an optional MIME type for the data field. We almost\nalways serialize to JSON and in that case this field is implicitly\n\"application/json\".",
+                    "type": "string"
+                },
+                "dataschema": {
+                    "description": "DataSchema is an optional URI pointing to a schema for the data field.",
+                    "type": "string"
+                },
+                "dataversion": {
+                    "description": "DataVersion is the version of the data type.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is an identifier for the event. The combination of ID and Source must\nbe unique.",
+                    "type": "string"
+                },
+                "producer": {
+                    "description": "Producer is a specific instance, process or device that creates the data structure describing the CloudEvent.",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Signature hold the signature of the a cloudevent's data field.",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the context in which the event happened. In a distributed system it might consist of multiple Producers.",
+                    "type": "string"
+                },
+                "specversion": {
+                    "description": "SpecVersion is the version of CloudEvents specification used.\nThis is always hardcoded \"1.0\".",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "Subject is an optional field identifying the subject of the event within\nthe context of the event producer. In practice, we always set this.",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags are a list of tags that can be used to filter events.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "description": "Time is an optional field giving the time at which the event occurred. In\npractice, we always set this.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type describes the type of event. It should generally be a reverse-DNS\nname.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_attestation_vinvc.DeviceEligibility": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
+                    "description": "CountryCode is the ISO 3166-1 alpha-3 code of the country of manufacture decoded from the VIN.",
+                    "type": "string"
+                },
+                "definitionMatch": {
+                    "description": "DefinitionMatch reports whether the VIN model year matches the vehicle definition, unset when either is unknown.",
+                    "type": "boolean"
+                },
+                "did": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error describes why the fingerprint messages of the device could not be used.",
+                    "type": "string"
+                },
+                "fingerprintCount": {
+                    "description": "FingerprintCount is the number of valid fingerprint messages within the consensus window.",
+                    "type": "integer"
+                },
+                "latestFingerprintAt": {
+                    "description": "LatestFingerprintAt is the time of the latest valid fingerprint message.",
+                    "type": "string"
+                },
+                "manufacturerMatch": {
+                    "description": "ManufacturerMatch reports whether the VIN manufacturer matches the vehicle record, unset when either is unknown.",
+                    "type": "boolean"
+                },
+                "manufacturerName": {
+                    "type": "string"
+                },
+                "signatureStatus": {
+                    "description": "SignatureStatus is the signature verification outcome of the latest valid fingerprint message.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the outcome of the fingerprint check.",
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "noFingerprints",
+                        "invalidFingerprint",
+                        "unsigned",
+                        "fetchError"
+                    ]
+                },
+                "type": {
+                    "type": "string"
+                },
+                "vin": {
+                    "description": "VIN is the VIN of the latest valid fingerprint message.",
+                    "type": "string"
+                },
+                "vinManufacturer": {
+                    "description": "VINManufacturer is the manufacturer decoded from the world manufacturer identifier of the VIN.",
+                    "type": "string"
+                },
+                "vinModelYear": {
+                    "description": "VINModelYear is the model year decoded from the VIN.",
+                    "type": "integer"
+                },
+                "vinValid": {
+                    "description": "VINValid is true when the VIN passes validation as a 17 character VIN or a Japanese chassis number,\nincluding the check digit of North American VINs.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_attestation_vinvc.EligibilityReport": {
+            "type": "object",
+            "properties": {
+                "conflictingVins": {
+                    "description": "ConflictingVINs are the other VINs reported by the fingerprint messages.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "devices": {
+                    "description": "Devices are the paired devices of the vehicle.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_attestation_vinvc.DeviceEligibility"
+                    }
+                },
+                "eligible": {
+                    "description": "Eligible is true when a VIN attestation would be created.",
+                    "type": "boolean"
+                },
+                "evidenceCount": {
+                    "description": "EvidenceCount is the number of fingerprint messages reporting the selected VIN.",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Message describes the decision.",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is the reason code of the decision.",
+                    "type": "string",
+                    "enum": [
+                        "eligible",
+                        "noPairedDevices",
+                        "noFingerprints",
+                        "invalidFingerprints",
+                        "unsignedFingerprints",
+                        "fingerprintFetchError",
+                        "insufficientEvidence"
+                    ]
+                },
+                "vehicleDid": {
+                    "type": "string"
+                },
+                "vin": {
+                    "description": "VIN is the VIN that would be attested.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.AttestationNotification": {
+            "type": "object",
+            "properties": {
+                "dataVersion": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "description": "EventID is the ID of the attestation cloud event.",
+                    "type": "string"
+                },
+                "requester": {
+                    "description": "Requester is the dev license or client that requested the attestation, empty for attestations the service\nissues on its own.",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the dev license that issued the attestation.",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "Subject is the DID of the vehicle.",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "validFrom": {
+                    "type": "string"
+                },
+                "validTo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "requested",
+                        "approved",
+                        "rejected",
+                        "issued",
+                        "failed"
+                    ]
+                },
+                "attestationId": {
+                    "description": "AttestationID is the cloud event ID of the issued attestation.",
+                    "type": "string"
+                },
+                "countryCode": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error describes why issuing the attestation failed.",
+                    "type": "string"
+                },
+                "evidenceRef": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is the reason of the request, or of the rejection for rejected entries.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "vin": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.JobStatus": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorCode": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/cloudevent.RawEvent"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "queued",
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "webhookState": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "contentHash": {
+                    "description": "ContentHash is the hex SHA-256 of the uploaded cloud event JSON.",
+                    "type": "string"
+                },
+                "dataVersion": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the ID of the attestation cloud event.",
+                    "type": "string"
+                },
+                "issuedAt": {
+                    "description": "IssuedAt is the time of the cloud event.",
+                    "type": "string"
+                },
+                "recordedAt": {
+                    "description": "RecordedAt is when the upload was last attempted.",
+                    "type": "string"
+                },
+                "requester": {
+                    "description": "Requester identifies the caller the attestation was issued for, the dev license of a token-exchange token,\nan mTLS client or an operator. It is empty for attestations the service issued on its own.",
+                    "type": "string"
+                },
+                "signer": {
+                    "description": "Signer is the address of the key that signed the attestation.",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the dev license that issued the attestation.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "uploaded",
+                        "failed"
+                    ]
+                },
+                "subject": {
+                    "description": "Subject is the DID of the vehicle.",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "uploadError": {
+                    "description": "UploadError is the error of a failed upload.",
+                    "type": "string"
+                },
+                "validFrom": {
+                    "type": "string"
+                },
+                "validTo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.LedgerPage": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.LedgerEntry"
+                    }
+                },
+                "next": {
+                    "description": "Next is the After of the next page, empty on the last page.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.ManualVINRequest": {
+            "type": "object",
+            "properties": {
+                "attestationId": {
+                    "description": "AttestationID is the cloud event ID of the issued attestation.",
+                    "type": "string"
+                },
+                "countryCode": {
+                    "type": "string"
+                },
+                "evidenceRef": {
+                    "description": "EvidenceRef references the supporting evidence, e.g. a support ticket or a document ID.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is why the VIN is attested manually.",
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "type": "string"
+                },
+                "reviewReason": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "description": "ReviewedBy is the operator who approved or rejected a request that requires approval.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "issued",
+                        "rejected",
+                        "failed"
+                    ]
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "vin": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.RecurringParameters": {
+            "type": "object",
+            "properties": {
+                "disclosureProfile": {
+                    "type": "string"
+                },
+                "h3Resolution": {
+                    "description": "H3Resolution is the H3 resolution of vehicle position attestations.",
+                    "type": "integer"
+                },
+                "periodDays": {
+                    "description": "PeriodDays is the number of days before the due date covered by vehicle and battery health attestations,\nthe interval when 0.",
+                    "type": "integer"
+                },
+                "ruleSet": {
+                    "description": "RuleSet is the health rule set of vehicle health attestations.",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "Unit and DisclosureProfile are the options of odometer statements.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.RecurringSubscription": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts is the number of failed attempts for NextDueAt.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "Interval is the time between due dates, months are calendar months.",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ]
+                },
+                "lastAttestationId": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "nextDueAt": {
+                    "description": "NextDueAt is the due date of the next attestation.",
+                    "type": "string"
+                },
+                "nextRunAt": {
+                    "description": "NextRunAt is when the next attestation is attempted, after NextDueAt when it is retried. While an attestation\nis issued, it is the end of the run's lease, after which a run that did not finish is attempted again.",
+                    "type": "string"
+                },
+                "notifyUrl": {
+                    "description": "NotifyURL is called with a signed RecurringFailure when an attestation fails.",
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Owner names the partner the attestations are issued for.",
+                    "type": "string"
+                },
+                "parameters": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringParameters"
+                },
+                "startAt": {
+                    "description": "StartAt is the first due date of the interval, the due dates are counted from. Monthly due dates keep its\nday of the month, or fall on the last day of shorter months.",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "paused"
+                    ]
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is the job type of the attestations, e.g. odometer-statement.",
+                    "type": "string",
+                    "enum": [
+                        "vin",
+                        "vehicle-position",
+                        "odometer-statement",
+                        "vehicle-health",
+                        "battery-health"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.RenewalSummary": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "description": "Candidates is the number of vehicles to renew.",
+                    "type": "integer"
+                },
+                "expiringBefore": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ineligible": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "description": "Processed is the number of vehicles the renewal was attempted for.",
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "renewed": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "completed"
+                    ]
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.Usage": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "Allowed is the number of requests that were within the limits.",
+                    "type": "integer"
+                },
+                "client": {
+                    "description": "Client is the dev license of the requests.",
+                    "type": "string"
+                },
+                "dailyQuota": {
+                    "description": "DailyQuota is the number of requests the client can make for the type per day, unlimited when 0.",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Date is the UTC day of the requests, e.g. 2026-01-02.",
+                    "type": "string"
+                },
+                "rejected": {
+                    "description": "Rejected is the number of requests rejected by a rate limit or quota.",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "vin",
+                        "vehicle-position",
+                        "odometer-statement",
+                        "vehicle-health",
+                        "battery-health"
+                    ]
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts is the number of calls made to the webhook.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "notification": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.AttestationNotification"
+                },
+                "replayOf": {
+                    "description": "ReplayOf is the ID of the delivery this delivery replays.",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ]
+                },
+                "subscriptionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_attestation-api_internal_models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "attestationTypes": {
+                    "description": "AttestationTypes are the data versions of the attestations, e.g. vin/v1.0.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "devLicenses": {
+                    "description": "DevLicenses are the requesters of the attestations, the dev licenses that requested them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Owner is the dev license managing the subscription, which only matches the attestations it requested and the\nattestations the service issues on its own for vehicles it requested before. It is empty for the subscriptions\nmanaged by operators.",
+                    "type": "string"
+                },
+                "partner": {
+                    "description": "Partner names the partner the webhook is registered for.",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret keys the HMAC signature of the notifications. It is only returned when the subscription is created.",
+                    "type": "string"
+                },
+                "tokenIds": {
+                    "description": "TokenIDs are the vehicle token IDs of the attestations.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.CreateBatteryHealthVCRequest": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string",
+                    "example": "2021-03-01T00:00:00Z"
+                },
+                "startTime": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.CreateManualVINRequest": {
+            "type": "object",
+            "required": [
+                "evidenceRef",
+                "reason",
+                "vin"
+            ],
+            "properties": {
+                "countryCode": {
+                    "description": "ISO 3166-1 alpha-3 country code, defaults to the country of manufacture decoded from the VIN.",
+                    "type": "string",
+                    "example": "USA"
+                },
+                "evidenceRef": {
+                    "description": "Reference to the supporting evidence, e.g. a support ticket or a document ID.",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the VIN is attested manually.",
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string",
+                    "example": "1HGCM82673A123456"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.CreateOdometerStatementVCRequest": {
+            "type": "object",
+            "properties": {
+                "disclosureProfile": {
+                    "description": "DisclosureProfile adds regional disclosure fields to the statement.",
+                    "type": "string",
+                    "enum": [
+                        "us-federal"
+                    ],
+                    "example": "us-federal"
+                },
+                "timestamp": {
+                    "description": "Optional timestamp",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "unit": {
+                    "description": "Unit of the attested reading, \"km\" or \"miles\". Defaults to \"km\", or \"miles\" for the us-federal disclosure profile.",
+                    "type": "string",
+                    "enum": [
+                        "km",
+                        "miles"
+                    ],
+                    "example": "miles"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.CreateRecurringRequest": {
+            "type": "object",
+            "required": [
+                "interval",
+                "owner",
+                "tokenId",
+                "type"
+            ],
+            "properties": {
+                "interval": {
+                    "description": "Time between due dates.",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ],
+                    "example": "monthly"
+                },
+                "notifyUrl": {
+                    "description": "HTTPS URL notified of failed attestations.",
+                    "type": "string",
+                    "example": "https://example.com/hooks/recurring"
+                },
+                "owner": {
+                    "description": "Name of the partner the attestations are issued for.",
+                    "type": "string",
+                    "example": "Acme Lending"
+                },
+                "parameters": {
+                    "description": "Options of the attestations.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringParameters"
+                        }
+                    ]
+                },
+                "startAt": {
+                    "description": "First due date, now when empty.",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "description": "Token Id of the vehicle NFT.",
+                    "type": "integer",
+                    "example": 123
+                },
+                "type": {
+                    "description": "Job type of the attestations.",
+                    "type": "string",
+                    "enum": [
+                        "vin",
+                        "vehicle-position",
+                        "odometer-statement",
+                        "vehicle-health",
+                        "battery-health"
+                    ],
+                    "example": "odometer-statement"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.CreateVehicleHealthVCRequest": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string",
+                    "example": "2021-01-15T00:00:00Z"
+                },
+                "ruleSet": {
+                    "description": "RuleSet is the name of the health rule set used to score the vehicle, e.g. \"resale\". Defaults to \"default\".",
+                    "type": "string",
+                    "example": "default"
+                },
+                "startTime": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 }
             }
         },
@@ -248,12 +2304,102 @@ const docTemplate = `{
                 "timestamp"
             ],
             "properties": {
+                "h3Resolution": {
+                    "description": "H3Resolution is the H3 resolution of the attested cell, from 1 to 8. Defaults to 8.",
+                    "type": "integer",
+                    "example": 8
+                },
                 "timestamp": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 }
             }
         },
+        "internal_controllers_httphandlers.CreateWebhookSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "partner",
+                "url"
+            ],
+            "properties": {
+                "attestationTypes": {
+                    "description": "Data versions of the attestations.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vin/v1.0"
+                    ]
+                },
+                "devLicenses": {
+                    "description": "Addresses of the dev licenses requesting the attestations.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "partner": {
+                    "description": "Name of the partner the webhook is registered for.",
+                    "type": "string",
+                    "example": "Acme Insurance"
+                },
+                "tokenIds": {
+                    "description": "Token Ids of the vehicle NFTs.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "description": "HTTPS URL notified of the matching attestations.",
+                    "type": "string",
+                    "example": "https://example.com/hooks/attestations"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.RejectManualVINRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_httphandlers.UpdateRecurringRequest": {
+            "type": "object",
+            "required": [
+                "interval"
+            ],
+            "properties": {
+                "interval": {
+                    "description": "Time between due dates.",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ],
+                    "example": "monthly"
+                },
+                "notifyUrl": {
+                    "description": "HTTPS URL notified of failed attestations, none when empty.",
+                    "type": "string",
+                    "example": "https://example.com/hooks/recurring"
+                },
+                "parameters": {
+                    "description": "Options of the attestations.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.RecurringParameters"
+                        }
+                    ]
+                }
+            }
+        },
         "internal_controllers_httphandlers.getVCResponse": {
             "type": "object",
             "properties": {
@@ -268,6 +2414,22 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers_httphandlers.manualVINResponse": {
+            "type": "object",
+            "properties": {
+                "attestation": {
+                    "description": "Attestation is the issued attestation, absent while the request awaits approval.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/cloudevent.RawEvent"
+                        }
+                    ]
+                },
+                "request": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_attestation-api_internal_models.ManualVINRequest"
+                }
+            }
+        },
         "internal_controllers_httphandlers.successResponse": {
             "type": "object",
             "properties": {
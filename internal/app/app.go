@@ -1,14 +1,30 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
 	"github.com/DIMO-Network/attestation-api/internal/config"
 	"github.com/DIMO-Network/attestation-api/internal/controllers/httphandlers"
 	"github.com/DIMO-Network/attestation-api/internal/controllers/rpc"
+	"github.com/DIMO-Network/attestation-api/internal/grpcauth"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/ratelimit"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
 	attgrpc "github.com/DIMO-Network/attestation-api/pkg/grpc"
 	"github.com/DIMO-Network/server-garage/pkg/fibercommon"
 	"github.com/DIMO-Network/server-garage/pkg/fibercommon/jwtmiddleware"
 	"github.com/DIMO-Network/shared/pkg/middleware/metrics"
 	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/MicahParks/keyfunc/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -20,19 +36,32 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-// CreateServers creates a new fiber app and grpc server with the given settings.
-func CreateServers(logger *zerolog.Logger, settings *config.Settings) (*fiber.App, *grpc.Server, error) {
-	httpCtrl, rpcCtrl, err := createControllers(logger, settings)
+// Servers are the servers and background workers of the application.
+type Servers struct {
+	HTTP *fiber.App
+	RPC  *grpc.Server
+	// Workers run in the background until their context is canceled.
+	Workers []func(ctx context.Context) error
+}
+
+// CreateServers creates a new fiber app, grpc server and background workers with the given settings.
+func CreateServers(logger *zerolog.Logger, settings *config.Settings) (*Servers, error) {
+	ctrls, err := createControllers(logger, settings)
+	if err != nil {
+		return nil, err
+	}
+	app := setupHttpServer(logger, settings, ctrls)
+	rpc, err := setupRPCServer(logger, settings, ctrls.rpc, ctrls.limiter)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	app := setupHttpServer(logger, settings, httpCtrl)
-	rpc := setupRPCServer(logger, rpcCtrl)
-	return app, rpc, nil
+	return &Servers{HTTP: app, RPC: rpc, Workers: ctrls.workers}, nil
 }
-func setupHttpServer(logger *zerolog.Logger, settings *config.Settings, httpCtrl *httphandlers.HTTPController) *fiber.App {
+func setupHttpServer(logger *zerolog.Logger, settings *config.Settings, ctrls *controllers) *fiber.App {
+	httpCtrl := ctrls.http
 	app := fiber.New(fiber.Config{
 		ErrorHandler:          fibercommon.ErrorHandler,
 		DisableStartupMessage: true,
@@ -61,37 +90,145 @@ func setupHttpServer(logger *zerolog.Logger, settings *config.Settings, httpCtrl
 		},
 		StatusCode: fiber.StatusTemporaryRedirect,
 	}))
-	app.Post("/v2/attestation/vin/:"+httphandlers.TokenIDParam, jwtAuth, vinMiddleware, httpCtrl.CreateVINAttestation)
+	// requester.Middleware records the dev license of the token as the requester of the issued attestations, which
+	// the limiter also keys the rate limits and quotas of the dev license on
+	app.Post("/v2/attestation/vin/:"+httphandlers.TokenIDParam, jwtAuth, requester.Middleware, vinMiddleware, ctrls.limiter.Middleware(models.JobTypeVIN, httphandlers.TokenIDParam), httpCtrl.CreateVINAttestation)
+	app.Get("/v2/attestation/vin/:"+httphandlers.TokenIDParam+"/eligibility", jwtAuth, vinMiddleware, httpCtrl.GetVINEligibility)
 
 	// Vehicle position attestation endpoint
-	locationMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, []string{tokenclaims.PermissionGetLocationHistory})
-	app.Post("/v2/attestation/vehicle-position/:"+httphandlers.TokenIDParam, jwtAuth, locationMiddleware, httpCtrl.CreateVehiclePositionAttestation)
+	locationMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, vehiclepositionvc.TelemetryPermissions)
+	app.Post("/v2/attestation/vehicle-position/:"+httphandlers.TokenIDParam, jwtAuth, requester.Middleware, locationMiddleware, ctrls.limiter.Middleware(models.JobTypeVehiclePosition, httphandlers.TokenIDParam), httpCtrl.CreateVehiclePositionAttestation)
 
 	// Odometer and health attestation endpoints
 	// OdometerStatement requires basic vehicle access
-	odometerMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, []string{tokenclaims.PermissionGetNonLocationHistory})
-	app.Post("/v2/attestation/odometer-statement/:"+httphandlers.TokenIDParam, jwtAuth, odometerMiddleware, httpCtrl.CreateOdometerStatementAttestation)
+	odometerMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, odometerstatementvc.TelemetryPermissions)
+	app.Post("/v2/attestation/odometer-statement/:"+httphandlers.TokenIDParam, jwtAuth, requester.Middleware, odometerMiddleware, ctrls.limiter.Middleware(models.JobTypeOdometerStatement, httphandlers.TokenIDParam), httpCtrl.CreateOdometerStatementAttestation)
 
 	// VehicleHealth requires location privilege as it includes health data over time
-	healthMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, []string{tokenclaims.PermissionGetNonLocationHistory, tokenclaims.PermissionGetLocationHistory})
-	app.Post("/v2/attestation/vehicle-health/:"+httphandlers.TokenIDParam, jwtAuth, healthMiddleware, httpCtrl.CreateVehicleHealthAttestation)
+	healthMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, vehiclehealthvc.TelemetryPermissions)
+	app.Post("/v2/attestation/vehicle-health/:"+httphandlers.TokenIDParam, jwtAuth, requester.Middleware, healthMiddleware, ctrls.limiter.Middleware(models.JobTypeVehicleHealth, httphandlers.TokenIDParam), httpCtrl.CreateVehicleHealthAttestation)
+
+	// BatteryHealth only includes battery and charging data
+	batteryMiddleware := jwtmiddleware.AllOfPermissions(vehicleAddr, httphandlers.TokenIDParam, batteryhealthvc.TelemetryPermissions)
+	app.Post("/v2/attestation/battery-health/:"+httphandlers.TokenIDParam, jwtAuth, requester.Middleware, batteryMiddleware, ctrls.limiter.Middleware(models.JobTypeBatteryHealth, httphandlers.TokenIDParam), httpCtrl.CreateBatteryHealthAttestation)
+
+	// Asynchronous jobs are polled with a token for the vehicle of the job
+	if ctrls.jobs != nil {
+		app.Get("/v2/attestation/jobs/:"+httphandlers.JobIDParam, jwtAuth, ctrls.jobs.GetJob)
+	}
+
+	// Dev licenses manage their own webhook subscriptions with any token-exchange token issued to them
+	if ctrls.webhooks != nil {
+		webhooks := app.Group("/v2/attestation/webhooks", jwtAuth, requester.Middleware)
+		webhooks.Post("/", ctrls.webhooks.CreateDevLicenseSubscription)
+		webhooks.Get("/", ctrls.webhooks.ListDevLicenseSubscriptions)
+		webhooks.Get("/:"+httphandlers.SubscriptionIDParam, ctrls.webhooks.GetDevLicenseSubscription)
+		webhooks.Delete("/:"+httphandlers.SubscriptionIDParam, ctrls.webhooks.DeleteDevLicenseSubscription)
+	}
+
+	// Admin endpoints require an operator token with the admin role instead of a vehicle token
+	if ctrls.admin != nil {
+		admin := app.Group("/v2/admin/attestation", ctrls.operators.Middleware())
+		admin.Post("/vin/:"+httphandlers.TokenIDParam+"/manual", ctrls.admin.CreateManualVINAttestation)
+		admin.Get("/vin/:"+httphandlers.TokenIDParam+"/audit", ctrls.admin.GetManualVINAuditLog)
+		admin.Post("/vin/requests/:"+httphandlers.RequestIDParam+"/approve", ctrls.admin.ApproveManualVINRequest)
+		admin.Post("/vin/requests/:"+httphandlers.RequestIDParam+"/reject", ctrls.admin.RejectManualVINRequest)
+		if ctrls.renewals != nil {
+			admin.Get("/vin/renewals/latest", ctrls.renewals.GetLatestRenewal)
+		}
+
+		admin.Post("/webhooks", ctrls.webhooks.CreateSubscription)
+		admin.Get("/webhooks", ctrls.webhooks.ListSubscriptions)
+		admin.Get("/webhooks/:"+httphandlers.SubscriptionIDParam, ctrls.webhooks.GetSubscription)
+		admin.Delete("/webhooks/:"+httphandlers.SubscriptionIDParam, ctrls.webhooks.DeleteSubscription)
+		admin.Get("/webhooks/:"+httphandlers.SubscriptionIDParam+"/deliveries", ctrls.webhooks.ListDeliveries)
+		admin.Post("/webhooks/deliveries/:"+httphandlers.DeliveryIDParam+"/replay", ctrls.webhooks.ReplayDelivery)
+
+		admin.Post("/recurring", ctrls.recurring.CreateRecurring)
+		admin.Get("/recurring", ctrls.recurring.ListRecurring)
+		admin.Get("/recurring/:"+httphandlers.RecurringIDParam, ctrls.recurring.GetRecurring)
+		admin.Put("/recurring/:"+httphandlers.RecurringIDParam, ctrls.recurring.UpdateRecurring)
+		admin.Delete("/recurring/:"+httphandlers.RecurringIDParam, ctrls.recurring.DeleteRecurring)
+		admin.Post("/recurring/:"+httphandlers.RecurringIDParam+"/pause", ctrls.recurring.PauseRecurring)
+		admin.Post("/recurring/:"+httphandlers.RecurringIDParam+"/resume", ctrls.recurring.ResumeRecurring)
+
+		if ctrls.ledger != nil {
+			admin.Get("/ledger", ctrls.ledger.ListLedgerEntries)
+			admin.Get("/ledger/export", ctrls.ledger.ExportLedger)
+			admin.Get("/ledger/:"+httphandlers.EventIDParam, ctrls.ledger.GetLedgerEntry)
+		}
+
+		admin.Get("/usage", ctrls.usage.GetUsage)
+	}
 
 	return app
 }
 
-func setupRPCServer(logger *zerolog.Logger, rpcCtrl *rpc.Server) *grpc.Server {
+func setupRPCServer(logger *zerolog.Logger, settings *config.Settings, rpcCtrl *rpc.Server, limiter *ratelimit.Limiter) (*grpc.Server, error) {
+	clients, err := grpcauth.ParseClientPermissions(settings.GRPCClientPermissions)
+	if err != nil {
+		return nil, err
+	}
+	jwks, err := keyfunc.Get(settings.TokenExchangeJWTKeySetURL, keyfunc.Options{
+		RefreshInterval:   time.Hour,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token exchange JWK set: %w", err)
+	}
+	authorizer := grpcauth.New(rpc.MethodPolicies(), clients, jwks.Keyfunc, common.HexToAddress(settings.VehicleNFTAddress))
+
 	grpcPanic := metrics.GRPCPanicker{Logger: logger}
-	server := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			metrics.GRPCMetricsAndLogMiddleware(logger),
 			grpc_ctxtags.UnaryServerInterceptor(),
 			grpc_prometheus.UnaryServerInterceptor,
 			recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(grpcPanic.GRPCPanicRecoveryHandler)),
+			authorizer.UnaryInterceptor(),
+			// after authorization, which sets the requester the limits are keyed on
+			limiter.UnaryInterceptor(rpc.LimitedMethods()),
 		)),
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-	)
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_prometheus.StreamServerInterceptor,
+			authorizer.StreamInterceptor(),
+		)),
+	}
+	if settings.GRPCTLSCertFile != "" {
+		tlsConfig, err := serverTLSConfig(settings)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(opts...)
 	attgrpc.RegisterAttestationServiceServer(server, rpcCtrl)
-	return server
+	return server, nil
+}
+
+// serverTLSConfig loads the gRPC server certificate and verifies client certificates when a client CA is set.
+func serverTLSConfig(settings *config.Settings) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(settings.GRPCTLSCertFile, settings.GRPCTLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if settings.GRPCClientCAFile != "" {
+		caPEM, err := os.ReadFile(settings.GRPCClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gRPC client CA file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in gRPC client CA file %s", settings.GRPCClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
 }
 
 // HealthCheck godoc
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/backfill"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/ledger"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/internal/client/tokenexchange"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+)
+
+// BackfillTypes are the types of the attestations a backfill can issue.
+var BackfillTypes = []string{
+	models.JobTypeVIN,
+	models.JobTypeVehiclePosition,
+	models.JobTypeOdometerStatement,
+	models.JobTypeVehicleHealth,
+	models.JobTypeBatteryHealth,
+}
+
+// BackfillOptions select the attestations issued by a backfill.
+type BackfillOptions struct {
+	// Type is the type of the attestations, one of BackfillTypes.
+	Type string
+	// Time is the time of the vehicle position and odometer statement attestations, the current time when zero.
+	Time time.Time
+	// StartTime and EndTime are the period of the vehicle health and battery health attestations.
+	StartTime time.Time
+	EndTime   time.Time
+	// LedgerStore records the issued attestations in the issuance ledger when set.
+	LedgerStore ledger.Store
+}
+
+// NewBackfillIssuer creates the issuer of the attestations of a backfill with the given settings. Attestations
+// created from telemetry need the token exchange to get vehicle tokens.
+func NewBackfillIssuer(logger *zerolog.Logger, settings *config.Settings, opts BackfillOptions) (backfill.Issuer, error) {
+	if !slices.Contains(BackfillTypes, opts.Type) {
+		return nil, fmt.Errorf("unknown attestation type %q", opts.Type)
+	}
+	if opts.Type == models.JobTypeVehicleHealth || opts.Type == models.JobTypeBatteryHealth {
+		if err := checkPeriod(opts); err != nil {
+			return nil, err
+		}
+	}
+	c, err := createClients(settings)
+	if err != nil {
+		return nil, err
+	}
+	if opts.LedgerStore != nil {
+		c.vcRepo.AddUploadRecorder(ledger.NewService(logger, opts.LedgerStore, crypto.PubkeyToAddress(c.privateKey.PublicKey)))
+	}
+	if opts.Type == models.JobTypeVIN {
+		return &backfill.VINIssuer{
+			VIN: vinvc.NewService(logger, c.vcRepo, c.identityAPI, c.fingerprintRepo, settings, c.privateKey),
+		}, nil
+	}
+
+	if settings.TokenExchangeURL == "" {
+		return nil, fmt.Errorf("%s attestations need TOKEN_EXCHANGE_URL to get vehicle tokens", opts.Type)
+	}
+	tokenExchangeClient, err := tokenexchange.NewClient(settings, c.devLicenseTokenCache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange client: %w", err)
+	}
+	telemetryAPI, err := telemetryapi.NewService(settings.TelemetryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry service: %w", err)
+	}
+	issuer := &backfill.TelemetryIssuer{Tokens: tokenExchangeClient}
+
+	switch opts.Type {
+	case models.JobTypeVehiclePosition:
+		service := vehiclepositionvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
+		issuer.Permissions = vehiclepositionvc.TelemetryPermissions
+		issuer.Create = func(ctx context.Context, tokenID uint32, jwtToken string) (*cloudevent.RawEvent, error) {
+			timestamp := opts.Time
+			if timestamp.IsZero() {
+				timestamp = time.Now()
+			}
+			return service.CreateVehiclePositionVC(ctx, tokenID, timestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
+		}
+	case models.JobTypeOdometerStatement:
+		service := odometerstatementvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
+		issuer.Permissions = odometerstatementvc.TelemetryPermissions
+		issuer.Create = func(ctx context.Context, tokenID uint32, jwtToken string) (*cloudevent.RawEvent, error) {
+			var timestamp *time.Time
+			if !opts.Time.IsZero() {
+				timestamp = &opts.Time
+			}
+			return service.CreateOdometerStatementVC(ctx, tokenID, timestamp, odometerstatementvc.StatementOptions{}, jwtToken)
+		}
+	case models.JobTypeVehicleHealth:
+		service, err := vehiclehealthvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vehicle health service: %w", err)
+		}
+		issuer.Permissions = vehiclehealthvc.TelemetryPermissions
+		issuer.Create = func(ctx context.Context, tokenID uint32, jwtToken string) (*cloudevent.RawEvent, error) {
+			return service.CreateVehicleHealthVC(ctx, tokenID, opts.StartTime, opts.EndTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+		}
+	case models.JobTypeBatteryHealth:
+		service := batteryhealthvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
+		issuer.Permissions = batteryhealthvc.TelemetryPermissions
+		issuer.Create = func(ctx context.Context, tokenID uint32, jwtToken string) (*cloudevent.RawEvent, error) {
+			return service.CreateBatteryHealthVC(ctx, tokenID, opts.StartTime, opts.EndTime, jwtToken)
+		}
+	default:
+		return nil, fmt.Errorf("unknown attestation type %q", opts.Type)
+	}
+	return issuer, nil
+}
+
+func checkPeriod(opts BackfillOptions) error {
+	if opts.StartTime.IsZero() || opts.EndTime.IsZero() {
+		return errors.New(opts.Type + " attestations need a start and end time")
+	}
+	if !opts.EndTime.After(opts.StartTime) {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
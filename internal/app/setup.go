@@ -1,11 +1,25 @@
 package app
 
 import (
+	"cmp"
+	"context"
+	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/DIMO-Network/attestation-api/internal/attestation/autovin"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/jobs"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/ledger"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/manualvin"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/notifications"
 	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/recurring"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/renewal"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
 	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/fingerprint"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/sqlitestore"
 	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/vcrepo"
 	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
 	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
@@ -15,28 +29,66 @@ import (
 	"github.com/DIMO-Network/attestation-api/internal/client/identity"
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/client/tokencache"
+	"github.com/DIMO-Network/attestation-api/internal/client/tokenexchange"
 	"github.com/DIMO-Network/attestation-api/internal/config"
 	"github.com/DIMO-Network/attestation-api/internal/controllers/httphandlers"
 	"github.com/DIMO-Network/attestation-api/internal/controllers/rpc"
+	"github.com/DIMO-Network/attestation-api/internal/eventsource"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/ratelimit"
+	"github.com/DIMO-Network/attestation-api/internal/webhook"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rs/zerolog"
 )
 
-// createControllers creates a new controllers with the given settings.
-func createControllers(logger *zerolog.Logger, settings *config.Settings) (*httphandlers.HTTPController, *rpc.Server, error) {
+// controllers are the request handlers of the servers.
+type controllers struct {
+	http *httphandlers.HTTPController
+	rpc  *rpc.Server
+	// admin, webhooks, recurring and operators are nil when the admin endpoints are disabled.
+	admin     *httphandlers.AdminController
+	webhooks  *httphandlers.WebhookController
+	recurring *httphandlers.RecurringController
+	operators *operator.Verifier
+	// jobs is nil when asynchronous jobs are disabled.
+	jobs *httphandlers.JobController
+	// renewals is nil when the VIN renewal is disabled.
+	renewals *httphandlers.RenewalController
+	// ledger is nil when the issuance ledger or the admin endpoints are disabled.
+	ledger *httphandlers.LedgerController
+	// limiter limits and meters the attestation requests of each dev license and vehicle.
+	limiter *ratelimit.Limiter
+	// usage is nil when the admin endpoints are disabled.
+	usage *httphandlers.UsageController
+	// workers run in the background until their context is canceled.
+	workers []func(ctx context.Context) error
+}
+
+// clients are the clients and repositories the attestation services are created with.
+type clients struct {
+	privateKey           *ecdsa.PrivateKey
+	devLicenseTokenCache *tokencache.Cache
+	fingerprintRepo      *fingerprint.Service
+	vcRepo               *vcrepo.Repo
+	identityAPI          *identity.Service
+}
+
+// createClients creates the clients shared by the attestation services with the given settings.
+func createClients(settings *config.Settings) (*clients, error) {
 	fetchAPIClient := fetchapi.New(settings)
 
 	privateKey, err := crypto.HexToECDSA(settings.SignerPrivateKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode private key: %w", err)
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
 	}
 
 	// Initialize fingerprint repository
-	fingerprintRepo := fingerprint.New(fetchAPIClient)
+	fingerprintRepo := fingerprint.New(fetchAPIClient, settings)
 
 	dexClient, err := dex.NewClient(settings)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create dex client: %w", err)
+		return nil, fmt.Errorf("failed to create dex client: %w", err)
 	}
 
 	// Initialize token cache with both token getters
@@ -46,48 +98,243 @@ func createControllers(logger *zerolog.Logger, settings *config.Settings) (*http
 		dexClient,
 	)
 	// Initialize VC repository
-	vcRepo, err := vcrepo.New(settings, devLicenseTokenCache)
+	vcRepo, err := vcrepo.New(settings, devLicenseTokenCache, fetchAPIClient)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create VC repository: %w", err)
+		return nil, fmt.Errorf("failed to create VC repository: %w", err)
 	}
 
 	// Initialize identity API client
 	identityAPI, err := identity.NewService(settings.IdentityAPIURL, settings.AfterMarketNFTAddress, settings.SyntheticNFTAddress, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create identity service: %w", err)
+		return nil, fmt.Errorf("failed to create identity service: %w", err)
+	}
+
+	return &clients{
+		privateKey:           privateKey,
+		devLicenseTokenCache: devLicenseTokenCache,
+		fingerprintRepo:      fingerprintRepo,
+		vcRepo:               vcRepo,
+		identityAPI:          identityAPI,
+	}, nil
+}
+
+// createControllers creates a new controllers with the given settings.
+func createControllers(logger *zerolog.Logger, settings *config.Settings) (*controllers, error) {
+	c, err := createClients(settings)
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize telemetry API client
 	telemetryAPI, err := telemetryapi.NewService(settings.TelemetryURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create telemetry service: %w", err)
+		return nil, fmt.Errorf("failed to create telemetry service: %w", err)
 	}
 
 	// Initialize VC service using the initialized services
-	vinvcService := vinvc.NewService(logger, vcRepo, identityAPI, fingerprintRepo, settings, privateKey)
+	vinvcService := vinvc.NewService(logger, c.vcRepo, c.identityAPI, c.fingerprintRepo, settings, c.privateKey)
 
 	// Initialize VehiclePositionVC service
-	vehiclePositionService := vehiclepositionvc.NewService(vcRepo, identityAPI, telemetryAPI, settings, privateKey)
+	vehiclePositionService := vehiclepositionvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
 
 	// Initialize OdometerStatementVC service
-	odometerStatementService := odometerstatementvc.NewService(vcRepo, identityAPI, telemetryAPI, settings, privateKey)
+	odometerStatementService := odometerstatementvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
 
 	// Initialize VehicleHealthVC service
-	vehicleHealthService := vehiclehealthvc.NewService(vcRepo, identityAPI, telemetryAPI, settings, privateKey)
+	vehicleHealthService, err := vehiclehealthvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vehicle health service: %w", err)
+	}
+
+	// Initialize BatteryHealthVC service
+	batteryHealthService := batteryhealthvc.NewService(c.vcRepo, c.identityAPI, telemetryAPI, settings, c.privateKey)
 
 	// conRepo := connectivity.NewConnectivityRepo(chConn, s3Client, settings.AutoPiDataType, settings.AutoPiBucketName, settings.HashDogDataType, settings.HashDogBucketName, settings.StatusDataType, settings.StatusBucketName, settings.CloudEventBucket)
 
 	// pomService, err := pom.NewService(logger, identityAPI, conRepo, vcRepo, settings.VehicleNFTAddress, settings.DIMORegistryChainID)
 	// if err != nil {
-	// 	return nil, nil, fmt.Errorf("failed to create POM service: %w", err)
+	// 	return nil, fmt.Errorf("failed to create POM service: %w", err)
 	// }
 
-	ctrl, err := httphandlers.NewVCController(vinvcService, nil, vehiclePositionService, odometerStatementService, vehicleHealthService, settings.TelemetryURL)
+	// The database is opened by the first feature that needs it
+	var store *boltstore.Store
+	openStore := func(feature string) (*boltstore.Store, error) {
+		if store != nil {
+			return store, nil
+		}
+		var err error
+		store, err = openDatabase(settings.DatabasePath, feature)
+		return store, err
+	}
+
+	limits, err := ratelimit.ParseLimits(settings.RateLimits)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create VC controller: %w", err)
+		return nil, err
+	}
+	ctrls := &controllers{limiter: ratelimit.NewLimiter(limits)}
+	// Daily quotas and usage survive restarts when the database is configured
+	if settings.DatabasePath != "" {
+		rateLimitStore, err := openStore("DATABASE_PATH")
+		if err != nil {
+			return nil, err
+		}
+		if err := ctrls.limiter.Restore(context.Background(), rateLimitStore); err != nil {
+			return nil, err
+		}
+		ctrls.workers = append(ctrls.workers, func(ctx context.Context) error {
+			return ctrls.limiter.Persist(ctx, logger, rateLimitStore)
+		})
 	}
 
-	server := rpc.NewServer(vinvcService, settings)
+	// Initialize issuance ledger recording every uploaded attestation
+	var ledgerService *ledger.Service
+	if settings.IssuanceLedger {
+		ledgerStore, err := openLedgerDatabase(settings.LedgerDatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		ledgerService = ledger.NewService(logger, ledgerStore, crypto.PubkeyToAddress(c.privateKey.PublicKey))
+		c.vcRepo.AddUploadRecorder(ledgerService)
+	}
 
-	return ctrl, server, nil
+	// vehicleTokens is nil when the token exchange is disabled
+	var vehicleTokens recurring.VehicleTokenSource
+	if settings.TokenExchangeURL != "" {
+		// Initialize token exchange client for attestations requested by internal callers and background jobs
+		tokenExchangeClient, err := tokenexchange.NewClient(settings, c.devLicenseTokenCache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create token exchange client: %w", err)
+		}
+		vehicleTokens = tokenExchangeClient
+	}
+
+	// Initialize async job service for requests preferring an asynchronous response
+	var jobService *jobs.Service
+	var jobQueue httphandlers.JobService
+	if settings.AsyncJobWorkers > 0 {
+		jobStore, err := openStore("ASYNC_JOB_WORKERS")
+		if err != nil {
+			return nil, err
+		}
+		jobService = jobs.NewService(logger, jobStore, webhook.NewSender(c.privateKey, 0, 0), vehicleTokens, settings)
+		jobQueue = jobService
+		ctrls.jobs = httphandlers.NewJobController(jobService, common.HexToAddress(settings.VehicleNFTAddress))
+	}
+
+	ctrl, err := httphandlers.NewVCController(vinvcService, nil, vehiclePositionService, odometerStatementService, vehicleHealthService, batteryHealthService, jobQueue, settings.TelemetryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VC controller: %w", err)
+	}
+	ctrls.http = ctrl
+	if jobService != nil {
+		ctrls.workers = append(ctrls.workers, func(ctx context.Context) error {
+			return jobService.Run(ctx, ctrl)
+		})
+	}
+
+	// Initialize scheduled renewal of the VIN attestations about to expire
+	if settings.VINRenewalInterval > 0 {
+		renewalStore, err := openStore("VIN_RENEWAL_INTERVAL")
+		if err != nil {
+			return nil, err
+		}
+		renewalService := renewal.NewService(logger, c.vcRepo, vinvcService, renewalStore, settings)
+		ctrls.renewals = httphandlers.NewRenewalController(renewalService)
+		ctrls.workers = append(ctrls.workers, renewalService.Schedule)
+	}
+
+	// Initialize VIN attestation of the fingerprint events reporting a new VIN
+	if settings.FingerprintNATSURL != "" {
+		autoVINStore, err := openStore("FINGERPRINT_NATS_URL")
+		if err != nil {
+			return nil, err
+		}
+		connectCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		source, err := eventsource.NewNATS(connectCtx, logger, eventsource.NATSConfig{
+			URL:      settings.FingerprintNATSURL,
+			Stream:   settings.FingerprintNATSStream,
+			Subject:  settings.FingerprintNATSSubject,
+			Consumer: cmp.Or(settings.FingerprintNATSConsumer, "attestation-api-auto-vin"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fingerprint event source: %w", err)
+		}
+		autoVINService := autovin.NewService(logger, source, c.fingerprintRepo, c.vcRepo, vinvcService, autoVINStore, settings)
+		ctrls.workers = append(ctrls.workers, func(ctx context.Context) error {
+			defer source.Close() //nolint:errcheck
+			return autoVINService.Run(ctx)
+		})
+	}
+
+	telemetryServices := rpc.TelemetryServices{
+		Position:      vehiclePositionService,
+		Odometer:      odometerStatementService,
+		Health:        vehicleHealthService,
+		Battery:       batteryHealthService,
+		VehicleTokens: vehicleTokens,
+	}
+	if settings.AdminJWKKeySetURL == "" {
+		ctrls.rpc = rpc.NewServer(vinvcService, nil, nil, telemetryServices, settings)
+		return ctrls, nil
+	}
+
+	// Initialize manual VIN service for the admin endpoints
+	operators, err := operator.NewJWKSVerifier(settings.AdminJWKKeySetURL, settings.AdminRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operator verifier: %w", err)
+	}
+	adminStore, err := openStore("ADMIN_JWK_KEY_SET_URL")
+	if err != nil {
+		return nil, err
+	}
+	manualVINService := manualvin.NewService(logger, vinvcService, adminStore, settings)
+	ctrls.admin = httphandlers.NewAdminController(manualVINService)
+	ctrls.operators = operators
+
+	// Initialize webhook subscriptions notified of every uploaded attestation
+	notificationService := notifications.NewService(logger, adminStore, webhook.NewSender(c.privateKey, 0, 0))
+	c.vcRepo.AddUploadListener(notificationService)
+	ctrls.webhooks = httphandlers.NewWebhookController(notificationService)
+	ctrls.workers = append(ctrls.workers, notificationService.Run)
+
+	// Initialize recurring attestations issued on the schedules of vehicle subscriptions
+	recurringService := recurring.NewService(logger, ctrl, vehicleTokens, webhook.NewSender(c.privateKey, 0, 0), adminStore)
+	ctrls.recurring = httphandlers.NewRecurringController(recurringService)
+	ctrls.workers = append(ctrls.workers, recurringService.Run)
+
+	if ledgerService != nil {
+		ctrls.ledger = httphandlers.NewLedgerController(ledgerService)
+	}
+	ctrls.usage = httphandlers.NewUsageController(ctrls.limiter)
+
+	ctrls.rpc = rpc.NewServer(vinvcService, manualVINService, operators, telemetryServices, settings)
+
+	return ctrls, nil
+}
+
+// openDatabase opens the database at path for the feature named by its setting. A path is required, since a
+// database in the temporary directory would lose the jobs and audit log of the feature on restart.
+func openDatabase(path, feature string) (*boltstore.Store, error) {
+	if path == "" {
+		return nil, fmt.Errorf("DATABASE_PATH is required when %s is set", feature)
+	}
+	store, err := boltstore.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return store, nil
+}
+
+// openLedgerDatabase opens the SQLite database of the issuance ledger at path. Unlike the database at DATABASE_PATH,
+// it can be written by the backfill command while the API runs.
+func openLedgerDatabase(path string) (*sqlitestore.Store, error) {
+	if path == "" {
+		return nil, errors.New("LEDGER_DATABASE_PATH is required when ISSUANCE_LEDGER is set")
+	}
+	store, err := sqlitestore.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger database: %w", err)
+	}
+	return store, nil
 }
@@ -0,0 +1,238 @@
+// Package autovin attests the VIN of a vehicle as soon as its fingerprint events report a VIN that is new or has
+// changed, instead of waiting for an app to request the attestation. The outcome is stored for every vehicle, so
+// events repeating a VIN that was already attested, or found ineligible recently, are acknowledged without work.
+package autovin
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/eventsource"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// IneligibleRetry is how long a VIN that could not be attested is not checked again, fingerprint events keep
+	// adding evidence for it.
+	IneligibleRetry = time.Hour
+
+	defaultWorkers = 4
+	// retryDelay is when an event that failed is received again.
+	retryDelay = time.Minute
+	// busyDelay is when an event is received again when another event of the vehicle is processing.
+	busyDelay = 10 * time.Second
+)
+
+// errBusy is returned when another event of the vehicle is processing.
+var errBusy = errors.New("vehicle is processing")
+
+// Service attests the VINs reported by fingerprint events.
+type Service struct {
+	logger      *zerolog.Logger
+	source      eventsource.Source
+	decoder     FingerprintDecoder
+	vcRepo      VCRepo
+	vin         VINService
+	store       Store
+	vehicleAddr common.Address
+	workers     int
+
+	mu       sync.Mutex
+	inFlight map[uint32]struct{}
+}
+
+// NewService creates a new Service processing settings.AutoVINWorkers events of the vehicle contract at once.
+func NewService(logger *zerolog.Logger, source eventsource.Source, decoder FingerprintDecoder, vcRepo VCRepo, vin VINService, store Store, settings *config.Settings) *Service {
+	workers := settings.AutoVINWorkers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	return &Service{
+		logger:      logger,
+		source:      source,
+		decoder:     decoder,
+		vcRepo:      vcRepo,
+		vin:         vin,
+		store:       store,
+		vehicleAddr: common.HexToAddress(settings.VehicleNFTAddress),
+		workers:     workers,
+		inFlight:    map[uint32]struct{}{},
+	}
+}
+
+// Run processes the events of the source until ctx is canceled or the source is closed. A new event is only received
+// when a worker is free. Events that fail are received again later.
+func (s *Service) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	slots := make(chan struct{}, s.workers)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case slots <- struct{}{}:
+		}
+		msg, err := s.source.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, eventsource.ErrClosed) {
+				return nil
+			}
+			<-slots
+			s.logger.Error().Err(err).Msg("Failed to receive fingerprint event.")
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.handle(ctx, msg)
+		}()
+	}
+}
+
+// handle processes the event of the message and acknowledges it, or asks for it again when it failed.
+func (s *Service) handle(ctx context.Context, msg eventsource.Message) {
+	event := msg.Event()
+	err := s.Process(ctx, event)
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			s.logger.Warn().Err(err).Str("eventId", event.ID).Msg("Failed to acknowledge fingerprint event.")
+		}
+		return
+	}
+
+	delay := retryDelay
+	if errors.Is(err, errBusy) {
+		delay = busyDelay
+	} else {
+		s.logger.Warn().Err(err).Str("eventId", event.ID).Str("subject", event.Subject).Msg("Failed to process fingerprint event.")
+	}
+	if err := msg.Nak(delay); err != nil {
+		s.logger.Warn().Err(err).Str("eventId", event.ID).Msg("Failed to reject fingerprint event.")
+	}
+}
+
+// Process attests the VIN of a fingerprint event when it is new or has changed. Events that are not fingerprints of
+// the vehicle contract, or do not carry a valid VIN, are ignored. An error is returned when the event should be
+// processed again.
+func (s *Service) Process(ctx context.Context, event *cloudevent.RawEvent) error {
+	if event.Type != cloudevent.TypeFingerprint {
+		return nil
+	}
+	vehicleDID, err := cloudevent.DecodeERC721DID(event.Subject)
+	if err != nil || vehicleDID.ContractAddress != s.vehicleAddr || !vehicleDID.TokenID.IsUint64() || vehicleDID.TokenID.Uint64() > math.MaxUint32 {
+		return nil
+	}
+	tokenID := uint32(vehicleDID.TokenID.Uint64())
+	fingerprint, err := s.decoder.DecodeFingerprintMessage(ctx, *event)
+	if err != nil {
+		s.logger.Debug().Err(err).Str("eventId", event.ID).Uint32("tokenId", tokenID).Msg("Ignoring fingerprint event without a valid VIN.")
+		return nil
+	}
+
+	if !s.claim(tokenID) {
+		return errBusy
+	}
+	defer s.release(tokenID)
+
+	state, err := s.store.GetAutoVINState(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if state != nil && state.VIN == fingerprint.VIN {
+		if state.State == models.AutoVINStateAttested || time.Since(state.UpdatedAt) < IneligibleRetry {
+			return nil
+		}
+	}
+
+	newState := &models.AutoVINState{
+		TokenID: tokenID,
+		VIN:     fingerprint.VIN,
+		EventID: event.ID,
+	}
+	if state == nil {
+		// The vehicle may have been attested on request before its first event was processed.
+		subject, err := s.vcRepo.GetLatestVINSubject(ctx, vehicleDID)
+		if err != nil && !isClientError(err) {
+			return err
+		}
+		if err == nil && subject.VehicleIdentificationNumber == fingerprint.VIN {
+			newState.State = models.AutoVINStateAttested
+			return s.save(ctx, newState)
+		}
+	}
+
+	report, err := s.vin.CheckVINEligibility(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if !report.Eligible {
+		newState.State = models.AutoVINStateIneligible
+		newState.Reason = report.Message
+		s.logger.Info().Uint32("tokenId", tokenID).Str("reason", report.Reason).Msg("Reported VIN is not eligible for an attestation.")
+		return s.save(ctx, newState)
+	}
+
+	// The credential is issued for the VIN the fingerprint messages agree on, which lags behind a changed VIN until
+	// enough events report it. Nothing is issued while they still agree on the attested VIN.
+	if report.VIN != fingerprint.VIN && state != nil && state.State == models.AutoVINStateAttested && state.VIN == report.VIN {
+		return nil
+	}
+	attestation, err := s.vin.CreateAndStoreVINAttestation(ctx, tokenID)
+	if err != nil {
+		var richErr richerrors.Error
+		if !isClientError(err) || !errors.As(err, &richErr) {
+			return err
+		}
+		newState.State = models.AutoVINStateIneligible
+		newState.Reason = richErr.ExternalMsg
+		return s.save(ctx, newState)
+	}
+	newState.VIN = report.VIN
+	newState.State = models.AutoVINStateAttested
+	newState.AttestationID = attestation.ID
+	s.logger.Info().Uint32("tokenId", tokenID).Str("attestationId", attestation.ID).Msg("Attested reported VIN.")
+	return s.save(ctx, newState)
+}
+
+func (s *Service) save(ctx context.Context, state *models.AutoVINState) error {
+	state.UpdatedAt = time.Now().UTC()
+	return s.store.SaveAutoVINState(ctx, state)
+}
+
+func (s *Service) claim(tokenID uint32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.inFlight[tokenID]; ok {
+		return false
+	}
+	s.inFlight[tokenID] = struct{}{}
+	return true
+}
+
+func (s *Service) release(tokenID uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, tokenID)
+}
+
+// isClientError reports whether err is a rich error with a 4xx code, which retrying does not fix.
+func isClientError(err error) bool {
+	var richErr richerrors.Error
+	return errors.As(err, &richErr) && richErr.Code >= http.StatusBadRequest && richErr.Code < http.StatusInternalServerError
+}
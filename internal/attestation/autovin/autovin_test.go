@@ -0,0 +1,246 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=autovin_test
+package autovin_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/autovin"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/eventsource"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	vehicleContract = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"
+	vin             = "1HGCM82633A004352"
+	otherVIN        = "1FTFW1ET5DFC10312"
+)
+
+type mocks struct {
+	decoder *MockFingerprintDecoder
+	vcRepo  *MockVCRepo
+	vin     *MockVINService
+}
+
+func newService(t *testing.T, source eventsource.Source) (*autovin.Service, *mocks, *boltstore.Store) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		decoder: NewMockFingerprintDecoder(ctrl),
+		vcRepo:  NewMockVCRepo(ctrl),
+		vin:     NewMockVINService(ctrl),
+	}
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	settings := &config.Settings{VehicleNFTAddress: vehicleContract, AutoVINWorkers: 2}
+	return autovin.NewService(&logger, source, m.decoder, m.vcRepo, m.vin, store, settings), m, store
+}
+
+func fingerprintEvent(id string, tokenID uint32) *cloudevent.RawEvent {
+	return &cloudevent.RawEvent{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			ID:      id,
+			Type:    cloudevent.TypeFingerprint,
+			Subject: fmt.Sprintf("did:erc721:137:%s:%d", vehicleContract, tokenID),
+		},
+	}
+}
+
+func decodes(m *mocks, vin string) {
+	m.decoder.EXPECT().DecodeFingerprintMessage(gomock.Any(), gomock.Any()).Return(&models.DecodedFingerprintData{VIN: vin}, nil)
+}
+
+func TestProcess(t *testing.T) {
+	notAttested := richerrors.Error{Err: errors.New("not found"), ExternalMsg: "No VIN attestation found for vehicle", Code: http.StatusBadRequest}
+
+	tests := []struct {
+		name       string
+		event      *cloudevent.RawEvent
+		state      *models.AutoVINState
+		setupMocks func(m *mocks)
+		wantErr    bool
+		wantState  *models.AutoVINState
+	}{
+		{
+			name:  "new VIN is attested",
+			event: fingerprintEvent("fp-1", 1),
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+				m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).Return(nil, notAttested)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true, VIN: vin}, nil)
+				m.vin.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(1)).Return(&cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+		},
+		{
+			name:  "VIN attested on request is not attested again",
+			event: fingerprintEvent("fp-1", 1),
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+				m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).Return(&types.VINSubject{VehicleIdentificationNumber: vin}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1"},
+		},
+		{
+			name:  "ineligible VIN",
+			event: fingerprintEvent("fp-1", 1),
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+				m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).Return(nil, notAttested)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Reason: "insufficientEvidence", Message: "Not enough fingerprint messages"}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateIneligible, Reason: "Not enough fingerprint messages", EventID: "fp-1"},
+		},
+		{
+			name:  "attested VIN is skipped",
+			event: fingerprintEvent("fp-2", 1),
+			state: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+		},
+		{
+			name:  "recently ineligible VIN is skipped",
+			event: fingerprintEvent("fp-2", 1),
+			state: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateIneligible, EventID: "fp-1"},
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateIneligible, EventID: "fp-1"},
+		},
+		{
+			name:  "changed VIN is attested",
+			event: fingerprintEvent("fp-2", 1),
+			state: &models.AutoVINState{TokenID: 1, VIN: otherVIN, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true, VIN: vin}, nil)
+				m.vin.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(1)).Return(&cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-2"}}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-2", AttestationID: "vc-2"},
+		},
+		{
+			name:  "consensus VIN is attested when the event VIN differs",
+			event: fingerprintEvent("fp-1", 1),
+			setupMocks: func(m *mocks) {
+				decodes(m, otherVIN)
+				m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).Return(nil, notAttested)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true, VIN: vin}, nil)
+				m.vin.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(1)).Return(&cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+		},
+		{
+			name:  "changed VIN without consensus does not attest the old VIN again",
+			event: fingerprintEvent("fp-2", 1),
+			state: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+			setupMocks: func(m *mocks) {
+				decodes(m, otherVIN)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true, VIN: vin}, nil)
+			},
+			wantState: &models.AutoVINState{TokenID: 1, VIN: vin, State: models.AutoVINStateAttested, EventID: "fp-1", AttestationID: "vc-1"},
+		},
+		{
+			name:  "failed issuance is retried",
+			event: fingerprintEvent("fp-1", 1),
+			setupMocks: func(m *mocks) {
+				decodes(m, vin)
+				m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).Return(nil, notAttested)
+				m.vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true, VIN: vin}, nil)
+				m.vin.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(1)).Return(nil, errors.New("upload failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "other event types are ignored",
+			event: &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{
+				ID:      "status-1",
+				Type:    cloudevent.TypeStatus,
+				Subject: fmt.Sprintf("did:erc721:137:%s:1", vehicleContract),
+			}},
+			setupMocks: func(*mocks) {},
+		},
+		{
+			name: "other vehicle contracts are ignored",
+			event: &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{
+				ID:      "fp-1",
+				Type:    cloudevent.TypeFingerprint,
+				Subject: "did:erc721:137:0x4CB8E0B6BCD3CB7D6B4bd0B2C4B5Dd1f2E3A4b5C:1",
+			}},
+			setupMocks: func(*mocks) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m, store := newService(t, eventsource.NewMemory(1))
+			tt.setupMocks(m)
+			if tt.state != nil {
+				tt.state.UpdatedAt = time.Now().UTC()
+				require.NoError(t, store.SaveAutoVINState(context.Background(), tt.state))
+			}
+
+			err := service.Process(context.Background(), tt.event)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			state, err := store.GetAutoVINState(context.Background(), 1)
+			require.NoError(t, err)
+			if tt.wantState == nil {
+				require.Nil(t, state)
+				return
+			}
+			require.NotNil(t, state)
+			state.UpdatedAt = time.Time{}
+			require.Equal(t, tt.wantState, state)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	source := eventsource.NewMemory(10)
+	service, m, _ := newService(t, source)
+
+	// the first event of vehicle 2 fails and is received again after a minute, so it is not acknowledged yet
+	m.decoder.EXPECT().DecodeFingerprintMessage(gomock.Any(), gomock.Any()).Return(&models.DecodedFingerprintData{VIN: vin}, nil).Times(2)
+	m.vcRepo.EXPECT().GetLatestVINSubject(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error) {
+		if vehicleDID.TokenID.Uint64() == 2 {
+			return nil, errors.New("fetch api unavailable")
+		}
+		return &types.VINSubject{VehicleIdentificationNumber: vin}, nil
+	}).Times(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- service.Run(ctx) }()
+
+	require.NoError(t, source.Publish(ctx, fingerprintEvent("fp-1", 1)))
+	require.NoError(t, source.Publish(ctx, fingerprintEvent("fp-2", 2)))
+	require.Eventually(t, func() bool {
+		return len(source.Acked()) == 1 && len(source.Naked()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"fp-1"}, source.Acked())
+	require.Equal(t, []string{"fp-2"}, source.Naked())
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,32 @@
+package autovin
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// FingerprintDecoder defines the interface for extracting the VIN of fingerprint messages.
+type FingerprintDecoder interface {
+	DecodeFingerprintMessage(ctx context.Context, msg cloudevent.RawEvent) (*models.DecodedFingerprintData, error)
+}
+
+// VCRepo defines the interface for reading the VIN attestations issued by the service.
+type VCRepo interface {
+	GetLatestVINSubject(ctx context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error)
+}
+
+// VINService defines the interface for checking and issuing VIN attestations.
+type VINService interface {
+	CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error)
+	CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
+}
+
+// Store defines the interface for persisting the VINs attested from fingerprint events.
+type Store interface {
+	GetAutoVINState(ctx context.Context, tokenID uint32) (*models.AutoVINState, error)
+	SaveAutoVINState(ctx context.Context, state *models.AutoVINState) error
+}
@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=autovin_test
+//
+
+// Package autovin_test is a generated GoMock package.
+package autovin_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	vinvc "github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	types "github.com/DIMO-Network/attestation-api/pkg/types"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFingerprintDecoder is a mock of FingerprintDecoder interface.
+type MockFingerprintDecoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockFingerprintDecoderMockRecorder
+	isgomock struct{}
+}
+
+// MockFingerprintDecoderMockRecorder is the mock recorder for MockFingerprintDecoder.
+type MockFingerprintDecoderMockRecorder struct {
+	mock *MockFingerprintDecoder
+}
+
+// NewMockFingerprintDecoder creates a new mock instance.
+func NewMockFingerprintDecoder(ctrl *gomock.Controller) *MockFingerprintDecoder {
+	mock := &MockFingerprintDecoder{ctrl: ctrl}
+	mock.recorder = &MockFingerprintDecoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFingerprintDecoder) EXPECT() *MockFingerprintDecoderMockRecorder {
+	return m.recorder
+}
+
+// DecodeFingerprintMessage mocks base method.
+func (m *MockFingerprintDecoder) DecodeFingerprintMessage(ctx context.Context, msg cloudevent.RawEvent) (*models.DecodedFingerprintData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecodeFingerprintMessage", ctx, msg)
+	ret0, _ := ret[0].(*models.DecodedFingerprintData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecodeFingerprintMessage indicates an expected call of DecodeFingerprintMessage.
+func (mr *MockFingerprintDecoderMockRecorder) DecodeFingerprintMessage(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodeFingerprintMessage", reflect.TypeOf((*MockFingerprintDecoder)(nil).DecodeFingerprintMessage), ctx, msg)
+}
+
+// MockVCRepo is a mock of VCRepo interface.
+type MockVCRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockVCRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockVCRepoMockRecorder is the mock recorder for MockVCRepo.
+type MockVCRepoMockRecorder struct {
+	mock *MockVCRepo
+}
+
+// NewMockVCRepo creates a new mock instance.
+func NewMockVCRepo(ctrl *gomock.Controller) *MockVCRepo {
+	mock := &MockVCRepo{ctrl: ctrl}
+	mock.recorder = &MockVCRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVCRepo) EXPECT() *MockVCRepoMockRecorder {
+	return m.recorder
+}
+
+// GetLatestVINSubject mocks base method.
+func (m *MockVCRepo) GetLatestVINSubject(ctx context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVINSubject", ctx, vehicleDID)
+	ret0, _ := ret[0].(*types.VINSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVINSubject indicates an expected call of GetLatestVINSubject.
+func (mr *MockVCRepoMockRecorder) GetLatestVINSubject(ctx, vehicleDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVINSubject", reflect.TypeOf((*MockVCRepo)(nil).GetLatestVINSubject), ctx, vehicleDID)
+}
+
+// MockVINService is a mock of VINService interface.
+type MockVINService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVINServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVINServiceMockRecorder is the mock recorder for MockVINService.
+type MockVINServiceMockRecorder struct {
+	mock *MockVINService
+}
+
+// NewMockVINService creates a new mock instance.
+func NewMockVINService(ctrl *gomock.Controller) *MockVINService {
+	mock := &MockVINService{ctrl: ctrl}
+	mock.recorder = &MockVINServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVINService) EXPECT() *MockVINServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckVINEligibility mocks base method.
+func (m *MockVINService) CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckVINEligibility", ctx, tokenID)
+	ret0, _ := ret[0].(*vinvc.EligibilityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckVINEligibility indicates an expected call of CheckVINEligibility.
+func (mr *MockVINServiceMockRecorder) CheckVINEligibility(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckVINEligibility", reflect.TypeOf((*MockVINService)(nil).CheckVINEligibility), ctx, tokenID)
+}
+
+// CreateAndStoreVINAttestation mocks base method.
+func (m *MockVINService) CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAndStoreVINAttestation", ctx, tokenID)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAndStoreVINAttestation indicates an expected call of CreateAndStoreVINAttestation.
+func (mr *MockVINServiceMockRecorder) CreateAndStoreVINAttestation(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndStoreVINAttestation", reflect.TypeOf((*MockVINService)(nil).CreateAndStoreVINAttestation), ctx, tokenID)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAutoVINState mocks base method.
+func (m *MockStore) GetAutoVINState(ctx context.Context, tokenID uint32) (*models.AutoVINState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoVINState", ctx, tokenID)
+	ret0, _ := ret[0].(*models.AutoVINState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoVINState indicates an expected call of GetAutoVINState.
+func (mr *MockStoreMockRecorder) GetAutoVINState(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoVINState", reflect.TypeOf((*MockStore)(nil).GetAutoVINState), ctx, tokenID)
+}
+
+// SaveAutoVINState mocks base method.
+func (m *MockStore) SaveAutoVINState(ctx context.Context, state *models.AutoVINState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAutoVINState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAutoVINState indicates an expected call of SaveAutoVINState.
+func (mr *MockStoreMockRecorder) SaveAutoVINState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAutoVINState", reflect.TypeOf((*MockStore)(nil).SaveAutoVINState), ctx, state)
+}
@@ -0,0 +1,179 @@
+// Package backfill issues attestations for many vehicles at once, e.g. for a token ID range after an outage. The
+// result of every vehicle is recorded in a checkpoint as soon as it is known, so an interrupted backfill resumes
+// with the vehicles it did not finish.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// StatusIssued is the status of a vehicle whose attestation was issued.
+	StatusIssued = "issued"
+	// StatusEligible is the status of a vehicle whose attestation would be issued in a dry run.
+	StatusEligible = "eligible"
+	// StatusIneligible is the status of a vehicle whose attestation cannot be issued, retrying will not help.
+	StatusIneligible = "ineligible"
+	// StatusFailed is the status of a vehicle whose attestation failed to be issued and can be retried.
+	StatusFailed = "failed"
+
+	defaultConcurrency = 4
+)
+
+// Result is the outcome of a vehicle.
+type Result struct {
+	TokenID uint32 `json:"tokenId"`
+	Status  string `json:"status"`
+	// Reason is why the attestation was not issued.
+	Reason string `json:"reason,omitempty"`
+	// EventID is the ID of the issued attestation cloud event.
+	EventID string    `json:"eventId,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Options configure a backfill.
+type Options struct {
+	// Concurrency is the number of vehicles processed at once, 4 when not positive.
+	Concurrency int
+	// DryRun checks whether the attestations would be issued without issuing them.
+	DryRun bool
+}
+
+// Summary counts the results of a backfill by status.
+type Summary struct {
+	// Skipped is the number of vehicles already done according to the checkpoint.
+	Skipped    int `json:"skipped"`
+	Issued     int `json:"issued"`
+	Eligible   int `json:"eligible"`
+	Ineligible int `json:"ineligible"`
+	Failed     int `json:"failed"`
+}
+
+// Run processes the vehicles with the token IDs that are not done according to checkpoint, recording the result of
+// each. Vehicles already processing finish when ctx is canceled, the others are left for the next run.
+func Run(ctx context.Context, logger *zerolog.Logger, issuer Issuer, checkpoint Checkpoint, tokenIDs []uint32, opts Options) (*Summary, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	// Dispatching stops when ctx is canceled or a result cannot be recorded.
+	dispatchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	summary := &Summary{}
+	pending := make(chan uint32)
+	go func() {
+		defer close(pending)
+		for _, tokenID := range tokenIDs {
+			if checkpoint.Done(tokenID) {
+				summary.Skipped++
+				continue
+			}
+			select {
+			case pending <- tokenID:
+			case <-dispatchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mtx       sync.Mutex
+		recordErr error
+		wg        sync.WaitGroup
+	)
+	// Vehicles keep their context when ctx is canceled so that their results are recorded.
+	vehicleCtx := context.WithoutCancel(ctx)
+	for range concurrency {
+		wg.Go(func() {
+			for tokenID := range pending {
+				if dispatchCtx.Err() != nil {
+					// received while dispatching was stopping
+					continue
+				}
+				result := process(vehicleCtx, issuer, tokenID, opts.DryRun)
+				logResult(logger, &result)
+
+				mtx.Lock()
+				if err := checkpoint.Record(result); err != nil && recordErr == nil {
+					recordErr = err
+					cancel()
+				}
+				count(summary, result.Status)
+				mtx.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if recordErr != nil {
+		return summary, recordErr
+	}
+	return summary, ctx.Err()
+}
+
+// process issues or checks the attestation of the vehicle.
+func process(ctx context.Context, issuer Issuer, tokenID uint32, dryRun bool) Result {
+	result := Result{TokenID: tokenID}
+	var err error
+	if dryRun {
+		err = issuer.Check(ctx, tokenID)
+		result.Status = StatusEligible
+	} else {
+		event, issueErr := issuer.Issue(ctx, tokenID)
+		err = issueErr
+		result.Status = StatusIssued
+		if event != nil {
+			result.EventID = event.ID
+		}
+	}
+	result.Time = time.Now().UTC()
+	if err == nil {
+		return result
+	}
+
+	result.Status = StatusFailed
+	result.Reason = err.Error()
+	var richErr richerrors.Error
+	if errors.As(err, &richErr) {
+		if richErr.ExternalMsg != "" {
+			result.Reason = richErr.ExternalMsg
+		}
+		if richErr.Code != 0 && richErr.Code < http.StatusInternalServerError {
+			result.Status = StatusIneligible
+		}
+	}
+	return result
+}
+
+func logResult(logger *zerolog.Logger, result *Result) {
+	switch result.Status {
+	case StatusFailed:
+		logger.Warn().Uint32("tokenId", result.TokenID).Str("reason", result.Reason).Msg("Failed to issue attestation.")
+	case StatusIneligible:
+		logger.Info().Uint32("tokenId", result.TokenID).Str("reason", result.Reason).Msg("Vehicle is not eligible for the attestation.")
+	default:
+		logger.Debug().Uint32("tokenId", result.TokenID).Str("status", result.Status).Msg("Processed vehicle.")
+	}
+}
+
+func count(summary *Summary, status string) {
+	switch status {
+	case StatusIssued:
+		summary.Issued++
+	case StatusEligible:
+		summary.Eligible++
+	case StatusIneligible:
+		summary.Ineligible++
+	case StatusFailed:
+		summary.Failed++
+	}
+}
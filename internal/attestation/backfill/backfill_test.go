@@ -0,0 +1,185 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=backfill_test
+package backfill_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/backfill"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func attestation(id string) *cloudevent.RawEvent {
+	return &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: id}}
+}
+
+func statuses(results []backfill.Result) map[uint32]string {
+	byToken := map[uint32]string{}
+	for _, result := range results {
+		byToken[result.TokenID] = result.Status
+	}
+	return byToken
+}
+
+func TestRun(t *testing.T) {
+	logger := zerolog.Nop()
+	notEligible := richerrors.Error{Err: errors.New("no fingerprints"), ExternalMsg: "No fingerprints found", Code: http.StatusBadRequest}
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		setupMocks   func(issuer *MockIssuer)
+		wantStatuses map[uint32]string
+		wantSummary  backfill.Summary
+	}{
+		{
+			name: "issue",
+			setupMocks: func(issuer *MockIssuer) {
+				issuer.EXPECT().Issue(gomock.Any(), uint32(1)).Return(attestation("vc-1"), nil)
+				issuer.EXPECT().Issue(gomock.Any(), uint32(2)).Return(nil, notEligible)
+				issuer.EXPECT().Issue(gomock.Any(), uint32(3)).Return(nil, errors.New("upload failed"))
+			},
+			wantStatuses: map[uint32]string{1: backfill.StatusIssued, 2: backfill.StatusIneligible, 3: backfill.StatusFailed},
+			wantSummary:  backfill.Summary{Issued: 1, Ineligible: 1, Failed: 1},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			setupMocks: func(issuer *MockIssuer) {
+				issuer.EXPECT().Check(gomock.Any(), uint32(1)).Return(nil)
+				issuer.EXPECT().Check(gomock.Any(), uint32(2)).Return(notEligible)
+				issuer.EXPECT().Check(gomock.Any(), uint32(3)).Return(nil)
+			},
+			wantStatuses: map[uint32]string{1: backfill.StatusEligible, 2: backfill.StatusIneligible, 3: backfill.StatusEligible},
+			wantSummary:  backfill.Summary{Eligible: 2, Ineligible: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuer := NewMockIssuer(ctrl)
+			tt.setupMocks(issuer)
+
+			checkpoint := &backfill.MemoryCheckpoint{}
+			summary, err := backfill.Run(context.Background(), &logger, issuer, checkpoint, []uint32{1, 2, 3}, backfill.Options{Concurrency: 2, DryRun: tt.dryRun})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSummary, *summary)
+			require.Equal(t, tt.wantStatuses, statuses(checkpoint.Results()))
+		})
+	}
+}
+
+func TestRun_Resume(t *testing.T) {
+	logger := zerolog.Nop()
+	path := filepath.Join(t.TempDir(), "backfill.checkpoint")
+	ctrl := gomock.NewController(t)
+	issuer := NewMockIssuer(ctrl)
+
+	issuer.EXPECT().Issue(gomock.Any(), uint32(1)).Return(attestation("vc-1"), nil)
+	issuer.EXPECT().Issue(gomock.Any(), uint32(2)).Return(nil, errors.New("upload failed"))
+	checkpoint, err := backfill.OpenFileCheckpoint(path)
+	require.NoError(t, err)
+	_, err = backfill.Run(context.Background(), &logger, issuer, checkpoint, []uint32{1, 2}, backfill.Options{})
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Close())
+
+	// the failed vehicle is retried, the issued one is skipped
+	issuer.EXPECT().Issue(gomock.Any(), uint32(2)).Return(attestation("vc-2"), nil)
+	checkpoint, err = backfill.OpenFileCheckpoint(path)
+	require.NoError(t, err)
+	defer checkpoint.Close() //nolint:errcheck
+	summary, err := backfill.Run(context.Background(), &logger, issuer, checkpoint, []uint32{1, 2}, backfill.Options{})
+	require.NoError(t, err)
+	require.Equal(t, backfill.Summary{Skipped: 1, Issued: 1}, *summary)
+
+	results := checkpoint.Results()
+	require.Len(t, results, 2)
+	require.Equal(t, "vc-1", results[0].EventID)
+	require.Equal(t, "vc-2", results[1].EventID)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	logger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	issuer := NewMockIssuer(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the vehicle processing when the backfill is canceled is recorded, the next is left for the next run
+	issuer.EXPECT().Issue(gomock.Any(), uint32(1)).DoAndReturn(func(context.Context, uint32) (*cloudevent.RawEvent, error) {
+		cancel()
+		return attestation("vc-1"), nil
+	})
+	checkpoint := &backfill.MemoryCheckpoint{}
+	summary, err := backfill.Run(ctx, &logger, issuer, checkpoint, []uint32{1, 2}, backfill.Options{Concurrency: 1})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, backfill.Summary{Issued: 1}, *summary)
+	require.Len(t, checkpoint.Results(), 1)
+}
+
+func TestVINIssuer_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	vin := NewMockVINService(ctrl)
+	issuer := &backfill.VINIssuer{VIN: vin}
+
+	vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(1)).Return(&vinvc.EligibilityReport{Eligible: true}, nil)
+	require.NoError(t, issuer.Check(context.Background(), 1))
+
+	vin.EXPECT().CheckVINEligibility(gomock.Any(), uint32(2)).Return(&vinvc.EligibilityReport{Reason: "noFingerprints", Message: "No fingerprint messages found"}, nil)
+	err := issuer.Check(context.Background(), 2)
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, richErr.Code)
+	require.Equal(t, "No fingerprint messages found", richErr.ExternalMsg)
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		tokenRange string
+		want       []uint32
+		wantErr    bool
+	}{
+		{tokenRange: "3-5", want: []uint32{3, 4, 5}},
+		{tokenRange: "7", want: []uint32{7}},
+		{tokenRange: "5-3", wantErr: true},
+		{tokenRange: "a-3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tokenRange, func(t *testing.T) {
+			got, err := backfill.ParseRange(tt.tokenRange)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadTokenIDs(t *testing.T) {
+	got, err := backfill.ReadTokenIDs(strings.NewReader("tokenId,vin\n1,VIN1\n\n 2,VIN2\n"))
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 2}, got)
+
+	_, err = backfill.ReadTokenIDs(strings.NewReader("1\nabc\n"))
+	require.Error(t, err)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := backfill.WriteCSV(&buf, []backfill.Result{
+		{TokenID: 1, Status: backfill.StatusIneligible, Reason: "No fingerprints, yet"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tokenId,status,reason,eventId,time\n1,ineligible,\"No fingerprints, yet\",,0001-01-01T00:00:00Z\n", buf.String())
+}
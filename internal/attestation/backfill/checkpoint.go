@@ -0,0 +1,129 @@
+package backfill
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+)
+
+// FileCheckpoint is a Checkpoint appending the results to a file as JSON lines. Vehicles whose attestation was
+// issued or that are not eligible are done, the others are processed again when the backfill resumes.
+type FileCheckpoint struct {
+	mtx     sync.Mutex
+	file    *os.File
+	results map[uint32]Result
+}
+
+// OpenFileCheckpoint opens the checkpoint file at path with the results of the previous runs, creating it if it does
+// not exist.
+func OpenFileCheckpoint(path string) (*FileCheckpoint, error) {
+	results := map[uint32]Result{}
+	existing, err := os.Open(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	default:
+		defer existing.Close() //nolint:errcheck
+		scanner := bufio.NewScanner(existing)
+		for line := 1; scanner.Scan(); line++ {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+			var result Result
+			if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+				return nil, fmt.Errorf("failed to parse checkpoint line %d: %w", line, err)
+			}
+			results[result.TokenID] = result
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	return &FileCheckpoint{file: file, results: results}, nil
+}
+
+// Done reports whether the attestation of the vehicle was issued or the vehicle is not eligible.
+func (c *FileCheckpoint) Done(tokenID uint32) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	result, ok := c.results[tokenID]
+	return ok && (result.Status == StatusIssued || result.Status == StatusIneligible)
+}
+
+// Record appends the result to the checkpoint file.
+func (c *FileCheckpoint) Record(result Result) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	c.results[result.TokenID] = result
+	return nil
+}
+
+// Results returns the latest result of every vehicle in the checkpoint, ordered by token ID.
+func (c *FileCheckpoint) Results() []Result {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	results := make([]Result, 0, len(c.results))
+	for _, result := range c.results {
+		results = append(results, result)
+	}
+	sortResults(results)
+	return results
+}
+
+// Close closes the checkpoint file.
+func (c *FileCheckpoint) Close() error {
+	return c.file.Close()
+}
+
+// MemoryCheckpoint is a Checkpoint keeping the results in memory, for backfills that are not resumed.
+type MemoryCheckpoint struct {
+	mtx     sync.Mutex
+	results []Result
+}
+
+// Done reports false, every vehicle is processed.
+func (*MemoryCheckpoint) Done(uint32) bool {
+	return false
+}
+
+// Record keeps the result.
+func (c *MemoryCheckpoint) Record(result Result) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.results = append(c.results, result)
+	return nil
+}
+
+// Results returns the recorded results ordered by token ID.
+func (c *MemoryCheckpoint) Results() []Result {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	results := append([]Result{}, c.results...)
+	sortResults(results)
+	return results
+}
+
+func sortResults(results []Result) {
+	slices.SortFunc(results, func(a, b Result) int {
+		return cmp.Compare(a.TokenID, b.TokenID)
+	})
+}
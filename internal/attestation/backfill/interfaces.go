@@ -0,0 +1,34 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Issuer defines the interface for issuing one type of attestation for vehicles.
+type Issuer interface {
+	// Issue creates and stores the attestation of the vehicle.
+	Issue(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
+	// Check returns nil when the attestation of the vehicle would be issued, or the reason it would not.
+	Check(ctx context.Context, tokenID uint32) error
+}
+
+// Checkpoint defines the interface for recording the results of a backfill so that it can be resumed.
+type Checkpoint interface {
+	// Done reports whether the vehicle does not need to be processed again.
+	Done(tokenID uint32) bool
+	Record(result Result) error
+}
+
+// VINService defines the interface for issuing VIN attestations.
+type VINService interface {
+	CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
+	CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error)
+}
+
+// VehicleTokenSource defines the interface for getting vehicle tokens obtained by the service.
+type VehicleTokenSource interface {
+	GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error)
+}
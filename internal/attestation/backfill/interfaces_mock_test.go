@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=backfill_test
+//
+
+// Package backfill_test is a generated GoMock package.
+package backfill_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	backfill "github.com/DIMO-Network/attestation-api/internal/attestation/backfill"
+	vinvc "github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIssuer is a mock of Issuer interface.
+type MockIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockIssuerMockRecorder is the mock recorder for MockIssuer.
+type MockIssuerMockRecorder struct {
+	mock *MockIssuer
+}
+
+// NewMockIssuer creates a new mock instance.
+func NewMockIssuer(ctrl *gomock.Controller) *MockIssuer {
+	mock := &MockIssuer{ctrl: ctrl}
+	mock.recorder = &MockIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuer) EXPECT() *MockIssuerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIssuer) Check(ctx context.Context, tokenID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIssuerMockRecorder) Check(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIssuer)(nil).Check), ctx, tokenID)
+}
+
+// Issue mocks base method.
+func (m *MockIssuer) Issue(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, tokenID)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockIssuerMockRecorder) Issue(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), ctx, tokenID)
+}
+
+// MockCheckpoint is a mock of Checkpoint interface.
+type MockCheckpoint struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckpointMockRecorder
+	isgomock struct{}
+}
+
+// MockCheckpointMockRecorder is the mock recorder for MockCheckpoint.
+type MockCheckpointMockRecorder struct {
+	mock *MockCheckpoint
+}
+
+// NewMockCheckpoint creates a new mock instance.
+func NewMockCheckpoint(ctrl *gomock.Controller) *MockCheckpoint {
+	mock := &MockCheckpoint{ctrl: ctrl}
+	mock.recorder = &MockCheckpointMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckpoint) EXPECT() *MockCheckpointMockRecorder {
+	return m.recorder
+}
+
+// Done mocks base method.
+func (m *MockCheckpoint) Done(tokenID uint32) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Done", tokenID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Done indicates an expected call of Done.
+func (mr *MockCheckpointMockRecorder) Done(tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockCheckpoint)(nil).Done), tokenID)
+}
+
+// Record mocks base method.
+func (m *MockCheckpoint) Record(result backfill.Result) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockCheckpointMockRecorder) Record(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockCheckpoint)(nil).Record), result)
+}
+
+// MockVINService is a mock of VINService interface.
+type MockVINService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVINServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVINServiceMockRecorder is the mock recorder for MockVINService.
+type MockVINServiceMockRecorder struct {
+	mock *MockVINService
+}
+
+// NewMockVINService creates a new mock instance.
+func NewMockVINService(ctrl *gomock.Controller) *MockVINService {
+	mock := &MockVINService{ctrl: ctrl}
+	mock.recorder = &MockVINServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVINService) EXPECT() *MockVINServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckVINEligibility mocks base method.
+func (m *MockVINService) CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckVINEligibility", ctx, tokenID)
+	ret0, _ := ret[0].(*vinvc.EligibilityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckVINEligibility indicates an expected call of CheckVINEligibility.
+func (mr *MockVINServiceMockRecorder) CheckVINEligibility(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckVINEligibility", reflect.TypeOf((*MockVINService)(nil).CheckVINEligibility), ctx, tokenID)
+}
+
+// CreateAndStoreVINAttestation mocks base method.
+func (m *MockVINService) CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAndStoreVINAttestation", ctx, tokenID)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAndStoreVINAttestation indicates an expected call of CreateAndStoreVINAttestation.
+func (mr *MockVINServiceMockRecorder) CreateAndStoreVINAttestation(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndStoreVINAttestation", reflect.TypeOf((*MockVINService)(nil).CreateAndStoreVINAttestation), ctx, tokenID)
+}
+
+// MockVehicleTokenSource is a mock of VehicleTokenSource interface.
+type MockVehicleTokenSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleTokenSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleTokenSourceMockRecorder is the mock recorder for MockVehicleTokenSource.
+type MockVehicleTokenSourceMockRecorder struct {
+	mock *MockVehicleTokenSource
+}
+
+// NewMockVehicleTokenSource creates a new mock instance.
+func NewMockVehicleTokenSource(ctrl *gomock.Controller) *MockVehicleTokenSource {
+	mock := &MockVehicleTokenSource{ctrl: ctrl}
+	mock.recorder = &MockVehicleTokenSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleTokenSource) EXPECT() *MockVehicleTokenSourceMockRecorder {
+	return m.recorder
+}
+
+// GetVehicleToken mocks base method.
+func (m *MockVehicleTokenSource) GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleToken", ctx, tokenID, permissions)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleToken indicates an expected call of GetVehicleToken.
+func (mr *MockVehicleTokenSourceMockRecorder) GetVehicleToken(ctx, tokenID, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleToken", reflect.TypeOf((*MockVehicleTokenSource)(nil).GetVehicleToken), ctx, tokenID, permissions)
+}
@@ -0,0 +1,70 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+)
+
+// VINIssuer issues VIN attestations.
+type VINIssuer struct {
+	VIN VINService
+}
+
+// Issue creates and stores the VIN attestation of the vehicle.
+func (v *VINIssuer) Issue(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	return v.VIN.CreateAndStoreVINAttestation(ctx, tokenID)
+}
+
+// Check returns the reason the vehicle is not eligible for a VIN attestation.
+func (v *VINIssuer) Check(ctx context.Context, tokenID uint32) error {
+	report, err := v.VIN.CheckVINEligibility(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if !report.Eligible {
+		return richerrors.Error{
+			Err:         fmt.Errorf("vehicle %d is not eligible: %s", tokenID, report.Reason),
+			ExternalMsg: report.Message,
+			Code:        http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// TelemetryIssuer issues attestations created from the telemetry of the vehicles, read with vehicle tokens that
+// the service obtains for itself.
+type TelemetryIssuer struct {
+	Tokens VehicleTokenSource
+	// Permissions are the permissions of the vehicle tokens Create needs.
+	Permissions []string
+	// Create creates and stores the attestation of the vehicle with the vehicle token.
+	Create func(ctx context.Context, tokenID uint32, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// Issue creates and stores the attestation of the vehicle.
+func (t *TelemetryIssuer) Issue(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	jwtToken, err := t.vehicleToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return t.Create(ctx, tokenID, jwtToken)
+}
+
+// Check returns the reason a vehicle token with the permissions cannot be obtained for the vehicle.
+// The telemetry itself is only read when the attestation is issued.
+func (t *TelemetryIssuer) Check(ctx context.Context, tokenID uint32) error {
+	_, err := t.vehicleToken(ctx, tokenID)
+	return err
+}
+
+func (t *TelemetryIssuer) vehicleToken(ctx context.Context, tokenID uint32) (string, error) {
+	jwtToken, err := t.Tokens.GetVehicleToken(ctx, tokenID, t.Permissions)
+	if err != nil {
+		return "", fmt.Errorf("failed to get vehicle token: %w", err)
+	}
+	return jwtToken, nil
+}
@@ -0,0 +1,100 @@
+package backfill
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRange returns the token IDs of an inclusive range written as "from-to", or of a single token ID.
+func ParseRange(tokenRange string) ([]uint32, error) {
+	fromStr, toStr, isRange := strings.Cut(tokenRange, "-")
+	from, err := strconv.ParseUint(strings.TrimSpace(fromStr), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token ID range %q: %w", tokenRange, err)
+	}
+	to := from
+	if isRange {
+		to, err = strconv.ParseUint(strings.TrimSpace(toStr), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token ID range %q: %w", tokenRange, err)
+		}
+	}
+	if to < from {
+		return nil, fmt.Errorf("invalid token ID range %q: end is before start", tokenRange)
+	}
+	tokenIDs := make([]uint32, 0, to-from+1)
+	for tokenID := from; tokenID <= to; tokenID++ {
+		tokenIDs = append(tokenIDs, uint32(tokenID))
+	}
+	return tokenIDs, nil
+}
+
+// ReadTokenIDs reads the token IDs in the first column of a CSV, skipping a header row.
+func ReadTokenIDs(r io.Reader) ([]uint32, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	var tokenIDs []uint32
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return tokenIDs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token IDs: %w", err)
+		}
+		value := strings.TrimSpace(record[0])
+		if value == "" {
+			continue
+		}
+		tokenID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			if row == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid token ID %q on row %d: %w", value, row, err)
+		}
+		tokenIDs = append(tokenIDs, uint32(tokenID))
+	}
+}
+
+// WriteCSV writes the results as CSV with a header row.
+func WriteCSV(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"tokenId", "status", "reason", "eventId", "time"}); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	for _, result := range results {
+		err := writer.Write([]string{
+			strconv.FormatUint(uint64(result.TokenID), 10),
+			result.Status,
+			result.Reason,
+			result.EventID,
+			result.Time.Format(time.RFC3339),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the results as a JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(results); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
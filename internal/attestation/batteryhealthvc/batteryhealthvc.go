@@ -0,0 +1,193 @@
+// Package batteryhealthvc attests the state of health of EV traction batteries.
+package batteryhealthvc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/erc191"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+)
+
+// TelemetryPermissions are the vehicle permissions needed to read the telemetry of a BatteryHealthVC.
+var TelemetryPermissions = []string{tokenclaims.PermissionGetNonLocationHistory}
+
+const (
+	// MaxTimeRange is the longest time range a battery attestation can cover.
+	MaxTimeRange = 90 * 24 * time.Hour
+	// MethodVersion is the version of the estimation method, bumped whenever the estimates change.
+	MethodVersion = "batteryhealth-method/v1.0.0"
+
+	// sampleInterval is the aggregation interval of the queried telemetry, short enough to separate charge sessions.
+	sampleInterval = "15m"
+)
+
+// batterySignals are the telemetry signals the battery attestation is derived from.
+var batterySignals = []string{
+	vss.FieldPowertrainTractionBatteryGrossCapacity,
+	vss.FieldPowertrainTractionBatteryStateOfHealth,
+	vss.FieldPowertrainTractionBatteryStateOfChargeCurrent,
+	vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy,
+	vss.FieldPowertrainTractionBatteryRange,
+	vss.FieldPowertrainTractionBatteryChargingIsCharging,
+	vss.FieldPowertrainTractionBatteryChargingPower,
+	vss.FieldPowertrainTractionBatteryChargingAddedEnergy,
+}
+
+// Service handles BatteryHealthVC-related operations.
+type Service struct {
+	vcRepo                 VCRepo
+	identityAPI            IdentityAPI
+	telemetryAPI           TelemetryAPI
+	vehicleContractAddress common.Address
+	chainID                uint64
+	privateKey             *ecdsa.PrivateKey
+	dataVersion            string
+	devLicense             common.Address
+}
+
+// NewService creates a new Service for BatteryHealthVC operations.
+func NewService(
+	vcRepo VCRepo,
+	identityAPI IdentityAPI,
+	telemetryAPI TelemetryAPI,
+	settings *config.Settings,
+	privateKey *ecdsa.PrivateKey,
+) *Service {
+	return &Service{
+		vcRepo:                 vcRepo,
+		identityAPI:            identityAPI,
+		telemetryAPI:           telemetryAPI,
+		vehicleContractAddress: common.HexToAddress(settings.VehicleNFTAddress),
+		chainID:                uint64(settings.DIMORegistryChainID),
+		privateKey:             privateKey,
+		dataVersion:            "batteryhealth/v1.0.0",
+		devLicense:             common.HexToAddress(settings.DevLicense),
+	}
+}
+
+// CreateBatteryHealthVC creates a BatteryHealthVC from the battery telemetry within the time range.
+func (s *Service) CreateBatteryHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, jwtToken string) (*cloudevent.RawEvent, error) {
+	if endTime.Before(startTime) || endTime.Sub(startTime) > MaxTimeRange {
+		return nil, richerrors.Error{
+			Err:         fmt.Errorf("invalid battery time range %s - %s", startTime, endTime),
+			ExternalMsg: "Time range must be ordered and cannot exceed 90 days",
+			Code:        http.StatusBadRequest,
+		}
+	}
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         s.chainID,
+		TokenID:         big.NewInt(int64(tokenID)),
+		ContractAddress: s.vehicleContractAddress,
+	}
+
+	// Get vehicle information to resolve the data sources
+	vehicleInfo, err := s.identityAPI.GetVehicleInfo(ctx, vehicleDID)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
+	}
+
+	options := telemetryapi.TelemetryHistoricalOptions{
+		TokenID:   vehicleDID.TokenID,
+		StartDate: startTime,
+		EndDate:   endTime,
+		Interval:  sampleInterval,
+		Signals:   batterySignals,
+	}
+	signals, err := s.telemetryAPI.GetHistoricalDataWithAuth(ctx, options, jwtToken)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get battery telemetry data", Code: http.StatusInternalServerError}
+	}
+	slices.SortFunc(signals, func(i, j telemetryapi.Signal) int {
+		// sort in descending order
+		return -i.Timestamp.Compare(j.Timestamp)
+	})
+
+	subject := estimateBatteryHealth(signals)
+	if subject.GrossCapacity == nil && subject.NetCapacity == nil && subject.StateOfHealth == nil && subject.ChargeSessions == 0 {
+		return nil, richerrors.Error{
+			Err:         errors.New("no battery data found"),
+			ExternalMsg: "No battery data found in the specified time range",
+			Code:        http.StatusNotFound,
+		}
+	}
+
+	dataSources := vehicleInfo.DataSources(signals)
+	subject.VehicleDID = vehicleDID
+	subject.SearchedTimeRange = types.TimeRange{Start: startTime, End: endTime}
+	subject.Producer = dataSources[0].Producer
+	subject.DataSources = dataSources
+
+	vc, err := s.createAttestation(subject)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create BatteryHealthVC", Code: http.StatusInternalServerError}
+	}
+
+	if err = s.vcRepo.UploadAttestation(ctx, vc); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to store BatteryHealthVC", Code: http.StatusInternalServerError}
+	}
+
+	return vc, nil
+}
+
+// createAttestation creates the attestation cloud event.
+func (s *Service) createAttestation(subject types.BatteryHealthVCSubject) (*cloudevent.RawEvent, error) {
+	issuanceDate := time.Now().UTC()
+	expirationDate := issuanceDate.Add(30 * 24 * time.Hour) // Valid for 30 days
+
+	credential := types.Credential{
+		ValidFrom: issuanceDate,
+		ValidTo:   expirationDate,
+	}
+
+	rawSubject, err := json.Marshal(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential subject: %w", err)
+	}
+	credential.CredentialSubject = rawSubject
+
+	marshaledCreds, err := json.Marshal(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential: %w", err)
+	}
+
+	signature, err := erc191.SignMessage(marshaledCreds, s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign credential: %w", err)
+	}
+
+	cloudEvent := cloudevent.CloudEvent[json.RawMessage]{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			SpecVersion:     "1.0",
+			ID:              ksuid.New().String(),
+			Time:            issuanceDate,
+			Source:          s.devLicense.Hex(),
+			Subject:         subject.VehicleDID.String(),
+			Producer:        subject.Producer,
+			Type:            cloudevent.TypeAttestation,
+			DataContentType: "application/json",
+			DataVersion:     s.dataVersion,
+			Signature:       signature,
+			Tags:            []string{"vehicle.battery", "vehicle"},
+		},
+		Data: marshaledCreds,
+	}
+
+	return &cloudEvent, nil
+}
@@ -0,0 +1,253 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=batteryhealthvc_test
+package batteryhealthvc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const producer = "did:erc721:137:0x9c94C395cBcBDe662235E0A9d3bB87Ad708561BA:42"
+
+func setupTestService(t *testing.T) (*batteryhealthvc.Service, *MockVCRepo, *MockIdentityAPI, *MockTelemetryAPI, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+
+	mockVCRepo := NewMockVCRepo(ctrl)
+	mockIdentityAPI := NewMockIdentityAPI(ctrl)
+	mockTelemetryAPI := NewMockTelemetryAPI(ctrl)
+
+	settings := &config.Settings{
+		VehicleNFTAddress:   "0x1234567890123456789012345678901234567890",
+		DIMORegistryChainID: 137,
+		DevLicense:          "0x49eAf63eD94FEf3d40692862Eee2C8dB416B1a5f",
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	service := batteryhealthvc.NewService(
+		mockVCRepo,
+		mockIdentityAPI,
+		mockTelemetryAPI,
+		settings,
+		privateKey,
+	)
+
+	return service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl
+}
+
+// sample returns the signals of one aggregation interval.
+func sample(timestamp time.Time, values map[string]float64) []telemetryapi.Signal {
+	var signals []telemetryapi.Signal
+	for name, value := range values {
+		signals = append(signals, telemetryapi.Signal{Name: name, Value: value, Timestamp: timestamp, Producer: producer})
+	}
+	return signals
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func TestCreateBatteryHealthVC(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	var chargingHistory []telemetryapi.Signal
+	for _, s := range [][]telemetryapi.Signal{
+		sample(at(0), map[string]float64{
+			vss.FieldPowertrainTractionBatteryGrossCapacity:              82,
+			vss.FieldPowertrainTractionBatteryChargingIsCharging:         0,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrent:       60,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy: 45,
+			vss.FieldPowertrainTractionBatteryRange:                      240,
+		}),
+		// AC session
+		sample(at(1), map[string]float64{
+			vss.FieldPowertrainTractionBatteryChargingIsCharging:         1,
+			vss.FieldPowertrainTractionBatteryChargingPower:              7,
+			vss.FieldPowertrainTractionBatteryChargingAddedEnergy:        5,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrent:       70,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy: 52.5,
+		}),
+		sample(at(3), map[string]float64{
+			vss.FieldPowertrainTractionBatteryChargingPower:              7,
+			vss.FieldPowertrainTractionBatteryChargingAddedEnergy:        20,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrent:       90,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy: 67.5,
+			vss.FieldPowertrainTractionBatteryRange:                      360,
+		}),
+		sample(at(4), map[string]float64{vss.FieldPowertrainTractionBatteryChargingIsCharging: 0}),
+		// DC fast charge session
+		sample(at(10), map[string]float64{
+			vss.FieldPowertrainTractionBatteryChargingIsCharging:   1,
+			vss.FieldPowertrainTractionBatteryChargingPower:        150,
+			vss.FieldPowertrainTractionBatteryChargingAddedEnergy:  30,
+			vss.FieldPowertrainTractionBatteryStateOfChargeCurrent: 80,
+			vss.FieldPowertrainTractionBatteryRange:                300,
+		}),
+		sample(at(11), map[string]float64{
+			vss.FieldPowertrainTractionBatteryChargingIsCharging: 0,
+			vss.FieldPowertrainTractionBatteryStateOfHealth:      94,
+		}),
+	} {
+		chargingHistory = append(chargingHistory, s...)
+	}
+
+	tests := []struct {
+		name            string
+		signals         []telemetryapi.Signal
+		expectedSubject types.BatteryHealthVCSubject
+	}{
+		{
+			name:    "charge sessions",
+			signals: chargingHistory,
+			expectedSubject: types.BatteryHealthVCSubject{
+				GrossCapacity:       ptr(82),
+				NetCapacity:         ptr(75),
+				StateOfHealth:       ptr(94),
+				FullChargeRange:     ptr(387.5), // median of 400 and 375 km
+				ChargeSessions:      2,
+				DCFastChargeShare:   ptr(60), // 30 of 50 kWh
+				EvidenceSampleCount: len(chargingHistory),
+			},
+		},
+		{
+			name: "sessions without added energy are counted",
+			signals: append(
+				sample(at(0), map[string]float64{vss.FieldPowertrainTractionBatteryChargingIsCharging: 1, vss.FieldPowertrainTractionBatteryChargingPower: 11}),
+				sample(at(2), map[string]float64{vss.FieldPowertrainTractionBatteryChargingIsCharging: 0, vss.FieldPowertrainTractionBatteryStateOfHealth: 99})...,
+			),
+			expectedSubject: types.BatteryHealthVCSubject{
+				StateOfHealth:       ptr(99),
+				ChargeSessions:      1,
+				DCFastChargeShare:   ptr(0),
+				EvidenceSampleCount: 4,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+
+			startTime := base
+			endTime := base.Add(24 * time.Hour)
+			jwtToken := "test-jwt-token"
+
+			mockIdentityAPI.EXPECT().
+				GetVehicleInfo(gomock.Any(), gomock.Any()).
+				Return(&models.VehicleInfo{}, nil)
+			mockTelemetryAPI.EXPECT().
+				GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+				DoAndReturn(func(ctx context.Context, options telemetryapi.TelemetryHistoricalOptions, jwt string) ([]telemetryapi.Signal, error) {
+					assert.Equal(t, startTime, options.StartDate)
+					assert.Equal(t, endTime, options.EndDate)
+					assert.Contains(t, options.Signals, vss.FieldPowertrainTractionBatteryStateOfHealth)
+					return tt.signals, nil
+				})
+
+			var uploadedAttestation *cloudevent.RawEvent
+			mockVCRepo.EXPECT().
+				UploadAttestation(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+					uploadedAttestation = attestation
+					return nil
+				})
+
+			_, err := service.CreateBatteryHealthVC(context.Background(), uint32(123), startTime, endTime, jwtToken)
+			require.NoError(t, err)
+
+			assert.Equal(t, cloudevent.TypeAttestation, uploadedAttestation.Type)
+			assert.Equal(t, "batteryhealth/v1.0.0", uploadedAttestation.DataVersion)
+			assert.Equal(t, producer, uploadedAttestation.Producer)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+			var subject types.BatteryHealthVCSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subject))
+
+			assert.Equal(t, batteryhealthvc.MethodVersion, subject.MethodVersion)
+			assert.Equal(t, types.TimeRange{Start: startTime, End: endTime}, subject.SearchedTimeRange)
+			assert.Equal(t, []types.DataSource{{Producer: producer}}, subject.DataSources)
+			assert.Equal(t, tt.expectedSubject.GrossCapacity, subject.GrossCapacity)
+			assert.Equal(t, tt.expectedSubject.NetCapacity, subject.NetCapacity)
+			assert.Equal(t, tt.expectedSubject.StateOfHealth, subject.StateOfHealth)
+			assert.Equal(t, tt.expectedSubject.FullChargeRange, subject.FullChargeRange)
+			assert.Equal(t, tt.expectedSubject.ChargeSessions, subject.ChargeSessions)
+			assert.Equal(t, tt.expectedSubject.DCFastChargeShare, subject.DCFastChargeShare)
+			assert.Equal(t, tt.expectedSubject.EvidenceSampleCount, subject.EvidenceSampleCount)
+		})
+	}
+}
+
+func TestCreateBatteryHealthVC_Errors(t *testing.T) {
+	startTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		endTime      time.Time
+		signals      []telemetryapi.Signal
+		telemetryErr error
+		expectedCode int
+	}{
+		{
+			name:         "time range too long",
+			endTime:      startTime.Add(batteryhealthvc.MaxTimeRange + time.Hour),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "telemetry error",
+			endTime:      startTime.Add(24 * time.Hour),
+			telemetryErr: errors.New("telemetry unavailable"),
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "no battery data",
+			endTime: startTime.Add(24 * time.Hour),
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTractionBatteryStateOfChargeCurrent, Value: 40.0, Timestamp: startTime},
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+
+			if tt.expectedCode != http.StatusBadRequest {
+				mockIdentityAPI.EXPECT().
+					GetVehicleInfo(gomock.Any(), gomock.Any()).
+					Return(&models.VehicleInfo{}, nil)
+				mockTelemetryAPI.EXPECT().
+					GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tt.signals, tt.telemetryErr)
+			}
+
+			_, err := service.CreateBatteryHealthVC(context.Background(), uint32(123), startTime, tt.endTime, "test-jwt-token")
+
+			var richErr richerrors.Error
+			require.ErrorAs(t, err, &richErr)
+			assert.Equal(t, tt.expectedCode, richErr.Code)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package batteryhealthvc
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+)
+
+const (
+	// minEstimateStateOfCharge is the lowest state of charge, in percent, extrapolated to a full battery.
+	// Extrapolating from an almost empty battery multiplies the rounding error of the readings.
+	minEstimateStateOfCharge = 50.0
+	// maxACChargingPower is the highest charging power in kW an AC charger delivers; faster sessions are DC fast charges.
+	// Charging power is queried as the peak of each interval, so short DC peaks are not missed.
+	maxACChargingPower = 22.0
+)
+
+// batterySample holds the battery readings of a single aggregation interval.
+type batterySample struct {
+	timestamp time.Time
+	values    map[string]float64
+}
+
+func (b batterySample) value(name string) (float64, bool) {
+	value, ok := b.values[name]
+	return value, ok
+}
+
+// chargeSession is a run of consecutive samples reporting the battery as charging.
+type chargeSession struct {
+	peakPower   float64
+	addedEnergy float64
+	// fullChargeRange is the range extrapolated to 100% from the last sample of the session with range and state of charge.
+	fullChargeRange *float64
+}
+
+// estimateBatteryHealth derives the battery attestation from the signals, sorted newest first.
+func estimateBatteryHealth(signals []telemetryapi.Signal) types.BatteryHealthVCSubject {
+	samples := groupSamples(signals)
+
+	subject := types.BatteryHealthVCSubject{
+		GrossCapacity:       latestValue(samples, vss.FieldPowertrainTractionBatteryGrossCapacity),
+		StateOfHealth:       latestValue(samples, vss.FieldPowertrainTractionBatteryStateOfHealth),
+		NetCapacity:         estimateNetCapacity(samples),
+		EvidenceSampleCount: len(signals),
+		MethodVersion:       MethodVersion,
+	}
+
+	sessions := chargeSessions(samples)
+	subject.ChargeSessions = len(sessions)
+	if len(sessions) == 0 {
+		return subject
+	}
+
+	var ranges []float64
+	var dcSessions int
+	var dcEnergy, totalEnergy float64
+	for _, session := range sessions {
+		if session.fullChargeRange != nil {
+			ranges = append(ranges, *session.fullChargeRange)
+		}
+		totalEnergy += session.addedEnergy
+		if session.peakPower > maxACChargingPower {
+			dcSessions++
+			dcEnergy += session.addedEnergy
+		}
+	}
+	subject.FullChargeRange = median(ranges)
+
+	// Weigh sessions by the energy they added, or count them when the vehicle does not report it
+	share := float64(dcSessions) / float64(len(sessions)) * 100
+	if totalEnergy > 0 {
+		share = dcEnergy / totalEnergy * 100
+	}
+	share = round(share)
+	subject.DCFastChargeShare = &share
+
+	return subject
+}
+
+// groupSamples groups the signals by timestamp into samples ordered oldest first.
+func groupSamples(signals []telemetryapi.Signal) []batterySample {
+	byTimestamp := make(map[time.Time]*batterySample)
+	var samples []*batterySample
+	for _, signal := range signals {
+		value, ok := signal.Value.(float64)
+		if !ok {
+			continue
+		}
+		sample, ok := byTimestamp[signal.Timestamp]
+		if !ok {
+			sample = &batterySample{timestamp: signal.Timestamp, values: make(map[string]float64)}
+			byTimestamp[signal.Timestamp] = sample
+			samples = append(samples, sample)
+		}
+		sample.values[signal.Name] = value
+	}
+
+	ordered := make([]batterySample, 0, len(samples))
+	for _, sample := range samples {
+		ordered = append(ordered, *sample)
+	}
+	slices.SortFunc(ordered, func(a, b batterySample) int {
+		return a.timestamp.Compare(b.timestamp)
+	})
+	return ordered
+}
+
+// latestValue returns the newest reading of the signal.
+func latestValue(samples []batterySample, name string) *float64 {
+	for i := len(samples) - 1; i >= 0; i-- {
+		if value, ok := samples[i].value(name); ok {
+			return &value
+		}
+	}
+	return nil
+}
+
+// estimateNetCapacity is the median of the stored energy divided by the state of charge.
+func estimateNetCapacity(samples []batterySample) *float64 {
+	var capacities []float64
+	for _, sample := range samples {
+		if capacity, ok := extrapolate(sample, vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy); ok {
+			capacities = append(capacities, capacity)
+		}
+	}
+	return median(capacities)
+}
+
+// chargeSessions splits the samples into charge sessions. Samples that do not report whether the
+// battery is charging continue the current state.
+func chargeSessions(samples []batterySample) []chargeSession {
+	var sessions []chargeSession
+	var current *chargeSession
+	for _, sample := range samples {
+		if isCharging, ok := sample.value(vss.FieldPowertrainTractionBatteryChargingIsCharging); ok {
+			switch {
+			case isCharging > 0 && current == nil:
+				current = &chargeSession{}
+			case isCharging <= 0 && current != nil:
+				sessions = append(sessions, *current)
+				current = nil
+			}
+		}
+		if current == nil {
+			continue
+		}
+
+		if power, ok := sample.value(vss.FieldPowertrainTractionBatteryChargingPower); ok {
+			current.peakPower = max(current.peakPower, power)
+		}
+		// Added energy accumulates over the session
+		if energy, ok := sample.value(vss.FieldPowertrainTractionBatteryChargingAddedEnergy); ok {
+			current.addedEnergy = max(current.addedEnergy, energy)
+		}
+		if fullRange, ok := extrapolate(sample, vss.FieldPowertrainTractionBatteryRange); ok {
+			current.fullChargeRange = &fullRange
+		}
+	}
+	if current != nil {
+		sessions = append(sessions, *current)
+	}
+	return sessions
+}
+
+// extrapolate scales the reading of the signal to a full battery using the state of charge of the same sample.
+func extrapolate(sample batterySample, name string) (float64, bool) {
+	value, ok := sample.value(name)
+	if !ok {
+		return 0, false
+	}
+	stateOfCharge, ok := sample.value(vss.FieldPowertrainTractionBatteryStateOfChargeCurrent)
+	if !ok || stateOfCharge < minEstimateStateOfCharge || stateOfCharge > 100 {
+		return 0, false
+	}
+	return value / stateOfCharge * 100, true
+}
+
+func median(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	result := sorted[mid]
+	if len(sorted)%2 == 0 {
+		result = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	result = round(result)
+	return &result
+}
+
+// round rounds to one decimal, estimates are not more precise than that.
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Runner defines the interface for creating the attestation of a job.
+type Runner interface {
+	RunJob(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error)
+}
+
+// VehicleTokenSource defines the interface for getting vehicle tokens obtained by the service.
+type VehicleTokenSource interface {
+	GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error)
+}
+
+// WebhookSender defines the interface for calling webhooks.
+type WebhookSender interface {
+	Send(ctx context.Context, webhookURL string, payload any) error
+}
+
+// Store defines the interface for persisting jobs and the job queue.
+type Store interface {
+	CreateJob(ctx context.Context, job *models.Job) error
+	GetJob(ctx context.Context, id string) (*models.Job, error)
+	UpdateJob(ctx context.Context, id string, update func(*models.Job) error) (*models.Job, error)
+	ClaimJob(ctx context.Context, now time.Time) (*models.Job, error)
+	CountQueuedJobs(ctx context.Context) (int, error)
+	RequeueRunningJobs(ctx context.Context) (int, error)
+	ListJobs(ctx context.Context, match func(*models.Job) bool) ([]models.Job, error)
+	DeleteJobsFinishedBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=jobs_test
+//
+
+// Package jobs_test is a generated GoMock package.
+package jobs_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunner is a mock of Runner interface.
+type MockRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockRunnerMockRecorder is the mock recorder for MockRunner.
+type MockRunnerMockRecorder struct {
+	mock *MockRunner
+}
+
+// NewMockRunner creates a new mock instance.
+func NewMockRunner(ctrl *gomock.Controller) *MockRunner {
+	mock := &MockRunner{ctrl: ctrl}
+	mock.recorder = &MockRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
+	return m.recorder
+}
+
+// RunJob mocks base method.
+func (m *MockRunner) RunJob(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunJob", ctx, job)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunJob indicates an expected call of RunJob.
+func (mr *MockRunnerMockRecorder) RunJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJob", reflect.TypeOf((*MockRunner)(nil).RunJob), ctx, job)
+}
+
+// MockVehicleTokenSource is a mock of VehicleTokenSource interface.
+type MockVehicleTokenSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleTokenSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleTokenSourceMockRecorder is the mock recorder for MockVehicleTokenSource.
+type MockVehicleTokenSourceMockRecorder struct {
+	mock *MockVehicleTokenSource
+}
+
+// NewMockVehicleTokenSource creates a new mock instance.
+func NewMockVehicleTokenSource(ctrl *gomock.Controller) *MockVehicleTokenSource {
+	mock := &MockVehicleTokenSource{ctrl: ctrl}
+	mock.recorder = &MockVehicleTokenSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleTokenSource) EXPECT() *MockVehicleTokenSourceMockRecorder {
+	return m.recorder
+}
+
+// GetVehicleToken mocks base method.
+func (m *MockVehicleTokenSource) GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleToken", ctx, tokenID, permissions)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleToken indicates an expected call of GetVehicleToken.
+func (mr *MockVehicleTokenSourceMockRecorder) GetVehicleToken(ctx, tokenID, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleToken", reflect.TypeOf((*MockVehicleTokenSource)(nil).GetVehicleToken), ctx, tokenID, permissions)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, webhookURL string, payload any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, webhookURL, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, webhookURL, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, webhookURL, payload)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimJob mocks base method.
+func (m *MockStore) ClaimJob(ctx context.Context, now time.Time) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", ctx, now)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockStoreMockRecorder) ClaimJob(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockStore)(nil).ClaimJob), ctx, now)
+}
+
+// CountQueuedJobs mocks base method.
+func (m *MockStore) CountQueuedJobs(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountQueuedJobs", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountQueuedJobs indicates an expected call of CountQueuedJobs.
+func (mr *MockStoreMockRecorder) CountQueuedJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQueuedJobs", reflect.TypeOf((*MockStore)(nil).CountQueuedJobs), ctx)
+}
+
+// CreateJob mocks base method.
+func (m *MockStore) CreateJob(ctx context.Context, job *models.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockStoreMockRecorder) CreateJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockStore)(nil).CreateJob), ctx, job)
+}
+
+// DeleteJobsFinishedBefore mocks base method.
+func (m *MockStore) DeleteJobsFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJobsFinishedBefore", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJobsFinishedBefore indicates an expected call of DeleteJobsFinishedBefore.
+func (mr *MockStoreMockRecorder) DeleteJobsFinishedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobsFinishedBefore", reflect.TypeOf((*MockStore)(nil).DeleteJobsFinishedBefore), ctx, before)
+}
+
+// GetJob mocks base method.
+func (m *MockStore) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockStoreMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockStore)(nil).GetJob), ctx, id)
+}
+
+// ListJobs mocks base method.
+func (m *MockStore) ListJobs(ctx context.Context, match func(*models.Job) bool) ([]models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, match)
+	ret0, _ := ret[0].([]models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockStoreMockRecorder) ListJobs(ctx, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockStore)(nil).ListJobs), ctx, match)
+}
+
+// RequeueRunningJobs mocks base method.
+func (m *MockStore) RequeueRunningJobs(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueRunningJobs", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueRunningJobs indicates an expected call of RequeueRunningJobs.
+func (mr *MockStoreMockRecorder) RequeueRunningJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueRunningJobs", reflect.TypeOf((*MockStore)(nil).RequeueRunningJobs), ctx)
+}
+
+// UpdateJob mocks base method.
+func (m *MockStore) UpdateJob(ctx context.Context, id string, update func(*models.Job) error) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJob", ctx, id, update)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockStoreMockRecorder) UpdateJob(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockStore)(nil).UpdateJob), ctx, id, update)
+}
@@ -0,0 +1,294 @@
+// Package jobs runs attestation requests in the background with a bounded pool of workers. Jobs are persisted,
+// so jobs queued or interrupted when the service stops run after it restarts. The caller's token is not persisted;
+// jobs reading telemetry get a vehicle token from the token exchange when they run.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// Retention is how long finished jobs can be polled before they are deleted.
+	Retention = 7 * 24 * time.Hour
+
+	defaultMaxQueued = 1000
+	jobTimeout       = 15 * time.Minute
+	pollInterval     = 5 * time.Second
+	pruneInterval    = time.Hour
+)
+
+// permissions are the privileges of the vehicle tokens of the job types reading telemetry.
+var permissions = map[string][]string{
+	models.JobTypeVehiclePosition:   vehiclepositionvc.TelemetryPermissions,
+	models.JobTypeOdometerStatement: odometerstatementvc.TelemetryPermissions,
+	models.JobTypeVehicleHealth:     vehiclehealthvc.TelemetryPermissions,
+	models.JobTypeBatteryHealth:     batteryhealthvc.TelemetryPermissions,
+}
+
+// Service queues and runs attestation jobs.
+type Service struct {
+	logger    *zerolog.Logger
+	store     Store
+	webhooks  WebhookSender
+	tokens    VehicleTokenSource
+	workers   int
+	maxQueued int
+	wake      chan struct{}
+}
+
+// NewService creates a new Service running jobs with settings.AsyncJobWorkers workers. Jobs reading telemetry are
+// rejected when tokens is nil.
+func NewService(logger *zerolog.Logger, store Store, webhooks WebhookSender, tokens VehicleTokenSource, settings *config.Settings) *Service {
+	maxQueued := settings.AsyncJobMaxQueued
+	if maxQueued <= 0 {
+		maxQueued = defaultMaxQueued
+	}
+	return &Service{
+		logger:    logger,
+		store:     store,
+		webhooks:  webhooks,
+		tokens:    tokens,
+		workers:   max(settings.AsyncJobWorkers, 1),
+		maxQueued: maxQueued,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Submit queues the job. Type, TokenID, Request and WebhookURL are taken from the job, and the requester from ctx.
+func (s *Service) Submit(ctx context.Context, job *models.Job) (*models.Job, error) {
+	if permissions[job.Type] != nil && s.tokens == nil {
+		return nil, richerrors.Error{
+			Err:         fmt.Errorf("no vehicle token source for %s", job.Type),
+			ExternalMsg: "Asynchronous " + job.Type + " attestations are disabled",
+			Code:        http.StatusBadRequest,
+		}
+	}
+	queued, err := s.store.CountQueuedJobs(ctx)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to queue job", Code: http.StatusInternalServerError}
+	}
+	if queued >= s.maxQueued {
+		return nil, richerrors.Error{Err: fmt.Errorf("%d jobs are queued", queued), ExternalMsg: "Too many queued jobs, try again later", Code: http.StatusServiceUnavailable}
+	}
+
+	record := &models.Job{
+		ID:         ksuid.New().String(),
+		Type:       job.Type,
+		TokenID:    job.TokenID,
+		Request:    job.Request,
+		Requester:  requester.FromContext(ctx),
+		WebhookURL: job.WebhookURL,
+		State:      models.JobStateQueued,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := s.store.CreateJob(ctx, record); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to queue job", Code: http.StatusInternalServerError}
+	}
+	s.notify()
+	return record, nil
+}
+
+// Get returns the job with the ID.
+func (s *Service) Get(ctx context.Context, id string) (*models.Job, error) {
+	return s.store.GetJob(ctx, id)
+}
+
+// Run runs the queued jobs with runner until ctx is canceled. Jobs left running by a previous process are queued
+// again and webhooks that were not delivered are retried.
+func (s *Service) Run(ctx context.Context, runner Runner) error {
+	requeued, err := s.store.RequeueRunningJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to requeue interrupted jobs: %w", err)
+	}
+	if requeued > 0 {
+		s.logger.Info().Int("count", requeued).Msg("Requeued interrupted attestation jobs.")
+	}
+	undelivered, err := s.store.ListJobs(ctx, func(job *models.Job) bool {
+		return job.Finished() && job.WebhookState == models.WebhookStatePending
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list undelivered webhooks: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range undelivered {
+		wg.Add(1)
+		go func(job *models.Job) {
+			defer wg.Done()
+			s.deliverWebhook(ctx, job)
+		}(&undelivered[i])
+	}
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, runner)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.prune(ctx)
+	}()
+	wg.Wait()
+	return nil
+}
+
+// notify wakes up an idle worker.
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) work(ctx context.Context, runner Runner) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := s.store.ClaimJob(ctx, time.Now().UTC())
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to claim attestation job.")
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			case <-ticker.C:
+			}
+			continue
+		}
+		// more jobs may be waiting for another idle worker
+		s.notify()
+		s.runJob(ctx, runner, job)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *Service) runJob(ctx context.Context, runner Runner, job *models.Job) {
+	logger := s.logger.With().Str("jobId", job.ID).Str("jobType", job.Type).Uint32("tokenId", job.TokenID).Logger()
+	runCtx, cancel := context.WithTimeout(requester.NewContext(ctx, job.Requester), jobTimeout)
+	result, runErr := s.run(runCtx, runner, job)
+	cancel()
+	if runErr != nil && ctx.Err() != nil {
+		// shutting down, the job is queued again on the next start
+		return
+	}
+
+	finished, err := s.store.UpdateJob(context.WithoutCancel(ctx), job.ID, func(j *models.Job) error {
+		now := time.Now().UTC()
+		j.FinishedAt = &now
+		if runErr != nil {
+			j.State = models.JobStateFailed
+			j.Error, j.ErrorCode = publicError(runErr)
+		} else {
+			j.State = models.JobStateSucceeded
+			j.Result = result
+		}
+		if j.WebhookURL != "" {
+			j.WebhookState = models.WebhookStatePending
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to store attestation job result.")
+		return
+	}
+	if runErr != nil {
+		logger.Warn().Err(runErr).Msg("Attestation job failed.")
+	}
+	s.deliverWebhook(ctx, finished)
+}
+
+// run gets a vehicle token for jobs reading telemetry and creates the attestation of the job.
+func (s *Service) run(ctx context.Context, runner Runner, job *models.Job) (*cloudevent.RawEvent, error) {
+	if perms := permissions[job.Type]; perms != nil {
+		if s.tokens == nil {
+			return nil, fmt.Errorf("no vehicle token source for %s", job.Type)
+		}
+		token, err := s.tokens.GetVehicleToken(ctx, job.TokenID, perms)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vehicle token: %w", err)
+		}
+		job.JWTToken = token
+	}
+	return runner.RunJob(ctx, job)
+}
+
+// deliverWebhook calls the webhook of a finished job and records the outcome.
+func (s *Service) deliverWebhook(ctx context.Context, job *models.Job) {
+	if job.WebhookURL == "" || job.WebhookState != models.WebhookStatePending {
+		return
+	}
+	state := models.WebhookStateDelivered
+	if err := s.webhooks.Send(ctx, job.WebhookURL, job.Status()); err != nil {
+		if ctx.Err() != nil {
+			// shutting down, the webhook is retried on the next start
+			return
+		}
+		s.logger.Warn().Err(err).Str("jobId", job.ID).Msg("Failed to deliver attestation job webhook.")
+		state = models.WebhookStateFailed
+	}
+	_, err := s.store.UpdateJob(context.WithoutCancel(ctx), job.ID, func(j *models.Job) error {
+		j.WebhookState = state
+		return nil
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Str("jobId", job.ID).Msg("Failed to store webhook state.")
+	}
+}
+
+// prune deletes the jobs that finished more than Retention ago.
+func (s *Service) prune(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		deleted, err := s.store.DeleteJobsFinishedBefore(ctx, time.Now().Add(-Retention))
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to delete expired attestation jobs.")
+		} else if deleted > 0 {
+			s.logger.Info().Int("count", deleted).Msg("Deleted expired attestation jobs.")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publicError returns the message and HTTP status code of err that can be shown to the caller.
+func publicError(err error) (string, int) {
+	var richErr richerrors.Error
+	if errors.As(err, &richErr) && richErr.ExternalMsg != "" {
+		code := richErr.Code
+		if code == 0 {
+			code = http.StatusInternalServerError
+		}
+		return richErr.ExternalMsg, code
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Attestation timed out", http.StatusGatewayTimeout
+	}
+	return "Failed to create attestation", http.StatusInternalServerError
+}
@@ -0,0 +1,190 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=jobs_test
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/jobs"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newService(t *testing.T, webhooks jobs.WebhookSender, tokens jobs.VehicleTokenSource, settings *config.Settings) *jobs.Service {
+	t.Helper()
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	return jobs.NewService(&logger, store, webhooks, tokens, settings)
+}
+
+// runService runs the service until the test ends.
+func runService(t *testing.T, service *jobs.Service, runner jobs.Runner) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- service.Run(ctx, runner) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func waitFinished(t *testing.T, service *jobs.Service, id string) *models.Job {
+	t.Helper()
+	var job *models.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = service.Get(context.Background(), id)
+		require.NoError(t, err)
+		return job.Finished() && job.WebhookState != models.WebhookStatePending
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestSubmit_QueueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := newService(t, NewMockWebhookSender(ctrl), nil, &config.Settings{AsyncJobWorkers: 1, AsyncJobMaxQueued: 1})
+
+	job, err := service.Submit(context.Background(), &models.Job{Type: models.JobTypeVIN, TokenID: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, job.ID)
+	require.Equal(t, models.JobStateQueued, job.State)
+
+	_, err = service.Submit(context.Background(), &models.Job{Type: models.JobTypeVIN, TokenID: 2})
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusServiceUnavailable, richErr.Code)
+}
+
+func TestSubmit_TelemetryWithoutTokenSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := newService(t, NewMockWebhookSender(ctrl), nil, &config.Settings{AsyncJobWorkers: 1})
+
+	_, err := service.Submit(context.Background(), &models.Job{Type: models.JobTypeOdometerStatement, TokenID: 1})
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, richErr.Code)
+}
+
+func TestRun(t *testing.T) {
+	vc := &cloudevent.RawEvent{
+		CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1", SpecVersion: "1.0"},
+		Data:             []byte(`{"vin":"1HGCM82673A123456"}`),
+	}
+	tests := []struct {
+		name                 string
+		webhookURL           string
+		tokenErr             error
+		runErr               error
+		webhookErr           error
+		expectedState        string
+		expectedError        string
+		expectedCode         int
+		expectedWebhookState string
+	}{
+		{
+			name:          "succeeded",
+			expectedState: models.JobStateSucceeded,
+		},
+		{
+			name:          "failed",
+			runErr:        richerrors.Error{Err: errors.New("no telemetry"), ExternalMsg: "No data found", Code: http.StatusNotFound},
+			expectedState: models.JobStateFailed,
+			expectedError: "No data found",
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "failed with internal error",
+			runErr:        errors.New("connection refused"),
+			expectedState: models.JobStateFailed,
+			expectedError: "Failed to create attestation",
+			expectedCode:  http.StatusInternalServerError,
+		},
+		{
+			name:          "failed to get vehicle token",
+			tokenErr:      errors.New("token exchange unavailable"),
+			expectedState: models.JobStateFailed,
+			expectedError: "Failed to create attestation",
+			expectedCode:  http.StatusInternalServerError,
+		},
+		{
+			name:                 "webhook delivered",
+			webhookURL:           "https://example.com/hooks",
+			expectedState:        models.JobStateSucceeded,
+			expectedWebhookState: models.WebhookStateDelivered,
+		},
+		{
+			name:                 "webhook failed",
+			webhookURL:           "https://example.com/hooks",
+			webhookErr:           errors.New("status 500"),
+			expectedState:        models.JobStateSucceeded,
+			expectedWebhookState: models.WebhookStateFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			runner := NewMockRunner(ctrl)
+			webhooks := NewMockWebhookSender(ctrl)
+			tokens := NewMockVehicleTokenSource(ctrl)
+			service := newService(t, webhooks, tokens, &config.Settings{AsyncJobWorkers: 2})
+
+			ctx := requester.NewContext(context.Background(), "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b")
+			submitted, err := service.Submit(ctx, &models.Job{Type: models.JobTypeOdometerStatement, TokenID: 5, WebhookURL: tt.webhookURL})
+			require.NoError(t, err)
+			// the queued job holds no token, which would expire while it waits
+			queued, err := service.Get(ctx, submitted.ID)
+			require.NoError(t, err)
+			require.Empty(t, queued.JWTToken)
+
+			result := vc
+			if tt.runErr != nil {
+				result = nil
+			}
+			tokens.EXPECT().GetVehicleToken(gomock.Any(), uint32(5), odometerstatementvc.TelemetryPermissions).Return("vehicle-jwt", tt.tokenErr)
+			if tt.tokenErr == nil {
+				runner.EXPECT().RunJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+					require.Equal(t, submitted.ID, job.ID)
+					require.Equal(t, "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b", requester.FromContext(ctx))
+					require.Equal(t, "vehicle-jwt", job.JWTToken)
+					return result, tt.runErr
+				})
+			}
+			if tt.webhookURL != "" {
+				webhooks.EXPECT().Send(gomock.Any(), tt.webhookURL, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, payload any) error {
+					status, ok := payload.(models.JobStatus)
+					require.True(t, ok)
+					require.Equal(t, tt.expectedState, status.State)
+					return tt.webhookErr
+				})
+			}
+			runService(t, service, runner)
+
+			job := waitFinished(t, service, submitted.ID)
+			require.Equal(t, tt.expectedState, job.State)
+			require.Equal(t, tt.expectedError, job.Error)
+			require.Equal(t, tt.expectedCode, job.ErrorCode)
+			require.Equal(t, tt.expectedWebhookState, job.WebhookState)
+			require.Empty(t, job.JWTToken)
+			require.NotNil(t, job.FinishedAt)
+			if tt.runErr == nil && tt.tokenErr == nil {
+				require.Equal(t, vc, job.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Export formats.
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+var csvHeader = []string{
+	"id", "type", "dataVersion", "subject", "tokenId", "source", "requester", "signer",
+	"validFrom", "validTo", "status", "uploadError", "contentHash", "issuedAt", "recordedAt",
+}
+
+// exportWriter writes ledger entries in an export format.
+type exportWriter interface {
+	Write(entries []models.LedgerEntry) error
+	Close() error
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case FormatCSV, "":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return nil, fmt.Errorf("failed to write ledger export: %w", err)
+		}
+		return &csvWriter{writer: writer}, nil
+	case FormatParquet:
+		return &parquetWriter{writer: parquet.NewGenericWriter[parquetRow](w)}, nil
+	default:
+		return nil, richerrors.Error{Err: fmt.Errorf("unknown export format %q", format), ExternalMsg: "Format must be csv or parquet", Code: http.StatusBadRequest}
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) Write(entries []models.LedgerEntry) error {
+	for i := range entries {
+		entry := &entries[i]
+		err := c.writer.Write([]string{
+			entry.ID,
+			entry.Type,
+			entry.DataVersion,
+			entry.Subject,
+			strconv.FormatUint(uint64(entry.TokenID), 10),
+			entry.Source,
+			entry.Requester,
+			entry.Signer,
+			formatTime(entry.ValidFrom),
+			formatTime(entry.ValidTo),
+			entry.Status,
+			entry.UploadError,
+			entry.ContentHash,
+			entry.IssuedAt.Format(time.RFC3339),
+			entry.RecordedAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write ledger export: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write ledger export: %w", err)
+	}
+	return nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// parquetRow is a ledger entry in the parquet export.
+type parquetRow struct {
+	ID          string     `parquet:"id"`
+	Type        string     `parquet:"type"`
+	DataVersion string     `parquet:"data_version"`
+	Subject     string     `parquet:"subject"`
+	TokenID     uint32     `parquet:"token_id"`
+	Source      string     `parquet:"source"`
+	Requester   string     `parquet:"requester,optional"`
+	Signer      string     `parquet:"signer"`
+	ValidFrom   *time.Time `parquet:"valid_from,optional"`
+	ValidTo     *time.Time `parquet:"valid_to,optional"`
+	Status      string     `parquet:"status"`
+	UploadError string     `parquet:"upload_error,optional"`
+	ContentHash string     `parquet:"content_hash"`
+	IssuedAt    time.Time  `parquet:"issued_at"`
+	RecordedAt  time.Time  `parquet:"recorded_at"`
+}
+
+type parquetWriter struct {
+	writer *parquet.GenericWriter[parquetRow]
+}
+
+func (p *parquetWriter) Write(entries []models.LedgerEntry) error {
+	rows := make([]parquetRow, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		rows[i] = parquetRow{
+			ID:          entry.ID,
+			Type:        entry.Type,
+			DataVersion: entry.DataVersion,
+			Subject:     entry.Subject,
+			TokenID:     entry.TokenID,
+			Source:      entry.Source,
+			Requester:   entry.Requester,
+			Signer:      entry.Signer,
+			ValidFrom:   entry.ValidFrom,
+			ValidTo:     entry.ValidTo,
+			Status:      entry.Status,
+			UploadError: entry.UploadError,
+			ContentHash: entry.ContentHash,
+			IssuedAt:    entry.IssuedAt,
+			RecordedAt:  entry.RecordedAt,
+		}
+	}
+	if _, err := p.writer.Write(rows); err != nil {
+		return fmt.Errorf("failed to write ledger export: %w", err)
+	}
+	return nil
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.writer.Close(); err != nil {
+		return fmt.Errorf("failed to write ledger export: %w", err)
+	}
+	return nil
+}
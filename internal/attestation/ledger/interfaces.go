@@ -0,0 +1,14 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+)
+
+// Store defines the interface for persisting ledger entries.
+type Store interface {
+	SaveLedgerEntry(ctx context.Context, entry *models.LedgerEntry) error
+	GetLedgerEntry(ctx context.Context, id string) (*models.LedgerEntry, error)
+	ListLedgerEntries(ctx context.Context, query *models.LedgerQuery) (*models.LedgerPage, error)
+}
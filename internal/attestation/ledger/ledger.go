@@ -0,0 +1,136 @@
+// Package ledger records every attestation the service issues, who requested it and the outcome of its upload,
+// so issued attestations can be queried and exported without going through DIS.
+package ledger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultLimit is the page size of queries without a limit.
+	DefaultLimit = 100
+	// MaxLimit is the largest page size of queries.
+	MaxLimit = 1000
+)
+
+// Service records issued attestations in the ledger.
+type Service struct {
+	logger *zerolog.Logger
+	store  Store
+	signer common.Address
+}
+
+// NewService creates a new Service recording attestations signed by signer.
+func NewService(logger *zerolog.Logger, store Store, signer common.Address) *Service {
+	return &Service{logger: logger, store: store, signer: signer}
+}
+
+// RecordUpload records the attestation and the outcome of its upload, requested by the requester of ctx.
+// Uploads of the same attestation replace its entry.
+func (s *Service) RecordUpload(ctx context.Context, attestation *cloudevent.RawEvent, uploadErr error) {
+	entry, err := s.newEntry(ctx, attestation, uploadErr)
+	if err != nil {
+		s.logger.Error().Err(err).Str("eventId", attestation.ID).Msg("Failed to create ledger entry.")
+		return
+	}
+	if err := s.store.SaveLedgerEntry(context.WithoutCancel(ctx), entry); err != nil {
+		s.logger.Error().Err(err).Str("eventId", attestation.ID).Msg("Failed to record attestation in ledger.")
+	}
+}
+
+func (s *Service) newEntry(ctx context.Context, attestation *cloudevent.RawEvent, uploadErr error) (*models.LedgerEntry, error) {
+	content, err := json.Marshal(attestation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cloud event: %w", err)
+	}
+	hash := sha256.Sum256(content)
+	entry := &models.LedgerEntry{
+		ID:          attestation.ID,
+		Type:        attestation.Type,
+		DataVersion: attestation.DataVersion,
+		Subject:     attestation.Subject,
+		Source:      attestation.Source,
+		Requester:   requester.FromContext(ctx),
+		Signer:      s.signer.Hex(),
+		Status:      models.LedgerStatusUploaded,
+		ContentHash: hex.EncodeToString(hash[:]),
+		IssuedAt:    attestation.Time,
+		RecordedAt:  time.Now().UTC(),
+	}
+	if common.IsHexAddress(attestation.Source) {
+		entry.Source = common.HexToAddress(attestation.Source).Hex()
+	}
+	if did, err := cloudevent.DecodeERC721DID(attestation.Subject); err == nil && did.TokenID.IsUint64() && did.TokenID.Uint64() <= uint64(^uint32(0)) {
+		entry.TokenID = uint32(did.TokenID.Uint64())
+	}
+	var credential types.Credential
+	if err := json.Unmarshal(attestation.Data, &credential); err == nil {
+		if !credential.ValidFrom.IsZero() {
+			entry.ValidFrom = &credential.ValidFrom
+		}
+		if !credential.ValidTo.IsZero() {
+			entry.ValidTo = &credential.ValidTo
+		}
+	}
+	if uploadErr != nil {
+		entry.Status = models.LedgerStatusFailed
+		entry.UploadError = uploadErr.Error()
+	}
+	return entry, nil
+}
+
+// Get returns the ledger entry of the attestation with the cloud event ID.
+func (s *Service) Get(ctx context.Context, id string) (*models.LedgerEntry, error) {
+	return s.store.GetLedgerEntry(ctx, id)
+}
+
+// List returns a page of the ledger entries matching the query, oldest first. The limit defaults to DefaultLimit
+// and cannot exceed MaxLimit.
+func (s *Service) List(ctx context.Context, query models.LedgerQuery) (*models.LedgerPage, error) {
+	if query.Limit < 0 || query.Limit > MaxLimit {
+		return nil, richerrors.Error{Err: fmt.Errorf("invalid limit %d", query.Limit), ExternalMsg: fmt.Sprintf("Limit must be between 1 and %d", MaxLimit), Code: http.StatusBadRequest}
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultLimit
+	}
+	return s.store.ListLedgerEntries(ctx, &query)
+}
+
+// Export writes every ledger entry matching the query to w in the format, oldest first. The page of the query is
+// ignored.
+func (s *Service) Export(ctx context.Context, query models.LedgerQuery, format string, w io.Writer) error {
+	writer, err := newExportWriter(format, w)
+	if err != nil {
+		return err
+	}
+	query.After = ""
+	query.Limit = MaxLimit
+	for {
+		page, err := s.store.ListLedgerEntries(ctx, &query)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(page.Entries); err != nil {
+			return err
+		}
+		if page.Next == "" {
+			return writer.Close()
+		}
+		query.After = page.Next
+	}
+}
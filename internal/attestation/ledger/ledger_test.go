@@ -0,0 +1,167 @@
+package ledger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/ledger"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/sqlitestore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	vehicleContract = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"
+	devLicense      = "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b"
+	clientLicense   = "0x9B7C1C2dA3b5cA8bF6C4E0D2d1a3F4E5c6B7a8D9"
+)
+
+var signer = common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+func newService(t *testing.T) *ledger.Service {
+	t.Helper()
+	store, err := sqlitestore.Open(filepath.Join(t.TempDir(), "ledger.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	return ledger.NewService(&logger, store, signer)
+}
+
+func attestation(id string, tokenID uint32, dataVersion string) *cloudevent.RawEvent {
+	return &cloudevent.RawEvent{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			ID:          id,
+			Type:        cloudevent.TypeAttestation,
+			DataVersion: dataVersion,
+			Source:      devLicense,
+			Subject:     fmt.Sprintf("did:erc721:137:%s:%d", vehicleContract, tokenID),
+			Time:        time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Data: []byte(`{"validFrom":"2026-01-02T03:04:05Z","validTo":"2026-02-02T03:04:05Z"}`),
+	}
+}
+
+func TestRecordUpload(t *testing.T) {
+	service := newService(t)
+	ctx := requester.NewContext(context.Background(), clientLicense)
+
+	service.RecordUpload(ctx, attestation("vc-1", 1, "vin/v1.0"), errors.New("DIS returned non-200 status code: 502"))
+
+	entry, err := service.Get(context.Background(), "vc-1")
+	require.NoError(t, err)
+	require.Equal(t, models.LedgerStatusFailed, entry.Status)
+	require.Equal(t, "DIS returned non-200 status code: 502", entry.UploadError)
+	require.Equal(t, uint32(1), entry.TokenID)
+	require.Equal(t, clientLicense, entry.Requester)
+	require.Equal(t, signer.Hex(), entry.Signer)
+	require.Equal(t, devLicense, entry.Source)
+	require.Len(t, entry.ContentHash, 64)
+	require.Equal(t, time.Date(2026, 2, 2, 3, 4, 5, 0, time.UTC), *entry.ValidTo)
+
+	// a successful retry of the upload replaces the entry
+	service.RecordUpload(ctx, attestation("vc-1", 1, "vin/v1.0"), nil)
+	entry, err = service.Get(context.Background(), "vc-1")
+	require.NoError(t, err)
+	require.Equal(t, models.LedgerStatusUploaded, entry.Status)
+	require.Empty(t, entry.UploadError)
+
+	_, err = service.Get(context.Background(), "missing")
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	require.Equal(t, http.StatusNotFound, richErr.Code)
+}
+
+func TestList(t *testing.T) {
+	service := newService(t)
+	ctx := requester.NewContext(context.Background(), clientLicense)
+	for i := range 5 {
+		service.RecordUpload(ctx, attestation(fmt.Sprintf("vc-%d", i), uint32(i%2+1), "vin/v1.0"), nil)
+	}
+	service.RecordUpload(context.Background(), attestation("odo-1", 1, "odometer/v1.0"), nil)
+
+	tests := []struct {
+		name    string
+		query   models.LedgerQuery
+		wantIDs []string
+		next    string
+	}{
+		{
+			name:    "every entry",
+			query:   models.LedgerQuery{},
+			wantIDs: []string{"vc-0", "vc-1", "vc-2", "vc-3", "vc-4", "odo-1"},
+		},
+		{
+			name:    "vehicle and type",
+			query:   models.LedgerQuery{TokenID: 1, DataVersion: "vin/v1.0"},
+			wantIDs: []string{"vc-0", "vc-2", "vc-4"},
+		},
+		{
+			name:    "requester",
+			query:   models.LedgerQuery{Requester: clientLicense, Limit: 2},
+			wantIDs: []string{"vc-0", "vc-1"},
+			next:    "vc-1",
+		},
+		{
+			name:    "next page",
+			query:   models.LedgerQuery{Requester: clientLicense, Limit: 2, After: "vc-3"},
+			wantIDs: []string{"vc-4"},
+		},
+		{
+			name:    "recorded before",
+			query:   models.LedgerQuery{To: time.Now().Add(-time.Hour)},
+			wantIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := service.List(context.Background(), tt.query)
+			require.NoError(t, err)
+			ids := []string{}
+			for _, entry := range page.Entries {
+				ids = append(ids, entry.ID)
+			}
+			require.Equal(t, tt.wantIDs, ids)
+			require.Equal(t, tt.next, page.Next)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	service := newService(t)
+	ctx := requester.NewContext(context.Background(), clientLicense)
+	service.RecordUpload(ctx, attestation("vc-1", 1, "vin/v1.0"), nil)
+	service.RecordUpload(context.Background(), attestation("vc-2", 2, "vin/v1.0"), errors.New("timeout"))
+
+	var csvOut bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), models.LedgerQuery{}, ledger.FormatCSV, &csvOut))
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "id", records[0][0])
+	require.Equal(t, []string{"vc-1", "1", clientLicense, "2026-02-02T03:04:05Z", "uploaded"}, []string{records[1][0], records[1][4], records[1][6], records[1][9], records[1][10]})
+	require.Equal(t, "timeout", records[2][11])
+
+	var parquetOut bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), models.LedgerQuery{Status: models.LedgerStatusFailed}, ledger.FormatParquet, &parquetOut))
+	file, err := parquet.OpenFile(bytes.NewReader(parquetOut.Bytes()), int64(parquetOut.Len()))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), file.NumRows())
+
+	err = service.Export(context.Background(), models.LedgerQuery{}, "xlsx", &bytes.Buffer{})
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	require.Equal(t, http.StatusBadRequest, richErr.Code)
+}
@@ -0,0 +1,21 @@
+package manualvin
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// VINIssuer defines the interface for issuing manual VIN attestations.
+type VINIssuer interface {
+	CreateManualVINAttestation(ctx context.Context, tokenID uint32, vin string, countryCode string) (*cloudevent.RawEvent, error)
+}
+
+// Store defines the interface for storing manual VIN requests and the audit log.
+type Store interface {
+	CreateManualVINRequest(ctx context.Context, req *models.ManualVINRequest) error
+	UpdateManualVINRequest(ctx context.Context, id string, update func(*models.ManualVINRequest) error) (*models.ManualVINRequest, error)
+	AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+	ListAuditEntries(ctx context.Context, tokenID uint32) ([]models.AuditEntry, error)
+}
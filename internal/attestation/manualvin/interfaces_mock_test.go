@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=manualvin_test
+//
+
+// Package manualvin_test is a generated GoMock package.
+package manualvin_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVINIssuer is a mock of VINIssuer interface.
+type MockVINIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockVINIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockVINIssuerMockRecorder is the mock recorder for MockVINIssuer.
+type MockVINIssuerMockRecorder struct {
+	mock *MockVINIssuer
+}
+
+// NewMockVINIssuer creates a new mock instance.
+func NewMockVINIssuer(ctrl *gomock.Controller) *MockVINIssuer {
+	mock := &MockVINIssuer{ctrl: ctrl}
+	mock.recorder = &MockVINIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVINIssuer) EXPECT() *MockVINIssuerMockRecorder {
+	return m.recorder
+}
+
+// CreateManualVINAttestation mocks base method.
+func (m *MockVINIssuer) CreateManualVINAttestation(ctx context.Context, tokenID uint32, vin, countryCode string) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManualVINAttestation", ctx, tokenID, vin, countryCode)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManualVINAttestation indicates an expected call of CreateManualVINAttestation.
+func (mr *MockVINIssuerMockRecorder) CreateManualVINAttestation(ctx, tokenID, vin, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManualVINAttestation", reflect.TypeOf((*MockVINIssuer)(nil).CreateManualVINAttestation), ctx, tokenID, vin, countryCode)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// AppendAuditEntry mocks base method.
+func (m *MockStore) AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendAuditEntry indicates an expected call of AppendAuditEntry.
+func (mr *MockStoreMockRecorder) AppendAuditEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuditEntry", reflect.TypeOf((*MockStore)(nil).AppendAuditEntry), ctx, entry)
+}
+
+// CreateManualVINRequest mocks base method.
+func (m *MockStore) CreateManualVINRequest(ctx context.Context, req *models.ManualVINRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManualVINRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateManualVINRequest indicates an expected call of CreateManualVINRequest.
+func (mr *MockStoreMockRecorder) CreateManualVINRequest(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManualVINRequest", reflect.TypeOf((*MockStore)(nil).CreateManualVINRequest), ctx, req)
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockStore) ListAuditEntries(ctx context.Context, tokenID uint32) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, tokenID)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockStoreMockRecorder) ListAuditEntries(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockStore)(nil).ListAuditEntries), ctx, tokenID)
+}
+
+// UpdateManualVINRequest mocks base method.
+func (m *MockStore) UpdateManualVINRequest(ctx context.Context, id string, update func(*models.ManualVINRequest) error) (*models.ManualVINRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManualVINRequest", ctx, id, update)
+	ret0, _ := ret[0].(*models.ManualVINRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateManualVINRequest indicates an expected call of UpdateManualVINRequest.
+func (mr *MockStoreMockRecorder) UpdateManualVINRequest(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManualVINRequest", reflect.TypeOf((*MockStore)(nil).UpdateManualVINRequest), ctx, id, update)
+}
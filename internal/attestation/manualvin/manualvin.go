@@ -0,0 +1,241 @@
+// Package manualvin issues VIN attestations on behalf of operators, with an optional second operator approval,
+// and records every step in an append-only audit log.
+package manualvin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/attestation-api/internal/vindecoder"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+// Service handles manual VIN attestation requests.
+type Service struct {
+	logger          *zerolog.Logger
+	issuer          VINIssuer
+	store           Store
+	requireApproval bool
+}
+
+// NewService creates a new Service for manual VIN attestations.
+func NewService(logger *zerolog.Logger, issuer VINIssuer, store Store, settings *config.Settings) *Service {
+	return &Service{
+		logger:          logger,
+		issuer:          issuer,
+		store:           store,
+		requireApproval: settings.ManualVINRequireApproval,
+	}
+}
+
+// Request is a manual VIN attestation request.
+type Request struct {
+	TokenID uint32
+	VIN     string
+	// CountryCode defaults to the country of manufacture decoded from the VIN.
+	CountryCode string
+	Reason      string
+	EvidenceRef string
+}
+
+// Result is the outcome of a manual VIN attestation request.
+type Result struct {
+	Request *models.ManualVINRequest
+	// Attestation is nil until the request is issued.
+	Attestation *cloudevent.RawEvent
+}
+
+// Submit records the request of the operator and issues the attestation, or leaves the request pending
+// when a second operator must approve it.
+func (s *Service) Submit(ctx context.Context, op *operator.Operator, req Request) (*Result, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, richerrors.Error{Err: errors.New("reason is empty"), ExternalMsg: "Reason is required", Code: http.StatusBadRequest}
+	}
+	evidenceRef := strings.TrimSpace(req.EvidenceRef)
+	if evidenceRef == "" {
+		return nil, richerrors.Error{Err: errors.New("evidence reference is empty"), ExternalMsg: "Evidence reference is required", Code: http.StatusBadRequest}
+	}
+	decoded, err := vindecoder.Default().Decode(req.VIN)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "VIN failed validation rules", Code: http.StatusBadRequest}
+	}
+	countryCode := strings.ToUpper(strings.TrimSpace(req.CountryCode))
+	if countryCode == "" {
+		countryCode = decoded.CountryCode
+	}
+
+	record := &models.ManualVINRequest{
+		ID:          ksuid.New().String(),
+		TokenID:     req.TokenID,
+		VIN:         decoded.VIN,
+		CountryCode: countryCode,
+		Reason:      reason,
+		EvidenceRef: evidenceRef,
+		Status:      models.ManualVINStatusApproved,
+		RequestedBy: op.ID,
+		RequestedAt: time.Now().UTC(),
+	}
+	if s.requireApproval {
+		record.Status = models.ManualVINStatusPending
+	}
+	if err := s.store.CreateManualVINRequest(ctx, record); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to store manual VIN request", Code: http.StatusInternalServerError}
+	}
+	if err := s.store.AppendAuditEntry(ctx, newAuditEntry(record, models.AuditActionRequested, op)); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to record manual VIN request", Code: http.StatusInternalServerError}
+	}
+	if s.requireApproval {
+		return &Result{Request: record}, nil
+	}
+	return s.issue(ctx, op, record)
+}
+
+// Approve approves a pending request of another operator and issues the attestation.
+func (s *Service) Approve(ctx context.Context, op *operator.Operator, requestID string) (*Result, error) {
+	record, err := s.store.UpdateManualVINRequest(ctx, requestID, func(r *models.ManualVINRequest) error {
+		if err := reviewable(r); err != nil {
+			return err
+		}
+		if r.RequestedBy == op.ID {
+			return richerrors.Error{
+				Err:         errors.New("operator approving own request"),
+				ExternalMsg: "Manual VIN requests must be approved by a different operator",
+				Code:        http.StatusForbidden,
+			}
+		}
+		now := time.Now().UTC()
+		r.Status = models.ManualVINStatusApproved
+		r.ReviewedBy = op.ID
+		r.ReviewedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, wrapStoreErr(err, "Failed to approve manual VIN request")
+	}
+	if err := s.store.AppendAuditEntry(ctx, newAuditEntry(record, models.AuditActionApproved, op)); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to record manual VIN approval", Code: http.StatusInternalServerError}
+	}
+	return s.issue(ctx, op, record)
+}
+
+// Reject rejects a pending request. Operators may reject their own requests to withdraw them.
+func (s *Service) Reject(ctx context.Context, op *operator.Operator, requestID string, reason string) (*models.ManualVINRequest, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, richerrors.Error{Err: errors.New("reason is empty"), ExternalMsg: "Reason is required", Code: http.StatusBadRequest}
+	}
+	record, err := s.store.UpdateManualVINRequest(ctx, requestID, func(r *models.ManualVINRequest) error {
+		if err := reviewable(r); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		r.Status = models.ManualVINStatusRejected
+		r.ReviewedBy = op.ID
+		r.ReviewedAt = &now
+		r.ReviewReason = reason
+		return nil
+	})
+	if err != nil {
+		return nil, wrapStoreErr(err, "Failed to reject manual VIN request")
+	}
+	entry := newAuditEntry(record, models.AuditActionRejected, op)
+	entry.Reason = reason
+	if err := s.store.AppendAuditEntry(ctx, entry); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to record manual VIN rejection", Code: http.StatusInternalServerError}
+	}
+	return record, nil
+}
+
+// AuditLog returns the manual VIN audit log of the vehicle, oldest entry first.
+func (s *Service) AuditLog(ctx context.Context, tokenID uint32) ([]models.AuditEntry, error) {
+	entries, err := s.store.ListAuditEntries(ctx, tokenID)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get manual VIN audit log", Code: http.StatusInternalServerError}
+	}
+	return entries, nil
+}
+
+// issue issues the attestation of an approved request and records the outcome.
+func (s *Service) issue(ctx context.Context, op *operator.Operator, record *models.ManualVINRequest) (*Result, error) {
+	rawVC, issueErr := s.issuer.CreateManualVINAttestation(requester.NewContext(ctx, op.ID), record.TokenID, record.VIN, record.CountryCode)
+	status, action := models.ManualVINStatusIssued, models.AuditActionIssued
+	if issueErr != nil {
+		status, action = models.ManualVINStatusFailed, models.AuditActionFailed
+	}
+	updated, err := s.store.UpdateManualVINRequest(ctx, record.ID, func(r *models.ManualVINRequest) error {
+		r.Status = status
+		if rawVC != nil {
+			r.AttestationID = rawVC.ID
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Str("requestId", record.ID).Str("status", status).Msg("Failed to update manual VIN request.")
+		failed := *record
+		failed.Status = status
+		if rawVC != nil {
+			failed.AttestationID = rawVC.ID
+		}
+		updated = &failed
+	}
+	entry := newAuditEntry(updated, action, op)
+	if issueErr != nil {
+		entry.Error = issueErr.Error()
+	}
+	if err := s.store.AppendAuditEntry(ctx, entry); err != nil {
+		// The outcome cannot be undone, so log the failure and keep the requested entry as the trace.
+		s.logger.Error().Err(err).Str("requestId", record.ID).Str("action", action).Msg("Failed to record manual VIN audit entry.")
+	}
+	if issueErr != nil {
+		return nil, issueErr
+	}
+	return &Result{Request: updated, Attestation: rawVC}, nil
+}
+
+// reviewable fails unless the request is pending.
+func reviewable(r *models.ManualVINRequest) error {
+	if r.Status != models.ManualVINStatusPending {
+		return richerrors.Error{
+			Err:         errors.New("manual VIN request is " + r.Status),
+			ExternalMsg: "Manual VIN request is not pending",
+			Code:        http.StatusConflict,
+		}
+	}
+	return nil
+}
+
+// wrapStoreErr keeps the status of errors returned by the request update and wraps the others.
+func wrapStoreErr(err error, msg string) error {
+	var richErr richerrors.Error
+	if errors.As(err, &richErr) {
+		return err
+	}
+	return richerrors.Error{Err: err, ExternalMsg: msg, Code: http.StatusInternalServerError}
+}
+
+func newAuditEntry(record *models.ManualVINRequest, action string, op *operator.Operator) *models.AuditEntry {
+	return &models.AuditEntry{
+		ID:            ksuid.New().String(),
+		TokenID:       record.TokenID,
+		RequestID:     record.ID,
+		Action:        action,
+		Operator:      op.ID,
+		VIN:           record.VIN,
+		CountryCode:   record.CountryCode,
+		Reason:        record.Reason,
+		EvidenceRef:   record.EvidenceRef,
+		AttestationID: record.AttestationID,
+		Time:          time.Now().UTC(),
+	}
+}
@@ -0,0 +1,198 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=manualvin_test
+package manualvin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/manualvin"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testVIN = "1HGCM82673A123456"
+
+var (
+	alice = &operator.Operator{ID: "alice@dimo.org"}
+	bob   = &operator.Operator{ID: "bob@dimo.org"}
+)
+
+func newService(t *testing.T, issuer manualvin.VINIssuer, requireApproval bool) *manualvin.Service {
+	t.Helper()
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	return manualvin.NewService(&logger, issuer, store, &config.Settings{ManualVINRequireApproval: requireApproval})
+}
+
+func requireCode(t *testing.T, err error, code int) {
+	t.Helper()
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok, "expected rich error, got %v", err)
+	require.Equal(t, code, richErr.Code)
+}
+
+func auditActions(t *testing.T, service *manualvin.Service, tokenID uint32) []string {
+	t.Helper()
+	entries, err := service.AuditLog(context.Background(), tokenID)
+	require.NoError(t, err)
+	actions := make([]string, len(entries))
+	for i, entry := range entries {
+		actions[i] = entry.Action
+	}
+	return actions
+}
+
+func TestSubmit(t *testing.T) {
+	valid := manualvin.Request{TokenID: 5, VIN: testVIN, Reason: "Device cannot read VIN", EvidenceRef: "SUP-1234"}
+
+	tests := []struct {
+		name           string
+		req            manualvin.Request
+		issueErr       error
+		expectedCode   int
+		expectedStatus string
+		expectedAudit  []string
+	}{
+		{
+			name:           "issued",
+			req:            valid,
+			expectedStatus: models.ManualVINStatusIssued,
+			expectedAudit:  []string{models.AuditActionRequested, models.AuditActionIssued},
+		},
+		{
+			name:          "issuing fails",
+			req:           valid,
+			issueErr:      richerrors.Error{Err: errors.New("dis down"), ExternalMsg: "Failed to store VC", Code: http.StatusInternalServerError},
+			expectedCode:  http.StatusInternalServerError,
+			expectedAudit: []string{models.AuditActionRequested, models.AuditActionFailed},
+		},
+		{
+			name:          "missing reason",
+			req:           manualvin.Request{TokenID: 5, VIN: testVIN, EvidenceRef: "SUP-1234"},
+			expectedCode:  http.StatusBadRequest,
+			expectedAudit: []string{},
+		},
+		{
+			name:          "missing evidence",
+			req:           manualvin.Request{TokenID: 5, VIN: testVIN, Reason: "Device cannot read VIN"},
+			expectedCode:  http.StatusBadRequest,
+			expectedAudit: []string{},
+		},
+		{
+			name:          "invalid VIN",
+			req:           manualvin.Request{TokenID: 5, VIN: "1HGCM82633A123456", Reason: "Device cannot read VIN", EvidenceRef: "SUP-1234"},
+			expectedCode:  http.StatusBadRequest,
+			expectedAudit: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuer := NewMockVINIssuer(ctrl)
+			service := newService(t, issuer, false)
+
+			if len(tt.expectedAudit) > 0 {
+				var rawVC *cloudevent.RawEvent
+				if tt.issueErr == nil {
+					rawVC = &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}
+				}
+				issuer.EXPECT().CreateManualVINAttestation(gomock.Any(), uint32(5), testVIN, "USA").Return(rawVC, tt.issueErr)
+			}
+
+			result, err := service.Submit(context.Background(), alice, tt.req)
+			require.Equal(t, tt.expectedAudit, auditActions(t, service, 5))
+			if tt.expectedCode != 0 {
+				requireCode(t, err, tt.expectedCode)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStatus, result.Request.Status)
+			require.Equal(t, "vc-1", result.Request.AttestationID)
+			require.Equal(t, "USA", result.Request.CountryCode)
+			require.Equal(t, alice.ID, result.Request.RequestedBy)
+			require.NotNil(t, result.Attestation)
+		})
+	}
+}
+
+func TestApproval(t *testing.T) {
+	ctx := context.Background()
+	req := manualvin.Request{TokenID: 5, VIN: testVIN, CountryCode: "can", Reason: "Device cannot read VIN", EvidenceRef: "SUP-1234"}
+
+	t.Run("approved by a second operator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		issuer := NewMockVINIssuer(ctrl)
+		service := newService(t, issuer, true)
+
+		result, err := service.Submit(ctx, alice, req)
+		require.NoError(t, err)
+		require.Equal(t, models.ManualVINStatusPending, result.Request.Status)
+		require.Nil(t, result.Attestation)
+
+		_, err = service.Approve(ctx, alice, result.Request.ID)
+		requireCode(t, err, http.StatusForbidden)
+
+		issuer.EXPECT().CreateManualVINAttestation(gomock.Any(), uint32(5), testVIN, "CAN").
+			Return(&cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil)
+		approved, err := service.Approve(ctx, bob, result.Request.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.ManualVINStatusIssued, approved.Request.Status)
+		require.Equal(t, bob.ID, approved.Request.ReviewedBy)
+		require.NotNil(t, approved.Attestation)
+
+		_, err = service.Approve(ctx, bob, result.Request.ID)
+		requireCode(t, err, http.StatusConflict)
+
+		entries, err := service.AuditLog(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		require.Equal(t, []string{alice.ID, bob.ID, bob.ID}, []string{entries[0].Operator, entries[1].Operator, entries[2].Operator})
+		require.Equal(t, []string{models.AuditActionRequested, models.AuditActionApproved, models.AuditActionIssued}, auditActions(t, service, 5))
+		require.Equal(t, "vc-1", entries[2].AttestationID)
+		require.Equal(t, "SUP-1234", entries[2].EvidenceRef)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := newService(t, NewMockVINIssuer(ctrl), true)
+
+		result, err := service.Submit(ctx, alice, req)
+		require.NoError(t, err)
+
+		_, err = service.Reject(ctx, bob, result.Request.ID, " ")
+		requireCode(t, err, http.StatusBadRequest)
+
+		rejected, err := service.Reject(ctx, bob, result.Request.ID, "Evidence does not show the VIN")
+		require.NoError(t, err)
+		require.Equal(t, models.ManualVINStatusRejected, rejected.Status)
+
+		_, err = service.Approve(ctx, bob, result.Request.ID)
+		requireCode(t, err, http.StatusConflict)
+
+		entries, err := service.AuditLog(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, []string{models.AuditActionRequested, models.AuditActionRejected}, auditActions(t, service, 5))
+		require.Equal(t, "Evidence does not show the VIN", entries[1].Reason)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := newService(t, NewMockVINIssuer(ctrl), true)
+
+		_, err := service.Approve(ctx, bob, "missing")
+		requireCode(t, err, http.StatusNotFound)
+	})
+}
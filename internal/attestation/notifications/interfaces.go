@@ -0,0 +1,28 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+)
+
+// Sender defines the interface for calling webhooks with HMAC signed payloads.
+type Sender interface {
+	SendHMAC(ctx context.Context, webhookURL, secret string, payload any) (int, error)
+}
+
+// Store defines the interface for persisting webhook subscriptions and their deliveries.
+type Store interface {
+	CreateWebhookSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	RecordRequestedVehicle(ctx context.Context, devLicense string, tokenID uint32) error
+	HasRequestedVehicle(ctx context.Context, devLicense string, tokenID uint32) (bool, error)
+	CreateWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, id string, update func(*models.WebhookDelivery) error) (*models.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, match func(*models.WebhookDelivery) bool) ([]models.WebhookDelivery, error)
+	DeleteWebhookDeliveriesFinishedBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=notifications_test
+//
+
+// Package notifications_test is a generated GoMock package.
+package notifications_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// SendHMAC mocks base method.
+func (m *MockSender) SendHMAC(ctx context.Context, webhookURL, secret string, payload any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHMAC", ctx, webhookURL, secret, payload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendHMAC indicates an expected call of SendHMAC.
+func (mr *MockSenderMockRecorder) SendHMAC(ctx, webhookURL, secret, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHMAC", reflect.TypeOf((*MockSender)(nil).SendHMAC), ctx, webhookURL, secret, payload)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockStore) CreateWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockStoreMockRecorder) CreateWebhookDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).CreateWebhookDeliveries), ctx, deliveries)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockStore) CreateWebhookSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockStoreMockRecorder) CreateWebhookSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockStore)(nil).CreateWebhookSubscription), ctx, sub)
+}
+
+// DeleteWebhookDeliveriesFinishedBefore mocks base method.
+func (m *MockStore) DeleteWebhookDeliveriesFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDeliveriesFinishedBefore", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookDeliveriesFinishedBefore indicates an expected call of DeleteWebhookDeliveriesFinishedBefore.
+func (mr *MockStoreMockRecorder) DeleteWebhookDeliveriesFinishedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDeliveriesFinishedBefore", reflect.TypeOf((*MockStore)(nil).DeleteWebhookDeliveriesFinishedBefore), ctx, before)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockStore) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockStoreMockRecorder) DeleteWebhookSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockStore)(nil).DeleteWebhookSubscription), ctx, id)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), ctx, id)
+}
+
+// GetWebhookSubscription mocks base method.
+func (m *MockStore) GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscription", ctx, id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscription indicates an expected call of GetWebhookSubscription.
+func (mr *MockStoreMockRecorder) GetWebhookSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscription", reflect.TypeOf((*MockStore)(nil).GetWebhookSubscription), ctx, id)
+}
+
+// HasRequestedVehicle mocks base method.
+func (m *MockStore) HasRequestedVehicle(ctx context.Context, devLicense string, tokenID uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRequestedVehicle", ctx, devLicense, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRequestedVehicle indicates an expected call of HasRequestedVehicle.
+func (mr *MockStoreMockRecorder) HasRequestedVehicle(ctx, devLicense, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRequestedVehicle", reflect.TypeOf((*MockStore)(nil).HasRequestedVehicle), ctx, devLicense, tokenID)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStore) ListWebhookDeliveries(ctx context.Context, match func(*models.WebhookDelivery) bool) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, match)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveries(ctx, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveries), ctx, match)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockStore) ListWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", ctx)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockStoreMockRecorder) ListWebhookSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockStore)(nil).ListWebhookSubscriptions), ctx)
+}
+
+// RecordRequestedVehicle mocks base method.
+func (m *MockStore) RecordRequestedVehicle(ctx context.Context, devLicense string, tokenID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRequestedVehicle", ctx, devLicense, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRequestedVehicle indicates an expected call of RecordRequestedVehicle.
+func (mr *MockStoreMockRecorder) RecordRequestedVehicle(ctx, devLicense, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRequestedVehicle", reflect.TypeOf((*MockStore)(nil).RecordRequestedVehicle), ctx, devLicense, tokenID)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockStore) UpdateWebhookDelivery(ctx context.Context, id string, update func(*models.WebhookDelivery) error) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", ctx, id, update)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockStoreMockRecorder) UpdateWebhookDelivery(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).UpdateWebhookDelivery), ctx, id, update)
+}
@@ -0,0 +1,490 @@
+// Package notifications notifies partner webhooks of issued attestations. Subscriptions filter the attestations
+// by vehicle, attestation type and requesting dev license, and every notification is recorded in a delivery log
+// from which it can be replayed. Operators manage every subscription, and dev licenses manage their own, which
+// only match the attestations the dev license requested, and the renewals and other attestations the service
+// issues on its own for the vehicles the dev license requested attestations of before.
+package notifications
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/attestation-api/internal/webhook"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// DeliveryRetention is how long finished deliveries are kept in the delivery log.
+	DeliveryRetention = 30 * 24 * time.Hour
+
+	maxConcurrentDeliveries = 4
+	pollInterval            = 30 * time.Second
+	pruneInterval           = time.Hour
+)
+
+// Service manages webhook subscriptions and delivers their notifications.
+type Service struct {
+	logger *zerolog.Logger
+	store  Store
+	sender Sender
+	wake   chan struct{}
+
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+}
+
+// NewService creates a new Service delivering notifications with sender.
+func NewService(logger *zerolog.Logger, store Store, sender Sender) *Service {
+	return &Service{
+		logger:   logger,
+		store:    store,
+		sender:   sender,
+		wake:     make(chan struct{}, 1),
+		inFlight: map[string]struct{}{},
+	}
+}
+
+// SubscriptionRequest is a webhook subscription to create. Empty filters match every attestation.
+type SubscriptionRequest struct {
+	Partner          string
+	URL              string
+	TokenIDs         []uint32
+	AttestationTypes []string
+	DevLicenses      []string
+}
+
+// Subscribe creates a webhook subscription on behalf of the operator. The returned subscription holds the secret
+// of the notification signatures, which is not returned again.
+func (s *Service) Subscribe(ctx context.Context, op *operator.Operator, req SubscriptionRequest) (*models.WebhookSubscription, error) {
+	devLicenses := make([]string, len(req.DevLicenses))
+	for i, devLicense := range req.DevLicenses {
+		if !common.IsHexAddress(devLicense) {
+			return nil, richerrors.Error{Err: fmt.Errorf("invalid dev license %q", devLicense), ExternalMsg: "Dev licenses must be hex addresses", Code: http.StatusBadRequest}
+		}
+		devLicenses[i] = common.HexToAddress(devLicense).Hex()
+	}
+	sub, err := s.create(ctx, req, devLicenses, "", op.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("subscriptionId", sub.ID).Str("partner", sub.Partner).Str("operator", op.ID).Msg("Created webhook subscription.")
+	return sub, nil
+}
+
+// SubscribeDevLicense creates a webhook subscription owned by the dev license, which only matches the attestations
+// the dev license requested, and those the service issues on its own for vehicles the dev license requested before.
+// The DevLicenses filter of the request may only hold the dev license itself.
+func (s *Service) SubscribeDevLicense(ctx context.Context, devLicense string, req SubscriptionRequest) (*models.WebhookSubscription, error) {
+	owner, err := ownerAddress(devLicense)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range req.DevLicenses {
+		if !common.IsHexAddress(filter) || common.HexToAddress(filter).Hex() != owner {
+			return nil, richerrors.Error{Err: fmt.Errorf("dev license filter %q of %s", filter, owner), ExternalMsg: "Subscriptions of a dev license only match the attestations it requested", Code: http.StatusBadRequest}
+		}
+	}
+	sub, err := s.create(ctx, req, []string{owner}, owner, owner)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("subscriptionId", sub.ID).Str("partner", sub.Partner).Str("devLicense", owner).Msg("Created webhook subscription.")
+	return sub, nil
+}
+
+// create validates and stores a new webhook subscription.
+func (s *Service) create(ctx context.Context, req SubscriptionRequest, devLicenses []string, owner, createdBy string) (*models.WebhookSubscription, error) {
+	partner := strings.TrimSpace(req.Partner)
+	if partner == "" {
+		return nil, richerrors.Error{Err: errors.New("partner is empty"), ExternalMsg: "Partner is required", Code: http.StatusBadRequest}
+	}
+	if err := webhook.ValidateURL(req.URL); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: err.Error(), Code: http.StatusBadRequest}
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create subscription", Code: http.StatusInternalServerError}
+	}
+
+	sub := &models.WebhookSubscription{
+		ID:               ksuid.New().String(),
+		Partner:          partner,
+		URL:              req.URL,
+		Secret:           secret,
+		TokenIDs:         req.TokenIDs,
+		AttestationTypes: req.AttestationTypes,
+		DevLicenses:      devLicenses,
+		Owner:            owner,
+		CreatedBy:        createdBy,
+		CreatedAt:        time.Now().UTC(),
+	}
+	if err := s.store.CreateWebhookSubscription(ctx, sub); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create subscription", Code: http.StatusInternalServerError}
+	}
+	return sub, nil
+}
+
+// Subscriptions returns all webhook subscriptions without their secrets.
+func (s *Service) Subscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subs, err := s.store.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+// Subscription returns the webhook subscription with the ID without its secret.
+func (s *Service) Subscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	sub, err := s.store.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Secret = ""
+	return sub, nil
+}
+
+// DevLicenseSubscriptions returns the webhook subscriptions owned by the dev license without their secrets.
+func (s *Service) DevLicenseSubscriptions(ctx context.Context, devLicense string) ([]models.WebhookSubscription, error) {
+	owner, err := ownerAddress(devLicense)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := s.Subscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owned := []models.WebhookSubscription{}
+	for i := range subs {
+		if subs[i].Owner == owner {
+			owned = append(owned, subs[i])
+		}
+	}
+	return owned, nil
+}
+
+// DevLicenseSubscription returns the webhook subscription with the ID owned by the dev license without its secret.
+// Subscriptions of others are reported as not found.
+func (s *Service) DevLicenseSubscription(ctx context.Context, devLicense, id string) (*models.WebhookSubscription, error) {
+	owner, err := ownerAddress(devLicense)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := s.Subscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Owner != owner {
+		return nil, richerrors.Error{Err: fmt.Errorf("%w: subscription %s of %q", boltstore.ErrNotFound, id, sub.Owner), ExternalMsg: "Record not found", Code: http.StatusNotFound}
+	}
+	return sub, nil
+}
+
+// UnsubscribeDevLicense deletes the webhook subscription with the ID owned by the dev license.
+func (s *Service) UnsubscribeDevLicense(ctx context.Context, devLicense, id string) error {
+	sub, err := s.DevLicenseSubscription(ctx, devLicense, id)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info().Str("subscriptionId", id).Str("devLicense", sub.Owner).Msg("Deleted webhook subscription.")
+	return nil
+}
+
+// Unsubscribe deletes the webhook subscription with the ID on behalf of the operator. Its pending deliveries fail
+// and its delivery log is kept until it expires.
+func (s *Service) Unsubscribe(ctx context.Context, op *operator.Operator, id string) error {
+	if err := s.store.DeleteWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info().Str("subscriptionId", id).Str("operator", op.ID).Msg("Deleted webhook subscription.")
+	return nil
+}
+
+// Deliveries returns the delivery log of the webhook subscription, oldest first.
+func (s *Service) Deliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	return s.store.ListWebhookDeliveries(ctx, func(delivery *models.WebhookDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID
+	})
+}
+
+// Replay sends the notification of a delivery to its subscription again as a new delivery.
+func (s *Service) Replay(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	original, err := s.store.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.store.GetWebhookSubscription(ctx, original.SubscriptionID); err != nil {
+		return nil, err
+	}
+	replay := models.WebhookDelivery{
+		ID:             ksuid.New().String(),
+		SubscriptionID: original.SubscriptionID,
+		Notification:   original.Notification,
+		State:          models.WebhookStatePending,
+		ReplayOf:       original.ID,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := s.store.CreateWebhookDeliveries(ctx, []models.WebhookDelivery{replay}); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to replay delivery", Code: http.StatusInternalServerError}
+	}
+	s.notify()
+	return &replay, nil
+}
+
+// AttestationUploaded queues a notification of the attestation requested by the requester of ctx for every
+// matching subscription. The vehicles requested by each dev license are recorded, so that its subscriptions are
+// notified of the attestations the service later issues for them on its own. Failures are logged, the attestation
+// is issued regardless.
+func (s *Service) AttestationUploaded(ctx context.Context, attestation *cloudevent.RawEvent) {
+	notification := newNotification(attestation, requester.FromContext(ctx))
+	logger := s.logger.With().Str("eventId", notification.EventID).Logger()
+	if common.IsHexAddress(notification.Requester) && notification.TokenID != 0 {
+		if err := s.store.RecordRequestedVehicle(context.WithoutCancel(ctx), notification.Requester, notification.TokenID); err != nil {
+			logger.Error().Err(err).Msg("Failed to record requested vehicle.")
+		}
+	}
+	subs, err := s.store.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list webhook subscriptions.")
+		return
+	}
+	var deliveries []models.WebhookDelivery
+	now := time.Now().UTC()
+	for i := range subs {
+		match := subs[i].Matches(&notification)
+		if !match && subs[i].MatchesUnrequested(&notification) {
+			match, err = s.store.HasRequestedVehicle(ctx, subs[i].Owner, notification.TokenID)
+			if err != nil {
+				logger.Error().Err(err).Str("subscriptionId", subs[i].ID).Msg("Failed to check requested vehicle.")
+				continue
+			}
+		}
+		if !match {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:             ksuid.New().String(),
+			SubscriptionID: subs[i].ID,
+			Notification:   notification,
+			State:          models.WebhookStatePending,
+			CreatedAt:      now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := s.store.CreateWebhookDeliveries(context.WithoutCancel(ctx), deliveries); err != nil {
+		logger.Error().Err(err).Msg("Failed to queue webhook notifications.")
+		return
+	}
+	s.notify()
+}
+
+// Run delivers the pending notifications until ctx is canceled. Notifications left pending by a previous process
+// are delivered first.
+func (s *Service) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.prune(ctx)
+	}()
+	defer wg.Wait()
+
+	slots := make(chan struct{}, maxConcurrentDeliveries)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := s.store.ListWebhookDeliveries(ctx, func(delivery *models.WebhookDelivery) bool {
+			return delivery.State == models.WebhookStatePending
+		})
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to list pending webhook deliveries.")
+		}
+		for i := range pending {
+			if !s.claim(pending[i].ID) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case slots <- struct{}{}:
+			}
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				defer s.release(delivery.ID)
+				s.deliver(ctx, delivery)
+			}(&pending[i])
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify wakes up the delivery loop.
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// claim marks the delivery in flight and reports whether it was not already.
+func (s *Service) claim(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.inFlight[id]; ok {
+		return false
+	}
+	s.inFlight[id] = struct{}{}
+	return true
+}
+
+func (s *Service) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, id)
+}
+
+// deliver calls the webhook of the delivery's subscription and records the outcome.
+func (s *Service) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	logger := s.logger.With().Str("deliveryId", delivery.ID).Str("subscriptionId", delivery.SubscriptionID).Logger()
+	var attempts int
+	var sendErr error
+	sub, err := s.store.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	switch {
+	case errors.Is(err, boltstore.ErrNotFound):
+		sendErr = errors.New("subscription was deleted")
+	case err != nil:
+		logger.Error().Err(err).Msg("Failed to get webhook subscription.")
+		return
+	default:
+		payload := models.WebhookPayload{
+			DeliveryID:              delivery.ID,
+			SubscriptionID:          delivery.SubscriptionID,
+			AttestationNotification: delivery.Notification,
+		}
+		attempts, sendErr = s.sender.SendHMAC(ctx, sub.URL, sub.Secret, payload)
+		if sendErr != nil && ctx.Err() != nil {
+			// shutting down, the delivery is retried on the next start
+			return
+		}
+	}
+
+	_, err = s.store.UpdateWebhookDelivery(context.WithoutCancel(ctx), delivery.ID, func(d *models.WebhookDelivery) error {
+		now := time.Now().UTC()
+		d.Attempts += attempts
+		d.FinishedAt = &now
+		if sendErr != nil {
+			d.State = models.WebhookStateFailed
+			d.LastError = sendErr.Error()
+		} else {
+			d.State = models.WebhookStateDelivered
+			d.LastError = ""
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to store webhook delivery state.")
+		return
+	}
+	if sendErr != nil {
+		logger.Warn().Err(sendErr).Msg("Failed to deliver webhook notification.")
+	}
+}
+
+// prune deletes the deliveries that finished more than DeliveryRetention ago.
+func (s *Service) prune(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		deleted, err := s.store.DeleteWebhookDeliveriesFinishedBefore(ctx, time.Now().Add(-DeliveryRetention))
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to delete expired webhook deliveries.")
+		} else if deleted > 0 {
+			s.logger.Info().Int("count", deleted).Msg("Deleted expired webhook deliveries.")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newNotification describes the attestation by its header, its requester and the validity of its credential.
+func newNotification(attestation *cloudevent.RawEvent, requesterID string) models.AttestationNotification {
+	notification := models.AttestationNotification{
+		EventID:     attestation.ID,
+		Type:        attestation.Type,
+		DataVersion: attestation.DataVersion,
+		Source:      attestation.Source,
+		Requester:   requesterID,
+		Subject:     attestation.Subject,
+		Time:        attestation.Time,
+	}
+	if common.IsHexAddress(attestation.Source) {
+		notification.Source = common.HexToAddress(attestation.Source).Hex()
+	}
+	if common.IsHexAddress(requesterID) {
+		notification.Requester = common.HexToAddress(requesterID).Hex()
+	}
+	if did, err := cloudevent.DecodeERC721DID(attestation.Subject); err == nil && did.TokenID.IsUint64() && did.TokenID.Uint64() <= uint64(^uint32(0)) {
+		notification.TokenID = uint32(did.TokenID.Uint64())
+	}
+	var credential types.Credential
+	if err := json.Unmarshal(attestation.Data, &credential); err == nil {
+		if !credential.ValidFrom.IsZero() {
+			notification.ValidFrom = &credential.ValidFrom
+		}
+		if !credential.ValidTo.IsZero() {
+			notification.ValidTo = &credential.ValidTo
+		}
+	}
+	return notification
+}
+
+// ownerAddress returns the checksummed address of the dev license managing subscriptions.
+func ownerAddress(devLicense string) (string, error) {
+	if !common.IsHexAddress(devLicense) {
+		return "", richerrors.Error{Err: fmt.Errorf("requester %q is not a dev license", devLicense), ExternalMsg: "Webhook subscriptions can only be managed with the token of a dev license", Code: http.StatusForbidden}
+	}
+	return common.HexToAddress(devLicense).Hex(), nil
+}
+
+// newSecret returns a random hex secret for HMAC signatures.
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
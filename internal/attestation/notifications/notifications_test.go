@@ -0,0 +1,353 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=notifications_test
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/notifications"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	vehicleContract = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"
+	devLicense      = "0x4CB8E0B6BCD3CB7D6B4bd0B2C4B5Dd1f2E3A4b5C"
+	partnerLicense  = "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b"
+	otherLicense    = "0x9dF2f0E4cD63B9C52a4E2a6f3a0c0Ef1b6F1a5D2"
+)
+
+var alice = &operator.Operator{ID: "alice@dimo.org"}
+
+func newService(t *testing.T, sender notifications.Sender) *notifications.Service {
+	t.Helper()
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	return notifications.NewService(&logger, store, sender)
+}
+
+// runService runs the service until the test ends.
+func runService(t *testing.T, service *notifications.Service) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- service.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func waitDeliveries(t *testing.T, service *notifications.Service, subscriptionID string, count int) []models.WebhookDelivery {
+	t.Helper()
+	var deliveries []models.WebhookDelivery
+	require.Eventually(t, func() bool {
+		var err error
+		deliveries, err = service.Deliveries(context.Background(), subscriptionID)
+		require.NoError(t, err)
+		if len(deliveries) != count {
+			return false
+		}
+		for _, delivery := range deliveries {
+			if delivery.State == models.WebhookStatePending {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return deliveries
+}
+
+func vinAttestation(tokenID string) *cloudevent.RawEvent {
+	return &cloudevent.RawEvent{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			ID:          "vc-" + tokenID,
+			Source:      devLicense,
+			Subject:     "did:erc721:137:" + vehicleContract + ":" + tokenID,
+			Type:        cloudevent.TypeAttestation,
+			DataVersion: "vin/v1.0",
+			Time:        time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Data: []byte(`{"validFrom":"2026-10-01T12:00:00Z","validTo":"2026-10-31T12:00:00Z","credentialSubject":{"vehicleIdentificationNumber":"1HGCM82673A123456"}}`),
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          notifications.SubscriptionRequest
+		expectedCode int
+	}{
+		{
+			name: "created",
+			req:  notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks", DevLicenses: []string{"0x4cb8e0b6bcd3cb7d6b4bd0b2c4b5dd1f2e3a4b5c"}},
+		},
+		{
+			name:         "missing partner",
+			req:          notifications.SubscriptionRequest{URL: "https://example.com/hooks"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "plain http URL",
+			req:          notifications.SubscriptionRequest{Partner: "Acme", URL: "http://example.com/hooks"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid dev license",
+			req:          notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks", DevLicenses: []string{"acme"}},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := newService(t, NewMockSender(ctrl))
+
+			sub, err := service.Subscribe(context.Background(), alice, tt.req)
+			if tt.expectedCode != 0 {
+				richErr, ok := richerrors.AsRichError(err)
+				require.True(t, ok, "expected rich error, got %v", err)
+				require.Equal(t, tt.expectedCode, richErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, sub.Secret)
+			require.Equal(t, alice.ID, sub.CreatedBy)
+			require.Equal(t, []string{devLicense}, sub.DevLicenses)
+
+			// the secret is only returned on creation
+			stored, err := service.Subscription(context.Background(), sub.ID)
+			require.NoError(t, err)
+			require.Empty(t, stored.Secret)
+			listed, err := service.Subscriptions(context.Background())
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			require.Empty(t, listed[0].Secret)
+		})
+	}
+}
+
+func TestDevLicenseSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := newService(t, NewMockSender(ctrl))
+	ctx := context.Background()
+
+	_, err := service.Subscribe(ctx, alice, notifications.SubscriptionRequest{Partner: "Operator", URL: "https://example.com/hooks"})
+	require.NoError(t, err)
+	sub, err := service.SubscribeDevLicense(ctx, strings.ToLower(partnerLicense), notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks"})
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.Secret)
+	require.Equal(t, partnerLicense, sub.Owner)
+	require.Equal(t, partnerLicense, sub.CreatedBy)
+	require.Equal(t, []string{partnerLicense}, sub.DevLicenses)
+
+	_, err = service.SubscribeDevLicense(ctx, partnerLicense, notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks", DevLicenses: []string{otherLicense}})
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, richErr.Code)
+	_, err = service.SubscribeDevLicense(ctx, alice.ID, notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks"})
+	richErr, ok = richerrors.AsRichError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, richErr.Code)
+
+	owned, err := service.DevLicenseSubscriptions(ctx, partnerLicense)
+	require.NoError(t, err)
+	require.Len(t, owned, 1)
+	require.Equal(t, sub.ID, owned[0].ID)
+	require.Empty(t, owned[0].Secret)
+	owned, err = service.DevLicenseSubscriptions(ctx, otherLicense)
+	require.NoError(t, err)
+	require.Empty(t, owned)
+
+	// subscriptions of other dev licenses are not found
+	_, err = service.DevLicenseSubscription(ctx, otherLicense, sub.ID)
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+	require.ErrorIs(t, service.UnsubscribeDevLicense(ctx, otherLicense, sub.ID), boltstore.ErrNotFound)
+
+	stored, err := service.DevLicenseSubscription(ctx, partnerLicense, sub.ID)
+	require.NoError(t, err)
+	require.Empty(t, stored.Secret)
+	require.NoError(t, service.UnsubscribeDevLicense(ctx, partnerLicense, sub.ID))
+	_, err = service.Subscription(ctx, sub.ID)
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+}
+
+func TestAttestationUploaded(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           notifications.SubscriptionRequest
+		sendErr       error
+		expectedState string
+		notified      bool
+	}{
+		{
+			name:          "all attestations",
+			req:           notifications.SubscriptionRequest{},
+			expectedState: models.WebhookStateDelivered,
+			notified:      true,
+		},
+		{
+			name:          "matching filters",
+			req:           notifications.SubscriptionRequest{TokenIDs: []uint32{7}, AttestationTypes: []string{"vin/v1.0"}, DevLicenses: []string{partnerLicense}},
+			expectedState: models.WebhookStateDelivered,
+			notified:      true,
+		},
+		{
+			name:     "other requester",
+			req:      notifications.SubscriptionRequest{DevLicenses: []string{otherLicense}},
+			notified: false,
+		},
+		{
+			name:     "issuing dev license is not the requester",
+			req:      notifications.SubscriptionRequest{DevLicenses: []string{devLicense}},
+			notified: false,
+		},
+		{
+			name:     "other vehicle",
+			req:      notifications.SubscriptionRequest{TokenIDs: []uint32{8}},
+			notified: false,
+		},
+		{
+			name:     "other attestation type",
+			req:      notifications.SubscriptionRequest{AttestationTypes: []string{"odometer/v1.0"}},
+			notified: false,
+		},
+		{
+			name:          "webhook fails",
+			req:           notifications.SubscriptionRequest{},
+			sendErr:       errors.New("webhook failed after 5 attempts"),
+			expectedState: models.WebhookStateFailed,
+			notified:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sender := NewMockSender(ctrl)
+			service := newService(t, sender)
+			tt.req.Partner = "Acme"
+			tt.req.URL = "https://example.com/hooks"
+			sub, err := service.Subscribe(context.Background(), alice, tt.req)
+			require.NoError(t, err)
+
+			if tt.notified {
+				attempts := 1
+				if tt.sendErr != nil {
+					attempts = 5
+				}
+				sender.EXPECT().SendHMAC(gomock.Any(), sub.URL, sub.Secret, gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, payload any) (int, error) {
+					body, ok := payload.(models.WebhookPayload)
+					require.True(t, ok)
+					require.Equal(t, sub.ID, body.SubscriptionID)
+					require.Equal(t, "vc-7", body.EventID)
+					require.Equal(t, uint32(7), body.TokenID)
+					require.Equal(t, devLicense, body.Source)
+					require.Equal(t, partnerLicense, body.Requester)
+					require.Equal(t, time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC), *body.ValidTo)
+					return attempts, tt.sendErr
+				})
+			}
+			runService(t, service)
+			ctx := requester.NewContext(context.Background(), strings.ToLower(partnerLicense))
+			service.AttestationUploaded(ctx, vinAttestation("7"))
+
+			if !tt.notified {
+				deliveries, err := service.Deliveries(context.Background(), sub.ID)
+				require.NoError(t, err)
+				require.Empty(t, deliveries)
+				return
+			}
+			deliveries := waitDeliveries(t, service, sub.ID, 1)
+			require.Equal(t, tt.expectedState, deliveries[0].State)
+			require.NotNil(t, deliveries[0].FinishedAt)
+			if tt.sendErr != nil {
+				require.Equal(t, 5, deliveries[0].Attempts)
+				require.Equal(t, tt.sendErr.Error(), deliveries[0].LastError)
+			}
+		})
+	}
+}
+
+func TestAttestationUploaded_Unrequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sender := NewMockSender(ctrl)
+	service := newService(t, sender)
+	ctx := context.Background()
+
+	owned, err := service.SubscribeDevLicense(ctx, partnerLicense, notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks"})
+	require.NoError(t, err)
+	other, err := service.SubscribeDevLicense(ctx, otherLicense, notifications.SubscriptionRequest{Partner: "Other", URL: "https://example.com/other"})
+	require.NoError(t, err)
+	operated, err := service.Subscribe(ctx, alice, notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/operated", DevLicenses: []string{partnerLicense}})
+	require.NoError(t, err)
+
+	sender.EXPECT().SendHMAC(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil).Times(3)
+	runService(t, service)
+
+	// the partner requests an attestation of vehicle 7, the service renews it and attests vehicle 8 on its own
+	service.AttestationUploaded(requester.NewContext(ctx, partnerLicense), vinAttestation("7"))
+	renewal := vinAttestation("7")
+	renewal.ID = "vc-7-renewal"
+	service.AttestationUploaded(ctx, renewal)
+	service.AttestationUploaded(ctx, vinAttestation("8"))
+
+	deliveries := waitDeliveries(t, service, owned.ID, 2)
+	requesters := map[string]string{}
+	for _, delivery := range deliveries {
+		requesters[delivery.Notification.EventID] = delivery.Notification.Requester
+	}
+	require.Equal(t, map[string]string{"vc-7": partnerLicense, "vc-7-renewal": ""}, requesters)
+	// operator subscriptions filtering by the dev license only match the attestations it requested
+	deliveries = waitDeliveries(t, service, operated.ID, 1)
+	require.Equal(t, "vc-7", deliveries[0].Notification.EventID)
+	deliveries, err = service.Deliveries(ctx, other.ID)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+}
+
+func TestReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sender := NewMockSender(ctrl)
+	service := newService(t, sender)
+	sub, err := service.Subscribe(context.Background(), alice, notifications.SubscriptionRequest{Partner: "Acme", URL: "https://example.com/hooks"})
+	require.NoError(t, err)
+
+	var deliveryIDs []string
+	sender.EXPECT().SendHMAC(gomock.Any(), sub.URL, sub.Secret, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, _, _ string, payload any) (int, error) {
+		body := payload.(models.WebhookPayload)
+		require.Equal(t, "vc-7", body.EventID)
+		deliveryIDs = append(deliveryIDs, body.DeliveryID)
+		return 1, nil
+	})
+	runService(t, service)
+	service.AttestationUploaded(context.Background(), vinAttestation("7"))
+	original := waitDeliveries(t, service, sub.ID, 1)[0]
+
+	replay, err := service.Replay(context.Background(), original.ID)
+	require.NoError(t, err)
+	require.Equal(t, original.ID, replay.ReplayOf)
+	deliveries := waitDeliveries(t, service, sub.ID, 2)
+	require.Equal(t, models.WebhookStateDelivered, deliveries[1].State)
+	require.Equal(t, []string{original.ID, replay.ID}, deliveryIDs)
+
+	// deliveries of deleted subscriptions cannot be replayed
+	require.NoError(t, service.Unsubscribe(context.Background(), alice, sub.ID))
+	_, err = service.Replay(context.Background(), original.ID)
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+}
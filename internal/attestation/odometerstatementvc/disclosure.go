@@ -0,0 +1,127 @@
+package odometerstatementvc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+)
+
+const (
+	// kilometersPerMile is the exact length of the international mile in kilometers.
+	kilometersPerMile = 1.609344
+	// odometerMechanicalLimitMiles is the highest reading of a six digit odometer.
+	odometerMechanicalLimitMiles = 999_999
+	// rollbackToleranceKm is how far a reading may drop below an earlier one before it is treated as a rollback.
+	rollbackToleranceKm = 1.0
+
+	// lastFullyRegulatedModelYear is the last model year covered by the pre-2021 ten year exemption (49 CFR 580.17).
+	lastFullyRegulatedModelYear = 2010
+	legacyExemptionAgeYears     = 10
+	exemptionAgeYears           = 20
+)
+
+// resolveUnit validates the requested unit and applies the profile default.
+func resolveUnit(opts StatementOptions) (string, error) {
+	switch opts.DisclosureProfile {
+	case "", types.DisclosureProfileUSFederal:
+	default:
+		return "", richerrors.Error{
+			Code:        http.StatusBadRequest,
+			Err:         fmt.Errorf("unknown disclosure profile %q", opts.DisclosureProfile),
+			ExternalMsg: "Unknown disclosure profile",
+		}
+	}
+
+	switch opts.Unit {
+	case types.OdometerUnitKilometers, types.OdometerUnitMiles:
+		return opts.Unit, nil
+	case "":
+		if opts.DisclosureProfile == types.DisclosureProfileUSFederal {
+			return types.OdometerUnitMiles, nil
+		}
+		return types.OdometerUnitKilometers, nil
+	default:
+		return "", richerrors.Error{
+			Code:        http.StatusBadRequest,
+			Err:         errors.New("unknown odometer unit " + opts.Unit),
+			ExternalMsg: "Unit must be km or miles",
+		}
+	}
+}
+
+// convertReading converts a kilometer reading into the requested unit.
+func convertReading(reading types.OdometerReading, unit string) types.OdometerReading {
+	if unit == types.OdometerUnitMiles {
+		reading.Value = kmToMiles(reading.Value)
+	}
+	reading.Unit = unit
+	return reading
+}
+
+func kmToMiles(km float64) float64 {
+	return km / kilometersPerMile
+}
+
+// usFederalDisclosure builds the US federal odometer disclosure for a kilometer reading.
+// readings are the odometer signals around the reading and are used to detect rollbacks.
+func usFederalDisclosure(vin, nameSlug string, reading types.OdometerReading, readings []telemetryapi.Signal, now time.Time) *types.OdometerDisclosure {
+	disclosure := &types.OdometerDisclosure{
+		Profile:                     types.DisclosureProfileUSFederal,
+		VehicleIdentificationNumber: vin,
+		ModelYear:                   models.ModelYearFromSlug(nameSlug),
+		MileageStatus:               types.MileageStatusActual,
+	}
+
+	switch {
+	case hasRollback(readings):
+		disclosure.MileageStatus = types.MileageStatusNotActual
+	case kmToMiles(reading.Value) > odometerMechanicalLimitMiles:
+		disclosure.MileageStatus = types.MileageStatusExceedsMechanicalLimits
+	}
+
+	if isModelYearExempt(disclosure.ModelYear, now) {
+		disclosure.Exempt = true
+		disclosure.ExemptionReasons = append(disclosure.ExemptionReasons, types.ExemptionModelYearAge)
+	}
+
+	return disclosure
+}
+
+// hasRollback reports whether any odometer reading drops below an earlier reading.
+func hasRollback(signals []telemetryapi.Signal) bool {
+	odometers := slices.DeleteFunc(slices.Clone(signals), func(signal telemetryapi.Signal) bool {
+		return !isOdometerSignal(signal)
+	})
+	slices.SortFunc(odometers, func(a, b telemetryapi.Signal) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	var maxSeen float64
+	for _, signal := range odometers {
+		value := signal.Value.(float64)
+		if value < maxSeen-rollbackToleranceKm {
+			return true
+		}
+		maxSeen = max(maxSeen, value)
+	}
+	return false
+}
+
+// isModelYearExempt applies the model year age exemption of 49 CFR 580.17.
+func isModelYearExempt(modelYear int, now time.Time) bool {
+	if modelYear == 0 {
+		return false
+	}
+	age := now.Year() - modelYear
+	if modelYear <= lastFullyRegulatedModelYear {
+		return age >= legacyExemptionAgeYears
+	}
+	return age >= exemptionAgeYears
+}
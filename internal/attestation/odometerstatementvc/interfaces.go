@@ -5,12 +5,14 @@ import (
 
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 )
 
 // VCRepo defines the interface for managing VC storage.
 type VCRepo interface {
 	UploadAttestation(ctx context.Context, attestation *cloudevent.RawEvent) error
+	GetLatestVINSubject(ctx context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error)
 }
 
 // IdentityAPI defines the interface for identity operations.
@@ -15,6 +15,7 @@ import (
 
 	telemetryapi "github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	models "github.com/DIMO-Network/attestation-api/internal/models"
+	types "github.com/DIMO-Network/attestation-api/pkg/types"
 	cloudevent "github.com/DIMO-Network/cloudevent"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,6 +44,21 @@ func (m *MockVCRepo) EXPECT() *MockVCRepoMockRecorder {
 	return m.recorder
 }
 
+// GetLatestVINSubject mocks base method.
+func (m *MockVCRepo) GetLatestVINSubject(ctx context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVINSubject", ctx, vehicleDID)
+	ret0, _ := ret[0].(*types.VINSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVINSubject indicates an expected call of GetLatestVINSubject.
+func (mr *MockVCRepoMockRecorder) GetLatestVINSubject(ctx, vehicleDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVINSubject", reflect.TypeOf((*MockVCRepo)(nil).GetLatestVINSubject), ctx, vehicleDID)
+}
+
 // UploadAttestation mocks base method.
 func (m *MockVCRepo) UploadAttestation(ctx context.Context, attestation *cloudevent.RawEvent) error {
 	m.ctrl.T.Helper()
@@ -13,16 +13,26 @@ import (
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/config"
 	"github.com/DIMO-Network/attestation-api/internal/erc191"
-	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/segmentio/ksuid"
 )
 
-const odometerUnit = "km"
+// TelemetryPermissions are the vehicle permissions needed to read the telemetry of a OdometerStatementVC.
+var TelemetryPermissions = []string{tokenclaims.PermissionGetNonLocationHistory}
+
+// StatementOptions are the caller selectable options of an odometer statement.
+type StatementOptions struct {
+	// Unit is the unit of the attested reading, "km" or "miles". Defaults to "km",
+	// or "miles" when the US federal disclosure profile is requested.
+	Unit string
+	// DisclosureProfile is the optional regional disclosure profile, e.g. "us-federal".
+	DisclosureProfile string
+}
 
 // Service handles OdometerStatementVC-related operations.
 type Service struct {
@@ -58,56 +68,73 @@ func NewService(
 
 // CreateOdometerStatementVC creates an OdometerStatementVC.
 // If timestamp is nil, it uses the latest odometer reading.
-func (s *Service) CreateOdometerStatementVC(ctx context.Context, tokenID uint32, timestamp *time.Time, jwtToken string) error {
+func (s *Service) CreateOdometerStatementVC(ctx context.Context, tokenID uint32, timestamp *time.Time, opts StatementOptions, jwtToken string) (*cloudevent.RawEvent, error) {
+	unit, err := resolveUnit(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	vehicleDID := cloudevent.ERC721DID{
 		ChainID:         s.chainID,
 		TokenID:         big.NewInt(int64(tokenID)),
 		ContractAddress: s.vehicleContractAddress,
 	}
 
-	// Get vehicle information to determine producer
+	// Get vehicle information to resolve the data source
 	vehicleInfo, err := s.identityAPI.GetVehicleInfo(ctx, vehicleDID)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
 	}
 
-	odometerReading, err := s.getOdometerReading(ctx, vehicleDID, timestamp, jwtToken)
+	odometerSignal, readings, err := s.getOdometerReading(ctx, vehicleDID, timestamp, jwtToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// Determine producer from paired devices (prefer aftermarket, then synthetic)
-	producer := ""
-	for _, device := range vehicleInfo.PairedDevices {
-		if device.Type == models.DeviceTypeAftermarket {
-			producer = device.DID.String()
-			break
-		} else if device.Type == models.DeviceTypeSynthetic && producer == "" {
-			producer = device.DID.String()
-		}
+	odometerReading := types.OdometerReading{
+		Value:     odometerSignal.Value.(float64),
+		Unit:      types.OdometerUnitKilometers,
+		Timestamp: odometerSignal.Timestamp,
 	}
 
+	// Record the integration that actually produced the reading
+	dataSource := vehicleInfo.DataSource(odometerSignal.Source, odometerSignal.Producer)
+
 	subject := types.OdometerStatementVCSubject{
 		VehicleDID:         vehicleDID,
-		OdometerReading:    *odometerReading,
+		Source:             dataSource.Source,
+		OdometerReading:    convertReading(odometerReading, unit),
 		RequestedTimestamp: timestamp,
-		Producer:           producer,
+		Producer:           dataSource.Producer,
+		DataSource:         &dataSource,
+	}
+
+	if opts.DisclosureProfile == types.DisclosureProfileUSFederal {
+		vinSubject, err := s.vcRepo.GetLatestVINSubject(ctx, vehicleDID)
+		if err != nil {
+			var richErr richerrors.Error
+			if errors.As(err, &richErr) {
+				return nil, richErr
+			}
+			return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get VIN attestation", Code: http.StatusInternalServerError}
+		}
+		subject.Disclosure = usFederalDisclosure(vinSubject.VehicleIdentificationNumber, vehicleInfo.NameSlug, odometerReading, readings, time.Now())
 	}
 
 	vc, err := s.createAttestation(subject)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to create OdometerStatementVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create OdometerStatementVC", Code: http.StatusInternalServerError}
 	}
 
 	if err = s.vcRepo.UploadAttestation(ctx, vc); err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to store OdometerStatementVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to store OdometerStatementVC", Code: http.StatusInternalServerError}
 	}
 
-	return nil
+	return vc, nil
 }
 
-// getOdometerReading retrieves the odometer reading for the specified timestamp or latest using telemetry API.
-func (s *Service) getOdometerReading(ctx context.Context, vehicleInfo cloudevent.ERC721DID, requestTime *time.Time, jwtToken string) (*types.OdometerReading, error) {
+// getOdometerReading retrieves the odometer signal for the specified timestamp or latest using telemetry API.
+// The signal value is always in kilometers. All signals considered are returned alongside it.
+func (s *Service) getOdometerReading(ctx context.Context, vehicleInfo cloudevent.ERC721DID, requestTime *time.Time, jwtToken string) (*telemetryapi.Signal, []telemetryapi.Signal, error) {
 	var records []telemetryapi.Signal
 	var err error
 
@@ -126,7 +153,7 @@ func (s *Service) getOdometerReading(ctx context.Context, vehicleInfo cloudevent
 
 		records, err = s.telemetryAPI.GetHistoricalDataWithAuth(ctx, options, jwtToken)
 		if err != nil {
-			return nil, richerrors.Error{
+			return nil, nil, richerrors.Error{
 				Code:        http.StatusInternalServerError,
 				Err:         err,
 				ExternalMsg: "Failed to get odometer telemetry data",
@@ -134,7 +161,8 @@ func (s *Service) getOdometerReading(ctx context.Context, vehicleInfo cloudevent
 		}
 
 		// Find closest odometer reading
-		return s.findClosestOdometerFromTelemetry(records, *requestTime)
+		reading, err := s.findClosestOdometerFromTelemetry(records, *requestTime)
+		return reading, records, err
 	}
 
 	// Get latest odometer reading
@@ -144,22 +172,23 @@ func (s *Service) getOdometerReading(ctx context.Context, vehicleInfo cloudevent
 		Signals:  []string{vss.FieldPowertrainTransmissionTravelledDistance},
 	})
 	if err != nil {
-		return nil, richerrors.Error{
+		return nil, nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
 			ExternalMsg: "Failed to get latest telemetry data",
 		}
 	}
 
-	return s.findClosestOdometerFromTelemetry(records, time.Now())
+	reading, err := s.findClosestOdometerFromTelemetry(records, time.Now())
+	return reading, records, err
 }
 
-// findClosestOdometerFromTelemetry finds the odometer reading closest to the requested time.
-func (s *Service) findClosestOdometerFromTelemetry(signals []telemetryapi.Signal, requestedTime time.Time) (*types.OdometerReading, error) {
+// findClosestOdometerFromTelemetry finds the odometer signal closest to the requested time.
+func (s *Service) findClosestOdometerFromTelemetry(signals []telemetryapi.Signal, requestedTime time.Time) (*telemetryapi.Signal, error) {
 	var closestSignal *telemetryapi.Signal
 	var closestTimeDiff time.Duration
 	for _, signal := range signals {
-		if !s.isOdometerSignal(signal) {
+		if !isOdometerSignal(signal) {
 			continue
 		}
 
@@ -178,15 +207,11 @@ func (s *Service) findClosestOdometerFromTelemetry(signals []telemetryapi.Signal
 		}
 	}
 
-	return &types.OdometerReading{
-		Value:     closestSignal.Value.(float64),
-		Unit:      odometerUnit,
-		Timestamp: closestSignal.Timestamp,
-	}, nil
+	return closestSignal, nil
 }
 
-// extractOdometerFromTelemetry extracts odometer data from a telemetry record.
-func (s *Service) isOdometerSignal(signal telemetryapi.Signal) bool {
+// isOdometerSignal reports whether the telemetry signal is a usable odometer reading.
+func isOdometerSignal(signal telemetryapi.Signal) bool {
 	// Check for the specific odometer signal from schema
 	if signal.Name != "powertrainTransmissionTravelledDistance" {
 		return false
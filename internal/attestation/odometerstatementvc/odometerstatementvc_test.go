@@ -202,7 +202,7 @@ func TestCreateOdometerStatementVC_WithTimestamp(t *testing.T) {
 			}
 
 			// Execute
-			err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, jwtToken)
+			_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, odometerstatementvc.StatementOptions{}, jwtToken)
 			if tt.expectedError {
 				require.Error(t, err)
 				return
@@ -310,7 +310,7 @@ func TestCreateOdometerStatementVC_WithoutTimestamp(t *testing.T) {
 			}
 
 			// Execute
-			err := service.CreateOdometerStatementVC(context.Background(), tokenID, nil, jwtToken)
+			_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, nil, odometerstatementvc.StatementOptions{}, jwtToken)
 			if tt.expectedError {
 				require.Error(t, err)
 				return
@@ -344,6 +344,61 @@ func TestCreateOdometerStatementVC_WithoutTimestamp(t *testing.T) {
 	}
 }
 
+func TestCreateOdometerStatementVC_DataSource(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	aftermarketDID := cloudevent.ERC721DID{TokenID: big.NewInt(456), ChainID: 137, ContractAddress: common.HexToAddress("0xabcd")}
+	syntheticDID := cloudevent.ERC721DID{TokenID: big.NewInt(789), ChainID: 137, ContractAddress: common.HexToAddress("0xef01")}
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{
+			PairedDevices: []models.PairedDevice{
+				{DID: aftermarketDID, Type: models.DeviceTypeAftermarket, ManufacturerName: "AutoPi"},
+				{DID: syntheticDID, Type: models.DeviceTypeSynthetic},
+			},
+		}, nil)
+
+	// The reading comes from the synthetic device even though an aftermarket device is paired
+	mockTelemetryAPI.EXPECT().
+		GetLatestSignalsWithAuth(gomock.Any(), gomock.Any()).
+		Return([]telemetryapi.Signal{
+			{
+				Name:      vss.FieldPowertrainTransmissionTravelledDistance,
+				Value:     75000.0,
+				Timestamp: time.Date(2024, 1, 15, 11, 45, 0, 0, time.UTC),
+				Source:    "0xsynthetic",
+				Producer:  syntheticDID.String(),
+			},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateOdometerStatementVC(context.Background(), uint32(123), nil, odometerstatementvc.StatementOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.OdometerStatementVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+	assert.Equal(t, "0xsynthetic", subjectData.Source)
+	assert.Equal(t, syntheticDID.String(), subjectData.Producer)
+	assert.Equal(t, syntheticDID.String(), uploadedAttestation.Producer)
+	assert.Equal(t, &types.DataSource{
+		Source:     "0xsynthetic",
+		Producer:   syntheticDID.String(),
+		DeviceType: string(models.DeviceTypeSynthetic),
+	}, subjectData.DataSource)
+}
+
 func TestCreateOdometerStatementVC_TelemetryAPIError(t *testing.T) {
 	service, _, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
 	defer ctrl.Finish()
@@ -370,7 +425,7 @@ func TestCreateOdometerStatementVC_TelemetryAPIError(t *testing.T) {
 		Return(nil, assert.AnError)
 
 	// Execute
-	err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, jwtToken)
+	_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, odometerstatementvc.StatementOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -405,7 +460,7 @@ func TestCreateOdometerStatementVC_NoOdometerData(t *testing.T) {
 		Return([]telemetryapi.Signal{}, nil)
 
 	// Execute
-	err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, jwtToken)
+	_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, odometerstatementvc.StatementOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -455,7 +510,7 @@ func TestCreateOdometerStatementVC_VCRepoError(t *testing.T) {
 		Return(assert.AnError)
 
 	// Execute
-	err := service.CreateOdometerStatementVC(context.Background(), tokenID, nil, jwtToken)
+	_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, nil, odometerstatementvc.StatementOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -463,3 +518,165 @@ func TestCreateOdometerStatementVC_VCRepoError(t *testing.T) {
 	assert.ErrorAs(t, err, &ctrlErr)
 	assert.Equal(t, http.StatusInternalServerError, ctrlErr.Code)
 }
+
+func TestCreateOdometerStatementVC_UnitsAndDisclosure(t *testing.T) {
+	requestedTime := time.Date(2024, 12, 23, 12, 34, 56, 0, time.UTC)
+	tests := []struct {
+		name               string
+		opts               odometerstatementvc.StatementOptions
+		nameSlug           string
+		signals            []telemetryapi.Signal
+		expectedValue      float64
+		expectedUnit       string
+		expectedDisclosure *types.OdometerDisclosure
+		expectedError      bool
+	}{
+		{
+			name: "miles are converted with the international mile",
+			opts: odometerstatementvc.StatementOptions{Unit: types.OdometerUnitMiles},
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 160934.4, Timestamp: requestedTime},
+			},
+			expectedValue: 100000,
+			expectedUnit:  types.OdometerUnitMiles,
+		},
+		{
+			name:     "us federal profile defaults to miles",
+			opts:     odometerstatementvc.StatementOptions{DisclosureProfile: types.DisclosureProfileUSFederal},
+			nameSlug: "toyota_tacoma-4wd_2023",
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 16093.44, Timestamp: requestedTime.Add(-10 * time.Minute)},
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 16100.0, Timestamp: requestedTime.Add(20 * time.Minute)},
+			},
+			expectedValue: 10000,
+			expectedUnit:  types.OdometerUnitMiles,
+			expectedDisclosure: &types.OdometerDisclosure{
+				Profile:                     types.DisclosureProfileUSFederal,
+				VehicleIdentificationNumber: "1HGCM82633A123456",
+				ModelYear:                   2023,
+				MileageStatus:               types.MileageStatusActual,
+			},
+		},
+		{
+			name:     "us federal profile flags rollback as not actual",
+			opts:     odometerstatementvc.StatementOptions{Unit: types.OdometerUnitKilometers, DisclosureProfile: types.DisclosureProfileUSFederal},
+			nameSlug: "ford_f-150_2009",
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 90000.0, Timestamp: requestedTime.Add(-40 * time.Minute)},
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 20000.0, Timestamp: requestedTime},
+			},
+			expectedValue: 20000,
+			expectedUnit:  types.OdometerUnitKilometers,
+			expectedDisclosure: &types.OdometerDisclosure{
+				Profile:                     types.DisclosureProfileUSFederal,
+				VehicleIdentificationNumber: "1HGCM82633A123456",
+				ModelYear:                   2009,
+				MileageStatus:               types.MileageStatusNotActual,
+				Exempt:                      true,
+				ExemptionReasons:            []string{types.ExemptionModelYearAge},
+			},
+		},
+		{
+			name:     "us federal profile flags readings past the mechanical limit",
+			opts:     odometerstatementvc.StatementOptions{DisclosureProfile: types.DisclosureProfileUSFederal},
+			nameSlug: "toyota_tacoma-4wd_2023",
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 1609344.0, Timestamp: requestedTime},
+			},
+			expectedValue: 1000000,
+			expectedUnit:  types.OdometerUnitMiles,
+			expectedDisclosure: &types.OdometerDisclosure{
+				Profile:                     types.DisclosureProfileUSFederal,
+				VehicleIdentificationNumber: "1HGCM82633A123456",
+				ModelYear:                   2023,
+				MileageStatus:               types.MileageStatusExceedsMechanicalLimits,
+			},
+		},
+		{
+			name:          "unknown unit",
+			opts:          odometerstatementvc.StatementOptions{Unit: "furlongs"},
+			expectedError: true,
+		},
+		{
+			name:          "unknown disclosure profile",
+			opts:          odometerstatementvc.StatementOptions{DisclosureProfile: "mars"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+
+			tokenID := uint32(123)
+			jwtToken := "test-jwt-token"
+
+			if tt.expectedError {
+				_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, tt.opts, jwtToken)
+				var richErr richerrors.Error
+				require.ErrorAs(t, err, &richErr)
+				assert.Equal(t, http.StatusBadRequest, richErr.Code)
+				return
+			}
+
+			mockIdentityAPI.EXPECT().
+				GetVehicleInfo(gomock.Any(), gomock.Any()).
+				Return(&models.VehicleInfo{NameSlug: tt.nameSlug}, nil)
+			mockTelemetryAPI.EXPECT().
+				GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+				Return(tt.signals, nil)
+			if tt.expectedDisclosure != nil {
+				mockVCRepo.EXPECT().
+					GetLatestVINSubject(gomock.Any(), gomock.Any()).
+					Return(&types.VINSubject{VehicleIdentificationNumber: "1HGCM82633A123456"}, nil)
+			}
+
+			var uploadedAttestation *cloudevent.RawEvent
+			mockVCRepo.EXPECT().
+				UploadAttestation(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+					uploadedAttestation = attestation
+					return nil
+				})
+
+			_, err := service.CreateOdometerStatementVC(context.Background(), tokenID, &requestedTime, tt.opts, jwtToken)
+			require.NoError(t, err)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+			var subjectData types.OdometerStatementVCSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+			assert.InEpsilon(t, tt.expectedValue, subjectData.OdometerReading.Value, 0.000001)
+			assert.Equal(t, tt.expectedUnit, subjectData.OdometerReading.Unit)
+			assert.Equal(t, tt.expectedDisclosure, subjectData.Disclosure)
+		})
+	}
+}
+
+func TestCreateOdometerStatementVC_DisclosureWithoutVINAttestation(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	requestedTime := time.Date(2024, 12, 23, 12, 34, 56, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{NameSlug: "toyota_tacoma-4wd_2023"}, nil)
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{Name: vss.FieldPowertrainTransmissionTravelledDistance, Value: 50000.0, Timestamp: requestedTime},
+		}, nil)
+	mockVCRepo.EXPECT().
+		GetLatestVINSubject(gomock.Any(), gomock.Any()).
+		Return(nil, richerrors.Error{Err: assert.AnError, ExternalMsg: "No VIN attestation found for vehicle", Code: http.StatusBadRequest})
+
+	opts := odometerstatementvc.StatementOptions{DisclosureProfile: types.DisclosureProfileUSFederal}
+	_, err := service.CreateOdometerStatementVC(context.Background(), 123, &requestedTime, opts, jwtToken)
+
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	assert.Equal(t, http.StatusBadRequest, richErr.Code)
+}
@@ -0,0 +1,32 @@
+package recurring
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Runner defines the interface for creating the attestation of a job.
+type Runner interface {
+	RunJob(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error)
+}
+
+// VehicleTokenSource defines the interface for getting vehicle tokens obtained by the service.
+type VehicleTokenSource interface {
+	GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error)
+}
+
+// WebhookSender defines the interface for calling webhooks.
+type WebhookSender interface {
+	Send(ctx context.Context, webhookURL string, payload any) error
+}
+
+// Store defines the interface for persisting recurring subscriptions.
+type Store interface {
+	CreateRecurringSubscription(ctx context.Context, sub *models.RecurringSubscription) error
+	GetRecurringSubscription(ctx context.Context, id string) (*models.RecurringSubscription, error)
+	UpdateRecurringSubscription(ctx context.Context, id string, update func(*models.RecurringSubscription) error) (*models.RecurringSubscription, error)
+	ListRecurringSubscriptions(ctx context.Context, match func(*models.RecurringSubscription) bool) ([]models.RecurringSubscription, error)
+	DeleteRecurringSubscription(ctx context.Context, id string) error
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=recurring_test
+//
+
+// Package recurring_test is a generated GoMock package.
+package recurring_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunner is a mock of Runner interface.
+type MockRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockRunnerMockRecorder is the mock recorder for MockRunner.
+type MockRunnerMockRecorder struct {
+	mock *MockRunner
+}
+
+// NewMockRunner creates a new mock instance.
+func NewMockRunner(ctrl *gomock.Controller) *MockRunner {
+	mock := &MockRunner{ctrl: ctrl}
+	mock.recorder = &MockRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
+	return m.recorder
+}
+
+// RunJob mocks base method.
+func (m *MockRunner) RunJob(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunJob", ctx, job)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunJob indicates an expected call of RunJob.
+func (mr *MockRunnerMockRecorder) RunJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJob", reflect.TypeOf((*MockRunner)(nil).RunJob), ctx, job)
+}
+
+// MockVehicleTokenSource is a mock of VehicleTokenSource interface.
+type MockVehicleTokenSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleTokenSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleTokenSourceMockRecorder is the mock recorder for MockVehicleTokenSource.
+type MockVehicleTokenSourceMockRecorder struct {
+	mock *MockVehicleTokenSource
+}
+
+// NewMockVehicleTokenSource creates a new mock instance.
+func NewMockVehicleTokenSource(ctrl *gomock.Controller) *MockVehicleTokenSource {
+	mock := &MockVehicleTokenSource{ctrl: ctrl}
+	mock.recorder = &MockVehicleTokenSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleTokenSource) EXPECT() *MockVehicleTokenSourceMockRecorder {
+	return m.recorder
+}
+
+// GetVehicleToken mocks base method.
+func (m *MockVehicleTokenSource) GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleToken", ctx, tokenID, permissions)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleToken indicates an expected call of GetVehicleToken.
+func (mr *MockVehicleTokenSourceMockRecorder) GetVehicleToken(ctx, tokenID, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleToken", reflect.TypeOf((*MockVehicleTokenSource)(nil).GetVehicleToken), ctx, tokenID, permissions)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, webhookURL string, payload any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, webhookURL, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, webhookURL, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, webhookURL, payload)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateRecurringSubscription mocks base method.
+func (m *MockStore) CreateRecurringSubscription(ctx context.Context, sub *models.RecurringSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringSubscription", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecurringSubscription indicates an expected call of CreateRecurringSubscription.
+func (mr *MockStoreMockRecorder) CreateRecurringSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringSubscription", reflect.TypeOf((*MockStore)(nil).CreateRecurringSubscription), ctx, sub)
+}
+
+// DeleteRecurringSubscription mocks base method.
+func (m *MockStore) DeleteRecurringSubscription(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurringSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurringSubscription indicates an expected call of DeleteRecurringSubscription.
+func (mr *MockStoreMockRecorder) DeleteRecurringSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurringSubscription", reflect.TypeOf((*MockStore)(nil).DeleteRecurringSubscription), ctx, id)
+}
+
+// GetRecurringSubscription mocks base method.
+func (m *MockStore) GetRecurringSubscription(ctx context.Context, id string) (*models.RecurringSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringSubscription", ctx, id)
+	ret0, _ := ret[0].(*models.RecurringSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringSubscription indicates an expected call of GetRecurringSubscription.
+func (mr *MockStoreMockRecorder) GetRecurringSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringSubscription", reflect.TypeOf((*MockStore)(nil).GetRecurringSubscription), ctx, id)
+}
+
+// ListRecurringSubscriptions mocks base method.
+func (m *MockStore) ListRecurringSubscriptions(ctx context.Context, match func(*models.RecurringSubscription) bool) ([]models.RecurringSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurringSubscriptions", ctx, match)
+	ret0, _ := ret[0].([]models.RecurringSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurringSubscriptions indicates an expected call of ListRecurringSubscriptions.
+func (mr *MockStoreMockRecorder) ListRecurringSubscriptions(ctx, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurringSubscriptions", reflect.TypeOf((*MockStore)(nil).ListRecurringSubscriptions), ctx, match)
+}
+
+// UpdateRecurringSubscription mocks base method.
+func (m *MockStore) UpdateRecurringSubscription(ctx context.Context, id string, update func(*models.RecurringSubscription) error) (*models.RecurringSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringSubscription", ctx, id, update)
+	ret0, _ := ret[0].(*models.RecurringSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringSubscription indicates an expected call of UpdateRecurringSubscription.
+func (mr *MockStoreMockRecorder) UpdateRecurringSubscription(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringSubscription", reflect.TypeOf((*MockStore)(nil).UpdateRecurringSubscription), ctx, id, update)
+}
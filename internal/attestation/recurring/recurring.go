@@ -0,0 +1,535 @@
+// Package recurring issues attestations of vehicles on a schedule, e.g. a monthly odometer statement for a lender.
+// Attestations created from telemetry are read with vehicle tokens the service obtains for itself. Failed
+// attestations are retried a few times before their due date is skipped, and the subscription's notify URL is
+// called with every failure. A run leases its subscription in the store, so schedulers sharing the store never run
+// a due date twice.
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/attestation-api/internal/webhook"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// MaxAttempts is how many times the attestation of a due date is attempted before the due date is skipped.
+	MaxAttempts = 3
+	// RetryDelay is the time between the attempts of a due date.
+	RetryDelay = 15 * time.Minute
+
+	maxConcurrentRuns = 4
+	pollInterval      = time.Minute
+	// runTimeout is how long issuing an attestation may take.
+	runTimeout = 15 * time.Minute
+	// runLease is how long a run holds its subscription. A subscription whose run never saved its outcome, because
+	// the process stopped, is run again when the lease expires.
+	runLease = 2 * runTimeout
+)
+
+// errLeased is returned when the subscription is no longer due because another run holds it.
+var errLeased = errors.New("recurring subscription is leased by another run")
+
+// permissions are the vehicle token permissions of the attestations created from telemetry.
+var permissions = map[string][]string{
+	models.JobTypeVehiclePosition:   vehiclepositionvc.TelemetryPermissions,
+	models.JobTypeOdometerStatement: odometerstatementvc.TelemetryPermissions,
+	models.JobTypeVehicleHealth:     vehiclehealthvc.TelemetryPermissions,
+	models.JobTypeBatteryHealth:     batteryhealthvc.TelemetryPermissions,
+}
+
+// maxPeriods are the longest periods covered by the attestations of a time range.
+var maxPeriods = map[string]time.Duration{
+	models.JobTypeVehicleHealth: vehiclehealthvc.MaxTimeRange,
+	models.JobTypeBatteryHealth: batteryhealthvc.MaxTimeRange,
+}
+
+var intervals = []string{models.IntervalDaily, models.IntervalWeekly, models.IntervalMonthly}
+
+// Service manages recurring subscriptions and issues their attestations.
+type Service struct {
+	logger *zerolog.Logger
+	runner Runner
+	tokens VehicleTokenSource
+	sender WebhookSender
+	store  Store
+	wake   chan struct{}
+}
+
+// NewService creates a new Service issuing attestations with runner. Subscriptions to attestations created from
+// telemetry are rejected when tokens is nil.
+func NewService(logger *zerolog.Logger, runner Runner, tokens VehicleTokenSource, sender WebhookSender, store Store) *Service {
+	return &Service{
+		logger: logger,
+		runner: runner,
+		tokens: tokens,
+		sender: sender,
+		store:  store,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Request is a recurring subscription to create.
+type Request struct {
+	TokenID  uint32
+	Type     string
+	Interval string
+	// StartAt is the first due date, now when zero.
+	StartAt    time.Time
+	Parameters models.RecurringParameters
+	Owner      string
+	NotifyURL  string
+}
+
+// Update replaces the schedule and options of a recurring subscription. The next due date is kept, and starts the
+// new interval when it changes.
+type Update struct {
+	Interval   string
+	Parameters models.RecurringParameters
+	NotifyURL  string
+}
+
+// Create creates a recurring subscription on behalf of the operator.
+func (s *Service) Create(ctx context.Context, op *operator.Operator, req Request) (*models.RecurringSubscription, error) {
+	owner := strings.TrimSpace(req.Owner)
+	if owner == "" {
+		return nil, badRequest(errors.New("owner is empty"), "Owner is required")
+	}
+	if req.Type != models.JobTypeVIN && permissions[req.Type] == nil {
+		return nil, badRequest(fmt.Errorf("unknown attestation type %q", req.Type), "Unknown attestation type")
+	}
+	if permissions[req.Type] != nil && s.tokens == nil {
+		return nil, badRequest(fmt.Errorf("no vehicle token source for %s", req.Type), "Recurring "+req.Type+" attestations are disabled")
+	}
+	if err := validate(req.Type, req.Interval, req.Parameters, req.NotifyURL); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	startAt := req.StartAt.UTC()
+	if startAt.IsZero() {
+		startAt = now
+	}
+	sub := &models.RecurringSubscription{
+		ID:         ksuid.New().String(),
+		TokenID:    req.TokenID,
+		Type:       req.Type,
+		Interval:   req.Interval,
+		Parameters: req.Parameters,
+		Owner:      owner,
+		NotifyURL:  req.NotifyURL,
+		State:      models.RecurringStateActive,
+		StartAt:    startAt,
+		NextDueAt:  startAt,
+		NextRunAt:  startAt,
+		CreatedBy:  op.ID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.store.CreateRecurringSubscription(ctx, sub); err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create subscription", Code: http.StatusInternalServerError}
+	}
+	s.logger.Info().Str("recurringId", sub.ID).Uint32("tokenId", sub.TokenID).Str("type", sub.Type).Str("operator", op.ID).Msg("Created recurring subscription.")
+	s.notify()
+	return sub, nil
+}
+
+// List returns the recurring subscriptions of the vehicle and owner, or of every vehicle and owner when they are
+// zero.
+func (s *Service) List(ctx context.Context, tokenID uint32, owner string) ([]models.RecurringSubscription, error) {
+	return s.store.ListRecurringSubscriptions(ctx, func(sub *models.RecurringSubscription) bool {
+		return (tokenID == 0 || sub.TokenID == tokenID) && (owner == "" || sub.Owner == owner)
+	})
+}
+
+// Get returns the recurring subscription with the ID.
+func (s *Service) Get(ctx context.Context, id string) (*models.RecurringSubscription, error) {
+	return s.store.GetRecurringSubscription(ctx, id)
+}
+
+// Update replaces the schedule and options of the recurring subscription on behalf of the operator.
+func (s *Service) Update(ctx context.Context, op *operator.Operator, id string, update Update) (*models.RecurringSubscription, error) {
+	sub, err := s.store.UpdateRecurringSubscription(ctx, id, func(sub *models.RecurringSubscription) error {
+		if err := validate(sub.Type, update.Interval, update.Parameters, update.NotifyURL); err != nil {
+			return err
+		}
+		if update.Interval != sub.Interval {
+			sub.StartAt = sub.NextDueAt
+		}
+		sub.Interval = update.Interval
+		sub.Parameters = update.Parameters
+		sub.NotifyURL = update.NotifyURL
+		sub.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("recurringId", id).Str("operator", op.ID).Msg("Updated recurring subscription.")
+	return sub, nil
+}
+
+// Delete deletes the recurring subscription on behalf of the operator.
+func (s *Service) Delete(ctx context.Context, op *operator.Operator, id string) error {
+	if err := s.store.DeleteRecurringSubscription(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info().Str("recurringId", id).Str("operator", op.ID).Msg("Deleted recurring subscription.")
+	return nil
+}
+
+// Pause stops issuing the attestations of the recurring subscription on behalf of the operator.
+func (s *Service) Pause(ctx context.Context, op *operator.Operator, id string) (*models.RecurringSubscription, error) {
+	sub, err := s.store.UpdateRecurringSubscription(ctx, id, func(sub *models.RecurringSubscription) error {
+		sub.State = models.RecurringStatePaused
+		sub.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("recurringId", id).Str("operator", op.ID).Msg("Paused recurring subscription.")
+	return sub, nil
+}
+
+// Resume issues the attestations of the paused recurring subscription again on behalf of the operator. Due dates
+// that passed while it was paused are skipped.
+func (s *Service) Resume(ctx context.Context, op *operator.Operator, id string) (*models.RecurringSubscription, error) {
+	sub, err := s.store.UpdateRecurringSubscription(ctx, id, func(sub *models.RecurringSubscription) error {
+		if sub.State == models.RecurringStateActive {
+			return nil
+		}
+		now := time.Now().UTC()
+		sub.State = models.RecurringStateActive
+		if sub.NextDueAt.Before(now) {
+			sub.NextDueAt = nextDue(sub.StartAt, sub.NextDueAt, sub.Interval, now)
+			sub.Attempts = 0
+		}
+		sub.NextRunAt = sub.NextDueAt
+		sub.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("recurringId", id).Str("operator", op.ID).Msg("Resumed recurring subscription.")
+	s.notify()
+	return sub, nil
+}
+
+// Run issues the attestations of the active subscriptions when they are due, until ctx is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	slots := make(chan struct{}, maxConcurrentRuns)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		due, err := s.store.ListRecurringSubscriptions(ctx, func(sub *models.RecurringSubscription) bool {
+			return sub.State == models.RecurringStateActive && !sub.NextRunAt.After(now)
+		})
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to list due recurring subscriptions.")
+		}
+		for i := range due {
+			select {
+			case <-ctx.Done():
+				return nil
+			case slots <- struct{}{}:
+			}
+			until, ok := s.lease(ctx, &due[i])
+			if !ok {
+				<-slots
+				continue
+			}
+			wg.Add(1)
+			go func(sub *models.RecurringSubscription) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				s.run(ctx, sub, until)
+			}(&due[i])
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue issues the attestation of the due date of the subscription and schedules the next one. Nothing is done when
+// the subscription is no longer due as stored, e.g. because another run holds it.
+func (s *Service) RunDue(ctx context.Context, sub *models.RecurringSubscription) {
+	until, ok := s.lease(ctx, sub)
+	if !ok {
+		return
+	}
+	s.run(ctx, sub, until)
+}
+
+// lease holds the subscription for a run by moving its next run past the run, so other schedulers listing the due
+// subscriptions skip it. It returns the end of the lease, and false when the subscription is not due as listed.
+func (s *Service) lease(ctx context.Context, sub *models.RecurringSubscription) (time.Time, bool) {
+	until := time.Now().UTC().Add(runLease)
+	_, err := s.store.UpdateRecurringSubscription(ctx, sub.ID, func(cur *models.RecurringSubscription) error {
+		if cur.State != models.RecurringStateActive || !cur.NextRunAt.Equal(sub.NextRunAt) {
+			return errLeased
+		}
+		cur.NextRunAt = until
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errLeased) && ctx.Err() == nil {
+			s.logger.Error().Err(err).Str("recurringId", sub.ID).Msg("Failed to lease recurring subscription.")
+		}
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// run issues the attestation of the subscription leased until the time and schedules the next one.
+func (s *Service) run(ctx context.Context, sub *models.RecurringSubscription, until time.Time) {
+	logger := s.logger.With().Str("recurringId", sub.ID).Uint32("tokenId", sub.TokenID).Str("type", sub.Type).Logger()
+	runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+	attestation, runErr := s.issue(runCtx, sub)
+	cancel()
+	if runErr != nil && ctx.Err() != nil {
+		// Attempted again by the next process.
+		s.release(context.WithoutCancel(ctx), sub, until)
+		return
+	}
+
+	var failure *models.RecurringFailure
+	now := time.Now().UTC()
+	updated, err := s.store.UpdateRecurringSubscription(context.WithoutCancel(ctx), sub.ID, func(cur *models.RecurringSubscription) error {
+		cur.LastRunAt = &now
+		cur.UpdatedAt = now
+		if !cur.NextDueAt.Equal(sub.NextDueAt) {
+			// Rescheduled while running.
+			return nil
+		}
+		if runErr == nil {
+			cur.LastAttestationID = attestation.ID
+			cur.LastError = ""
+			cur.Attempts = 0
+			cur.NextDueAt = nextDue(cur.StartAt, cur.NextDueAt, cur.Interval, now)
+			cur.NextRunAt = cur.NextDueAt
+			return nil
+		}
+
+		msg, code := publicError(runErr)
+		cur.Attempts++
+		cur.LastError = msg
+		failure = &models.RecurringFailure{
+			SubscriptionID: cur.ID,
+			TokenID:        cur.TokenID,
+			Type:           cur.Type,
+			Owner:          cur.Owner,
+			DueAt:          cur.NextDueAt,
+			Error:          msg,
+			ErrorCode:      code,
+			Attempts:       cur.Attempts,
+		}
+		if code < http.StatusInternalServerError || cur.Attempts >= MaxAttempts {
+			failure.Skipped = true
+			cur.Attempts = 0
+			cur.NextDueAt = nextDue(cur.StartAt, cur.NextDueAt, cur.Interval, now)
+			cur.NextRunAt = cur.NextDueAt
+		} else {
+			cur.NextRunAt = now.Add(RetryDelay)
+		}
+		failure.NextDueAt = cur.NextDueAt
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to save recurring subscription run.")
+		return
+	}
+
+	if runErr == nil {
+		logger.Info().Str("attestationId", attestation.ID).Msg("Issued recurring attestation.")
+		return
+	}
+	logger.Warn().Err(runErr).Int("attempts", failure.Attempts).Bool("skipped", failure.Skipped).Msg("Failed to issue recurring attestation.")
+	if updated.NotifyURL == "" {
+		return
+	}
+	if err := s.sender.Send(context.WithoutCancel(ctx), updated.NotifyURL, failure); err != nil {
+		logger.Warn().Err(err).Msg("Failed to notify recurring subscription of failure.")
+	}
+}
+
+// issue creates the attestation of the subscription for its due date.
+func (s *Service) issue(ctx context.Context, sub *models.RecurringSubscription) (*cloudevent.RawEvent, error) {
+	job, err := s.job(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	// the operator who created the subscription is the requester of its attestations
+	return s.runner.RunJob(requester.NewContext(ctx, sub.CreatedBy), job)
+}
+
+// jobRequest is the request body of the attestation endpoint of a subscription's due date.
+type jobRequest struct {
+	models.RecurringParameters
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// job returns the job creating the attestation of the subscription's due date.
+func (s *Service) job(ctx context.Context, sub *models.RecurringSubscription) (*models.Job, error) {
+	due := sub.NextDueAt
+	req := jobRequest{RecurringParameters: sub.Parameters}
+	switch sub.Type {
+	case models.JobTypeVehiclePosition, models.JobTypeOdometerStatement:
+		req.Timestamp = &due
+	case models.JobTypeVehicleHealth, models.JobTypeBatteryHealth:
+		start := due.AddDate(0, 0, -sub.Parameters.PeriodDays)
+		if sub.Parameters.PeriodDays == 0 {
+			start = previousDue(sub.StartAt, due, sub.Interval)
+		}
+		req.StartTime = &start
+		req.EndTime = &due
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job request: %w", err)
+	}
+
+	job := &models.Job{ID: sub.ID, Type: sub.Type, TokenID: sub.TokenID, Request: body}
+	if perms := permissions[sub.Type]; perms != nil {
+		if s.tokens == nil {
+			return nil, fmt.Errorf("no vehicle token source for %s", sub.Type)
+		}
+		job.JWTToken, err = s.tokens.GetVehicleToken(ctx, sub.TokenID, perms)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vehicle token: %w", err)
+		}
+	}
+	return job, nil
+}
+
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// release gives up the lease of the subscription, so it runs again as soon as it is due instead of when the lease
+// expires. Nothing is changed when the lease expired and another run holds it.
+func (s *Service) release(ctx context.Context, sub *models.RecurringSubscription, until time.Time) {
+	_, err := s.store.UpdateRecurringSubscription(ctx, sub.ID, func(cur *models.RecurringSubscription) error {
+		if !cur.NextRunAt.Equal(until) {
+			return errLeased
+		}
+		cur.NextRunAt = sub.NextRunAt
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLeased) {
+		s.logger.Warn().Err(err).Str("recurringId", sub.ID).Msg("Failed to release recurring subscription.")
+	}
+}
+
+// validate returns a bad request error when the schedule or options of a subscription of the type are invalid.
+func validate(typ, interval string, params models.RecurringParameters, notifyURL string) error {
+	if !slices.Contains(intervals, interval) {
+		return badRequest(fmt.Errorf("unknown interval %q", interval), "Interval must be daily, weekly or monthly")
+	}
+	maxDays := int(maxPeriods[typ] / (24 * time.Hour))
+	if params.PeriodDays < 0 || params.PeriodDays > maxDays {
+		return badRequest(fmt.Errorf("invalid period of %d days", params.PeriodDays), fmt.Sprintf("Period must be between 0 and %d days", maxDays))
+	}
+	if notifyURL != "" {
+		if err := webhook.ValidateURL(notifyURL); err != nil {
+			return badRequest(err, err.Error())
+		}
+	}
+	return nil
+}
+
+// nextDue returns the first due date of the interval starting at start that is after due and now. Due dates of
+// subscriptions stored without a start are counted from due.
+func nextDue(start, due time.Time, interval string, now time.Time) time.Time {
+	if start.IsZero() || start.After(due) {
+		start = due
+	}
+	for n := 1; ; n++ {
+		next := dueDate(start, interval, n)
+		if next.After(due) && next.After(now) {
+			return next
+		}
+	}
+}
+
+// previousDue returns the due date of the interval starting at start that is before due.
+func previousDue(start, due time.Time, interval string) time.Time {
+	if start.IsZero() || start.After(due) {
+		start = due
+	}
+	previous := dueDate(start, interval, -1)
+	for n := 0; ; n++ {
+		next := dueDate(start, interval, n)
+		if !next.Before(due) {
+			return previous
+		}
+		previous = next
+	}
+}
+
+// dueDate returns the due date n intervals after start. Monthly due dates keep the day of the month of start, or
+// fall on the last day of shorter months instead of overflowing into the next month.
+func dueDate(start time.Time, interval string, n int) time.Time {
+	switch interval {
+	case models.IntervalDaily:
+		return start.AddDate(0, 0, n)
+	case models.IntervalWeekly:
+		return start.AddDate(0, 0, 7*n)
+	default:
+		first := time.Date(start.Year(), start.Month()+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		lastDay := first.AddDate(0, 1, -1).Day()
+		return first.AddDate(0, 0, min(start.Day(), lastDay)-1)
+	}
+}
+
+// publicError returns the message and HTTP status code of err that can be shown to the owner.
+func publicError(err error) (string, int) {
+	var richErr richerrors.Error
+	if errors.As(err, &richErr) && richErr.ExternalMsg != "" {
+		code := richErr.Code
+		if code == 0 {
+			code = http.StatusInternalServerError
+		}
+		return richErr.ExternalMsg, code
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Attestation timed out", http.StatusGatewayTimeout
+	}
+	return "Failed to create attestation", http.StatusInternalServerError
+}
+
+func badRequest(err error, msg string) error {
+	return richerrors.Error{Err: err, ExternalMsg: msg, Code: http.StatusBadRequest}
+}
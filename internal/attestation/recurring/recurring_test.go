@@ -0,0 +1,394 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=recurring_test
+package recurring_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/recurring"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const notifyURL = "https://example.com/hooks/recurring"
+
+var op = &operator.Operator{ID: "ops@example.com"}
+
+type mocks struct {
+	runner *MockRunner
+	tokens *MockVehicleTokenSource
+	sender *MockWebhookSender
+}
+
+func newService(t *testing.T, withTokens bool) (*recurring.Service, *mocks, *boltstore.Store) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		runner: NewMockRunner(ctrl),
+		tokens: NewMockVehicleTokenSource(ctrl),
+		sender: NewMockWebhookSender(ctrl),
+	}
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	var tokens recurring.VehicleTokenSource
+	if withTokens {
+		tokens = m.tokens
+	}
+	return recurring.NewService(&logger, m.runner, tokens, m.sender, store), m, store
+}
+
+func requireCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	require.Equal(t, code, richErr.Code)
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		withTokens bool
+		req        recurring.Request
+		wantCode   int
+	}{
+		{
+			name:       "odometer statement",
+			withTokens: true,
+			req:        recurring.Request{TokenID: 1, Type: models.JobTypeOdometerStatement, Interval: models.IntervalMonthly, Owner: "Acme Lending", NotifyURL: notifyURL},
+		},
+		{
+			name: "VIN without token exchange",
+			req:  recurring.Request{TokenID: 1, Type: models.JobTypeVIN, Interval: models.IntervalWeekly, Owner: "Acme Lending"},
+		},
+		{
+			name:     "telemetry without token exchange",
+			req:      recurring.Request{TokenID: 1, Type: models.JobTypeOdometerStatement, Interval: models.IntervalMonthly, Owner: "Acme Lending"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown type",
+			withTokens: true,
+			req:        recurring.Request{TokenID: 1, Type: "pom", Interval: models.IntervalMonthly, Owner: "Acme Lending"},
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "unknown interval",
+			withTokens: true,
+			req:        recurring.Request{TokenID: 1, Type: models.JobTypeVIN, Interval: "hourly", Owner: "Acme Lending"},
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "missing owner",
+			withTokens: true,
+			req:        recurring.Request{TokenID: 1, Type: models.JobTypeVIN, Interval: models.IntervalDaily, Owner: " "},
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "battery period too long",
+			withTokens: true,
+			req: recurring.Request{TokenID: 1, Type: models.JobTypeBatteryHealth, Interval: models.IntervalMonthly, Owner: "Acme Lending",
+				Parameters: models.RecurringParameters{PeriodDays: 91}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "insecure notify URL",
+			withTokens: true,
+			req:        recurring.Request{TokenID: 1, Type: models.JobTypeVIN, Interval: models.IntervalDaily, Owner: "Acme Lending", NotifyURL: "http://example.com"},
+			wantCode:   http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, store := newService(t, tt.withTokens)
+
+			sub, err := service.Create(context.Background(), op, tt.req)
+			if tt.wantCode != 0 {
+				requireCode(t, err, tt.wantCode)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, models.RecurringStateActive, sub.State)
+			require.Equal(t, op.ID, sub.CreatedBy)
+			require.Equal(t, sub.NextDueAt, sub.NextRunAt)
+
+			stored, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.req.Type, stored.Type)
+		})
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	due := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	serverErr := richerrors.Error{Err: errors.New("telemetry unavailable"), ExternalMsg: "Failed to get odometer", Code: http.StatusInternalServerError}
+	noData := richerrors.Error{Err: errors.New("no data"), ExternalMsg: "No odometer reading found", Code: http.StatusNotFound}
+
+	tests := []struct {
+		name         string
+		attempts     int
+		runErr       error
+		wantNotified bool
+		wantSkipped  bool
+		wantAttempts int
+	}{
+		{
+			name: "issued attestation schedules next due date",
+		},
+		{
+			name:         "server error is retried",
+			runErr:       serverErr,
+			wantNotified: true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "last attempt skips due date",
+			attempts:     recurring.MaxAttempts - 1,
+			runErr:       serverErr,
+			wantNotified: true,
+			wantSkipped:  true,
+		},
+		{
+			name:         "client error skips due date",
+			runErr:       noData,
+			wantNotified: true,
+			wantSkipped:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m, store := newService(t, true)
+			sub := &models.RecurringSubscription{
+				ID:         "rec-1",
+				TokenID:    1,
+				Type:       models.JobTypeOdometerStatement,
+				Interval:   models.IntervalMonthly,
+				Parameters: models.RecurringParameters{Unit: "mi"},
+				Owner:      "Acme Lending",
+				NotifyURL:  notifyURL,
+				State:      models.RecurringStateActive,
+				StartAt:    due,
+				NextDueAt:  due,
+				NextRunAt:  due,
+				Attempts:   tt.attempts,
+			}
+			require.NoError(t, store.CreateRecurringSubscription(context.Background(), sub))
+
+			m.tokens.EXPECT().GetVehicleToken(gomock.Any(), uint32(1), odometerstatementvc.TelemetryPermissions).Return("vehicle-jwt", nil)
+			m.runner.EXPECT().RunJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+				require.Equal(t, models.JobTypeOdometerStatement, job.Type)
+				require.Equal(t, "vehicle-jwt", job.JWTToken)
+				var req struct {
+					Timestamp time.Time `json:"timestamp"`
+					Unit      string    `json:"unit"`
+				}
+				require.NoError(t, json.Unmarshal(job.Request, &req))
+				require.True(t, due.Equal(req.Timestamp))
+				require.Equal(t, "mi", req.Unit)
+				if tt.runErr != nil {
+					return nil, tt.runErr
+				}
+				return &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil
+			})
+			var failure *models.RecurringFailure
+			if tt.wantNotified {
+				m.sender.EXPECT().Send(gomock.Any(), notifyURL, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, payload any) error {
+					failure = payload.(*models.RecurringFailure)
+					return nil
+				})
+			}
+
+			service.RunDue(context.Background(), sub)
+
+			stored, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+			require.NoError(t, err)
+			require.NotNil(t, stored.LastRunAt)
+			require.Equal(t, tt.wantAttempts, stored.Attempts)
+			nextDue := due.AddDate(0, 1, 0)
+			if nextDue.Day() != due.Day() {
+				// due on the 29th to 31st, the next due date is the last day of the shorter month
+				nextDue = nextDue.AddDate(0, 0, -nextDue.Day())
+			}
+			switch {
+			case tt.runErr == nil:
+				require.Equal(t, "vc-1", stored.LastAttestationID)
+				require.Empty(t, stored.LastError)
+				require.True(t, nextDue.Equal(stored.NextDueAt))
+				require.True(t, nextDue.Equal(stored.NextRunAt))
+			case tt.wantSkipped:
+				require.True(t, nextDue.Equal(stored.NextDueAt))
+				require.True(t, failure.Skipped)
+				require.True(t, nextDue.Equal(failure.NextDueAt))
+			default:
+				require.True(t, due.Equal(stored.NextDueAt))
+				require.True(t, stored.NextRunAt.After(time.Now().Add(recurring.RetryDelay-time.Minute)))
+				require.False(t, failure.Skipped)
+				require.Equal(t, 1, failure.Attempts)
+			}
+			if failure != nil {
+				require.Equal(t, "rec-1", failure.SubscriptionID)
+				require.True(t, due.Equal(failure.DueAt))
+				require.Equal(t, stored.LastError, failure.Error)
+			}
+		})
+	}
+}
+
+func TestRunDue_HealthPeriod(t *testing.T) {
+	service, m, store := newService(t, true)
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	sub := &models.RecurringSubscription{
+		ID:        "rec-1",
+		TokenID:   1,
+		Type:      models.JobTypeBatteryHealth,
+		Interval:  models.IntervalMonthly,
+		Owner:     "Acme Lending",
+		State:     models.RecurringStateActive,
+		NextDueAt: due,
+		NextRunAt: due,
+	}
+	require.NoError(t, store.CreateRecurringSubscription(context.Background(), sub))
+
+	m.tokens.EXPECT().GetVehicleToken(gomock.Any(), uint32(1), gomock.Any()).Return("vehicle-jwt", nil)
+	m.runner.EXPECT().RunJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+		var req struct {
+			StartTime time.Time `json:"startTime"`
+			EndTime   time.Time `json:"endTime"`
+		}
+		require.NoError(t, json.Unmarshal(job.Request, &req))
+		require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), req.StartTime)
+		require.Equal(t, due, req.EndTime)
+		return &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil
+	})
+
+	service.RunDue(context.Background(), sub)
+
+	stored, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+	require.True(t, stored.NextDueAt.After(time.Now()))
+	require.Equal(t, due.Day(), stored.NextDueAt.Day())
+}
+
+func TestRunDue_Lease(t *testing.T) {
+	service, m, store := newService(t, false)
+	logger := zerolog.Nop()
+	// a second scheduler sharing the store
+	other := recurring.NewService(&logger, m.runner, nil, m.sender, store)
+	due := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	sub := &models.RecurringSubscription{
+		ID:        "rec-1",
+		TokenID:   1,
+		Type:      models.JobTypeVIN,
+		Interval:  models.IntervalWeekly,
+		Owner:     "Acme Lending",
+		State:     models.RecurringStateActive,
+		StartAt:   due,
+		NextDueAt: due,
+		NextRunAt: due,
+	}
+	require.NoError(t, store.CreateRecurringSubscription(context.Background(), sub))
+
+	running := make(chan struct{})
+	finish := make(chan struct{})
+	m.runner.EXPECT().RunJob(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *models.Job) (*cloudevent.RawEvent, error) {
+		close(running)
+		<-finish
+		return &cloudevent.RawEvent{CloudEventHeader: cloudevent.CloudEventHeader{ID: "vc-1"}}, nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.RunDue(context.Background(), sub)
+	}()
+	<-running
+
+	leased, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+	require.True(t, leased.NextRunAt.After(time.Now()))
+	// the due date is not run again while the first run holds it
+	other.RunDue(context.Background(), sub)
+
+	close(finish)
+	<-done
+	stored, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, "vc-1", stored.LastAttestationID)
+	require.True(t, due.AddDate(0, 0, 7).Equal(stored.NextDueAt))
+	require.True(t, stored.NextDueAt.Equal(stored.NextRunAt))
+}
+
+func TestRunDue_Canceled(t *testing.T) {
+	service, m, store := newService(t, false)
+	due := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	sub := &models.RecurringSubscription{
+		ID:        "rec-1",
+		TokenID:   1,
+		Type:      models.JobTypeVIN,
+		Interval:  models.IntervalWeekly,
+		Owner:     "Acme Lending",
+		State:     models.RecurringStateActive,
+		StartAt:   due,
+		NextDueAt: due,
+		NextRunAt: due,
+	}
+	require.NoError(t, store.CreateRecurringSubscription(context.Background(), sub))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.runner.EXPECT().RunJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *models.Job) (*cloudevent.RawEvent, error) {
+		cancel()
+		return nil, ctx.Err()
+	})
+
+	service.RunDue(ctx, sub)
+
+	// the lease is released, so the due date runs again as soon as the service restarts
+	stored, err := store.GetRecurringSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+	require.True(t, due.Equal(stored.NextRunAt))
+	require.True(t, due.Equal(stored.NextDueAt))
+	require.Nil(t, stored.LastRunAt)
+	require.Zero(t, stored.Attempts)
+}
+
+func TestPauseResume(t *testing.T) {
+	service, _, store := newService(t, false)
+	due := time.Now().UTC().AddDate(0, 0, -10).Truncate(time.Second)
+	sub := &models.RecurringSubscription{
+		ID:        "rec-1",
+		TokenID:   1,
+		Type:      models.JobTypeVIN,
+		Interval:  models.IntervalWeekly,
+		Owner:     "Acme Lending",
+		State:     models.RecurringStateActive,
+		NextDueAt: due,
+		NextRunAt: due,
+	}
+	require.NoError(t, store.CreateRecurringSubscription(context.Background(), sub))
+
+	paused, err := service.Pause(context.Background(), op, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.RecurringStatePaused, paused.State)
+
+	// the due date passed while paused is skipped
+	resumed, err := service.Resume(context.Background(), op, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.RecurringStateActive, resumed.State)
+	require.True(t, due.AddDate(0, 0, 14).Equal(resumed.NextDueAt))
+	require.Equal(t, resumed.NextDueAt, resumed.NextRunAt)
+
+	_, err = service.Pause(context.Background(), op, "missing")
+	requireCode(t, err, http.StatusNotFound)
+}
@@ -0,0 +1,68 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestNextDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Time
+		due      time.Time
+		interval string
+		now      time.Time
+		expected time.Time
+	}{
+		{name: "31st into February", start: date(2026, 1, 31), due: date(2026, 1, 31), interval: models.IntervalMonthly, now: date(2026, 1, 31), expected: date(2026, 2, 28)},
+		{name: "31st after February", start: date(2026, 1, 31), due: date(2026, 2, 28), interval: models.IntervalMonthly, now: date(2026, 2, 28), expected: date(2026, 3, 31)},
+		{name: "31st into April", start: date(2026, 1, 31), due: date(2026, 3, 31), interval: models.IntervalMonthly, now: date(2026, 3, 31), expected: date(2026, 4, 30)},
+		{name: "31st into leap February", start: date(2027, 12, 31), due: date(2028, 1, 31), interval: models.IntervalMonthly, now: date(2028, 1, 31), expected: date(2028, 2, 29)},
+		{name: "30th into February", start: date(2026, 1, 30), due: date(2026, 1, 30), interval: models.IntervalMonthly, now: date(2026, 1, 30), expected: date(2026, 2, 28)},
+		{name: "30th after February", start: date(2026, 1, 30), due: date(2026, 2, 28), interval: models.IntervalMonthly, now: date(2026, 2, 28), expected: date(2026, 3, 30)},
+		{name: "29th into February", start: date(2026, 1, 29), due: date(2026, 1, 29), interval: models.IntervalMonthly, now: date(2026, 1, 29), expected: date(2026, 2, 28)},
+		{name: "29th after February", start: date(2026, 1, 29), due: date(2026, 2, 28), interval: models.IntervalMonthly, now: date(2026, 2, 28), expected: date(2026, 3, 29)},
+		{name: "29th into leap February", start: date(2028, 1, 29), due: date(2028, 1, 29), interval: models.IntervalMonthly, now: date(2028, 1, 29), expected: date(2028, 2, 29)},
+		{name: "missed months skipped", start: date(2026, 1, 31), due: date(2026, 2, 28), interval: models.IntervalMonthly, now: date(2026, 5, 1), expected: date(2026, 5, 31)},
+		{name: "weekly", start: date(2026, 1, 31), due: date(2026, 2, 7), interval: models.IntervalWeekly, now: date(2026, 2, 7), expected: date(2026, 2, 14)},
+		{name: "daily", start: date(2026, 1, 31), due: date(2026, 1, 31), interval: models.IntervalDaily, now: date(2026, 1, 31), expected: date(2026, 2, 1)},
+		{name: "no start", due: date(2026, 1, 31), interval: models.IntervalMonthly, now: date(2026, 1, 31), expected: date(2026, 2, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, nextDue(tt.start, tt.due, tt.interval, tt.now))
+		})
+	}
+}
+
+func TestPreviousDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Time
+		due      time.Time
+		interval string
+		expected time.Time
+	}{
+		{name: "first 31st", start: date(2026, 3, 31), due: date(2026, 3, 31), interval: models.IntervalMonthly, expected: date(2026, 2, 28)},
+		{name: "31st after February", start: date(2026, 1, 31), due: date(2026, 3, 31), interval: models.IntervalMonthly, expected: date(2026, 2, 28)},
+		{name: "31st after April", start: date(2026, 1, 31), due: date(2026, 5, 31), interval: models.IntervalMonthly, expected: date(2026, 4, 30)},
+		{name: "30th in February", start: date(2026, 1, 30), due: date(2026, 2, 28), interval: models.IntervalMonthly, expected: date(2026, 1, 30)},
+		{name: "30th after February", start: date(2026, 1, 30), due: date(2026, 3, 30), interval: models.IntervalMonthly, expected: date(2026, 2, 28)},
+		{name: "29th after leap February", start: date(2028, 1, 29), due: date(2028, 3, 29), interval: models.IntervalMonthly, expected: date(2028, 2, 29)},
+		{name: "29th after February", start: date(2026, 1, 29), due: date(2026, 3, 29), interval: models.IntervalMonthly, expected: date(2026, 2, 28)},
+		{name: "weekly", start: date(2026, 1, 31), due: date(2026, 2, 14), interval: models.IntervalWeekly, expected: date(2026, 2, 7)},
+		{name: "daily", start: date(2026, 1, 31), due: date(2026, 1, 31), interval: models.IntervalDaily, expected: date(2026, 1, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, previousDue(tt.start, tt.due, tt.interval))
+		})
+	}
+}
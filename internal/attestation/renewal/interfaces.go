@@ -0,0 +1,25 @@
+package renewal
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Lister defines the interface for listing the VIN attestations issued by the service.
+type Lister interface {
+	ListVINAttestations(ctx context.Context, after time.Time, limit int32) ([]cloudevent.RawEvent, error)
+}
+
+// Issuer defines the interface for issuing VIN attestations.
+type Issuer interface {
+	CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
+}
+
+// Store defines the interface for persisting the progress of renewal runs.
+type Store interface {
+	SaveRenewalRun(ctx context.Context, run *models.RenewalRun) error
+	LatestRenewalRun(ctx context.Context) (*models.RenewalRun, error)
+}
@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=renewal_test
+//
+
+// Package renewal_test is a generated GoMock package.
+package renewal_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/attestation-api/internal/models"
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLister is a mock of Lister interface.
+type MockLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockListerMockRecorder
+	isgomock struct{}
+}
+
+// MockListerMockRecorder is the mock recorder for MockLister.
+type MockListerMockRecorder struct {
+	mock *MockLister
+}
+
+// NewMockLister creates a new mock instance.
+func NewMockLister(ctrl *gomock.Controller) *MockLister {
+	mock := &MockLister{ctrl: ctrl}
+	mock.recorder = &MockListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLister) EXPECT() *MockListerMockRecorder {
+	return m.recorder
+}
+
+// ListVINAttestations mocks base method.
+func (m *MockLister) ListVINAttestations(ctx context.Context, after time.Time, limit int32) ([]cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVINAttestations", ctx, after, limit)
+	ret0, _ := ret[0].([]cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVINAttestations indicates an expected call of ListVINAttestations.
+func (mr *MockListerMockRecorder) ListVINAttestations(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVINAttestations", reflect.TypeOf((*MockLister)(nil).ListVINAttestations), ctx, after, limit)
+}
+
+// MockIssuer is a mock of Issuer interface.
+type MockIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockIssuerMockRecorder is the mock recorder for MockIssuer.
+type MockIssuerMockRecorder struct {
+	mock *MockIssuer
+}
+
+// NewMockIssuer creates a new mock instance.
+func NewMockIssuer(ctrl *gomock.Controller) *MockIssuer {
+	mock := &MockIssuer{ctrl: ctrl}
+	mock.recorder = &MockIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuer) EXPECT() *MockIssuerMockRecorder {
+	return m.recorder
+}
+
+// CreateAndStoreVINAttestation mocks base method.
+func (m *MockIssuer) CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAndStoreVINAttestation", ctx, tokenID)
+	ret0, _ := ret[0].(*cloudevent.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAndStoreVINAttestation indicates an expected call of CreateAndStoreVINAttestation.
+func (mr *MockIssuerMockRecorder) CreateAndStoreVINAttestation(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndStoreVINAttestation", reflect.TypeOf((*MockIssuer)(nil).CreateAndStoreVINAttestation), ctx, tokenID)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// LatestRenewalRun mocks base method.
+func (m *MockStore) LatestRenewalRun(ctx context.Context) (*models.RenewalRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestRenewalRun", ctx)
+	ret0, _ := ret[0].(*models.RenewalRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestRenewalRun indicates an expected call of LatestRenewalRun.
+func (mr *MockStoreMockRecorder) LatestRenewalRun(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestRenewalRun", reflect.TypeOf((*MockStore)(nil).LatestRenewalRun), ctx)
+}
+
+// SaveRenewalRun mocks base method.
+func (m *MockStore) SaveRenewalRun(ctx context.Context, run *models.RenewalRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRenewalRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRenewalRun indicates an expected call of SaveRenewalRun.
+func (mr *MockStoreMockRecorder) SaveRenewalRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRenewalRun", reflect.TypeOf((*MockStore)(nil).SaveRenewalRun), ctx, run)
+}
@@ -0,0 +1,255 @@
+// Package renewal renews the VIN attestations issued by the service before they expire. The progress of a run is
+// saved after every vehicle, so a run that is interrupted resumes where it stopped.
+package renewal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// Lookback is how long ago the considered attestations were issued, VIN attestations are valid for a week at most.
+	// Vehicles whose latest attestation is older are not renewed.
+	Lookback = 14 * 24 * time.Hour
+
+	defaultWindow = 48 * time.Hour
+	defaultRate   = 2.0
+	pageSize      = 1000
+)
+
+// Service renews VIN attestations.
+type Service struct {
+	logger      *zerolog.Logger
+	lister      Lister
+	issuer      Issuer
+	store       Store
+	vehicleAddr common.Address
+	interval    time.Duration
+	window      time.Duration
+	rate        float64
+}
+
+// NewService creates a new Service renewing the VIN attestations of vehicles of the vehicle contract that expire
+// within settings.VINRenewalWindow, at most settings.VINRenewalRate per second.
+func NewService(logger *zerolog.Logger, lister Lister, issuer Issuer, store Store, settings *config.Settings) *Service {
+	window := settings.VINRenewalWindow
+	if window <= 0 {
+		window = defaultWindow
+	}
+	rate := settings.VINRenewalRate
+	if rate <= 0 {
+		rate = defaultRate
+	}
+	return &Service{
+		logger:      logger,
+		lister:      lister,
+		issuer:      issuer,
+		store:       store,
+		vehicleAddr: common.HexToAddress(settings.VehicleNFTAddress),
+		interval:    settings.VINRenewalInterval,
+		window:      window,
+		rate:        rate,
+	}
+}
+
+// Schedule runs a renewal every settings.VINRenewalInterval until ctx is canceled, starting with an unfinished run
+// of a previous process.
+func (s *Service) Schedule(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Run(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error().Err(err).Msg("VIN renewal run failed.")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Latest returns the latest renewal run, or nil when there is none.
+func (s *Service) Latest(ctx context.Context) (*models.RenewalRun, error) {
+	return s.store.LatestRenewalRun(ctx)
+}
+
+// Run renews the VIN attestations expiring within the window and returns the summary of the run. An unfinished
+// run is resumed if its attestations have not expired yet. When ctx is canceled the progress is saved and the
+// context error is returned.
+func (s *Service) Run(ctx context.Context) (*models.RenewalRun, error) {
+	now := time.Now().UTC()
+
+	run, err := s.store.LatestRenewalRun(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest renewal run: %w", err)
+	}
+	if run != nil && run.State == models.RenewalStateRunning && run.ExpiringBefore.After(now) {
+		s.logger.Info().Str("runId", run.ID).Int("next", run.Next).Int("candidates", len(run.Candidates)).Msg("Resuming VIN renewal run.")
+	} else {
+		expiringBefore := now.Add(s.window)
+		candidates, err := s.expiringVehicles(ctx, now.Add(-Lookback), expiringBefore)
+		if err != nil {
+			return nil, err
+		}
+		run = &models.RenewalRun{
+			ID:             ksuid.New().String(),
+			State:          models.RenewalStateRunning,
+			StartedAt:      now,
+			ExpiringBefore: expiringBefore,
+			Candidates:     candidates,
+		}
+		s.logger.Info().Str("runId", run.ID).Int("candidates", len(candidates)).Time("expiringBefore", expiringBefore).Msg("Starting VIN renewal run.")
+	}
+	if err := s.store.SaveRenewalRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save renewal run: %w", err)
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.rate))
+	defer ticker.Stop()
+	for run.Next < len(run.Candidates) {
+		select {
+		case <-ctx.Done():
+			return run, s.save(run, ctx.Err())
+		case <-ticker.C:
+		}
+		// select picks at random when the run was canceled while the ticker fired
+		if ctx.Err() != nil {
+			return run, s.save(run, ctx.Err())
+		}
+		tokenID := run.Candidates[run.Next]
+		_, err := s.issuer.CreateAndStoreVINAttestation(ctx, tokenID)
+		if err != nil && ctx.Err() != nil {
+			// the vehicle is renewed again when the run resumes
+			return run, s.save(run, ctx.Err())
+		}
+		s.record(run, tokenID, err)
+		run.Next++
+		if err := s.save(run, nil); err != nil {
+			return run, err
+		}
+	}
+
+	finished := time.Now().UTC()
+	run.State = models.RenewalStateCompleted
+	run.FinishedAt = &finished
+	if err := s.save(run, nil); err != nil {
+		return run, err
+	}
+	s.logger.Info().Str("runId", run.ID).Int("renewed", run.Renewed).Int("ineligible", run.Ineligible).Int("failed", run.Failed).Msg("Finished VIN renewal run.")
+	return run, nil
+}
+
+// record counts the outcome of renewing the vehicle.
+func (s *Service) record(run *models.RenewalRun, tokenID uint32, err error) {
+	if err == nil {
+		run.Renewed++
+		return
+	}
+	reason := "Failed to create attestation"
+	code := http.StatusInternalServerError
+	var richErr richerrors.Error
+	if errors.As(err, &richErr) {
+		if richErr.ExternalMsg != "" {
+			reason = richErr.ExternalMsg
+		}
+		if richErr.Code != 0 {
+			code = richErr.Code
+		}
+	}
+	if run.Reasons == nil {
+		run.Reasons = map[string]int{}
+	}
+	run.Reasons[reason]++
+	if code < http.StatusInternalServerError {
+		run.Ineligible++
+		s.logger.Info().Err(err).Uint32("tokenId", tokenID).Msg("Vehicle is no longer eligible for a VIN attestation.")
+		return
+	}
+	run.Failed++
+	s.logger.Warn().Err(err).Uint32("tokenId", tokenID).Msg("Failed to renew VIN attestation.")
+}
+
+// save stores the progress of the run and returns cause, or the error of storing it.
+func (s *Service) save(run *models.RenewalRun, cause error) error {
+	if err := s.store.SaveRenewalRun(context.Background(), run); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to save renewal run: %w", err))
+	}
+	return cause
+}
+
+// expiringVehicles returns the token IDs of the vehicles whose latest VIN attestation issued after the time
+// expires before expiringBefore, in ascending order.
+func (s *Service) expiringVehicles(ctx context.Context, after, expiringBefore time.Time) ([]uint32, error) {
+	type latestAttestation struct {
+		issuedAt time.Time
+		validTo  time.Time
+	}
+	latest := map[uint32]latestAttestation{}
+	seen := map[string]struct{}{}
+	for {
+		events, err := s.lister.ListVINAttestations(ctx, after, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for i := range events {
+			if _, ok := seen[events[i].ID]; ok {
+				continue
+			}
+			seen[events[i].ID] = struct{}{}
+			added++
+			tokenID, validTo, ok := s.parseAttestation(&events[i])
+			if !ok {
+				continue
+			}
+			if current, ok := latest[tokenID]; ok && current.issuedAt.After(events[i].Time) {
+				continue
+			}
+			latest[tokenID] = latestAttestation{issuedAt: events[i].Time, validTo: validTo}
+		}
+		// events issued at the same time as the last one of a page are listed again on the next page
+		if len(events) < pageSize || added == 0 {
+			break
+		}
+		after = events[len(events)-1].Time.Add(-time.Nanosecond)
+	}
+
+	var candidates []uint32
+	for tokenID, attestation := range latest {
+		if attestation.validTo.Before(expiringBefore) {
+			candidates = append(candidates, tokenID)
+		}
+	}
+	slices.Sort(candidates)
+	return candidates, nil
+}
+
+// parseAttestation returns the vehicle token ID and the expiry of a VIN attestation.
+func (s *Service) parseAttestation(event *cloudevent.RawEvent) (uint32, time.Time, bool) {
+	did, err := cloudevent.DecodeERC721DID(event.Subject)
+	if err != nil || did.ContractAddress != s.vehicleAddr || !did.TokenID.IsUint64() || did.TokenID.Uint64() > uint64(^uint32(0)) {
+		s.logger.Debug().Str("eventId", event.ID).Str("subject", event.Subject).Msg("Skipping VIN attestation of an unknown subject.")
+		return 0, time.Time{}, false
+	}
+	var credential types.Credential
+	if err := json.Unmarshal(event.Data, &credential); err != nil || credential.ValidTo.IsZero() {
+		s.logger.Debug().Str("eventId", event.ID).Msg("Skipping VIN attestation without expiry.")
+		return 0, time.Time{}, false
+	}
+	return uint32(did.TokenID.Uint64()), credential.ValidTo, true
+}
@@ -0,0 +1,155 @@
+//go:generate go tool mockgen -source=interfaces.go -destination=interfaces_mock_test.go -package=renewal_test
+package renewal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/renewal"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const vehicleContract = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"
+
+func newService(t *testing.T, lister renewal.Lister, issuer renewal.Issuer) (*renewal.Service, *boltstore.Store) {
+	t.Helper()
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	logger := zerolog.Nop()
+	settings := &config.Settings{VehicleNFTAddress: vehicleContract, VINRenewalRate: 1000}
+	return renewal.NewService(&logger, lister, issuer, store, settings), store
+}
+
+func vinAttestation(contract string, tokenID uint32, issuedAt, validTo time.Time) cloudevent.RawEvent {
+	return cloudevent.RawEvent{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			ID:      fmt.Sprintf("vc-%d-%d", tokenID, issuedAt.Unix()),
+			Subject: fmt.Sprintf("did:erc721:137:%s:%d", contract, tokenID),
+			Time:    issuedAt,
+		},
+		Data: []byte(fmt.Sprintf(`{"validFrom":%q,"validTo":%q}`, issuedAt.Format(time.RFC3339), validTo.Format(time.RFC3339))),
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lister := NewMockLister(ctrl)
+	issuer := NewMockIssuer(ctrl)
+	service, _ := newService(t, lister, issuer)
+
+	now := time.Now().UTC()
+	soon := now.Add(12 * time.Hour)
+	later := now.Add(6 * 24 * time.Hour)
+	lister.EXPECT().ListVINAttestations(gomock.Any(), gomock.Any(), gomock.Any()).Return([]cloudevent.RawEvent{
+		vinAttestation(vehicleContract, 1, now.Add(-6*24*time.Hour), soon),
+		vinAttestation(vehicleContract, 2, now.Add(-24*time.Hour), later),
+		// renewed already, the latest attestation is valid beyond the window
+		vinAttestation(vehicleContract, 3, now.Add(-6*24*time.Hour), soon),
+		vinAttestation(vehicleContract, 3, now.Add(-time.Hour), later),
+		// expired recently
+		vinAttestation(vehicleContract, 4, now.Add(-8*24*time.Hour), now.Add(-24*time.Hour)),
+		vinAttestation(vehicleContract, 5, now.Add(-5*24*time.Hour), soon),
+		// vehicle of another contract
+		vinAttestation("0x45fbCD3ef7361d156e8b16F5538AE36DEdf61Da8", 6, now.Add(-6*24*time.Hour), soon),
+	}, nil)
+
+	var renewed []uint32
+	issuer.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(_ context.Context, tokenID uint32) (*cloudevent.RawEvent, error) {
+		renewed = append(renewed, tokenID)
+		switch tokenID {
+		case 4:
+			return nil, richerrors.Error{Err: errors.New("no fingerprints"), ExternalMsg: "No valid fingerprint found", Code: http.StatusBadRequest}
+		case 5:
+			return nil, errors.New("DIS unavailable")
+		default:
+			return &cloudevent.RawEvent{}, nil
+		}
+	})
+
+	run, err := service.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 4, 5}, renewed)
+	require.Equal(t, []uint32{1, 4, 5}, run.Candidates)
+	require.Equal(t, models.RenewalStateCompleted, run.State)
+	require.NotNil(t, run.FinishedAt)
+	require.Equal(t, 1, run.Renewed)
+	require.Equal(t, 1, run.Ineligible)
+	require.Equal(t, 1, run.Failed)
+	require.Equal(t, map[string]int{"No valid fingerprint found": 1, "Failed to create attestation": 1}, run.Reasons)
+
+	latest, err := service.Latest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, run.ID, latest.ID)
+	require.Equal(t, 3, latest.Summary().Processed)
+}
+
+func TestRun_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lister := NewMockLister(ctrl)
+	issuer := NewMockIssuer(ctrl)
+	service, store := newService(t, lister, issuer)
+
+	// an interrupted run that renewed the first vehicle
+	now := time.Now().UTC()
+	interrupted := &models.RenewalRun{
+		ID:             "run-1",
+		State:          models.RenewalStateRunning,
+		StartedAt:      now.Add(-time.Hour),
+		ExpiringBefore: now.Add(47 * time.Hour),
+		Candidates:     []uint32{7, 8, 9},
+		Next:           1,
+		Renewed:        1,
+	}
+	require.NoError(t, store.SaveRenewalRun(context.Background(), interrupted))
+
+	issuer.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(8)).Return(&cloudevent.RawEvent{}, nil)
+	issuer.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(9)).Return(&cloudevent.RawEvent{}, nil)
+
+	run, err := service.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "run-1", run.ID)
+	require.Equal(t, 3, run.Renewed)
+	require.Equal(t, models.RenewalStateCompleted, run.State)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lister := NewMockLister(ctrl)
+	issuer := NewMockIssuer(ctrl)
+	service, store := newService(t, lister, issuer)
+
+	now := time.Now().UTC()
+	lister.EXPECT().ListVINAttestations(gomock.Any(), gomock.Any(), gomock.Any()).Return([]cloudevent.RawEvent{
+		vinAttestation(vehicleContract, 1, now.Add(-6*24*time.Hour), now.Add(time.Hour)),
+		vinAttestation(vehicleContract, 2, now.Add(-6*24*time.Hour), now.Add(time.Hour)),
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	issuer.EXPECT().CreateAndStoreVINAttestation(gomock.Any(), uint32(1)).DoAndReturn(func(context.Context, uint32) (*cloudevent.RawEvent, error) {
+		cancel()
+		return &cloudevent.RawEvent{}, nil
+	})
+
+	_, err := service.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the progress is saved for the next run
+	saved, err := store.LatestRenewalRun(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.RenewalStateRunning, saved.State)
+	require.Equal(t, 1, saved.Next)
+	require.Equal(t, 1, saved.Renewed)
+}
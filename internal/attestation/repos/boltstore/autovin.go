@@ -0,0 +1,44 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var autoVINBucket = []byte("autoVin")
+
+// SaveAutoVINState creates or replaces the state of the vehicle.
+func (s *Store) SaveAutoVINState(_ context.Context, state *models.AutoVINState) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putJSON(tx.Bucket(autoVINBucket), autoVINKey(state.TokenID), state)
+	})
+}
+
+// GetAutoVINState returns the state of the vehicle. It returns nil when there is none.
+func (s *Store) GetAutoVINState(_ context.Context, tokenID uint32) (*models.AutoVINState, error) {
+	var state *models.AutoVINState
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(autoVINBucket).Get(autoVINKey(tokenID))
+		if value == nil {
+			return nil
+		}
+		state = &models.AutoVINState{}
+		if err := json.Unmarshal(value, state); err != nil {
+			return fmt.Errorf("failed to unmarshal auto VIN state of vehicle %d: %w", tokenID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func autoVINKey(tokenID uint32) []byte {
+	return binary.BigEndian.AppendUint32(make([]byte, 0, 4), tokenID)
+}
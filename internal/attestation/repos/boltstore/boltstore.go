@@ -0,0 +1,164 @@
+// Package boltstore keeps the state of the service in an embedded bbolt database file.
+package boltstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	manualVINRequestsBucket = []byte("manualVinRequests")
+	manualVINAuditBucket    = []byte("manualVinAudit")
+)
+
+var (
+	// ErrNotFound is returned when a record does not exist.
+	ErrNotFound = errors.New("record not found")
+	// ErrExists is returned when a record would be overwritten.
+	ErrExists = errors.New("record already exists")
+)
+
+// Store is a bbolt backed store.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens the database file at path, creating it when it does not exist.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{manualVINRequestsBucket, manualVINAuditBucket, jobsBucket, jobQueueBucket, webhookSubscriptionsBucket, webhookDeliveriesBucket, requestedVehiclesBucket, renewalRunsBucket, autoVINBucket, recurringSubscriptionsBucket, rateLimitsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// CreateManualVINRequest stores a new manual VIN request.
+func (s *Store) CreateManualVINRequest(_ context.Context, req *models.ManualVINRequest) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(manualVINRequestsBucket)
+		if bucket.Get([]byte(req.ID)) != nil {
+			return fmt.Errorf("%w: manual VIN request %s", ErrExists, req.ID)
+		}
+		return putJSON(bucket, []byte(req.ID), req)
+	})
+}
+
+// GetManualVINRequest returns the manual VIN request with the ID.
+func (s *Store) GetManualVINRequest(_ context.Context, id string) (*models.ManualVINRequest, error) {
+	var req models.ManualVINRequest
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return getJSON(tx.Bucket(manualVINRequestsBucket), []byte(id), &req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// UpdateManualVINRequest applies update to the manual VIN request with the ID in a single transaction,
+// nothing is stored when update fails.
+func (s *Store) UpdateManualVINRequest(_ context.Context, id string, update func(*models.ManualVINRequest) error) (*models.ManualVINRequest, error) {
+	var req models.ManualVINRequest
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(manualVINRequestsBucket)
+		if err := getJSON(bucket, []byte(id), &req); err != nil {
+			return err
+		}
+		if err := update(&req); err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte(id), &req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// AppendAuditEntry appends an entry to the manual VIN audit log. There is no way to change or remove entries.
+func (s *Store) AppendAuditEntry(_ context.Context, entry *models.AuditEntry) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(manualVINAuditBucket)
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return fmt.Errorf("failed to get audit sequence: %w", err)
+		}
+		return putJSON(bucket, auditKey(entry.TokenID, seq), entry)
+	})
+}
+
+// ListAuditEntries returns the manual VIN audit log of the vehicle in the order the entries were appended.
+func (s *Store) ListAuditEntries(_ context.Context, tokenID uint32) ([]models.AuditEntry, error) {
+	entries := []models.AuditEntry{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		prefix := binary.BigEndian.AppendUint32(nil, tokenID)
+		cursor := tx.Bucket(manualVINAuditBucket).Cursor()
+		for key, value := cursor.Seek(prefix); bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+			var entry models.AuditEntry
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return fmt.Errorf("failed to unmarshal audit entry %d: %w", binary.BigEndian.Uint64(key[len(prefix):]), err)
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// auditKey orders the audit entries by token ID and then by the sequence they were appended in.
+func auditKey(tokenID uint32, seq uint64) []byte {
+	key := binary.BigEndian.AppendUint32(make([]byte, 0, 12), tokenID)
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+func putJSON(bucket *bbolt.Bucket, key []byte, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	if err := bucket.Put(key, data); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+func getJSON(bucket *bbolt.Bucket, key []byte, value any) error {
+	data := bucket.Get(key)
+	if data == nil {
+		return richerrors.Error{Err: fmt.Errorf("%w: %s", ErrNotFound, key), ExternalMsg: "Record not found", Code: http.StatusNotFound}
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package boltstore_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/boltstore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t *testing.T) *boltstore.Store {
+	t.Helper()
+	store, err := boltstore.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	return store
+}
+
+func TestManualVINRequests(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	req := &models.ManualVINRequest{ID: "req-1", TokenID: 7, VIN: "1HGCM82673A123456", Status: models.ManualVINStatusPending, RequestedBy: "a@dimo.org"}
+	require.NoError(t, store.CreateManualVINRequest(ctx, req))
+	require.ErrorIs(t, store.CreateManualVINRequest(ctx, req), boltstore.ErrExists)
+
+	updated, err := store.UpdateManualVINRequest(ctx, "req-1", func(r *models.ManualVINRequest) error {
+		r.Status = models.ManualVINStatusApproved
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.ManualVINStatusApproved, updated.Status)
+
+	// a failed update stores nothing
+	_, err = store.UpdateManualVINRequest(ctx, "req-1", func(r *models.ManualVINRequest) error {
+		r.Status = models.ManualVINStatusRejected
+		return errors.New("not pending")
+	})
+	require.Error(t, err)
+	stored, err := store.GetManualVINRequest(ctx, "req-1")
+	require.NoError(t, err)
+	require.Equal(t, models.ManualVINStatusApproved, stored.Status)
+
+	_, err = store.GetManualVINRequest(ctx, "missing")
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+	_, err = store.UpdateManualVINRequest(ctx, "missing", func(*models.ManualVINRequest) error { return nil })
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+}
+
+func TestAuditEntries(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var appended []models.AuditEntry
+	for i, action := range []string{models.AuditActionRequested, models.AuditActionApproved, models.AuditActionIssued} {
+		entry := models.AuditEntry{ID: ksuid.New().String(), TokenID: 7, RequestID: "req-1", Action: action, Time: now.Add(time.Duration(i) * time.Second)}
+		require.NoError(t, store.AppendAuditEntry(ctx, &entry))
+		appended = append(appended, entry)
+	}
+	// entries of other vehicles, including a token ID sharing the leading bytes
+	require.NoError(t, store.AppendAuditEntry(ctx, &models.AuditEntry{ID: ksuid.New().String(), TokenID: 8, Action: models.AuditActionRequested}))
+	require.NoError(t, store.AppendAuditEntry(ctx, &models.AuditEntry{ID: ksuid.New().String(), TokenID: 7 << 8, Action: models.AuditActionRequested}))
+
+	entries, err := store.ListAuditEntries(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, appended, entries)
+
+	entries, err = store.ListAuditEntries(ctx, 9)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestJobs(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	created := time.Now().UTC().Add(-time.Hour)
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		require.NoError(t, store.CreateJob(ctx, &models.Job{ID: id, Type: models.JobTypeVIN, State: models.JobStateQueued, CreatedAt: created}))
+	}
+	require.ErrorIs(t, store.CreateJob(ctx, &models.Job{ID: "job-1"}), boltstore.ErrExists)
+	queued, err := store.CountQueuedJobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, queued)
+
+	// jobs are claimed in the order they were queued
+	now := time.Now().UTC()
+	first, err := store.ClaimJob(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, "job-1", first.ID)
+	require.Equal(t, models.JobStateRunning, first.State)
+	require.NotNil(t, first.StartedAt)
+	second, err := store.ClaimJob(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, "job-2", second.ID)
+
+	// a running job of a stopped process is queued again
+	requeued, err := store.RequeueRunningJobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, requeued)
+	queued, err = store.CountQueuedJobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, queued)
+
+	var claimed []string
+	for {
+		job, err := store.ClaimJob(ctx, now)
+		require.NoError(t, err)
+		if job == nil {
+			break
+		}
+		claimed = append(claimed, job.ID)
+	}
+	require.Equal(t, []string{"job-3", "job-1", "job-2"}, claimed)
+
+	finished := now.Add(-time.Minute)
+	for id, webhookState := range map[string]string{"job-1": "", "job-2": models.WebhookStatePending, "job-3": models.WebhookStateDelivered} {
+		_, err := store.UpdateJob(ctx, id, func(j *models.Job) error {
+			j.State = models.JobStateSucceeded
+			j.FinishedAt = &finished
+			j.WebhookState = webhookState
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	// jobs waiting for their webhook are kept
+	deleted, err := store.DeleteJobsFinishedBefore(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	remaining, err := store.ListJobs(ctx, func(*models.Job) bool { return true })
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	require.Equal(t, "job-2", remaining[0].ID)
+
+	_, err = store.GetJob(ctx, "job-1")
+	require.ErrorIs(t, err, boltstore.ErrNotFound)
+}
+
+func TestRateLimitSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	snapshot, err := store.RateLimitSnapshot(ctx)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	saved := &models.RateLimitSnapshot{
+		DailyCounts: []models.DailyCount{{Date: "2026-01-02", Type: "vin", TokenID: 7, Count: 3}},
+		Usage:       []models.Usage{{Date: "2026-01-02", Client: "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b", Type: "vin", Allowed: 3}},
+		SavedAt:     time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.SaveRateLimitSnapshot(ctx, saved))
+	snapshot, err = store.RateLimitSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, saved, snapshot)
+}
+
+func TestRequestedVehicles(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	const devLicense = "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b"
+	require.NoError(t, store.RecordRequestedVehicle(ctx, devLicense, 7))
+	require.NoError(t, store.RecordRequestedVehicle(ctx, devLicense, 7))
+
+	requested, err := store.HasRequestedVehicle(ctx, devLicense, 7)
+	require.NoError(t, err)
+	require.True(t, requested)
+	requested, err = store.HasRequestedVehicle(ctx, devLicense, 8)
+	require.NoError(t, err)
+	require.False(t, requested)
+	requested, err = store.HasRequestedVehicle(ctx, "0x9dF2f0E4cD63B9C52a4E2a6f3a0c0Ef1b6F1a5D2", 7)
+	require.NoError(t, err)
+	require.False(t, requested)
+}
@@ -0,0 +1,200 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	jobsBucket     = []byte("jobs")
+	jobQueueBucket = []byte("jobQueue")
+)
+
+// CreateJob stores a new job and appends it to the job queue.
+func (s *Store) CreateJob(_ context.Context, job *models.Job) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		if bucket.Get([]byte(job.ID)) != nil {
+			return fmt.Errorf("%w: job %s", ErrExists, job.ID)
+		}
+		if err := putJSON(bucket, []byte(job.ID), job); err != nil {
+			return err
+		}
+		return enqueueJob(tx, job.ID)
+	})
+}
+
+// GetJob returns the job with the ID.
+func (s *Store) GetJob(_ context.Context, id string) (*models.Job, error) {
+	var job models.Job
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return getJSON(tx.Bucket(jobsBucket), []byte(id), &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateJob applies update to the job with the ID in a single transaction, nothing is stored when update fails.
+func (s *Store) UpdateJob(_ context.Context, id string, update func(*models.Job) error) (*models.Job, error) {
+	var job models.Job
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		if err := getJSON(bucket, []byte(id), &job); err != nil {
+			return err
+		}
+		if err := update(&job); err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte(id), &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimJob removes the oldest job from the queue and marks it running. It returns nil when the queue is empty.
+func (s *Store) ClaimJob(_ context.Context, now time.Time) (*models.Job, error) {
+	var claimed *models.Job
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		queue := tx.Bucket(jobQueueBucket)
+		jobs := tx.Bucket(jobsBucket)
+		cursor := queue.Cursor()
+		for key, id := cursor.First(); key != nil; key, id = cursor.First() {
+			if err := cursor.Delete(); err != nil {
+				return fmt.Errorf("failed to dequeue job %s: %w", id, err)
+			}
+			var job models.Job
+			if jobs.Get(id) == nil {
+				// pruned while queued
+				continue
+			}
+			if err := getJSON(jobs, id, &job); err != nil {
+				return err
+			}
+			job.State = models.JobStateRunning
+			job.StartedAt = &now
+			claimed = &job
+			return putJSON(jobs, id, &job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// CountQueuedJobs returns the number of jobs waiting in the queue.
+func (s *Store) CountQueuedJobs(_ context.Context) (int, error) {
+	var count int
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		count = tx.Bucket(jobQueueBucket).Stats().KeyN
+		return nil
+	})
+	return count, err
+}
+
+// RequeueRunningJobs queues the jobs left running by a previous process again and returns how many there were.
+func (s *Store) RequeueRunningJobs(_ context.Context) (int, error) {
+	var count int
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		jobs := tx.Bucket(jobsBucket)
+		var running []models.Job
+		err := forEachJob(jobs, func(job *models.Job) error {
+			if job.State == models.JobStateRunning {
+				running = append(running, *job)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, job := range running {
+			job.State = models.JobStateQueued
+			job.StartedAt = nil
+			if err := putJSON(jobs, []byte(job.ID), &job); err != nil {
+				return err
+			}
+			if err := enqueueJob(tx, job.ID); err != nil {
+				return err
+			}
+		}
+		count = len(running)
+		return nil
+	})
+	return count, err
+}
+
+// ListJobs returns the jobs for which match returns true.
+func (s *Store) ListJobs(_ context.Context, match func(*models.Job) bool) ([]models.Job, error) {
+	jobs := []models.Job{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return forEachJob(tx.Bucket(jobsBucket), func(job *models.Job) error {
+			if match(job) {
+				jobs = append(jobs, *job)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// DeleteJobsFinishedBefore deletes the jobs that finished before the time and returns how many were deleted.
+func (s *Store) DeleteJobsFinishedBefore(_ context.Context, before time.Time) (int, error) {
+	var count int
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		jobs := tx.Bucket(jobsBucket)
+		var ids []string
+		err := forEachJob(jobs, func(job *models.Job) error {
+			if job.Finished() && job.FinishedAt != nil && job.FinishedAt.Before(before) && job.WebhookState != models.WebhookStatePending {
+				ids = append(ids, job.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := jobs.Delete([]byte(id)); err != nil {
+				return fmt.Errorf("failed to delete job %s: %w", id, err)
+			}
+		}
+		count = len(ids)
+		return nil
+	})
+	return count, err
+}
+
+// enqueueJob appends the job ID to the queue, which is ordered by a sequence so jobs run in the order they were queued.
+func enqueueJob(tx *bbolt.Tx, id string) error {
+	queue := tx.Bucket(jobQueueBucket)
+	seq, err := queue.NextSequence()
+	if err != nil {
+		return fmt.Errorf("failed to get job queue sequence: %w", err)
+	}
+	if err := queue.Put(binary.BigEndian.AppendUint64(nil, seq), []byte(id)); err != nil {
+		return fmt.Errorf("failed to queue job %s: %w", id, err)
+	}
+	return nil
+}
+
+func forEachJob(bucket *bbolt.Bucket, fn func(*models.Job) error) error {
+	return bucket.ForEach(func(key, _ []byte) error {
+		var job models.Job
+		if err := getJSON(bucket, key, &job); err != nil {
+			return err
+		}
+		return fn(&job)
+	})
+}
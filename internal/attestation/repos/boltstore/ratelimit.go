@@ -0,0 +1,42 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	rateLimitsBucket     = []byte("rateLimits")
+	rateLimitSnapshotKey = []byte("snapshot")
+)
+
+// SaveRateLimitSnapshot replaces the saved state of the rate limits.
+func (s *Store) SaveRateLimitSnapshot(_ context.Context, snapshot *models.RateLimitSnapshot) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putJSON(tx.Bucket(rateLimitsBucket), rateLimitSnapshotKey, snapshot)
+	})
+}
+
+// RateLimitSnapshot returns the saved state of the rate limits. It returns nil when there is none.
+func (s *Store) RateLimitSnapshot(_ context.Context) (*models.RateLimitSnapshot, error) {
+	var snapshot *models.RateLimitSnapshot
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(rateLimitsBucket).Get(rateLimitSnapshotKey)
+		if data == nil {
+			return nil
+		}
+		snapshot = &models.RateLimitSnapshot{}
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			return fmt.Errorf("failed to unmarshal rate limit snapshot: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
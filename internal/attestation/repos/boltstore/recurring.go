@@ -0,0 +1,91 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var recurringSubscriptionsBucket = []byte("recurringSubscriptions")
+
+// CreateRecurringSubscription stores a new recurring subscription.
+func (s *Store) CreateRecurringSubscription(_ context.Context, sub *models.RecurringSubscription) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(recurringSubscriptionsBucket)
+		if bucket.Get([]byte(sub.ID)) != nil {
+			return fmt.Errorf("%w: recurring subscription %s", ErrExists, sub.ID)
+		}
+		return putJSON(bucket, []byte(sub.ID), sub)
+	})
+}
+
+// GetRecurringSubscription returns the recurring subscription with the ID.
+func (s *Store) GetRecurringSubscription(_ context.Context, id string) (*models.RecurringSubscription, error) {
+	var sub models.RecurringSubscription
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return getJSON(tx.Bucket(recurringSubscriptionsBucket), []byte(id), &sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// UpdateRecurringSubscription applies update to the recurring subscription with the ID in a single transaction,
+// nothing is stored when update fails.
+func (s *Store) UpdateRecurringSubscription(_ context.Context, id string, update func(*models.RecurringSubscription) error) (*models.RecurringSubscription, error) {
+	var sub models.RecurringSubscription
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(recurringSubscriptionsBucket)
+		if err := getJSON(bucket, []byte(id), &sub); err != nil {
+			return err
+		}
+		if err := update(&sub); err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte(id), &sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListRecurringSubscriptions returns the recurring subscriptions for which match returns true, ordered by ID.
+func (s *Store) ListRecurringSubscriptions(_ context.Context, match func(*models.RecurringSubscription) bool) ([]models.RecurringSubscription, error) {
+	subs := []models.RecurringSubscription{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(recurringSubscriptionsBucket).ForEach(func(key, value []byte) error {
+			var sub models.RecurringSubscription
+			if err := json.Unmarshal(value, &sub); err != nil {
+				return fmt.Errorf("failed to unmarshal recurring subscription %s: %w", key, err)
+			}
+			if match(&sub) {
+				subs = append(subs, sub)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// DeleteRecurringSubscription deletes the recurring subscription with the ID.
+func (s *Store) DeleteRecurringSubscription(_ context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(recurringSubscriptionsBucket)
+		var sub models.RecurringSubscription
+		if err := getJSON(bucket, []byte(id), &sub); err != nil {
+			return err
+		}
+		if err := bucket.Delete([]byte(id)); err != nil {
+			return fmt.Errorf("failed to delete recurring subscription %s: %w", id, err)
+		}
+		return nil
+	})
+}
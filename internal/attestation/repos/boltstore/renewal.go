@@ -0,0 +1,39 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var renewalRunsBucket = []byte("renewalRuns")
+
+// SaveRenewalRun creates or replaces the renewal run.
+func (s *Store) SaveRenewalRun(_ context.Context, run *models.RenewalRun) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putJSON(tx.Bucket(renewalRunsBucket), []byte(run.ID), run)
+	})
+}
+
+// LatestRenewalRun returns the renewal run with the greatest ID. It returns nil when there is none.
+func (s *Store) LatestRenewalRun(_ context.Context) (*models.RenewalRun, error) {
+	var run *models.RenewalRun
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		key, value := tx.Bucket(renewalRunsBucket).Cursor().Last()
+		if key == nil {
+			return nil
+		}
+		run = &models.RenewalRun{}
+		if err := json.Unmarshal(value, run); err != nil {
+			return fmt.Errorf("failed to unmarshal renewal run %s: %w", key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
@@ -0,0 +1,205 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	webhookSubscriptionsBucket = []byte("webhookSubscriptions")
+	webhookDeliveriesBucket    = []byte("webhookDeliveries")
+	// requestedVehiclesBucket holds the vehicles each dev license requested attestations of.
+	requestedVehiclesBucket = []byte("requestedVehicles")
+)
+
+// CreateWebhookSubscription stores a new webhook subscription.
+func (s *Store) CreateWebhookSubscription(_ context.Context, sub *models.WebhookSubscription) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(webhookSubscriptionsBucket)
+		if bucket.Get([]byte(sub.ID)) != nil {
+			return fmt.Errorf("%w: webhook subscription %s", ErrExists, sub.ID)
+		}
+		return putJSON(bucket, []byte(sub.ID), sub)
+	})
+}
+
+// GetWebhookSubscription returns the webhook subscription with the ID.
+func (s *Store) GetWebhookSubscription(_ context.Context, id string) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return getJSON(tx.Bucket(webhookSubscriptionsBucket), []byte(id), &sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListWebhookSubscriptions returns all webhook subscriptions ordered by ID.
+func (s *Store) ListWebhookSubscriptions(_ context.Context) ([]models.WebhookSubscription, error) {
+	subs := []models.WebhookSubscription{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(webhookSubscriptionsBucket).ForEach(func(key, value []byte) error {
+			var sub models.WebhookSubscription
+			if err := json.Unmarshal(value, &sub); err != nil {
+				return fmt.Errorf("failed to unmarshal webhook subscription %s: %w", key, err)
+			}
+			subs = append(subs, sub)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription with the ID. Its deliveries are kept.
+func (s *Store) DeleteWebhookSubscription(_ context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(webhookSubscriptionsBucket)
+		var sub models.WebhookSubscription
+		if err := getJSON(bucket, []byte(id), &sub); err != nil {
+			return err
+		}
+		if err := bucket.Delete([]byte(id)); err != nil {
+			return fmt.Errorf("failed to delete webhook subscription %s: %w", id, err)
+		}
+		return nil
+	})
+}
+
+// CreateWebhookDeliveries stores new webhook deliveries in a single transaction.
+func (s *Store) CreateWebhookDeliveries(_ context.Context, deliveries []models.WebhookDelivery) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(webhookDeliveriesBucket)
+		for i := range deliveries {
+			if bucket.Get([]byte(deliveries[i].ID)) != nil {
+				return fmt.Errorf("%w: webhook delivery %s", ErrExists, deliveries[i].ID)
+			}
+			if err := putJSON(bucket, []byte(deliveries[i].ID), &deliveries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetWebhookDelivery returns the webhook delivery with the ID.
+func (s *Store) GetWebhookDelivery(_ context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return getJSON(tx.Bucket(webhookDeliveriesBucket), []byte(id), &delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery applies update to the webhook delivery with the ID in a single transaction,
+// nothing is stored when update fails.
+func (s *Store) UpdateWebhookDelivery(_ context.Context, id string, update func(*models.WebhookDelivery) error) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(webhookDeliveriesBucket)
+		if err := getJSON(bucket, []byte(id), &delivery); err != nil {
+			return err
+		}
+		if err := update(&delivery); err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte(id), &delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListWebhookDeliveries returns the webhook deliveries for which match returns true, ordered by ID.
+func (s *Store) ListWebhookDeliveries(_ context.Context, match func(*models.WebhookDelivery) bool) ([]models.WebhookDelivery, error) {
+	deliveries := []models.WebhookDelivery{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(webhookDeliveriesBucket).ForEach(func(key, value []byte) error {
+			var delivery models.WebhookDelivery
+			if err := json.Unmarshal(value, &delivery); err != nil {
+				return fmt.Errorf("failed to unmarshal webhook delivery %s: %w", key, err)
+			}
+			if match(&delivery) {
+				deliveries = append(deliveries, delivery)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// DeleteWebhookDeliveriesFinishedBefore deletes the webhook deliveries that finished before the time and returns
+// how many were deleted.
+func (s *Store) DeleteWebhookDeliveriesFinishedBefore(_ context.Context, before time.Time) (int, error) {
+	var count int
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		deliveries := tx.Bucket(webhookDeliveriesBucket)
+		var ids []string
+		err := deliveries.ForEach(func(key, value []byte) error {
+			var delivery models.WebhookDelivery
+			if err := json.Unmarshal(value, &delivery); err != nil {
+				return fmt.Errorf("failed to unmarshal webhook delivery %s: %w", key, err)
+			}
+			if delivery.FinishedAt != nil && delivery.FinishedAt.Before(before) {
+				ids = append(ids, delivery.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := deliveries.Delete([]byte(id)); err != nil {
+				return fmt.Errorf("failed to delete webhook delivery %s: %w", id, err)
+			}
+		}
+		count = len(ids)
+		return nil
+	})
+	return count, err
+}
+
+// RecordRequestedVehicle records that the dev license requested an attestation of the vehicle.
+func (s *Store) RecordRequestedVehicle(ctx context.Context, devLicense string, tokenID uint32) error {
+	if requested, err := s.HasRequestedVehicle(ctx, devLicense, tokenID); err != nil || requested {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		key := requestedVehicleKey(devLicense, tokenID)
+		if err := tx.Bucket(requestedVehiclesBucket).Put(key, []byte(time.Now().UTC().Format(time.RFC3339))); err != nil {
+			return fmt.Errorf("failed to store requested vehicle %d of %s: %w", tokenID, devLicense, err)
+		}
+		return nil
+	})
+}
+
+// HasRequestedVehicle reports whether the dev license requested an attestation of the vehicle.
+func (s *Store) HasRequestedVehicle(_ context.Context, devLicense string, tokenID uint32) (bool, error) {
+	var requested bool
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		requested = tx.Bucket(requestedVehiclesBucket).Get(requestedVehicleKey(devLicense, tokenID)) != nil
+		return nil
+	})
+	return requested, err
+}
+
+// requestedVehicleKey orders the requested vehicles by dev license and then by token ID.
+func requestedVehicleKey(devLicense string, tokenID uint32) []byte {
+	return binary.BigEndian.AppendUint32([]byte(devLicense), tokenID)
+}
@@ -5,16 +5,22 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/attestation-api/internal/client/fetchapi"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/erc191"
 	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/vindecoder"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/fetch-api/pkg/grpc"
 	"github.com/DIMO-Network/model-garage/pkg/modules"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
-	"github.com/DIMO-Network/shared/pkg/vin"
+	"github.com/ethereum/go-ethereum/common"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -26,43 +32,59 @@ func (d decodeError) Error() string {
 
 // Service manages and retrieves fingerprint messages.
 type Service struct {
-	fetchService *fetchapi.FetchAPIService
+	fetchService      *fetchapi.FetchAPIService
+	requireSignatures bool
 }
 
 // New creates a new instance of Service.
-func New(fetchService *fetchapi.FetchAPIService) *Service {
+func New(fetchService *fetchapi.FetchAPIService, settings *config.Settings) *Service {
 	return &Service{
-		fetchService: fetchService,
+		fetchService:      fetchService,
+		requireSignatures: settings.RequireFingerprintSignatures,
 	}
 }
 
-// GetLatestFingerprintMessages fetches the latest fingerprint message from S3.
-func (s *Service) GetLatestFingerprintMessages(ctx context.Context, vehicleDID cloudevent.ERC721DID, device models.PairedDevice) (*models.DecodedFingerprintData, error) {
-	fingerprintType := cloudevent.TypeFingerprint
+// GetFingerprintMessages fetches up to limit fingerprint messages of the device sent after the given time, newest first.
+// Messages that do not carry a valid VIN or fail signature verification are skipped; if none of the messages pass,
+// the error of the newest is returned.
+func (s *Service) GetFingerprintMessages(ctx context.Context, vehicleDID cloudevent.ERC721DID, device models.PairedDevice, after time.Time, limit int32) ([]models.DecodedFingerprintData, error) {
 	opts := &grpc.SearchOptions{
 		Subject:  wrapperspb.String(vehicleDID.String()),
 		Producer: wrapperspb.String(device.DID.String()),
-		Type:     wrapperspb.String(fingerprintType),
+		Type:     wrapperspb.String(cloudevent.TypeFingerprint),
+		After:    timestamppb.New(after),
 	}
-	dataObj, err := s.fetchService.GetLatestCloudEvent(ctx, opts)
+	events, err := s.fetchService.GetAllCloudEvents(ctx, opts, limit)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, richerrors.Error{
-				Code:        http.StatusBadRequest,
-				Err:         err,
-				ExternalMsg: "No fingerprint message found",
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get fingerprint messages: %w", err)
+	}
+
+	var fingerprints []models.DecodedFingerprintData
+	var decodeErr error
+	for _, event := range events {
+		msg, err := s.DecodeFingerprintMessage(ctx, event)
+		if err == nil {
+			msg.SignatureStatus, err = s.verifySignature(event, device)
+		}
+		if err != nil {
+			if decodeErr == nil {
+				decodeErr = err
 			}
+			continue
 		}
-		return nil, fmt.Errorf("failed to get fingerprint message: %w", err)
+		fingerprints = append(fingerprints, *msg)
 	}
-	msg, err := s.decodeFingerprintMessage(ctx, dataObj)
-	if err != nil {
-		return nil, err
+	if len(fingerprints) == 0 && decodeErr != nil {
+		return nil, decodeErr
 	}
-	return msg, nil
+	return fingerprints, nil
 }
 
-func (s *Service) decodeFingerprintMessage(ctx context.Context, msg cloudevent.RawEvent) (*models.DecodedFingerprintData, error) {
+// DecodeFingerprintMessage extracts and validates the VIN of a fingerprint message without verifying its signature.
+func (s *Service) DecodeFingerprintMessage(ctx context.Context, msg cloudevent.RawEvent) (*models.DecodedFingerprintData, error) {
 	var vinVal string
 	var err error
 	fp, err := modules.ConvertToFingerprint(ctx, msg.Source, msg)
@@ -99,14 +121,41 @@ func (s *Service) decodeFingerprintMessage(ctx context.Context, msg cloudevent.R
 	}, nil
 }
 
-// validateVIN checks if VIN is valid as a 17 character traditional VIN or as a japanese chassis number
-func validateVIN(vinValue string) bool {
-	vinObj := vin.VIN(vinValue)
+// verifySignature checks that a message of an aftermarket device is signed by the device. Devices sign the
+// event data with ERC-191. Messages signed by another key are rejected, unsigned messages are rejected when
+// signatures are required and marked unsigned otherwise. Synthetic devices do not sign their messages.
+func (s *Service) verifySignature(msg cloudevent.RawEvent, device models.PairedDevice) (string, error) {
+	if device.Type != models.DeviceTypeAftermarket || device.Address == (common.Address{}) {
+		return types.SignatureStatusNotApplicable, nil
+	}
+	if msg.Signature == "" {
+		if s.requireSignatures {
+			return "", richerrors.Error{
+				Code:        http.StatusBadRequest,
+				Err:         fmt.Errorf("unsigned fingerprint message %s from device %s", msg.ID, device.DID.String()),
+				ExternalMsg: "Fingerprint message is not signed by the paired device",
+			}
+		}
+		return types.SignatureStatusUnsigned, nil
+	}
 
-	if vinObj.IsValidVIN() {
-		return true
-	} else if vinObj.IsValidJapanChassis() {
-		return true
+	signer, err := erc191.RecoverAddress(msg.Data, msg.Signature)
+	if err != nil || signer != device.Address {
+		if err == nil {
+			err = fmt.Errorf("fingerprint message %s signed by %s instead of device %s", msg.ID, signer.Hex(), device.Address.Hex())
+		}
+		return "", richerrors.Error{
+			Code:        http.StatusBadRequest,
+			Err:         err,
+			ExternalMsg: "Fingerprint message signature does not match the paired device",
+		}
 	}
-	return false
+	return types.SignatureStatusVerified, nil
+}
+
+// validateVIN checks if VIN is valid as a 17 character traditional VIN or as a japanese chassis number.
+// North American VINs must also have a valid check digit.
+func validateVIN(vinValue string) bool {
+	_, err := vindecoder.Default().Decode(vinValue)
+	return err == nil
 }
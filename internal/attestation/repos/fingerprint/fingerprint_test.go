@@ -5,9 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DIMO-Network/attestation-api/internal/erc191"
 	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,7 +34,7 @@ func TestDecodeFingerprintMessage(t *testing.T) {
 				"data":{
 					"timestamp":1721830108107,
 					"device":{"rpiUptimeSecs":109,"batteryVoltage":14.3},
-					"vin":"1HGCM82633A123456",
+					"vin":"1HGCM82673A123456",
 					"protocol":"6",
 					"softwareVersion":"1.25.5"
 				},
@@ -48,7 +51,7 @@ func TestDecodeFingerprintMessage(t *testing.T) {
 					Source:      "0x5e31bBc786D7bEd95216383787deA1ab0f1c1897",
 					Signature:   "0x8f4a67281978a93fafc9231e10c6a3489b5c732239ffc72b02e3603608c7375516f876e9ac33aa3b5a2b475521dbca4e1e68d85a797ea7b07f7d9b6369b805751c",
 				},
-				VIN: "1HGCM82633A123456",
+				VIN: "1HGCM82673A123456",
 			},
 			expectError: false,
 		},
@@ -110,7 +113,7 @@ func TestDecodeFingerprintMessage(t *testing.T) {
 					Source:      common.HexToAddress("0x4c674ddE8189aEF6e3b58F5a36d7438b2b1f6Bc2").String(), // hashdog source
 					Type:        cloudevent.TypeFingerprint,
 				},
-				VIN: "1ABCD2EFGH3JKLMN0",
+				VIN: "1ABCD2EF9H3JKLMN0",
 			},
 		},
 		{
@@ -146,7 +149,7 @@ func TestDecodeFingerprintMessage(t *testing.T) {
 			event := cloudevent.CloudEvent[json.RawMessage]{}
 			err := json.Unmarshal(tt.data, &event)
 			require.NoError(t, err)
-			decodedData, err := srv.decodeFingerprintMessage(t.Context(), event)
+			decodedData, err := srv.DecodeFingerprintMessage(t.Context(), event)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -173,7 +176,7 @@ func TestValidateVIN(t *testing.T) {
 		},
 		{
 			name:     "Valid 17 character VIN",
-			vin:      "1FTFX1E57JKE37092",
+			vin:      "1FTFX1E53JKE37092",
 			expected: true,
 		},
 		{
@@ -181,14 +184,24 @@ func TestValidateVIN(t *testing.T) {
 			vin:      "123456789",
 			expected: true, // This is actually a valid Japanese chassis format
 		},
+		{
+			name:     "Invalid VIN - North American check digit",
+			vin:      "1FTFX1E57JKE37092",
+			expected: false,
+		},
+		{
+			name:     "Valid European VIN without check digit",
+			vin:      "WBA8E9G59GNU12345",
+			expected: true,
+		},
 		{
 			name:     "Invalid VIN - too long",
-			vin:      "1FTFX1E57JKE37092EXTRA",
+			vin:      "1FTFX1E53JKE37092EXTRA",
 			expected: false,
 		},
 		{
 			name:     "Invalid VIN - contains invalid characters",
-			vin:      "1FTFX1E57JKE3709I", // 'I' is not allowed in VINs
+			vin:      "1FTFX1E53JKE3709I", // 'I' is not allowed in VINs
 			expected: false,
 		},
 		{
@@ -322,7 +335,7 @@ var hashdogFPPayload = `{
       "protocol": 6,
       "signature": "0x9a8b7c6d5e4f3g2h1i0j9k8l7m6n5o4p3q2r1s0t9u8v7w6x5y4z3a2b1c0d9e8f7g6h5i4j3k2l1m",
       "timestamp": "2025-03-05T12:46:32.000Z",
-      "vin": "1ABCD2EFGH3JKLMN0"
+      "vin": "1ABCD2EF9H3JKLMN0"
     },
     "device": {
       "id": "A1B2C3D4E5F6G7H8",
@@ -412,3 +425,79 @@ var hashdogFPPayload = `{
     ]
   }
 }`
+
+func TestVerifySignature(t *testing.T) {
+	deviceKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	data := json.RawMessage(`{"vin":"1HGCM82673A123456"}`)
+	deviceSignature, err := erc191.SignMessage(data, deviceKey)
+	require.NoError(t, err)
+	otherSignature, err := erc191.SignMessage(data, otherKey)
+	require.NoError(t, err)
+
+	aftermarket := models.PairedDevice{Type: models.DeviceTypeAftermarket, Address: crypto.PubkeyToAddress(deviceKey.PublicKey)}
+	tests := []struct {
+		name              string
+		device            models.PairedDevice
+		signature         string
+		requireSignatures bool
+		expectedStatus    string
+		expectError       bool
+	}{
+		{
+			name:           "Signed by the paired device",
+			device:         aftermarket,
+			signature:      deviceSignature,
+			expectedStatus: types.SignatureStatusVerified,
+		},
+		{
+			name:        "Signed by another key",
+			device:      aftermarket,
+			signature:   otherSignature,
+			expectError: true,
+		},
+		{
+			name:        "Malformed signature",
+			device:      aftermarket,
+			signature:   "0x1234",
+			expectError: true,
+		},
+		{
+			name:           "Unsigned message",
+			device:         aftermarket,
+			expectedStatus: types.SignatureStatusUnsigned,
+		},
+		{
+			name:              "Unsigned message when signatures are required",
+			device:            aftermarket,
+			requireSignatures: true,
+			expectError:       true,
+		},
+		{
+			name:              "Synthetic device",
+			device:            models.PairedDevice{Type: models.DeviceTypeSynthetic},
+			signature:         otherSignature,
+			requireSignatures: true,
+			expectedStatus:    types.SignatureStatusNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{requireSignatures: tt.requireSignatures}
+			msg := cloudevent.RawEvent{
+				CloudEventHeader: cloudevent.CloudEventHeader{ID: "2jhCq04sdOL4fzgXccW8cJSG3vn", Signature: tt.signature},
+				Data:             data,
+			}
+			status, err := s.verifySignature(msg, tt.device)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+)
+
+// SaveLedgerEntry stores the ledger entry, replacing the entry with the same ID. A replaced entry keeps its place in
+// the order the entries were first recorded.
+func (s *Store) SaveLedgerEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ledger entry %s: %w", entry.ID, err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO ledger_entries (id, token_id, data_version, requester, status, recorded_at, entry)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			token_id = excluded.token_id,
+			data_version = excluded.data_version,
+			requester = excluded.requester,
+			status = excluded.status,
+			recorded_at = excluded.recorded_at,
+			entry = excluded.entry`,
+		entry.ID, entry.TokenID, entry.DataVersion, entry.Requester, entry.Status, entry.RecordedAt.UnixNano(), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store ledger entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// GetLedgerEntry returns the ledger entry with the ID.
+func (s *Store) GetLedgerEntry(ctx context.Context, id string) (*models.LedgerEntry, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT entry FROM ledger_entries WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFound(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger entry %s: %w", id, err)
+	}
+	var entry models.LedgerEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ledger entry %s: %w", id, err)
+	}
+	return &entry, nil
+}
+
+// ListLedgerEntries returns a page of the ledger entries matching the query in the order they were first
+// recorded.
+func (s *Store) ListLedgerEntries(ctx context.Context, query *models.LedgerQuery) (*models.LedgerPage, error) {
+	var conds []string
+	var args []any
+	if query.After != "" {
+		var seq int64
+		err := s.db.QueryRowContext(ctx, `SELECT seq FROM ledger_entries WHERE id = ?`, query.After).Scan(&seq)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound(query.After)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ledger entry %s: %w", query.After, err)
+		}
+		conds, args = append(conds, "seq > ?"), append(args, seq)
+	}
+	if query.TokenID != 0 {
+		conds, args = append(conds, "token_id = ?"), append(args, query.TokenID)
+	}
+	if query.DataVersion != "" {
+		conds, args = append(conds, "data_version = ?"), append(args, query.DataVersion)
+	}
+	if query.Requester != "" {
+		conds, args = append(conds, "requester = ?"), append(args, query.Requester)
+	}
+	if query.Status != "" {
+		conds, args = append(conds, "status = ?"), append(args, query.Status)
+	}
+	if !query.From.IsZero() {
+		conds, args = append(conds, "recorded_at >= ?"), append(args, query.From.UnixNano())
+	}
+	if !query.To.IsZero() {
+		conds, args = append(conds, "recorded_at < ?"), append(args, query.To.UnixNano())
+	}
+
+	stmt := `SELECT entry FROM ledger_entries`
+	if len(conds) > 0 {
+		stmt += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	stmt += ` ORDER BY seq`
+	if query.Limit > 0 {
+		// one more entry tells whether there is a next page
+		stmt += ` LIMIT ?`
+		args = append(args, query.Limit+1)
+	}
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	page := &models.LedgerPage{Entries: []models.LedgerEntry{}}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read ledger entry: %w", err)
+		}
+		if query.Limit > 0 && len(page.Entries) == query.Limit {
+			page.Next = page.Entries[len(page.Entries)-1].ID
+			break
+		}
+		var entry models.LedgerEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ledger entry: %w", err)
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	return page, nil
+}
+
+func notFound(id string) error {
+	return richerrors.Error{Err: fmt.Errorf("%w: ledger entry %s", ErrNotFound, id), ExternalMsg: "Record not found", Code: http.StatusNotFound}
+}
@@ -0,0 +1,64 @@
+// Package sqlitestore keeps the issuance ledger in an SQLite database file. Unlike the bbolt database, the file can be
+// written by several processes at once, e.g. the API and the backfill command.
+package sqlitestore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	// registers the sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ErrNotFound is returned when a record does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// busyTimeout is how long a write waits for the writes of other processes.
+const busyTimeout = 5 * time.Second
+
+const schema = `
+CREATE TABLE IF NOT EXISTS ledger_entries (
+	seq INTEGER PRIMARY KEY AUTOINCREMENT,
+	id TEXT NOT NULL UNIQUE,
+	token_id INTEGER NOT NULL,
+	data_version TEXT NOT NULL,
+	requester TEXT NOT NULL,
+	status TEXT NOT NULL,
+	recorded_at INTEGER NOT NULL,
+	entry TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS ledger_entries_token_id ON ledger_entries (token_id);
+CREATE INDEX IF NOT EXISTS ledger_entries_requester ON ledger_entries (requester);
+CREATE INDEX IF NOT EXISTS ledger_entries_recorded_at ON ledger_entries (recorded_at);
+`
+
+// Store is an SQLite backed store.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database file at path, creating it when it does not exist. The file is opened in WAL mode, so
+// reads do not wait for writes, and writes of other processes are waited for instead of failing.
+func Open(path string) (*Store, error) {
+	params := url.Values{}
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", fmt.Sprint(busyTimeout.Milliseconds()))
+	params.Set("_txlock", "immediate")
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create schema of database %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
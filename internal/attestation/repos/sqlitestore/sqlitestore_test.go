@@ -0,0 +1,105 @@
+package sqlitestore_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/repos/sqlitestore"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t *testing.T, path string) *sqlitestore.Store {
+	t.Helper()
+	store, err := sqlitestore.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	return store
+}
+
+func TestLedgerEntries(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t, filepath.Join(t.TempDir(), "ledger.sqlite"))
+
+	recordedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, entry := range []models.LedgerEntry{
+		{ID: "vc-1", TokenID: 1, DataVersion: "vin/v1.0", Status: models.LedgerStatusFailed, UploadError: "DIS unavailable"},
+		{ID: "vc-2", TokenID: 2, DataVersion: "vin/v1.0", Requester: "0xClient", Status: models.LedgerStatusUploaded},
+		{ID: "vc-3", TokenID: 1, DataVersion: "odometer/v1.0", Requester: "0xClient", Status: models.LedgerStatusUploaded},
+		{ID: "vc-4", TokenID: 1, DataVersion: "vin/v1.0", Status: models.LedgerStatusUploaded},
+	} {
+		entry.RecordedAt = recordedAt.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, store.SaveLedgerEntry(ctx, &entry))
+	}
+
+	// a retried upload replaces the entry and keeps its place
+	retried := &models.LedgerEntry{ID: "vc-1", TokenID: 1, DataVersion: "vin/v1.0", Status: models.LedgerStatusUploaded, RecordedAt: recordedAt.Add(4 * time.Hour)}
+	require.NoError(t, store.SaveLedgerEntry(ctx, retried))
+	entry, err := store.GetLedgerEntry(ctx, "vc-1")
+	require.NoError(t, err)
+	require.Equal(t, retried, entry)
+
+	_, err = store.GetLedgerEntry(ctx, "missing")
+	require.ErrorIs(t, err, sqlitestore.ErrNotFound)
+
+	tests := []struct {
+		name     string
+		query    models.LedgerQuery
+		wantIDs  []string
+		wantNext string
+	}{
+		{name: "every entry", wantIDs: []string{"vc-1", "vc-2", "vc-3", "vc-4"}},
+		{name: "token", query: models.LedgerQuery{TokenID: 1}, wantIDs: []string{"vc-1", "vc-3", "vc-4"}},
+		{name: "data version and requester", query: models.LedgerQuery{DataVersion: "vin/v1.0", Requester: "0xClient"}, wantIDs: []string{"vc-2"}},
+		{name: "status", query: models.LedgerQuery{Status: models.LedgerStatusUploaded, TokenID: 2}, wantIDs: []string{"vc-2"}},
+		{name: "recording time", query: models.LedgerQuery{From: recordedAt.Add(time.Hour), To: recordedAt.Add(3 * time.Hour)}, wantIDs: []string{"vc-2", "vc-3"}},
+		{name: "first page", query: models.LedgerQuery{TokenID: 1, Limit: 2}, wantIDs: []string{"vc-1", "vc-3"}, wantNext: "vc-3"},
+		{name: "last page", query: models.LedgerQuery{TokenID: 1, Limit: 2, After: "vc-3"}, wantIDs: []string{"vc-4"}},
+		{name: "full last page", query: models.LedgerQuery{Limit: 2, After: "vc-2"}, wantIDs: []string{"vc-3", "vc-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := store.ListLedgerEntries(ctx, &tt.query)
+			require.NoError(t, err)
+			ids := []string{}
+			for _, entry := range page.Entries {
+				ids = append(ids, entry.ID)
+			}
+			require.Equal(t, tt.wantIDs, ids)
+			require.Equal(t, tt.wantNext, page.Next)
+		})
+	}
+
+	_, err = store.ListLedgerEntries(ctx, &models.LedgerQuery{After: "missing"})
+	require.ErrorIs(t, err, sqlitestore.ErrNotFound)
+}
+
+func TestLedgerEntries_SharedFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ledger.sqlite")
+	// e.g. the API and the backfill command
+	stores := []*sqlitestore.Store{openStore(t, path), openStore(t, path)}
+
+	var wg sync.WaitGroup
+	for i, store := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 50 {
+				entry := &models.LedgerEntry{ID: fmt.Sprintf("vc-%d-%d", i, n), TokenID: uint32(n + 1), Status: models.LedgerStatusUploaded, RecordedAt: time.Now().UTC()}
+				require.NoError(t, store.SaveLedgerEntry(ctx, entry))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, store := range stores {
+		page, err := store.ListLedgerEntries(ctx, &models.LedgerQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Entries, 100)
+	}
+}
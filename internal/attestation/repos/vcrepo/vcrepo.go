@@ -9,23 +9,48 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/DIMO-Network/attestation-api/internal/client/fetchapi"
 	"github.com/DIMO-Network/attestation-api/internal/client/tokencache"
 	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/fetch-api/pkg/grpc"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // Repo manages storing and retrieving VCs.
 type Repo struct {
-	disURL     *url.URL
-	tokenCache *tokencache.Cache
-	devLicense string
-	privateKey *ecdsa.PrivateKey
+	disURL         *url.URL
+	tokenCache     *tokencache.Cache
+	fetchService   *fetchapi.FetchAPIService
+	devLicense     string
+	vinDataVersion string
+	privateKey     *ecdsa.PrivateKey
+
+	uploadListeners []UploadListener
+	uploadRecorders []UploadRecorder
+}
+
+// UploadListener is notified of every attestation uploaded to DIS.
+type UploadListener interface {
+	AttestationUploaded(ctx context.Context, attestation *cloudevent.RawEvent)
+}
+
+// UploadRecorder is notified of every attempted upload to DIS, with the error of failed uploads.
+type UploadRecorder interface {
+	RecordUpload(ctx context.Context, attestation *cloudevent.RawEvent, uploadErr error)
 }
 
 // New creates a new instance of VCRepo.
-func New(settings *config.Settings, tokenCache *tokencache.Cache) (*Repo, error) {
+func New(settings *config.Settings, tokenCache *tokencache.Cache, fetchService *fetchapi.FetchAPIService) (*Repo, error) {
 	disURL, err := url.Parse(settings.DISURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DIS URL: %w", err)
@@ -35,15 +60,35 @@ func New(settings *config.Settings, tokenCache *tokencache.Cache) (*Repo, error)
 		return nil, fmt.Errorf("failed to convert private key to ECDSA: %w", err)
 	}
 	return &Repo{
-		disURL:     disURL,
-		tokenCache: tokenCache,
-		devLicense: settings.DevLicense,
-		privateKey: privateKey,
+		disURL:         disURL,
+		tokenCache:     tokenCache,
+		fetchService:   fetchService,
+		devLicense:     settings.DevLicense,
+		vinDataVersion: settings.VINDataVersion,
+		privateKey:     privateKey,
 	}, nil
 }
 
+// AddUploadListener registers a listener notified after each successful upload. Listeners must be added before
+// the repository is used.
+func (r *Repo) AddUploadListener(listener UploadListener) {
+	r.uploadListeners = append(r.uploadListeners, listener)
+}
+
+// AddUploadRecorder registers a recorder notified after each upload attempt. Recorders must be added before the
+// repository is used.
+func (r *Repo) AddUploadRecorder(recorder UploadRecorder) {
+	r.uploadRecorders = append(r.uploadRecorders, recorder)
+}
+
 // UploadAttestation uploads a new attestation to DIS.
-func (r *Repo) UploadAttestation(ctx context.Context, attestation *cloudevent.RawEvent) error {
+func (r *Repo) UploadAttestation(ctx context.Context, attestation *cloudevent.RawEvent) (err error) {
+	defer func() {
+		for _, recorder := range r.uploadRecorders {
+			recorder.RecordUpload(ctx, attestation, err)
+		}
+	}()
+
 	eventBytes, err := json.Marshal(attestation)
 	if err != nil {
 		return fmt.Errorf("failed to marshal cloud event: %w", err)
@@ -77,5 +122,67 @@ func (r *Repo) UploadAttestation(ctx context.Context, attestation *cloudevent.Ra
 		return fmt.Errorf("DIS returned non-200 status code: %d; %s", resp.StatusCode, string(bodyBytes))
 	}
 
+	for _, listener := range r.uploadListeners {
+		listener.AttestationUploaded(ctx, attestation)
+	}
 	return nil
 }
+
+// ListVINAttestations lists the VIN attestations issued by this service after the time, oldest first.
+func (r *Repo) ListVINAttestations(ctx context.Context, after time.Time, limit int32) ([]cloudevent.RawEvent, error) {
+	opts := &grpc.SearchOptions{
+		After:        timestamppb.New(after),
+		TimestampAsc: wrapperspb.Bool(true),
+		Source:       wrapperspb.String(common.HexToAddress(r.devLicense).Hex()),
+		Type:         wrapperspb.String(cloudevent.TypeAttestation),
+		DataVersion:  wrapperspb.String(r.vinDataVersion),
+	}
+	events, err := r.fetchService.GetAllCloudEvents(ctx, opts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VIN attestations: %w", err)
+	}
+	return events, nil
+}
+
+// GetLatestVINSubject fetches the latest VIN attestation issued by this service for the vehicle and returns its subject.
+// An expired attestation is rejected, since its VIN may have been superseded.
+func (r *Repo) GetLatestVINSubject(ctx context.Context, vehicleDID cloudevent.ERC721DID) (*types.VINSubject, error) {
+	opts := &grpc.SearchOptions{
+		Subject:     wrapperspb.String(vehicleDID.String()),
+		Source:      wrapperspb.String(common.HexToAddress(r.devLicense).Hex()),
+		Type:        wrapperspb.String(cloudevent.TypeAttestation),
+		DataVersion: wrapperspb.String(r.vinDataVersion),
+	}
+	event, err := r.fetchService.GetLatestCloudEvent(ctx, opts)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, richerrors.Error{
+				Code:        http.StatusBadRequest,
+				Err:         err,
+				ExternalMsg: "No VIN attestation found for vehicle",
+			}
+		}
+		return nil, fmt.Errorf("failed to get VIN attestation: %w", err)
+	}
+	return vinSubject(&event, time.Now())
+}
+
+// vinSubject returns the subject of a VIN attestation that is still valid at now.
+func vinSubject(event *cloudevent.RawEvent, now time.Time) (*types.VINSubject, error) {
+	var credential types.Credential
+	if err := json.Unmarshal(event.Data, &credential); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal VIN credential: %w", err)
+	}
+	if !credential.ValidTo.IsZero() && credential.ValidTo.Before(now) {
+		return nil, richerrors.Error{
+			Code:        http.StatusBadRequest,
+			Err:         fmt.Errorf("VIN attestation %s expired at %s", event.ID, credential.ValidTo.Format(time.RFC3339)),
+			ExternalMsg: "VIN attestation for vehicle has expired",
+		}
+	}
+	var subject types.VINSubject
+	if err := json.Unmarshal(credential.CredentialSubject, &subject); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal VIN credential subject: %w", err)
+	}
+	return &subject, nil
+}
@@ -0,0 +1,44 @@
+package vcrepo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVINSubject(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		validTo   time.Time
+		expectErr bool
+	}{
+		{name: "valid", validTo: now.Add(time.Hour)},
+		{name: "no expiry", validTo: time.Time{}},
+		{name: "expired", validTo: now.Add(-time.Hour), expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, err := json.Marshal(types.VINSubject{VehicleIdentificationNumber: "1HGCM82633A123456"})
+			require.NoError(t, err)
+			data, err := json.Marshal(types.Credential{ValidTo: tt.validTo, CredentialSubject: subject})
+			require.NoError(t, err)
+
+			vin, err := vinSubject(&cloudevent.RawEvent{Data: data}, now)
+			if tt.expectErr {
+				var richErr richerrors.Error
+				require.ErrorAs(t, err, &richErr)
+				require.Equal(t, http.StatusBadRequest, richErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "1HGCM82633A123456", vin.VehicleIdentificationNumber)
+		})
+	}
+}
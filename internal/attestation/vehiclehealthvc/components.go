@@ -0,0 +1,203 @@
+package vehiclehealthvc
+
+import (
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+)
+
+// Component thresholds. A reading beyond the warning limit is a warning, beyond the critical limit it is critical.
+const (
+	// 12V battery voltage in V. A healthy battery rests at ~12.6V and charges at up to ~14.7V.
+	batteryVoltageWarningLow  = 12.2
+	batteryVoltageCriticalLow = 11.8
+	batteryVoltageWarningHigh = 15.0
+
+	// Highest engine coolant temperature in °C.
+	coolantWarningHigh  = 110.0
+	coolantCriticalHigh = 120.0
+
+	// Engine oil level in percent of the maximum level. Oil life is not evaluated, since the telemetry API has no
+	// oil life signal.
+	oilLevelWarningLow  = 40.0
+	oilLevelCriticalLow = 20.0
+
+	// Brake pad wear in percent, 0 is new and 100 is worn.
+	brakePadWearWarningHigh  = 75.0
+	brakePadWearCriticalHigh = 90.0
+
+	// Traction battery state of health in percent of the original capacity.
+	stateOfHealthWarningLow  = 80.0
+	stateOfHealthCriticalLow = 70.0
+
+	// Spread between the highest and lowest traction battery cell voltages in mV.
+	cellImbalanceWarningHigh  = 50.0
+	cellImbalanceCriticalHigh = 100.0
+)
+
+// componentSignals are the telemetry signals used to evaluate vehicle components.
+var componentSignals = []string{
+	vss.FieldLowVoltageBatteryCurrentVoltage,
+	vss.FieldPowertrainCombustionEngineECT,
+	vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel,
+	telemetryapi.FieldChassisAxleRow1WheelLeftBrakePadWear,
+	telemetryapi.FieldChassisAxleRow1WheelRightBrakePadWear,
+	telemetryapi.FieldChassisAxleRow2WheelLeftBrakePadWear,
+	telemetryapi.FieldChassisAxleRow2WheelRightBrakePadWear,
+	vss.FieldPowertrainTractionBatteryStateOfHealth,
+	telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax,
+	telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin,
+}
+
+var brakePadWearSignals = []string{
+	telemetryapi.FieldChassisAxleRow1WheelLeftBrakePadWear,
+	telemetryapi.FieldChassisAxleRow1WheelRightBrakePadWear,
+	telemetryapi.FieldChassisAxleRow2WheelLeftBrakePadWear,
+	telemetryapi.FieldChassisAxleRow2WheelRightBrakePadWear,
+}
+
+// evaluateComponents evaluates each component the vehicle reported readings for from the signals, sorted newest first.
+// Components without readings are left out. Coolant temperatures are the highest of each interval, so the hottest
+// reading is the peak of the period.
+func evaluateComponents(signals []telemetryapi.Signal) []types.ComponentHealth {
+	latest := make(map[string]telemetryapi.Signal)
+	var hottest *telemetryapi.Signal
+	for i, signal := range signals {
+		value, ok := signal.Value.(float64)
+		if !ok {
+			continue
+		}
+		if _, ok := latest[signal.Name]; !ok {
+			latest[signal.Name] = signal
+		}
+		if signal.Name == vss.FieldPowertrainCombustionEngineECT && (hottest == nil || value > hottest.Value.(float64)) {
+			hottest = &signals[i]
+		}
+	}
+
+	var components []types.ComponentHealth
+	if signal, ok := latest[vss.FieldLowVoltageBatteryCurrentVoltage]; ok {
+		value := signal.Value.(float64)
+		status := statusBelow(value, batteryVoltageWarningLow, batteryVoltageCriticalLow)
+		if value > batteryVoltageWarningHigh {
+			status = types.ComponentStatusWarning
+		}
+		components = append(components, componentHealth(types.ComponentLowVoltageBattery, status, value, "V", signal))
+	}
+	if hottest != nil {
+		value := hottest.Value.(float64)
+		status := statusAbove(value, coolantWarningHigh, coolantCriticalHigh)
+		components = append(components, componentHealth(types.ComponentCoolant, status, value, "celsius", *hottest))
+	}
+	if signal, ok := latest[vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel]; ok {
+		value := signal.Value.(float64)
+		status := statusBelow(value, oilLevelWarningLow, oilLevelCriticalLow)
+		components = append(components, componentHealth(types.ComponentEngineOil, status, value, "percent", signal))
+	}
+	if signal, ok := mostWornBrakePad(latest); ok {
+		value := signal.Value.(float64)
+		status := statusAbove(value, brakePadWearWarningHigh, brakePadWearCriticalHigh)
+		components = append(components, componentHealth(types.ComponentBrakePads, status, value, "percent", signal))
+	}
+	if signal, ok := latest[vss.FieldPowertrainTractionBatteryStateOfHealth]; ok {
+		value := signal.Value.(float64)
+		status := statusBelow(value, stateOfHealthWarningLow, stateOfHealthCriticalLow)
+		components = append(components, componentHealth(types.ComponentTractionBatteryStateOfHealth, status, value, "percent", signal))
+	}
+	if imbalance, ok := latestCellImbalance(signals); ok {
+		components = append(components, imbalance)
+	}
+	return components
+}
+
+func componentHealth(component, status string, value float64, unit string, signal telemetryapi.Signal) types.ComponentHealth {
+	return types.ComponentHealth{
+		Component: component,
+		Status:    status,
+		Value:     value,
+		Unit:      unit,
+		Timestamp: signal.Timestamp,
+		Signals:   []string{signal.Name},
+	}
+}
+
+// mostWornBrakePad returns the latest reading of the most worn brake pad.
+func mostWornBrakePad(latest map[string]telemetryapi.Signal) (telemetryapi.Signal, bool) {
+	var worn telemetryapi.Signal
+	found := false
+	for _, name := range brakePadWearSignals {
+		signal, ok := latest[name]
+		if !ok {
+			continue
+		}
+		if !found || signal.Value.(float64) > worn.Value.(float64) {
+			worn = signal
+			found = true
+		}
+	}
+	return worn, found
+}
+
+// latestCellImbalance returns the cell imbalance of the newest interval reporting both the highest and lowest
+// traction battery cell voltages, so the two readings are taken at the same time.
+func latestCellImbalance(signals []telemetryapi.Signal) (types.ComponentHealth, bool) {
+	maxVoltages := make(map[time.Time]float64)
+	minVoltages := make(map[time.Time]float64)
+	for _, signal := range signals {
+		value, ok := signal.Value.(float64)
+		if !ok {
+			continue
+		}
+		switch signal.Name {
+		case telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax:
+			maxVoltages[signal.Timestamp] = value
+		case telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin:
+			minVoltages[signal.Timestamp] = value
+		default:
+			continue
+		}
+
+		maxVoltage, hasMax := maxVoltages[signal.Timestamp]
+		minVoltage, hasMin := minVoltages[signal.Timestamp]
+		if !hasMax || !hasMin {
+			continue
+		}
+		imbalance := (maxVoltage - minVoltage) * 1000
+		return types.ComponentHealth{
+			Component: types.ComponentTractionBatteryCellImbalance,
+			Status:    statusAbove(imbalance, cellImbalanceWarningHigh, cellImbalanceCriticalHigh),
+			Value:     imbalance,
+			Unit:      "mV",
+			Timestamp: signal.Timestamp,
+			Signals: []string{
+				telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax,
+				telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin,
+			},
+		}, true
+	}
+	return types.ComponentHealth{}, false
+}
+
+// statusBelow rates a reading that is unhealthy when it is too low.
+func statusBelow(value, warning, critical float64) string {
+	switch {
+	case value < critical:
+		return types.ComponentStatusCritical
+	case value < warning:
+		return types.ComponentStatusWarning
+	}
+	return types.ComponentStatusOK
+}
+
+// statusAbove rates a reading that is unhealthy when it is too high.
+func statusAbove(value, warning, critical float64) string {
+	switch {
+	case value > critical:
+		return types.ComponentStatusCritical
+	case value > warning:
+		return types.ComponentStatusWarning
+	}
+	return types.ComponentStatusOK
+}
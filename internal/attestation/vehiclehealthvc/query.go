@@ -0,0 +1,145 @@
+package vehiclehealthvc
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxTimeRange is the longest time range a health attestation can cover.
+	MaxTimeRange = 366 * 24 * time.Hour
+	// healthQueryChunk is the longest time range requested from the telemetry API in one query.
+	healthQueryChunk = 7 * 24 * time.Hour
+	// defaultHealthQueryConcurrency is the number of chunks queried at once when not configured.
+	defaultHealthQueryConcurrency = 4
+)
+
+// healthInterval returns the aggregation interval for a time range, coarser for longer ranges so a
+// year of data stays at a few thousand samples per signal. Every interval divides healthQueryChunk.
+func healthInterval(timeRange time.Duration) string {
+	switch {
+	case timeRange <= 7*24*time.Hour:
+		return "5m"
+	case timeRange <= 31*24*time.Hour:
+		return "15m"
+	case timeRange <= 92*24*time.Hour:
+		return "1h"
+	default:
+		return "4h"
+	}
+}
+
+// chunkTimeRange splits a time range into consecutive ranges of at most size.
+func chunkTimeRange(start, end time.Time, size time.Duration) []types.TimeRange {
+	var chunks []types.TimeRange
+	for chunkStart := start; chunkStart.Before(end); chunkStart = chunkStart.Add(size) {
+		chunkEnd := chunkStart.Add(size)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		chunks = append(chunks, types.TimeRange{Start: chunkStart, End: chunkEnd})
+	}
+	if len(chunks) == 0 {
+		chunks = append(chunks, types.TimeRange{Start: start, End: end})
+	}
+	return chunks
+}
+
+// queryHealthSignals queries the health signals of the vehicle in chunks, at most s.queryConcurrency at a time,
+// and returns the merged signals sorted newest first together with the aggregation interval used.
+func (s *Service) queryHealthSignals(ctx context.Context, vehicleDID *cloudevent.ERC721DID, signalNames []string, startTime, endTime time.Time, jwtToken string) ([]telemetryapi.Signal, string, error) {
+	interval := healthInterval(endTime.Sub(startTime))
+	chunks := chunkTimeRange(startTime, endTime, healthQueryChunk)
+	results := make([][]telemetryapi.Signal, len(chunks))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.queryConcurrency)
+	for i, chunk := range chunks {
+		group.Go(func() error {
+			options := telemetryapi.TelemetryHistoricalOptions{
+				TokenID:   vehicleDID.TokenID,
+				StartDate: chunk.Start,
+				EndDate:   chunk.End,
+				Interval:  interval,
+				Signals:   signalNames,
+			}
+			signals, err := s.telemetryAPI.GetHistoricalDataWithAuth(groupCtx, options, jwtToken)
+			if err != nil {
+				return err
+			}
+			results[i] = signals
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, "", err
+	}
+
+	return mergeSignals(results), interval, nil
+}
+
+// mergeSignals merges the signals of all chunks sorted newest first, dropping samples that
+// appear in two chunks because they fall on a chunk boundary.
+func mergeSignals(chunks [][]telemetryapi.Signal) []telemetryapi.Signal {
+	type sampleKey struct {
+		name      string
+		timestamp time.Time
+	}
+	merged := slices.Concat(chunks...)
+	slices.SortStableFunc(merged, func(i, j telemetryapi.Signal) int {
+		// sort in descending order
+		if c := -i.Timestamp.Compare(j.Timestamp); c != 0 {
+			return c
+		}
+		return strings.Compare(i.Name, j.Name)
+	})
+
+	seen := make(map[sampleKey]struct{}, len(merged))
+	return slices.DeleteFunc(merged, func(signal telemetryapi.Signal) bool {
+		key := sampleKey{name: signal.Name, timestamp: signal.Timestamp.UTC()}
+		if _, ok := seen[key]; ok {
+			return true
+		}
+		seen[key] = struct{}{}
+		return false
+	})
+}
+
+// signalStatistics summarizes each numeric signal over the whole time range, ordered by signal name.
+func signalStatistics(signals []telemetryapi.Signal) []types.SignalStatistics {
+	stats := make(map[string]*types.SignalStatistics)
+	sums := make(map[string]float64)
+	for _, signal := range signals {
+		value, ok := signal.Value.(float64)
+		if !ok {
+			continue
+		}
+		stat, ok := stats[signal.Name]
+		if !ok {
+			stat = &types.SignalStatistics{Signal: signal.Name, Min: math.Inf(1), Max: math.Inf(-1)}
+			stats[signal.Name] = stat
+		}
+		stat.Min = min(stat.Min, value)
+		stat.Max = max(stat.Max, value)
+		stat.Count++
+		sums[signal.Name] += value
+	}
+
+	statistics := make([]types.SignalStatistics, 0, len(stats))
+	for name, stat := range stats {
+		stat.Avg = sums[name] / float64(stat.Count)
+		statistics = append(statistics, *stat)
+	}
+	slices.SortFunc(statistics, func(a, b types.SignalStatistics) int {
+		return strings.Compare(a.Signal, b.Signal)
+	})
+	return statistics
+}
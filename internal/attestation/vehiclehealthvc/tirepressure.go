@@ -0,0 +1,138 @@
+package vehiclehealthvc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+)
+
+const (
+	// defaultPlacardTirePressure is the placard pressure (in kPa) assumed when nothing is known about the vehicle.
+	defaultPlacardTirePressure = 240.0 // ~35 psi
+	// tirePressureTolerance is how far, as a fraction of the placard pressure, a tire may deviate and still be normal.
+	tirePressureTolerance = 0.15
+)
+
+// placard holds the recommended cold tire pressures (in kPa) of each axle.
+type placard struct {
+	Front float64 `json:"front"`
+	Rear  float64 `json:"rear"`
+}
+
+// parsePlacardTable parses the configured fallback table of placards keyed by definition ID prefix.
+func parsePlacardTable(raw string) (map[string]placard, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var table map[string]placard
+	if err := json.Unmarshal([]byte(raw), &table); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tire pressure fallback table: %w", err)
+	}
+	for prefix, p := range table {
+		if p.Front <= 0 || p.Rear <= 0 {
+			return nil, fmt.Errorf("tire pressure fallback for %q must have positive front and rear pressures", prefix)
+		}
+	}
+	return table, nil
+}
+
+// tirePressureThresholds resolves the placard pressures of the vehicle, preferring its device definition
+// attributes, then the longest matching fallback table prefix, then the generic default.
+func (s *Service) tirePressureThresholds(vehicleInfo *models.VehicleInfo) *types.TirePressureThresholds {
+	p, source := placardFromDefinition(vehicleInfo.DefinitionAttributes), types.TireThresholdSourceDeviceDefinition
+	if p == nil {
+		p, source = s.fallbackPlacard(vehicleInfo.NameSlug), types.TireThresholdSourceFallback
+	}
+	if p == nil {
+		p, source = &placard{Front: defaultPlacardTirePressure, Rear: defaultPlacardTirePressure}, types.TireThresholdSourceDefault
+	}
+
+	return &types.TirePressureThresholds{
+		Source:       source,
+		FrontPlacard: p.Front,
+		FrontMin:     p.Front * (1 - tirePressureTolerance),
+		FrontMax:     p.Front * (1 + tirePressureTolerance),
+		RearPlacard:  p.Rear,
+		RearMin:      p.Rear * (1 - tirePressureTolerance),
+		RearMax:      p.Rear * (1 + tirePressureTolerance),
+	}
+}
+
+// placardFromDefinition reads the placard pressures from device definition attributes.
+// A definition that only lists one axle uses it for both.
+func placardFromDefinition(attributes map[string]string) *placard {
+	front := parsePressureAttribute(attributes[models.DefinitionAttributeFrontTirePressure])
+	rear := parsePressureAttribute(attributes[models.DefinitionAttributeRearTirePressure])
+	switch {
+	case front == 0 && rear == 0:
+		return nil
+	case front == 0:
+		front = rear
+	case rear == 0:
+		rear = front
+	}
+	return &placard{Front: front, Rear: rear}
+}
+
+func parsePressureAttribute(value string) float64 {
+	pressure, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || pressure <= 0 {
+		return 0
+	}
+	return pressure
+}
+
+// fallbackPlacard returns the fallback table entry with the longest prefix of the definition ID.
+func (s *Service) fallbackPlacard(definitionID string) *placard {
+	var best *placard
+	bestLen := -1
+	for prefix, p := range s.tirePressureFallback {
+		if strings.HasPrefix(definitionID, prefix) && len(prefix) > bestLen {
+			best = &p
+			bestLen = len(prefix)
+		}
+	}
+	return best
+}
+
+// evaluateTirePressure flags each tire against its axle's normal range.
+func evaluateTirePressure(tp *types.TirePressureStatus, thresholds *types.TirePressureThresholds) {
+	tp.Thresholds = thresholds
+	tp.LowTires = nil
+	tp.HighTires = nil
+
+	for _, tire := range tirePositions(tp) {
+		switch {
+		case tire.pressure == nil:
+		case *tire.pressure < tire.min:
+			tp.LowTires = append(tp.LowTires, tire.position)
+		case *tire.pressure > tire.max:
+			tp.HighTires = append(tp.HighTires, tire.position)
+		}
+	}
+	tp.IsNormal = len(tp.LowTires) == 0 && len(tp.HighTires) == 0
+}
+
+type tireReading struct {
+	position string
+	pressure *float64
+	min      float64
+	max      float64
+}
+
+func tirePositions(tp *types.TirePressureStatus) []tireReading {
+	var th types.TirePressureThresholds
+	if tp.Thresholds != nil {
+		th = *tp.Thresholds
+	}
+	return []tireReading{
+		{position: types.TirePositionFrontLeft, pressure: tp.FrontLeft, min: th.FrontMin, max: th.FrontMax},
+		{position: types.TirePositionFrontRight, pressure: tp.FrontRight, min: th.FrontMin, max: th.FrontMax},
+		{position: types.TirePositionRearLeft, pressure: tp.RearLeft, min: th.RearMin, max: th.RearMax},
+		{position: types.TirePositionRearRight, pressure: tp.RearRight, min: th.RearMin, max: th.RearMax},
+	}
+}
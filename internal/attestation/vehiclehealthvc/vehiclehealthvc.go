@@ -13,23 +13,32 @@ import (
 
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/dtc"
 	"github.com/DIMO-Network/attestation-api/internal/erc191"
+	"github.com/DIMO-Network/attestation-api/internal/healthscore"
 	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/segmentio/ksuid"
 )
 
+// TelemetryPermissions are the vehicle permissions needed to read the telemetry of a VehicleHealthVC.
+var TelemetryPermissions = []string{tokenclaims.PermissionGetNonLocationHistory, tokenclaims.PermissionGetLocationHistory}
+
 const (
-	// Tire pressure thresholds (in kPa)
-	minNormalTirePressure   = 206.84 // 30 psi
-	maxNormalTirePressure   = 275.79 // 40 psi
 	defaultTirePressureUnit = "kPa"
 )
 
+// HealthOptions are the caller selectable options of a health attestation.
+type HealthOptions struct {
+	// RuleSet is the name of the health rule set used to score the vehicle, the default rule set when empty.
+	RuleSet string
+}
+
 // Service handles VehicleHealthVC-related operations.
 type Service struct {
 	vcRepo                 VCRepo
@@ -40,6 +49,10 @@ type Service struct {
 	privateKey             *ecdsa.PrivateKey
 	dataVersion            string
 	devLicense             common.Address
+	dtcDictionary          *dtc.Dictionary
+	tirePressureFallback   map[string]placard
+	healthRules            *healthscore.Engine
+	queryConcurrency       int
 }
 
 // NewService creates a new Service for VehicleHealthVC operations.
@@ -49,7 +62,19 @@ func NewService(
 	telemetryAPI TelemetryAPI,
 	settings *config.Settings,
 	privateKey *ecdsa.PrivateKey,
-) *Service {
+) (*Service, error) {
+	tirePressureFallback, err := parsePlacardTable(settings.TirePressureFallback)
+	if err != nil {
+		return nil, err
+	}
+	healthRules, err := healthscore.Load(settings.HealthRulesFile)
+	if err != nil {
+		return nil, err
+	}
+	queryConcurrency := settings.HealthQueryConcurrency
+	if queryConcurrency <= 0 {
+		queryConcurrency = defaultHealthQueryConcurrency
+	}
 	return &Service{
 		vcRepo:                 vcRepo,
 		identityAPI:            identityAPI,
@@ -59,39 +84,48 @@ func NewService(
 		privateKey:             privateKey,
 		dataVersion:            "vehiclehealth/v1.0.0", // You may want to add this to settings
 		devLicense:             common.HexToAddress(settings.DevLicense),
-	}
+		dtcDictionary:          dtc.Default(),
+		tirePressureFallback:   tirePressureFallback,
+		healthRules:            healthRules,
+		queryConcurrency:       queryConcurrency,
+	}, nil
 }
 
 // CreateVehicleHealthVC creates a VehicleHealthVC for a specific time range.
-// Note: The time range validation (max 30 days) is performed at the HTTP handler level.
-func (s *Service) CreateVehicleHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, jwtToken string) error {
+// Ranges longer than a week are queried in chunks at a coarser interval, up to MaxTimeRange.
+func (s *Service) CreateVehicleHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, opts HealthOptions, jwtToken string) (*cloudevent.RawEvent, error) {
+	if endTime.Before(startTime) || endTime.Sub(startTime) > MaxTimeRange {
+		return nil, richerrors.Error{
+			Err:         fmt.Errorf("invalid health time range %s - %s", startTime, endTime),
+			ExternalMsg: "Time range must be ordered and cannot exceed 366 days",
+			Code:        http.StatusBadRequest,
+		}
+	}
+
+	ruleSet, err := s.healthRules.RuleSet(opts.RuleSet)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Unknown health rule set", Code: http.StatusBadRequest}
+	}
+
 	vehicleDID := cloudevent.ERC721DID{
 		ChainID:         s.chainID,
 		TokenID:         big.NewInt(int64(tokenID)),
 		ContractAddress: s.vehicleContractAddress,
 	}
 
-	// Get vehicle information to determine producer
+	// Get vehicle information to resolve the data sources
 	vehicleInfo, err := s.identityAPI.GetVehicleInfo(ctx, vehicleDID)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
 	}
 
-	healthStatus, err := s.analyzeVehicleHealth(ctx, &vehicleDID, startTime, endTime, jwtToken)
+	healthStatus, signals, err := s.analyzeVehicleHealth(ctx, &vehicleDID, vehicleInfo, ruleSet, startTime, endTime, jwtToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Determine producer from paired devices (prefer aftermarket, then synthetic)
-	producer := ""
-	for _, device := range vehicleInfo.PairedDevices {
-		if device.Type == models.DeviceTypeAftermarket {
-			producer = device.DID.String()
-			break
-		} else if device.Type == models.DeviceTypeSynthetic && producer == "" {
-			producer = device.DID.String()
-		}
-	}
+	// Signals are sorted newest first, so the first source is the most recent producer
+	dataSources := vehicleInfo.DataSources(signals)
 
 	subject := types.VehicleHealthVCSubject{
 		VehicleDID:   vehicleDID,
@@ -100,65 +134,63 @@ func (s *Service) CreateVehicleHealthVC(ctx context.Context, tokenID uint32, sta
 			Start: startTime,
 			End:   endTime,
 		},
-		Producer: producer,
+		Producer:             dataSources[0].Producer,
+		DataSources:          dataSources,
+		DTCDictionaryVersion: s.dtcDictionary.Version,
 	}
 
 	vc, err := s.createAttestation(subject)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to create VehicleHealthVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create VehicleHealthVC", Code: http.StatusInternalServerError}
 	}
 
 	if err = s.vcRepo.UploadAttestation(ctx, vc); err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to store VehicleHealthVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to store VehicleHealthVC", Code: http.StatusInternalServerError}
 	}
 
-	return nil
+	return vc, nil
 }
 
 // analyzeVehicleHealth analyzes vehicle health data within the time range using telemetry API.
-func (s *Service) analyzeVehicleHealth(ctx context.Context, vehicleDID *cloudevent.ERC721DID, startTime, endTime time.Time, jwtToken string) (*types.VehicleHealthStatus, error) {
+// The analyzed signals are returned sorted newest first.
+func (s *Service) analyzeVehicleHealth(ctx context.Context, vehicleDID *cloudevent.ERC721DID, vehicleInfo *models.VehicleInfo, ruleSet *healthscore.RuleSet, startTime, endTime time.Time, jwtToken string) (*types.VehicleHealthStatus, []telemetryapi.Signal, error) {
 	// Query telemetry data for health-related signals
-	options := telemetryapi.TelemetryHistoricalOptions{
-		TokenID:   vehicleDID.TokenID,
-		StartDate: startTime,
-		EndDate:   endTime,
-		Interval:  "5m",
-		Signals: []string{
-			vss.FieldOBDDTCList,
-			vss.FieldOBDStatusDTCCount,
-			vss.FieldChassisAxleRow1WheelLeftTirePressure,
-			vss.FieldChassisAxleRow1WheelRightTirePressure,
-			vss.FieldChassisAxleRow2WheelLeftTirePressure,
-			vss.FieldChassisAxleRow2WheelRightTirePressure,
-		},
+	signalNames := []string{
+		vss.FieldOBDDTCList,
+		vss.FieldOBDStatusDTCCount,
+		vss.FieldChassisAxleRow1WheelLeftTirePressure,
+		vss.FieldChassisAxleRow1WheelRightTirePressure,
+		vss.FieldChassisAxleRow2WheelLeftTirePressure,
+		vss.FieldChassisAxleRow2WheelRightTirePressure,
 	}
+	signalNames = append(signalNames, componentSignals...)
 
 	// Get health data from telemetry API
-	signals, err := s.telemetryAPI.GetHistoricalDataWithAuth(ctx, options, jwtToken)
+	signals, interval, err := s.queryHealthSignals(ctx, vehicleDID, signalNames, startTime, endTime, jwtToken)
 	if err != nil {
-		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get health telemetry data", Code: http.StatusInternalServerError}
+		return nil, nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get health telemetry data", Code: http.StatusInternalServerError}
 	}
 
 	if len(signals) == 0 {
-		return nil, richerrors.Error{Err: err, ExternalMsg: "No health data found in the specified time range", Code: http.StatusNotFound}
+		return nil, nil, richerrors.Error{Err: err, ExternalMsg: "No health data found in the specified time range", Code: http.StatusNotFound}
 	}
-	slices.SortFunc(signals, func(i, j telemetryapi.Signal) int {
-		// sort in descending order
-		return -i.Timestamp.Compare(j.Timestamp)
-	})
 
 	// Process records to extract health information
 	healthStatus := processHealthTelemetrySignals(signals)
+	healthStatus.SampleInterval = interval
+	s.describeDTCs(healthStatus.DTCs, vehicleInfo.NameSlug)
+	if healthStatus.TirePressure != nil {
+		evaluateTirePressure(healthStatus.TirePressure, s.tirePressureThresholds(vehicleInfo))
+	}
 
 	// Calculate health score and overall health status
-	s.calculateHealthScore(healthStatus)
+	ruleSet.Score(healthStatus)
 
-	return healthStatus, nil
+	return healthStatus, signals, nil
 }
 
-// processHealthTelemetrySignals processes telemetry signals to extract health status.
+// processHealthTelemetrySignals processes telemetry signals, sorted newest first, to extract health status.
 func processHealthTelemetrySignals(signals []telemetryapi.Signal) *types.VehicleHealthStatus {
-	dtcMap := make(map[string]types.DiagnosticTroubleCode)
 	var latestTirePressure *types.TirePressureStatus
 	var lastUpdated time.Time
 
@@ -168,79 +200,124 @@ func processHealthTelemetrySignals(signals []telemetryapi.Signal) *types.Vehicle
 			lastUpdated = signal.Timestamp
 		}
 
-		// Extract DTCs
-		dtcs := extractDTCsFromTelemetry(signal)
-		for _, dtc := range dtcs {
-			// Store unique DTCs by code
-			if _, exists := dtcMap[dtc.Code]; !exists {
-				dtcMap[dtc.Code] = dtc
-			}
-		}
-
 		// Extract tire pressure
 		latestTirePressure = updateTirePressureFromTelemetry(signal, latestTirePressure)
 	}
 
-	// Convert DTCs map to slice
-	dtcs := make([]types.DiagnosticTroubleCode, 0, len(dtcMap))
-	for _, dtc := range dtcMap {
-		dtcs = append(dtcs, dtc)
+	return &types.VehicleHealthStatus{
+		DTCs:         trackDTCLifecycles(signals),
+		TirePressure: latestTirePressure,
+		Components:   evaluateComponents(signals),
+		Statistics:   signalStatistics(signals),
+		LastUpdated:  lastUpdated,
 	}
+}
 
-	// Check if all pressures are normal
-	if latestTirePressure != nil {
-		latestTirePressure.IsNormal = isTirePressureNormal(latestTirePressure)
+// trackDTCLifecycles follows each DTC through the DTC samples, sorted newest first, and returns one entry per code.
+// A code is cleared once a later sample of the same signal no longer reports it, and becomes active again,
+// counting another occurrence, if it is reported after that.
+func trackDTCLifecycles(signals []telemetryapi.Signal) []types.DiagnosticTroubleCode {
+	type lifecycle struct {
+		dtc        types.DiagnosticTroubleCode
+		signalName string
 	}
+	lifecycles := make(map[string]*lifecycle)
 
-	return &types.VehicleHealthStatus{
-		DTCs:         dtcs,
-		TirePressure: latestTirePressure,
-		LastUpdated:  lastUpdated,
+	for i := len(signals) - 1; i >= 0; i-- {
+		signal := signals[i]
+		reported, ok := extractDTCsFromTelemetry(signal)
+		if !ok {
+			continue
+		}
+
+		reportedCodes := make(map[string]struct{}, len(reported))
+		for _, dtc := range reported {
+			reportedCodes[dtc.Code] = struct{}{}
+			tracked, exists := lifecycles[dtc.Code]
+			if !exists {
+				dtc.FirstSeen = signal.Timestamp
+				tracked = &lifecycle{dtc: dtc, signalName: signal.Name}
+				lifecycles[dtc.Code] = tracked
+			}
+			if tracked.dtc.Status != types.DTCStatusActive {
+				tracked.dtc.Occurrences++
+				tracked.dtc.Status = types.DTCStatusActive
+				tracked.dtc.ClearedAt = nil
+			}
+			tracked.dtc.LastSeen = signal.Timestamp
+			tracked.dtc.Timestamp = signal.Timestamp
+		}
+
+		for code, tracked := range lifecycles {
+			if _, stillReported := reportedCodes[code]; stillReported || tracked.signalName != signal.Name || tracked.dtc.Status != types.DTCStatusActive {
+				continue
+			}
+			clearedAt := signal.Timestamp
+			tracked.dtc.Status = types.DTCStatusCleared
+			tracked.dtc.ClearedAt = &clearedAt
+		}
 	}
+
+	dtcs := make([]types.DiagnosticTroubleCode, 0, len(lifecycles))
+	for _, tracked := range lifecycles {
+		dtcs = append(dtcs, tracked.dtc)
+	}
+	slices.SortFunc(dtcs, func(a, b types.DiagnosticTroubleCode) int {
+		if c := a.FirstSeen.Compare(b.FirstSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+	return dtcs
 }
 
 // extractDTCsFromTelemetry extracts diagnostic trouble codes from a telemetry record.
-func extractDTCsFromTelemetry(signal telemetryapi.Signal) []types.DiagnosticTroubleCode {
-	var dtcs []types.DiagnosticTroubleCode
-
+// ok is false when the record is not a DTC sample; a sample reporting no codes returns ok with no DTCs.
+func extractDTCsFromTelemetry(signal telemetryapi.Signal) (dtcs []types.DiagnosticTroubleCode, ok bool) {
 	switch signal.Name {
 	case vss.FieldOBDDTCList:
 		dtcValue, ok := signal.Value.(string)
-		if !ok || dtcValue == "" {
-			return dtcs
+		if !ok {
+			return nil, false
+		}
+		if dtcValue == "" {
+			return nil, true
 		}
 		var codes []string
 		if err := json.Unmarshal([]byte(dtcValue), &codes); err != nil {
-			return dtcs
+			return nil, false
 		}
 		for _, code := range codes {
 			code = strings.TrimSpace(code)
 			if code != "" && code != "0" && code != "00000" {
 				dtc := types.DiagnosticTroubleCode{
-					Code:      code,
-					Severity:  categorizeDTCSeverity(code),
+					Code:      strings.ToUpper(code),
 					Timestamp: signal.Timestamp,
 				}
 				dtcs = append(dtcs, dtc)
 			}
 		}
+		return dtcs, true
 
 	// Check for DTC count to infer MIL status
 	case vss.FieldOBDStatusDTCCount:
 		dtcCount, ok := signal.Value.(float64)
-		if !ok || dtcCount <= 0 {
-			return dtcs
+		if !ok {
+			return nil, false
+		}
+		if dtcCount <= 0 {
+			return nil, true
 		}
 		dtc := types.DiagnosticTroubleCode{
-			Code:        "MIL_ON",
+			Code:        types.DTCCodeMILOn,
 			Description: "Check Engine Light is ON (DTC count > 0)",
 			Severity:    "warning",
 			Timestamp:   signal.Timestamp,
 		}
-		dtcs = append(dtcs, dtc)
+		return []types.DiagnosticTroubleCode{dtc}, true
 	}
 
-	return dtcs
+	return nil, false
 }
 
 // updateTirePressureFromTelemetry updates tire pressure data from a telemetry record.
@@ -302,72 +379,18 @@ func updateTirePressureFromTelemetry(signal telemetryapi.Signal, tirePressure *t
 	return tirePressure
 }
 
-// categorizeDTCSeverity categorizes DTC severity based on the code.
-func categorizeDTCSeverity(code string) string {
-	// P0XXX codes are powertrain
-	// P1XXX codes are manufacturer specific
-	// C0XXX codes are chassis
-	// B0XXX codes are body
-	// U0XXX codes are network
-
-	if strings.HasPrefix(code, "P0") {
-		// Common critical codes
-		if strings.HasPrefix(code, "P01") || strings.HasPrefix(code, "P02") {
-			return "critical" // Fuel and air metering
-		}
-		if strings.HasPrefix(code, "P03") || strings.HasPrefix(code, "P04") {
-			return "warning" // Ignition system or auxiliary emission
+// describeDTCs fills in the dictionary description, system, severity and recommended action of reported DTCs.
+func (s *Service) describeDTCs(dtcs []types.DiagnosticTroubleCode, definitionID string) {
+	for i := range dtcs {
+		if dtcs[i].Code == types.DTCCodeMILOn {
+			continue
 		}
+		entry := s.dtcDictionary.Lookup(dtcs[i].Code, definitionID)
+		dtcs[i].Description = entry.Description
+		dtcs[i].System = entry.System
+		dtcs[i].Severity = entry.Severity
+		dtcs[i].RecommendedAction = entry.RecommendedAction
 	}
-
-	if strings.HasPrefix(code, "U") {
-		return "warning" // Network communication codes
-	}
-
-	return "info" // Default to info
-}
-
-// isTirePressureNormal checks if all tire pressures are within normal range.
-func isTirePressureNormal(tp *types.TirePressureStatus) bool {
-	pressures := []*float64{tp.FrontLeft, tp.FrontRight, tp.RearLeft, tp.RearRight}
-
-	for _, pressure := range pressures {
-		if pressure != nil && (*pressure < minNormalTirePressure || *pressure > maxNormalTirePressure) {
-			return false
-		}
-	}
-
-	return true
-}
-
-// calculateHealthScore calculates the overall health score.
-func (s *Service) calculateHealthScore(status *types.VehicleHealthStatus) {
-	score := 100
-
-	// Deduct points for DTCs based on severity
-	for _, dtc := range status.DTCs {
-		switch dtc.Severity {
-		case "critical":
-			score -= 30
-		case "warning":
-			score -= 15
-		case "info":
-			score -= 5
-		}
-	}
-
-	// Deduct points for abnormal tire pressure
-	if status.TirePressure != nil && !status.TirePressure.IsNormal {
-		score -= 20
-	}
-
-	// Ensure score doesn't go below 0
-	if score < 0 {
-		score = 0
-	}
-
-	status.HealthScore = score
-	status.IsHealthy = score >= 70 // Consider healthy if score is 70 or above
 }
 
 // createAttestation creates the attestation cloud event.
@@ -9,12 +9,14 @@ import (
 	"encoding/json"
 	"math/big"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/dtc"
 	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
@@ -37,18 +39,23 @@ func setupTestService(t *testing.T) (*vehiclehealthvc.Service, *MockVCRepo, *Moc
 		VehicleNFTAddress:   "0x1234567890123456789012345678901234567890",
 		DIMORegistryChainID: 137,
 		DevLicense:          "0x49eAf63eD94FEf3d40692862Eee2C8dB416B1a5f",
+		TirePressureFallback: `{
+			"ford": {"front": 241, "rear": 241},
+			"ford_f-250": {"front": 414, "rear": 552}
+		}`,
 	}
 
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
 
-	service := vehiclehealthvc.NewService(
+	service, err := vehiclehealthvc.NewService(
 		mockVCRepo,
 		mockIdentityAPI,
 		mockTelemetryAPI,
 		settings,
 		privateKey,
 	)
+	require.NoError(t, err)
 
 	return service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl
 }
@@ -61,6 +68,18 @@ func TestNewService(t *testing.T) {
 	assert.NotNil(t, service)
 }
 
+func TestNewService_MissingHealthRulesFile(t *testing.T) {
+	_, err := vehiclehealthvc.NewService(nil, nil, nil, &config.Settings{HealthRulesFile: "/does/not/exist.json"}, nil)
+	assert.Error(t, err)
+}
+
+func TestNewService_InvalidTirePressureFallback(t *testing.T) {
+	for _, fallback := range []string{`{`, `{"ford": {"front": 0, "rear": 241}}`} {
+		_, err := vehiclehealthvc.NewService(nil, nil, nil, &config.Settings{TirePressureFallback: fallback}, nil)
+		assert.Error(t, err, fallback)
+	}
+}
+
 func TestCreateVehicleHealthVC_Success(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -224,7 +243,7 @@ func TestCreateVehicleHealthVC_Success(t *testing.T) {
 				})
 
 			// Execute
-			err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, jwtToken)
+			_, err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
 
 			// Assert
 			assert.NoError(t, err)
@@ -258,6 +277,544 @@ func TestCreateVehicleHealthVC_Success(t *testing.T) {
 	}
 }
 
+func TestCreateVehicleHealthVC_DataSources(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	aftermarketDID := cloudevent.ERC721DID{TokenID: big.NewInt(456), ChainID: 137, ContractAddress: common.HexToAddress("0xabcd")}
+	syntheticDID := cloudevent.ERC721DID{TokenID: big.NewInt(789), ChainID: 137, ContractAddress: common.HexToAddress("0xef01")}
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{
+			PairedDevices: []models.PairedDevice{
+				{DID: aftermarketDID, Type: models.DeviceTypeAftermarket, ManufacturerName: "AutoPi"},
+				{DID: syntheticDID, Type: models.DeviceTypeSynthetic},
+			},
+		}, nil)
+
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{
+				Name:      vss.FieldChassisAxleRow1WheelLeftTirePressure,
+				Value:     240.0,
+				Timestamp: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+				Source:    "0xsynthetic",
+				Producer:  syntheticDID.String(),
+			},
+			{
+				Name:      vss.FieldChassisAxleRow1WheelLeftTirePressure,
+				Value:     241.0,
+				Timestamp: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+				Source:    "0xaftermarket",
+				Producer:  aftermarketDID.String(),
+			},
+			{
+				Name:      vss.FieldChassisAxleRow1WheelLeftTirePressure,
+				Value:     242.0,
+				Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+				Source:    "0xaftermarket",
+				Producer:  aftermarketDID.String(),
+			},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehicleHealthVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+	assert.Equal(t, aftermarketDID.String(), subjectData.Producer)
+	assert.Equal(t, []types.DataSource{
+		{
+			Source:           "0xaftermarket",
+			Producer:         aftermarketDID.String(),
+			DeviceType:       string(models.DeviceTypeAftermarket),
+			ManufacturerName: "AutoPi",
+		},
+		{
+			Source:     "0xsynthetic",
+			Producer:   syntheticDID.String(),
+			DeviceType: string(models.DeviceTypeSynthetic),
+		},
+	}, subjectData.DataSources)
+}
+
+func TestCreateVehicleHealthVC_DTCDictionary(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{NameSlug: "ford_f-150_2021"}, nil)
+
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{
+				Name:      vss.FieldOBDDTCList,
+				Value:     `["P0420", "P1000"]`,
+				Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehicleHealthVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+	assert.Equal(t, dtc.Default().Version, subjectData.DTCDictionaryVersion)
+
+	dtcs := make(map[string]types.DiagnosticTroubleCode)
+	for _, code := range subjectData.HealthStatus.DTCs {
+		dtcs[code.Code] = code
+	}
+	require.Len(t, dtcs, 2)
+	assert.Equal(t, "Catalyst System Efficiency Below Threshold (Bank 1)", dtcs["P0420"].Description)
+	assert.Equal(t, "Powertrain - Auxiliary Emission Controls", dtcs["P0420"].System)
+	assert.Equal(t, dtc.SeverityWarning, dtcs["P0420"].Severity)
+	assert.NotEmpty(t, dtcs["P0420"].RecommendedAction)
+	// P1000 is described by the manufacturer override for the vehicle's make
+	assert.Equal(t, "OBD-II Monitor Testing Not Complete", dtcs["P1000"].Description)
+	assert.Equal(t, dtc.SeverityInfo, dtcs["P1000"].Severity)
+}
+
+func TestCreateVehicleHealthVC_DTCLifecycle(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{}, nil)
+
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{Name: vss.FieldOBDDTCList, Value: `["P0420", "P0171"]`, Timestamp: at(8)},
+			{Name: vss.FieldOBDDTCList, Value: `["P0420"]`, Timestamp: at(9)},
+			{Name: vss.FieldOBDDTCList, Value: `[]`, Timestamp: at(10)},
+			{Name: vss.FieldOBDDTCList, Value: `["P0420"]`, Timestamp: at(12)},
+			{Name: vss.FieldOBDDTCList, Value: `["P0420"]`, Timestamp: at(13)},
+			{Name: vss.FieldOBDStatusDTCCount, Value: 1.0, Timestamp: at(8)},
+			{Name: vss.FieldOBDStatusDTCCount, Value: 0.0, Timestamp: at(11)},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehicleHealthVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+
+	dtcs := make(map[string]types.DiagnosticTroubleCode)
+	for _, code := range subjectData.HealthStatus.DTCs {
+		dtcs[code.Code] = code
+	}
+	require.Len(t, dtcs, 3)
+
+	// P0420 cleared at 10:00 and recurred at 12:00
+	assert.Equal(t, types.DTCStatusActive, dtcs["P0420"].Status)
+	assert.Equal(t, 2, dtcs["P0420"].Occurrences)
+	assert.Equal(t, at(8), dtcs["P0420"].FirstSeen)
+	assert.Equal(t, at(13), dtcs["P0420"].LastSeen)
+	assert.Nil(t, dtcs["P0420"].ClearedAt)
+
+	// P0171 was no longer reported from 09:00
+	assert.Equal(t, types.DTCStatusCleared, dtcs["P0171"].Status)
+	assert.Equal(t, 1, dtcs["P0171"].Occurrences)
+	assert.Equal(t, at(8), dtcs["P0171"].LastSeen)
+	require.NotNil(t, dtcs["P0171"].ClearedAt)
+	assert.Equal(t, at(9), *dtcs["P0171"].ClearedAt)
+
+	// The check engine light went off with the DTC count
+	assert.Equal(t, types.DTCStatusCleared, dtcs["MIL_ON"].Status)
+	require.NotNil(t, dtcs["MIL_ON"].ClearedAt)
+	assert.Equal(t, at(11), *dtcs["MIL_ON"].ClearedAt)
+
+	// Only the active P0420 warning affects the score
+	assert.Equal(t, 85, subjectData.HealthStatus.HealthScore)
+}
+
+func TestCreateVehicleHealthVC_TirePressureThresholds(t *testing.T) {
+	tests := []struct {
+		name               string
+		vehicleInfo        *models.VehicleInfo
+		frontPressure      float64
+		rearPressure       float64
+		expectedSource     string
+		expectedLow        []string
+		expectedHigh       []string
+		expectedTireNormal bool
+		expectedScore      int
+	}{
+		{
+			name: "placard from device definition",
+			vehicleInfo: &models.VehicleInfo{
+				NameSlug: "porsche_911_2022",
+				DefinitionAttributes: map[string]string{
+					models.DefinitionAttributeFrontTirePressure: "250",
+					models.DefinitionAttributeRearTirePressure:  "300",
+				},
+			},
+			frontPressure:      250,
+			rearPressure:       300,
+			expectedSource:     types.TireThresholdSourceDeviceDefinition,
+			expectedTireNormal: true,
+			expectedScore:      100,
+		},
+		{
+			name:               "longest fallback prefix evaluates axles separately",
+			vehicleInfo:        &models.VehicleInfo{NameSlug: "ford_f-250_2021"},
+			frontPressure:      414,
+			rearPressure:       414,
+			expectedSource:     types.TireThresholdSourceFallback,
+			expectedLow:        []string{types.TirePositionRearLeft, types.TirePositionRearRight},
+			expectedTireNormal: false,
+			expectedScore:      70, // 2 x (5 + 10) for rear tires 25% below placard
+		},
+		{
+			name:               "default placard",
+			vehicleInfo:        &models.VehicleInfo{NameSlug: "toyota_camry_2020"},
+			frontPressure:      320,
+			rearPressure:       240,
+			expectedSource:     types.TireThresholdSourceDefault,
+			expectedHigh:       []string{types.TirePositionFrontLeft, types.TirePositionFrontRight},
+			expectedTireNormal: false,
+			expectedScore:      60, // 2 x 20, capped for front tires 33% above placard
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+
+			startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			jwtToken := "test-jwt-token"
+			timestamp := time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+
+			mockIdentityAPI.EXPECT().
+				GetVehicleInfo(gomock.Any(), gomock.Any()).
+				Return(tt.vehicleInfo, nil)
+			mockTelemetryAPI.EXPECT().
+				GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+				Return([]telemetryapi.Signal{
+					{Name: vss.FieldChassisAxleRow1WheelLeftTirePressure, Value: tt.frontPressure, Timestamp: timestamp},
+					{Name: vss.FieldChassisAxleRow1WheelRightTirePressure, Value: tt.frontPressure, Timestamp: timestamp},
+					{Name: vss.FieldChassisAxleRow2WheelLeftTirePressure, Value: tt.rearPressure, Timestamp: timestamp},
+					{Name: vss.FieldChassisAxleRow2WheelRightTirePressure, Value: tt.rearPressure, Timestamp: timestamp},
+				}, nil)
+
+			var uploadedAttestation *cloudevent.RawEvent
+			mockVCRepo.EXPECT().
+				UploadAttestation(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+					uploadedAttestation = attestation
+					return nil
+				})
+
+			_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+			require.NoError(t, err)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+			var subjectData types.VehicleHealthVCSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+
+			tirePressure := subjectData.HealthStatus.TirePressure
+			require.NotNil(t, tirePressure)
+			require.NotNil(t, tirePressure.Thresholds)
+			assert.Equal(t, tt.expectedSource, tirePressure.Thresholds.Source)
+			assert.Equal(t, tt.expectedLow, tirePressure.LowTires)
+			assert.Equal(t, tt.expectedHigh, tirePressure.HighTires)
+			assert.Equal(t, tt.expectedTireNormal, tirePressure.IsNormal)
+			assert.Equal(t, tt.expectedScore, subjectData.HealthStatus.HealthScore)
+		})
+	}
+}
+
+func TestCreateVehicleHealthVC_Components(t *testing.T) {
+	older := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+	newest := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		signals            []telemetryapi.Signal
+		expectedComponents []types.ComponentHealth
+		expectedScore      int
+	}{
+		{
+			name: "combustion vehicle",
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 12.6, Timestamp: older},
+				{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 11.5, Timestamp: newer},
+				{Name: vss.FieldPowertrainCombustionEngineECT, Value: 115.0, Timestamp: older},
+				{Name: vss.FieldPowertrainCombustionEngineECT, Value: 90.0, Timestamp: newer},
+				{Name: vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel, Value: 60.0, Timestamp: newer},
+				{Name: telemetryapi.FieldChassisAxleRow1WheelLeftBrakePadWear, Value: 30.0, Timestamp: newer},
+				{Name: telemetryapi.FieldChassisAxleRow2WheelRightBrakePadWear, Value: 80.0, Timestamp: older},
+			},
+			expectedComponents: []types.ComponentHealth{
+				{Component: types.ComponentLowVoltageBattery, Status: types.ComponentStatusCritical, Value: 11.5, Unit: "V", Timestamp: newer, Signals: []string{vss.FieldLowVoltageBatteryCurrentVoltage}},
+				{Component: types.ComponentCoolant, Status: types.ComponentStatusWarning, Value: 115, Unit: "celsius", Timestamp: older, Signals: []string{vss.FieldPowertrainCombustionEngineECT}},
+				{Component: types.ComponentEngineOil, Status: types.ComponentStatusOK, Value: 60, Unit: "percent", Timestamp: newer, Signals: []string{vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel}},
+				{Component: types.ComponentBrakePads, Status: types.ComponentStatusWarning, Value: 80, Unit: "percent", Timestamp: older, Signals: []string{telemetryapi.FieldChassisAxleRow2WheelRightBrakePadWear}},
+			},
+			expectedScore: 60, // 20 for the battery, 10 each for coolant and brake pads
+		},
+		{
+			name: "electric vehicle pairs cell voltages of the same interval",
+			signals: []telemetryapi.Signal{
+				{Name: vss.FieldPowertrainTractionBatteryStateOfHealth, Value: 75.0, Timestamp: newer},
+				{Name: telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax, Value: 4.125, Timestamp: newer},
+				{Name: telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin, Value: 4.0, Timestamp: newer},
+				{Name: telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax, Value: 4.0, Timestamp: newest},
+			},
+			expectedComponents: []types.ComponentHealth{
+				{Component: types.ComponentTractionBatteryStateOfHealth, Status: types.ComponentStatusWarning, Value: 75, Unit: "percent", Timestamp: newer, Signals: []string{vss.FieldPowertrainTractionBatteryStateOfHealth}},
+				{
+					Component: types.ComponentTractionBatteryCellImbalance, Status: types.ComponentStatusCritical, Value: 125, Unit: "mV", Timestamp: newer,
+					Signals: []string{telemetryapi.FieldPowertrainTractionBatteryCellVoltageMax, telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin},
+				},
+			},
+			expectedScore: 70, // 10 for the state of health, 20 for the cell imbalance
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+
+			startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			jwtToken := "test-jwt-token"
+
+			mockIdentityAPI.EXPECT().
+				GetVehicleInfo(gomock.Any(), gomock.Any()).
+				Return(&models.VehicleInfo{}, nil)
+			mockTelemetryAPI.EXPECT().
+				GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+				DoAndReturn(func(ctx context.Context, options telemetryapi.TelemetryHistoricalOptions, jwt string) ([]telemetryapi.Signal, error) {
+					assert.Contains(t, options.Signals, vss.FieldLowVoltageBatteryCurrentVoltage)
+					assert.Contains(t, options.Signals, telemetryapi.FieldPowertrainTractionBatteryCellVoltageMin)
+					return tt.signals, nil
+				})
+
+			var uploadedAttestation *cloudevent.RawEvent
+			mockVCRepo.EXPECT().
+				UploadAttestation(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+					uploadedAttestation = attestation
+					return nil
+				})
+
+			_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+			require.NoError(t, err)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+			var subjectData types.VehicleHealthVCSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+
+			assert.Equal(t, tt.expectedComponents, subjectData.HealthStatus.Components)
+			assert.Equal(t, tt.expectedScore, subjectData.HealthStatus.HealthScore)
+		})
+	}
+}
+
+func TestCreateVehicleHealthVC_RuleSet(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{}, nil)
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{Name: vss.FieldOBDDTCList, Value: `["P0420"]`, Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			{Name: vss.FieldOBDStatusDTCCount, Value: 1.0, Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	opts := vehiclehealthvc.HealthOptions{RuleSet: "resale"}
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, opts, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehicleHealthVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+	assert.Equal(t, "resale", subjectData.HealthStatus.RuleSet)
+	assert.Equal(t, "resale/v1.1.0", subjectData.HealthStatus.RuleSetVersion)
+	// The check engine light is not counted on top of the code behind it
+	assert.Equal(t, 85, subjectData.HealthStatus.HealthScore)
+	assert.Equal(t, []types.HealthDeduction{{Rule: "dtc-warning", Points: 15}}, subjectData.HealthStatus.Deductions)
+	assert.True(t, subjectData.HealthStatus.IsHealthy)
+}
+
+func TestCreateVehicleHealthVC_UnknownRuleSet(t *testing.T) {
+	service, _, _, _, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	opts := vehiclehealthvc.HealthOptions{RuleSet: "missing"}
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, opts, "test-jwt-token")
+
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	assert.Equal(t, http.StatusBadRequest, richErr.Code)
+}
+
+func TestCreateVehicleHealthVC_LongTimeRange(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+	boundary := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{}, nil)
+
+	var mu sync.Mutex
+	var chunks []types.TimeRange
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Times(5).
+		DoAndReturn(func(ctx context.Context, options telemetryapi.TelemetryHistoricalOptions, jwt string) ([]telemetryapi.Signal, error) {
+			assert.Equal(t, "15m", options.Interval)
+			mu.Lock()
+			chunks = append(chunks, types.TimeRange{Start: options.StartDate, End: options.EndDate})
+			mu.Unlock()
+
+			switch options.StartDate {
+			case startTime:
+				return []telemetryapi.Signal{
+					{Name: vss.FieldOBDDTCList, Value: `["P0420"]`, Timestamp: startTime.Add(time.Hour)},
+					{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 12.0, Timestamp: startTime.Add(time.Hour)},
+					{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 12.4, Timestamp: boundary},
+				}, nil
+			case boundary:
+				return []telemetryapi.Signal{
+					{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 12.4, Timestamp: boundary},
+					{Name: vss.FieldOBDDTCList, Value: `[]`, Timestamp: boundary.Add(time.Hour)},
+					{Name: vss.FieldLowVoltageBatteryCurrentVoltage, Value: 12.8, Timestamp: boundary.Add(time.Hour)},
+				}, nil
+			}
+			return nil, nil
+		})
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []types.TimeRange{
+		{Start: startTime, End: boundary},
+		{Start: boundary, End: boundary.Add(7 * 24 * time.Hour)},
+		{Start: boundary.Add(7 * 24 * time.Hour), End: boundary.Add(14 * 24 * time.Hour)},
+		{Start: boundary.Add(14 * 24 * time.Hour), End: boundary.Add(21 * 24 * time.Hour)},
+		{Start: boundary.Add(21 * 24 * time.Hour), End: endTime},
+	}, chunks)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehicleHealthVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+
+	status := subjectData.HealthStatus
+	assert.Equal(t, "15m", status.SampleInterval)
+	require.Len(t, status.DTCs, 1)
+	assert.Equal(t, types.DTCStatusCleared, status.DTCs[0].Status)
+	require.NotNil(t, status.DTCs[0].ClearedAt)
+	assert.Equal(t, boundary.Add(time.Hour), *status.DTCs[0].ClearedAt)
+
+	// The boundary sample returned by both chunks is counted once
+	assert.Equal(t, []types.SignalStatistics{
+		{Signal: vss.FieldLowVoltageBatteryCurrentVoltage, Min: 12.0, Max: 12.8, Avg: 12.4, Count: 3},
+	}, status.Statistics)
+}
+
+func TestCreateVehicleHealthVC_TimeRangeTooLong(t *testing.T) {
+	service, _, _, _, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(vehiclehealthvc.MaxTimeRange + time.Hour)
+
+	_, err := service.CreateVehicleHealthVC(context.Background(), uint32(123), startTime, endTime, vehiclehealthvc.HealthOptions{}, "test-jwt-token")
+
+	var richErr richerrors.Error
+	require.ErrorAs(t, err, &richErr)
+	assert.Equal(t, http.StatusBadRequest, richErr.Code)
+}
+
 func TestCreateVehicleHealthVC_TelemetryAPIError(t *testing.T) {
 	service, _, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
 	defer ctrl.Finish()
@@ -285,7 +842,7 @@ func TestCreateVehicleHealthVC_TelemetryAPIError(t *testing.T) {
 		Return(nil, assert.AnError)
 
 	// Execute
-	err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, jwtToken)
+	_, err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -321,7 +878,7 @@ func TestCreateVehicleHealthVC_NoHealthData(t *testing.T) {
 		Return([]telemetryapi.Signal{}, nil)
 
 	// Execute
-	err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, jwtToken)
+	_, err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -369,7 +926,7 @@ func TestCreateVehicleHealthVC_VCRepoError(t *testing.T) {
 		Return(assert.AnError)
 
 	// Execute
-	err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, jwtToken)
+	_, err := service.CreateVehicleHealthVC(context.Background(), tokenID, startTime, endTime, vehiclehealthvc.HealthOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -12,21 +12,30 @@ import (
 	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
 	"github.com/DIMO-Network/attestation-api/internal/config"
 	"github.com/DIMO-Network/attestation-api/internal/erc191"
-	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/segmentio/ksuid"
 	"github.com/uber/h3-go/v4"
 )
 
+// TelemetryPermissions are the vehicle permissions needed to read the telemetry of a VehiclePositionVC.
+var TelemetryPermissions = []string{tokenclaims.PermissionGetLocationHistory}
+
 const (
-	// h3Resolution resolution for h3 hex 8 ~= 0.737327598 km2
-	h3Resolution = 8
+	// MaxH3Resolution is the finest H3 resolution of attested positions, hex 8 ~= 0.737327598 km2.
+	MaxH3Resolution = 8
 )
 
+// PositionOptions controls the precision of the attested position.
+type PositionOptions struct {
+	// H3Resolution is the H3 resolution of the attested cell, from 1 to MaxH3Resolution. Defaults to MaxH3Resolution.
+	H3Resolution int
+}
+
 // Service handles VehiclePositionVC-related operations.
 type Service struct {
 	vcRepo                 VCRepo
@@ -59,57 +68,69 @@ func NewService(
 	}
 }
 
-// CreateVehiclePositionVC creates a VehiclePositionVC for a specific timestamp.
-func (s *Service) CreateVehiclePositionVC(ctx context.Context, tokenID uint32, requestedTimestamp time.Time, jwtToken string) error {
+// CreateVehiclePositionVC creates a VehiclePositionVC for a specific timestamp and returns the stored attestation.
+func (s *Service) CreateVehiclePositionVC(ctx context.Context, tokenID uint32, requestedTimestamp time.Time, opts PositionOptions, jwtToken string) (*cloudevent.RawEvent, error) {
+	resolution := opts.H3Resolution
+	if resolution == 0 {
+		resolution = MaxH3Resolution
+	}
+	if resolution < 1 || resolution > MaxH3Resolution {
+		return nil, richerrors.Error{
+			Err:         fmt.Errorf("invalid H3 resolution %d", opts.H3Resolution),
+			ExternalMsg: fmt.Sprintf("H3 resolution must be between 1 and %d", MaxH3Resolution),
+			Code:        http.StatusBadRequest,
+		}
+	}
+
 	vehicleDID := cloudevent.ERC721DID{
 		ChainID:         s.chainID,
 		TokenID:         big.NewInt(int64(tokenID)),
 		ContractAddress: s.vehicleContractAddress,
 	}
 
-	// Get vehicle information to determine producer
+	// Get vehicle information to resolve the data source
 	vehicleInfo, err := s.identityAPI.GetVehicleInfo(ctx, vehicleDID)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
 	}
 
-	location, err := s.findClosestLocation(ctx, vehicleDID, requestedTimestamp, jwtToken)
+	location, err := s.findClosestLocation(ctx, vehicleDID, requestedTimestamp, resolution, jwtToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Determine producer from paired devices (prefer aftermarket, then synthetic)
-	producer := ""
-	for _, device := range vehicleInfo.PairedDevices {
-		if device.Type == models.DeviceTypeAftermarket {
-			producer = device.DID.String()
-			break
-		} else if device.Type == models.DeviceTypeSynthetic && producer == "" {
-			producer = device.DID.String()
-		}
-	}
+	// Record the integration that actually produced the location
+	dataSource := vehicleInfo.DataSource(location.source, location.producer)
 
 	subject := types.VehiclePositionVCSubject{
 		VehicleDID:         vehicleDID.String(),
-		Location:           *location,
+		Location:           location.Location,
 		RequestedTimestamp: requestedTimestamp,
-		Producer:           producer,
+		Producer:           dataSource.Producer,
+		DataSource:         &dataSource,
 	}
 
 	vc, err := s.createAttestation(subject)
 	if err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to create VehiclePositionVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to create VehiclePositionVC", Code: http.StatusInternalServerError}
 	}
 
 	if err = s.vcRepo.UploadAttestation(ctx, vc); err != nil {
-		return richerrors.Error{Err: err, ExternalMsg: "Failed to store VehiclePositionVC", Code: http.StatusInternalServerError}
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to store VehiclePositionVC", Code: http.StatusInternalServerError}
 	}
 
-	return nil
+	return vc, nil
+}
+
+// sourcedLocation is a location together with the source and producer that reported it.
+type sourcedLocation struct {
+	types.Location
+	source   string
+	producer string
 }
 
 // findClosestLocation finds the location closest to the requested timestamp using telemetry API.
-func (s *Service) findClosestLocation(ctx context.Context, vehicleInfo cloudevent.ERC721DID, requestedTime time.Time, jwtToken string) (*types.Location, error) {
+func (s *Service) findClosestLocation(ctx context.Context, vehicleInfo cloudevent.ERC721DID, requestedTime time.Time, resolution int, jwtToken string) (*sourcedLocation, error) {
 	// Define time window around requested timestamp (1 hour before and after)
 	startTime := requestedTime.Add(-time.Hour)
 	endTime := requestedTime.Add(time.Hour)
@@ -129,10 +150,10 @@ func (s *Service) findClosestLocation(ctx context.Context, vehicleInfo cloudeven
 	}
 
 	// Find the location closest to requested timestamp
-	var closestLocation *types.Location
+	var closestLocation *sourcedLocation
 	var closestTimeDiff time.Duration
 
-	h3Locations := signalsToH3Values(signals)
+	h3Locations := signalsToH3Values(signals, resolution)
 
 	for _, h3Location := range h3Locations {
 		timeDiff := absTimeDiff(h3Location.Timestamp, requestedTime)
@@ -195,8 +216,8 @@ func (s *Service) createAttestation(subject types.VehiclePositionVCSubject) (*cl
 	return &cloudEvent, nil
 }
 
-func signalsToH3Values(signals []telemetryapi.Signal) []types.Location {
-	h3Locations := make([]types.Location, 0, len(signals))
+func signalsToH3Values(signals []telemetryapi.Signal, resolution int) []sourcedLocation {
+	h3Locations := make([]sourcedLocation, 0, len(signals))
 	for _, signal := range signals {
 		if signal.Name != vss.FieldCurrentLocationCoordinates && signal.Name != "currentLocationApproximateCoordinates" {
 			continue
@@ -206,14 +227,18 @@ func signalsToH3Values(signals []telemetryapi.Signal) []types.Location {
 			// TODO(elffjs): Doesn't feel good to be silent here.
 			continue
 		}
-		cell, err := h3.LatLngToCell(h3.NewLatLng(loc.Latitude, loc.Longitude), h3Resolution)
+		cell, err := h3.LatLngToCell(h3.NewLatLng(loc.Latitude, loc.Longitude), resolution)
 		if err != nil {
 			continue
 		}
-		h3Locations = append(h3Locations, types.Location{
-			LocationType:  types.LocationTypeH3Cell,
-			LocationValue: types.H3Cell{CellID: cell.String()},
-			Timestamp:     signal.Timestamp,
+		h3Locations = append(h3Locations, sourcedLocation{
+			Location: types.Location{
+				LocationType:  types.LocationTypeH3Cell,
+				LocationValue: types.H3Cell{CellID: cell.String()},
+				Timestamp:     signal.Timestamp,
+			},
+			source:   signal.Source,
+			producer: signal.Producer,
 		})
 	}
 	return h3Locations
@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
 	"go.uber.org/mock/gomock"
 )
 
@@ -155,11 +156,12 @@ func TestCreateVehiclePositionVC_Success(t *testing.T) {
 				})
 
 			// Execute
-			err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, jwtToken)
+			vc, err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
 
 			// Assert
 			assert.NoError(t, err)
 			assert.NotNil(t, uploadedAttestation)
+			assert.Equal(t, uploadedAttestation, vc)
 
 			// Verify cloud event structure
 			assert.Equal(t, "1.0", uploadedAttestation.SpecVersion)
@@ -197,6 +199,121 @@ func TestCreateVehiclePositionVC_Success(t *testing.T) {
 	}
 }
 
+func TestCreateVehiclePositionVC_H3Resolution(t *testing.T) {
+	tests := []struct {
+		name               string
+		resolution         int
+		expectedResolution int
+		expectedCode       int
+	}{
+		{name: "default", resolution: 0, expectedResolution: vehiclepositionvc.MaxH3Resolution},
+		{name: "coarser", resolution: 5, expectedResolution: 5},
+		{name: "finer than allowed", resolution: 9, expectedCode: http.StatusBadRequest},
+		{name: "negative", resolution: -1, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+			defer ctrl.Finish()
+			requestedTimestamp := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+			if tt.expectedCode == 0 {
+				mockIdentityAPI.EXPECT().GetVehicleInfo(gomock.Any(), gomock.Any()).Return(&models.VehicleInfo{}, nil)
+				mockTelemetryAPI.EXPECT().GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), gomock.Any()).Return([]telemetryapi.Signal{
+					{
+						Name:      vss.FieldCurrentLocationCoordinates,
+						Value:     telemetryapi.Location{Latitude: 37.7749, Longitude: -122.4194},
+						Timestamp: requestedTimestamp,
+					},
+				}, nil)
+				mockVCRepo.EXPECT().UploadAttestation(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			vc, err := service.CreateVehiclePositionVC(context.Background(), 123, requestedTimestamp, vehiclepositionvc.PositionOptions{H3Resolution: tt.resolution}, "test-jwt-token")
+			if tt.expectedCode != 0 {
+				var richErr richerrors.Error
+				require.ErrorAs(t, err, &richErr)
+				require.Equal(t, tt.expectedCode, richErr.Code)
+				return
+			}
+			require.NoError(t, err)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(vc.Data, &credential))
+			var subject types.VehiclePositionVCSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subject))
+			cellValue, ok := subject.Location.LocationValue.(types.H3Cell)
+			require.True(t, ok)
+			cell := h3.Cell(h3.IndexFromString(cellValue.CellID))
+			require.True(t, cell.IsValid())
+			require.Equal(t, tt.expectedResolution, cell.Resolution())
+		})
+	}
+}
+
+func TestCreateVehiclePositionVC_DataSource(t *testing.T) {
+	service, mockVCRepo, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
+	defer ctrl.Finish()
+
+	aftermarketDID := cloudevent.ERC721DID{TokenID: big.NewInt(456), ChainID: 137, ContractAddress: common.HexToAddress("0xabcd")}
+	syntheticDID := cloudevent.ERC721DID{TokenID: big.NewInt(789), ChainID: 137, ContractAddress: common.HexToAddress("0xef01")}
+	requestedTimestamp := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	jwtToken := "test-jwt-token"
+
+	mockIdentityAPI.EXPECT().
+		GetVehicleInfo(gomock.Any(), gomock.Any()).
+		Return(&models.VehicleInfo{
+			PairedDevices: []models.PairedDevice{
+				{DID: aftermarketDID, Type: models.DeviceTypeAftermarket, ManufacturerName: "AutoPi"},
+				{DID: syntheticDID, Type: models.DeviceTypeSynthetic},
+			},
+		}, nil)
+
+	// The closest location comes from the synthetic device even though an aftermarket device is paired
+	mockTelemetryAPI.EXPECT().
+		GetHistoricalDataWithAuth(gomock.Any(), gomock.Any(), jwtToken).
+		Return([]telemetryapi.Signal{
+			{
+				Name:      vss.FieldCurrentLocationCoordinates,
+				Value:     telemetryapi.Location{Latitude: 37.7749, Longitude: -122.4194},
+				Timestamp: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+				Source:    "0xaftermarket",
+				Producer:  aftermarketDID.String(),
+			},
+			{
+				Name:      vss.FieldCurrentLocationCoordinates,
+				Value:     telemetryapi.Location{Latitude: 37.7849, Longitude: -122.4094},
+				Timestamp: time.Date(2024, 1, 15, 11, 55, 0, 0, time.UTC),
+				Source:    "0xsynthetic",
+				Producer:  syntheticDID.String(),
+			},
+		}, nil)
+
+	var uploadedAttestation *cloudevent.RawEvent
+	mockVCRepo.EXPECT().
+		UploadAttestation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+			uploadedAttestation = attestation
+			return nil
+		})
+
+	_, err := service.CreateVehiclePositionVC(context.Background(), uint32(123), requestedTimestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
+	require.NoError(t, err)
+
+	var credential types.Credential
+	require.NoError(t, json.Unmarshal(uploadedAttestation.Data, &credential))
+	var subjectData types.VehiclePositionVCSubject
+	require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subjectData))
+	assert.Equal(t, syntheticDID.String(), subjectData.Producer)
+	assert.Equal(t, syntheticDID.String(), uploadedAttestation.Producer)
+	assert.Equal(t, &types.DataSource{
+		Source:     "0xsynthetic",
+		Producer:   syntheticDID.String(),
+		DeviceType: string(models.DeviceTypeSynthetic),
+	}, subjectData.DataSource)
+}
+
 func TestCreateVehiclePositionVC_TelemetryAPIError(t *testing.T) {
 	service, _, mockIdentityAPI, mockTelemetryAPI, ctrl := setupTestService(t)
 	defer ctrl.Finish()
@@ -223,7 +340,7 @@ func TestCreateVehiclePositionVC_TelemetryAPIError(t *testing.T) {
 		Return(nil, assert.AnError)
 
 	// Execute
-	err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, jwtToken)
+	_, err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -258,7 +375,7 @@ func TestCreateVehiclePositionVC_NoLocationData(t *testing.T) {
 		Return([]telemetryapi.Signal{}, nil)
 
 	// Execute
-	err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, jwtToken)
+	_, err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
@@ -305,7 +422,7 @@ func TestCreateVehiclePositionVC_VCRepoError(t *testing.T) {
 		Return(assert.AnError)
 
 	// Execute
-	err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, jwtToken)
+	_, err := service.CreateVehiclePositionVC(context.Background(), tokenID, requestedTimestamp, vehiclepositionvc.PositionOptions{}, jwtToken)
 
 	// Assert
 	assert.Error(t, err)
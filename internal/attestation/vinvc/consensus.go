@@ -0,0 +1,158 @@
+package vinvc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+)
+
+const (
+	defaultConsensusWindowDays  = 30
+	defaultConsensusMinMessages = 3
+	defaultConsensusMinDays     = 2
+	// fingerprintsPerDevice is the most fingerprint messages fetched for each paired device.
+	fingerprintsPerDevice = 100
+)
+
+// consensusPolicy decides when the fingerprint messages agree enough on a VIN to attest it.
+type consensusPolicy struct {
+	// window is how far back fingerprint messages are considered.
+	window time.Duration
+	// minMessages is how many messages must report the VIN.
+	minMessages int
+	// minSpan is how far apart the first and last report of a VIN suffice when there are fewer than minMessages.
+	minSpan time.Duration
+	// acceptUnsigned counts unsigned messages of devices that never signed a message.
+	acceptUnsigned bool
+}
+
+func newConsensusPolicy(settings *config.Settings) consensusPolicy {
+	windowDays := settings.VINConsensusWindowDays
+	if windowDays <= 0 {
+		windowDays = defaultConsensusWindowDays
+	}
+	minMessages := settings.VINConsensusMinMessages
+	if minMessages <= 0 {
+		minMessages = defaultConsensusMinMessages
+	}
+	minDays := settings.VINConsensusMinDays
+	if minDays <= 0 {
+		minDays = defaultConsensusMinDays
+	}
+	return consensusPolicy{
+		window:         time.Duration(windowDays) * 24 * time.Hour,
+		minMessages:    minMessages,
+		minSpan:        time.Duration(minDays) * 24 * time.Hour,
+		acceptUnsigned: settings.AcceptUnsignedFingerprints,
+	}
+}
+
+// vinConsensus is the VIN selected from the fingerprint messages and the evidence behind it.
+type vinConsensus struct {
+	// latest is the newest fingerprint message reporting the selected VIN.
+	latest          models.DecodedFingerprintData
+	evidenceCount   int
+	sourcesAgree    bool
+	conflictingVINs []string
+}
+
+type vinEvidence struct {
+	vin         string
+	count       int
+	first, last time.Time
+	latest      models.DecodedFingerprintData
+}
+
+// trustedFingerprints returns the fingerprint messages of a device that count towards the consensus. Unsigned
+// messages are left out, since anyone able to publish messages for the device could otherwise choose its VIN.
+// When unsigned messages are accepted, they only count for devices that never signed a message.
+func (c consensusPolicy) trustedFingerprints(fingerprints []models.DecodedFingerprintData) []models.DecodedFingerprintData {
+	signed := slices.ContainsFunc(fingerprints, func(fingerprint models.DecodedFingerprintData) bool {
+		return fingerprint.SignatureStatus == types.SignatureStatusVerified
+	})
+	if c.acceptUnsigned && !signed {
+		return fingerprints
+	}
+	return slices.DeleteFunc(slices.Clone(fingerprints), func(fingerprint models.DecodedFingerprintData) bool {
+		return fingerprint.SignatureStatus == types.SignatureStatusUnsigned
+	})
+}
+
+// preferFingerprint reports whether the candidate message should replace the current one as the evidence of a VIN.
+func preferFingerprint(candidate, current models.DecodedFingerprintData) bool {
+	candidateUnsigned := candidate.SignatureStatus == types.SignatureStatusUnsigned
+	currentUnsigned := current.SignatureStatus == types.SignatureStatusUnsigned
+	if candidateUnsigned != currentUnsigned {
+		return currentUnsigned
+	}
+	return candidate.Time.After(current.Time)
+}
+
+// selectVIN selects the VIN reported by the most fingerprint messages, the most recently reported VIN on ties,
+// and fails unless it was reported by enough messages or over a long enough period.
+func (c consensusPolicy) selectVIN(fingerprints []models.DecodedFingerprintData) (*vinConsensus, error) {
+	byVIN := make(map[string]*vinEvidence)
+	for _, fingerprint := range fingerprints {
+		evidence, ok := byVIN[fingerprint.VIN]
+		if !ok {
+			evidence = &vinEvidence{vin: fingerprint.VIN, first: fingerprint.Time, last: fingerprint.Time, latest: fingerprint}
+			byVIN[fingerprint.VIN] = evidence
+		}
+		evidence.count++
+		if fingerprint.Time.Before(evidence.first) {
+			evidence.first = fingerprint.Time
+		}
+		if fingerprint.Time.After(evidence.last) {
+			evidence.last = fingerprint.Time
+		}
+		if preferFingerprint(fingerprint, evidence.latest) {
+			evidence.latest = fingerprint
+		}
+	}
+	if len(byVIN) == 0 {
+		return nil, richerrors.Error{Err: errors.New("no fingerprint messages"), ExternalMsg: "No fingerprint message found", Code: http.StatusBadRequest}
+	}
+
+	candidates := make([]*vinEvidence, 0, len(byVIN))
+	for _, evidence := range byVIN {
+		candidates = append(candidates, evidence)
+	}
+	slices.SortFunc(candidates, func(a, b *vinEvidence) int {
+		if a.count != b.count {
+			return b.count - a.count
+		}
+		if c := b.last.Compare(a.last); c != 0 {
+			return c
+		}
+		return strings.Compare(a.vin, b.vin)
+	})
+
+	selected := candidates[0]
+	if selected.count < c.minMessages && selected.last.Sub(selected.first) < c.minSpan {
+		return nil, richerrors.Error{
+			Err: fmt.Errorf("VIN %s reported by %d fingerprint messages over %s, need %d messages or %s",
+				selected.vin, selected.count, selected.last.Sub(selected.first), c.minMessages, c.minSpan),
+			ExternalMsg: "Not enough consistent fingerprint messages to attest the VIN",
+			Code:        http.StatusBadRequest,
+		}
+	}
+
+	consensus := &vinConsensus{
+		latest:        selected.latest,
+		evidenceCount: selected.count,
+		sourcesAgree:  len(candidates) == 1,
+	}
+	for _, other := range candidates[1:] {
+		consensus.conflictingVINs = append(consensus.conflictingVINs, other.vin)
+	}
+	slices.Sort(consensus.conflictingVINs)
+	return consensus, nil
+}
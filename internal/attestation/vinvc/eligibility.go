@@ -0,0 +1,224 @@
+package vinvc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/vindecoder"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reason codes of the VIN eligibility decision.
+const (
+	ReasonEligible              = "eligible"
+	ReasonNoPairedDevices       = "noPairedDevices"
+	ReasonNoFingerprints        = "noFingerprints"
+	ReasonInvalidFingerprints   = "invalidFingerprints"
+	ReasonUnsignedFingerprints  = "unsignedFingerprints"
+	ReasonFingerprintFetchError = "fingerprintFetchError"
+	ReasonInsufficientEvidence  = "insufficientEvidence"
+)
+
+// Outcomes of the fingerprint check of a paired device.
+const (
+	DeviceStatusOK                 = "ok"
+	DeviceStatusNoFingerprints     = "noFingerprints"
+	DeviceStatusInvalidFingerprint = "invalidFingerprint"
+	DeviceStatusUnsigned           = "unsigned"
+	DeviceStatusFetchError         = "fetchError"
+)
+
+// EligibilityReport explains whether a VIN attestation can be created for a vehicle and why.
+type EligibilityReport struct {
+	VehicleDID string `json:"vehicleDid"`
+	// Eligible is true when a VIN attestation would be created.
+	Eligible bool `json:"eligible"`
+	// Reason is the reason code of the decision.
+	Reason string `json:"reason" enums:"eligible,noPairedDevices,noFingerprints,invalidFingerprints,unsignedFingerprints,fingerprintFetchError,insufficientEvidence"`
+	// Message describes the decision.
+	Message string `json:"message"`
+	// VIN is the VIN that would be attested.
+	VIN string `json:"vin,omitempty"`
+	// EvidenceCount is the number of fingerprint messages reporting the selected VIN.
+	EvidenceCount int `json:"evidenceCount,omitempty"`
+	// ConflictingVINs are the other VINs reported by the fingerprint messages.
+	ConflictingVINs []string `json:"conflictingVins,omitempty"`
+	// Devices are the paired devices of the vehicle.
+	Devices []DeviceEligibility `json:"devices"`
+}
+
+// DeviceEligibility is the outcome of the fingerprint check of a paired device.
+type DeviceEligibility struct {
+	DID              string `json:"did"`
+	Type             string `json:"type"`
+	ManufacturerName string `json:"manufacturerName,omitempty"`
+	// Status is the outcome of the fingerprint check.
+	Status string `json:"status" enums:"ok,noFingerprints,invalidFingerprint,unsigned,fetchError"`
+	// Error describes why the fingerprint messages of the device could not be used.
+	Error string `json:"error,omitempty"`
+	// FingerprintCount is the number of valid fingerprint messages within the consensus window.
+	FingerprintCount int `json:"fingerprintCount"`
+	// LatestFingerprintAt is the time of the latest valid fingerprint message.
+	LatestFingerprintAt *time.Time `json:"latestFingerprintAt,omitempty"`
+	// VIN is the VIN of the latest valid fingerprint message.
+	VIN string `json:"vin,omitempty"`
+	// VINValid is true when the VIN passes validation as a 17 character VIN or a Japanese chassis number,
+	// including the check digit of North American VINs.
+	VINValid bool `json:"vinValid"`
+	// SignatureStatus is the signature verification outcome of the latest valid fingerprint message.
+	SignatureStatus string `json:"signatureStatus,omitempty"`
+	// VINModelYear is the model year decoded from the VIN.
+	VINModelYear int `json:"vinModelYear,omitempty"`
+	// DefinitionMatch reports whether the VIN model year matches the vehicle definition, unset when either is unknown.
+	DefinitionMatch *bool `json:"definitionMatch,omitempty"`
+	// VINManufacturer is the manufacturer decoded from the world manufacturer identifier of the VIN.
+	VINManufacturer string `json:"vinManufacturer,omitempty"`
+	// ManufacturerMatch reports whether the VIN manufacturer matches the vehicle record, unset when either is unknown.
+	ManufacturerMatch *bool `json:"manufacturerMatch,omitempty"`
+	// CountryCode is the ISO 3166-1 alpha-3 code of the country of manufacture decoded from the VIN.
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+// deviceFingerprints are the fingerprint messages of a paired device, or the error fetching them.
+type deviceFingerprints struct {
+	device       models.PairedDevice
+	fingerprints []models.DecodedFingerprintData
+	err          error
+}
+
+// CheckVINEligibility runs the VIN attestation checks for the vehicle without creating an attestation
+// and reports the outcome of each step.
+func (v *Service) CheckVINEligibility(ctx context.Context, tokenID uint32) (*EligibilityReport, error) {
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         v.chainID,
+		ContractAddress: common.HexToAddress(v.vehicleNFTAddress),
+		TokenID:         big.NewInt(int64(tokenID)),
+	}
+	vehicleInfo, err := v.identityAPI.GetVehicleInfo(ctx, vehicleDID)
+	if err != nil {
+		return nil, richerrors.Error{Err: err, ExternalMsg: "Failed to get vehicle info", Code: http.StatusInternalServerError}
+	}
+
+	report := &EligibilityReport{
+		VehicleDID: vehicleDID.String(),
+		Devices:    []DeviceEligibility{},
+	}
+	if len(vehicleInfo.PairedDevices) == 0 {
+		report.Reason = ReasonNoPairedDevices
+		report.Message = "No paired devices"
+		return report, nil
+	}
+
+	definitionYear := models.ModelYearFromSlug(vehicleInfo.NameSlug)
+	var fingerprints []models.DecodedFingerprintData
+	var invalid, unsigned, fetchFailed bool
+	for _, result := range v.fetchFingerprints(ctx, vehicleInfo) {
+		device := DeviceEligibility{
+			DID:              result.device.DID.String(),
+			Type:             string(result.device.Type),
+			ManufacturerName: result.device.ManufacturerName,
+			FingerprintCount: len(result.fingerprints),
+		}
+		switch {
+		case result.err != nil:
+			device.Status = DeviceStatusFetchError
+			device.Error = "Failed to get fingerprint messages"
+			var richErr richerrors.Error
+			if errors.As(result.err, &richErr) {
+				device.Error = richErr.ExternalMsg
+				if richErr.Code == http.StatusBadRequest {
+					device.Status = DeviceStatusInvalidFingerprint
+				}
+			}
+			invalid = invalid || device.Status == DeviceStatusInvalidFingerprint
+			fetchFailed = fetchFailed || device.Status == DeviceStatusFetchError
+		case len(result.fingerprints) == 0:
+			device.Status = DeviceStatusNoFingerprints
+		default:
+			device.Status = DeviceStatusOK
+			latest := result.fingerprints[0]
+			for _, fingerprint := range result.fingerprints[1:] {
+				if fingerprint.Time.After(latest.Time) {
+					latest = fingerprint
+				}
+			}
+			device.LatestFingerprintAt = &latest.Time
+			device.VIN = latest.VIN
+			device.SignatureStatus = latest.SignatureStatus
+			if decoded, err := vindecoder.Default().Decode(latest.VIN); err == nil {
+				device.VINValid = true
+				device.VINModelYear = decoded.ModelYear
+				device.DefinitionMatch = matchModelYear(decoded.ModelYear, definitionYear)
+				device.VINManufacturer = decoded.Manufacturer.Name
+				device.ManufacturerMatch = decoded.MatchesManufacturer(vehicleInfo.ManufacturerName)
+				device.CountryCode = decoded.CountryCode
+			}
+		}
+		trusted := v.consensus.trustedFingerprints(result.fingerprints)
+		if len(trusted) == 0 && device.Status == DeviceStatusOK {
+			device.Status = DeviceStatusUnsigned
+			device.Error = "Fingerprint messages are not signed by the paired device"
+			unsigned = true
+		}
+		report.Devices = append(report.Devices, device)
+		fingerprints = append(fingerprints, trusted...)
+	}
+
+	if len(fingerprints) == 0 {
+		switch {
+		case fetchFailed:
+			report.Reason = ReasonFingerprintFetchError
+			report.Message = "Failed to get fingerprint messages"
+		case unsigned:
+			report.Reason = ReasonUnsignedFingerprints
+			report.Message = "Fingerprint messages are not signed by the paired devices"
+		case invalid:
+			report.Reason = ReasonInvalidFingerprints
+			report.Message = "No fingerprint message with a valid VIN found"
+		default:
+			report.Reason = ReasonNoFingerprints
+			report.Message = "No fingerprint message found"
+		}
+		return report, nil
+	}
+
+	consensus, err := v.consensus.selectVIN(fingerprints)
+	if err != nil {
+		report.Reason = ReasonInsufficientEvidence
+		report.Message = "Not enough consistent fingerprint messages to attest the VIN"
+		return report, nil
+	}
+	report.Eligible = true
+	report.Reason = ReasonEligible
+	report.Message = "A VIN attestation can be created"
+	report.VIN = consensus.latest.VIN
+	report.EvidenceCount = consensus.evidenceCount
+	report.ConflictingVINs = consensus.conflictingVINs
+	return report, nil
+}
+
+// fetchFingerprints fetches the fingerprint messages within the consensus window for each paired device.
+func (v *Service) fetchFingerprints(ctx context.Context, vehicleInfo *models.VehicleInfo) []deviceFingerprints {
+	after := time.Now().Add(-v.consensus.window)
+	results := make([]deviceFingerprints, 0, len(vehicleInfo.PairedDevices))
+	for _, device := range vehicleInfo.PairedDevices {
+		fingerprints, err := v.fingerprintRepo.GetFingerprintMessages(ctx, vehicleInfo.DID, device, after, fingerprintsPerDevice)
+		results = append(results, deviceFingerprints{device: device, fingerprints: fingerprints, err: err})
+	}
+	return results
+}
+
+// matchModelYear compares the model year of the VIN to the definition year, unset when either is unknown.
+func matchModelYear(vinYear, definitionYear int) *bool {
+	if vinYear == 0 || definitionYear == 0 {
+		return nil
+	}
+	match := vinYear == definitionYear
+	return &match
+}
@@ -0,0 +1,220 @@
+package vinvc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckVINEligibility(t *testing.T) {
+	const (
+		vin2023 = "1HGCV1F34PA123456"
+		vin2003 = "1HGCM82673A123456"
+	)
+	now := time.Now().UTC().Truncate(time.Second)
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         polygonChainID,
+		TokenID:         big.NewInt(150),
+		ContractAddress: common.HexToAddress(defaultNFTAddress),
+	}
+	aftermarket := models.PairedDevice{
+		Type:             models.DeviceTypeAftermarket,
+		ManufacturerName: "AutoPi",
+		DID:              cloudevent.ERC721DID{ChainID: polygonChainID, TokenID: big.NewInt(20), ContractAddress: common.HexToAddress(defaultNFTAddress)},
+	}
+	synthetic := models.PairedDevice{
+		Type: models.DeviceTypeSynthetic,
+		DID:  cloudevent.ERC721DID{ChainID: polygonChainID, TokenID: big.NewInt(21), ContractAddress: common.HexToAddress(defaultNFTAddress)},
+	}
+	fingerprint := func(vin string, age time.Duration) models.DecodedFingerprintData {
+		return models.DecodedFingerprintData{
+			CloudEventHeader: cloudevent.CloudEventHeader{Time: now.Add(-age), Producer: aftermarket.DID.String()},
+			VIN:              vin,
+			SignatureStatus:  types.SignatureStatusVerified,
+		}
+	}
+	unsigned := func(fingerprint models.DecodedFingerprintData) models.DecodedFingerprintData {
+		fingerprint.SignatureStatus = types.SignatureStatusUnsigned
+		return fingerprint
+	}
+	invalidErr := richerrors.Error{Err: errors.New("bad vin"), ExternalMsg: "VIN in vehicle payload failed validation rules", Code: http.StatusBadRequest}
+	latest := now.Add(-time.Hour)
+
+	tests := []struct {
+		name             string
+		manufacturerName string
+		pairedDevices    []models.PairedDevice
+		fingerprints     []models.DecodedFingerprintData
+		fingerprintErr   error
+		expectedEligible bool
+		expectedReason   string
+		expectedVIN      string
+		expectedCount    int
+		expectedDevices  []vinvc.DeviceEligibility
+	}{
+		{
+			name:             "eligible",
+			manufacturerName: "Honda",
+			pairedDevices:    []models.PairedDevice{aftermarket, synthetic},
+			fingerprints:     []models.DecodedFingerprintData{fingerprint(vin2023, 2*time.Hour), fingerprint(vin2023, time.Hour), fingerprint(vin2023, 3*time.Hour)},
+			expectedEligible: true,
+			expectedReason:   vinvc.ReasonEligible,
+			expectedVIN:      vin2023,
+			expectedCount:    3,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusOK, FingerprintCount: 3, LatestFingerprintAt: &latest, VIN: vin2023, VINValid: true,
+					SignatureStatus: types.SignatureStatusVerified, VINModelYear: 2023, DefinitionMatch: ptr(true),
+					VINManufacturer: "Honda", ManufacturerMatch: ptr(true), CountryCode: "USA",
+				},
+				{DID: synthetic.DID.String(), Type: string(models.DeviceTypeSynthetic), Status: vinvc.DeviceStatusNoFingerprints},
+			},
+		},
+		{
+			name:             "model year and manufacturer do not match the vehicle",
+			manufacturerName: "Toyota",
+			pairedDevices:    []models.PairedDevice{aftermarket},
+			fingerprints:     []models.DecodedFingerprintData{fingerprint(vin2003, time.Hour), fingerprint(vin2003, 2*time.Hour), fingerprint(vin2003, 3*time.Hour)},
+			expectedEligible: true,
+			expectedReason:   vinvc.ReasonEligible,
+			expectedVIN:      vin2003,
+			expectedCount:    3,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusOK, FingerprintCount: 3, LatestFingerprintAt: &latest, VIN: vin2003, VINValid: true,
+					SignatureStatus: types.SignatureStatusVerified, VINModelYear: 2003, DefinitionMatch: ptr(false),
+					VINManufacturer: "Honda", ManufacturerMatch: ptr(false), CountryCode: "USA",
+				},
+			},
+		},
+		{
+			name:            "no paired devices",
+			expectedReason:  vinvc.ReasonNoPairedDevices,
+			expectedDevices: []vinvc.DeviceEligibility{},
+		},
+		{
+			name:           "no fingerprint messages",
+			pairedDevices:  []models.PairedDevice{aftermarket},
+			expectedReason: vinvc.ReasonNoFingerprints,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi", Status: vinvc.DeviceStatusNoFingerprints},
+			},
+		},
+		{
+			name:           "invalid fingerprint messages",
+			pairedDevices:  []models.PairedDevice{aftermarket},
+			fingerprintErr: invalidErr,
+			expectedReason: vinvc.ReasonInvalidFingerprints,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusInvalidFingerprint, Error: invalidErr.ExternalMsg,
+				},
+			},
+		},
+		{
+			name:           "unsigned fingerprint messages",
+			pairedDevices:  []models.PairedDevice{aftermarket},
+			fingerprints:   []models.DecodedFingerprintData{unsigned(fingerprint(vin2023, time.Hour)), unsigned(fingerprint(vin2023, 2*time.Hour)), unsigned(fingerprint(vin2023, 3*time.Hour))},
+			expectedReason: vinvc.ReasonUnsignedFingerprints,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusUnsigned, Error: "Fingerprint messages are not signed by the paired device",
+					FingerprintCount: 3, LatestFingerprintAt: &latest, VIN: vin2023, VINValid: true,
+					SignatureStatus: types.SignatureStatusUnsigned, VINModelYear: 2023, DefinitionMatch: ptr(true),
+					VINManufacturer: "Honda", CountryCode: "USA",
+				},
+			},
+		},
+		{
+			name:           "fetching fingerprint messages fails",
+			pairedDevices:  []models.PairedDevice{aftermarket},
+			fingerprintErr: errors.New("connection refused"),
+			expectedReason: vinvc.ReasonFingerprintFetchError,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusFetchError, Error: "Failed to get fingerprint messages",
+				},
+			},
+		},
+		{
+			name:           "not enough fingerprint messages",
+			pairedDevices:  []models.PairedDevice{aftermarket},
+			fingerprints:   []models.DecodedFingerprintData{fingerprint(vin2023, time.Hour)},
+			expectedReason: vinvc.ReasonInsufficientEvidence,
+			expectedDevices: []vinvc.DeviceEligibility{
+				{
+					DID: aftermarket.DID.String(), Type: string(models.DeviceTypeAftermarket), ManufacturerName: "AutoPi",
+					Status: vinvc.DeviceStatusOK, FingerprintCount: 1, LatestFingerprintAt: &latest, VIN: vin2023, VINValid: true,
+					SignatureStatus: types.SignatureStatusVerified, VINModelYear: 2023, DefinitionMatch: ptr(true),
+					VINManufacturer: "Honda", CountryCode: "USA",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vcRepo := NewMockVCRepo(ctrl)
+			identityAPI := NewMockIdentityAPI(ctrl)
+			fingerprintRepo := NewMockFingerprintRepo(ctrl)
+
+			vehicleInfo := &models.VehicleInfo{DID: vehicleDID, PairedDevices: tt.pairedDevices, NameSlug: defaultNameSlug, ManufacturerName: tt.manufacturerName}
+			identityAPI.EXPECT().GetVehicleInfo(gomock.Any(), vehicleDID).Return(vehicleInfo, nil)
+			for _, device := range tt.pairedDevices {
+				if device.Type == models.DeviceTypeAftermarket {
+					fingerprintRepo.EXPECT().GetFingerprintMessages(gomock.Any(), vehicleDID, device, gomock.Any(), gomock.Any()).Return(tt.fingerprints, tt.fingerprintErr)
+					continue
+				}
+				fingerprintRepo.EXPECT().GetFingerprintMessages(gomock.Any(), vehicleDID, device, gomock.Any(), gomock.Any()).Return(nil, nil)
+			}
+
+			pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			require.NoError(t, err)
+			settings := &config.Settings{
+				VehicleNFTAddress:   defaultNFTAddress,
+				DIMORegistryChainID: polygonChainID,
+				VINDataVersion:      "vin/v1.0",
+				DevLicense:          testDevLicense,
+			}
+			logger := zerolog.Nop()
+			service := vinvc.NewService(&logger, vcRepo, identityAPI, fingerprintRepo, settings, pk)
+
+			report, err := service.CheckVINEligibility(context.Background(), 150)
+			require.NoError(t, err)
+			require.Equal(t, vehicleDID.String(), report.VehicleDID)
+			require.Equal(t, tt.expectedEligible, report.Eligible)
+			require.Equal(t, tt.expectedReason, report.Reason)
+			require.NotEmpty(t, report.Message)
+			require.Equal(t, tt.expectedVIN, report.VIN)
+			require.Equal(t, tt.expectedCount, report.EvidenceCount)
+			require.Equal(t, tt.expectedDevices, report.Devices)
+		})
+	}
+}
+
+func ptr(v bool) *bool { return &v }
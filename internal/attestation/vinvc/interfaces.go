@@ -2,6 +2,7 @@ package vinvc
 
 import (
 	"context"
+	"time"
 
 	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/cloudevent"
@@ -19,5 +20,5 @@ type IdentityAPI interface {
 
 // FingerprintRepo defines the interface for fingerprint message operations.
 type FingerprintRepo interface {
-	GetLatestFingerprintMessages(ctx context.Context, vehicle cloudevent.ERC721DID, pairedDeviceAddr models.PairedDevice) (*models.DecodedFingerprintData, error)
+	GetFingerprintMessages(ctx context.Context, vehicle cloudevent.ERC721DID, pairedDeviceAddr models.PairedDevice, after time.Time, limit int32) ([]models.DecodedFingerprintData, error)
 }
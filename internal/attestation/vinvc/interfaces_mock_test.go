@@ -12,6 +12,7 @@ package vinvc_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/DIMO-Network/attestation-api/internal/models"
 	cloudevent "github.com/DIMO-Network/cloudevent"
@@ -119,17 +120,17 @@ func (m *MockFingerprintRepo) EXPECT() *MockFingerprintRepoMockRecorder {
 	return m.recorder
 }
 
-// GetLatestFingerprintMessages mocks base method.
-func (m *MockFingerprintRepo) GetLatestFingerprintMessages(ctx context.Context, vehicle cloudevent.ERC721DID, pairedDeviceAddr models.PairedDevice) (*models.DecodedFingerprintData, error) {
+// GetFingerprintMessages mocks base method.
+func (m *MockFingerprintRepo) GetFingerprintMessages(ctx context.Context, vehicle cloudevent.ERC721DID, pairedDeviceAddr models.PairedDevice, after time.Time, limit int32) ([]models.DecodedFingerprintData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestFingerprintMessages", ctx, vehicle, pairedDeviceAddr)
-	ret0, _ := ret[0].(*models.DecodedFingerprintData)
+	ret := m.ctrl.Call(m, "GetFingerprintMessages", ctx, vehicle, pairedDeviceAddr, after, limit)
+	ret0, _ := ret[0].([]models.DecodedFingerprintData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLatestFingerprintMessages indicates an expected call of GetLatestFingerprintMessages.
-func (mr *MockFingerprintRepoMockRecorder) GetLatestFingerprintMessages(ctx, vehicle, pairedDeviceAddr any) *gomock.Call {
+// GetFingerprintMessages indicates an expected call of GetFingerprintMessages.
+func (mr *MockFingerprintRepoMockRecorder) GetFingerprintMessages(ctx, vehicle, pairedDeviceAddr, after, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestFingerprintMessages", reflect.TypeOf((*MockFingerprintRepo)(nil).GetLatestFingerprintMessages), ctx, vehicle, pairedDeviceAddr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFingerprintMessages", reflect.TypeOf((*MockFingerprintRepo)(nil).GetFingerprintMessages), ctx, vehicle, pairedDeviceAddr, after, limit)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/DIMO-Network/attestation-api/internal/erc191"
 	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/attestation-api/internal/sources"
+	"github.com/DIMO-Network/attestation-api/internal/vindecoder"
 	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
@@ -38,6 +39,7 @@ type Service struct {
 	VINVCDataVersion  string
 	privateKey        *ecdsa.PrivateKey
 	devLicense        common.Address
+	consensus         consensusPolicy
 }
 
 // NewService creates a new Service for VIN VC operations.
@@ -60,6 +62,7 @@ func NewService(
 		privateKey:        privateKey,
 		VINVCDataVersion:  settings.VINDataVersion,
 		devLicense:        common.HexToAddress(settings.DevLicense),
+		consensus:         newConsensusPolicy(settings),
 	}
 }
 
@@ -98,20 +101,35 @@ func (v *Service) createVINAttestation(ctx context.Context, tokenID uint32) (*cl
 	}
 
 	// get a valid VIN for the vehilce
-	validFP, err := v.getValidFingerPrint(ctx, vehicleInfo, "")
+	consensus, err := v.getValidFingerPrint(ctx, vehicleInfo, "")
 	if err != nil {
 		return nil, err
 	}
+	validFP := consensus.latest
+
+	// decode the VIN offline to infer the country and check it against the vehicle record
+	var countryCode string
+	if decoded, err := vindecoder.Default().Decode(validFP.VIN); err == nil {
+		countryCode = decoded.CountryCode
+		if match := decoded.MatchesManufacturer(vehicleInfo.ManufacturerName); match != nil && !*match {
+			v.logger.Warn().Str("vehicle", vehicleDID.String()).Str("vin", validFP.VIN).Str("vinManufacturer", decoded.Manufacturer.Name).
+				Str("manufacturer", vehicleInfo.ManufacturerName).Msg("VIN manufacturer does not match the vehicle record")
+		}
+	}
 
 	// creatae the subject for the VC
 	vinSubject := types.VINSubject{
 		VehicleDID:                  vehicleDID.String(),
 		VehicleIdentificationNumber: validFP.VIN,
 		VehicleTokenID:              tokenID,
-		CountryCode:                 "",
+		CountryCode:                 countryCode,
 		RecordedBy:                  validFP.Producer,
 		RecordedAt:                  validFP.Time,
 		VehicleContractAddress:      "eth:" + v.vehicleNFTAddress,
+		EvidenceCount:               consensus.evidenceCount,
+		SourcesAgree:                &consensus.sourcesAgree,
+		ConflictingVINs:             consensus.conflictingVINs,
+		SignatureStatus:             validFP.SignatureStatus,
 	}
 
 	// create the new VC
@@ -124,38 +142,53 @@ func (v *Service) createVINAttestation(ctx context.Context, tokenID uint32) (*cl
 	return rawVC, nil
 }
 
-// getValidFingerPrint validates and reconciles VINs from the paired devices.
-func (v *Service) getValidFingerPrint(ctx context.Context, vehicleInfo *models.VehicleInfo, countryCode string) (*models.DecodedFingerprintData, error) {
+// getValidFingerPrint validates and reconciles VINs from the fingerprint history of the paired devices.
+func (v *Service) getValidFingerPrint(ctx context.Context, vehicleInfo *models.VehicleInfo, countryCode string) (*vinConsensus, error) {
 	if len(vehicleInfo.PairedDevices) == 0 {
 		return nil, richerrors.Error{Err: errors.New("no paired devices"), ExternalMsg: "No paired devices", Code: http.StatusBadRequest}
 	}
-	var latestFP *models.DecodedFingerprintData
+	var fingerprints []models.DecodedFingerprintData
 
 	var fingerprintErr error
-	for _, device := range vehicleInfo.PairedDevices {
-		fingerprint, err := v.fingerprintRepo.GetLatestFingerprintMessages(ctx, vehicleInfo.DID, device)
-		if err != nil {
+	var unsigned bool
+	for _, result := range v.fetchFingerprints(ctx, vehicleInfo) {
+		if err := result.err; err != nil {
 			var richErr richerrors.Error
 			if !errors.As(err, &richErr) {
 				// log the error and continue to the next device if possible
-				msg := fmt.Sprintf("Failed to get latest vin message for device %s", device.DID.String())
+				msg := fmt.Sprintf("Failed to get latest vin message for device %s", result.device.DID.String())
 				err = richerrors.Error{Err: err, ExternalMsg: msg, Code: http.StatusInternalServerError}
 			}
 			fingerprintErr = errors.Join(fingerprintErr, err)
 			continue
 		}
 
-		if latestFP == nil || latestFP.VIN == "" || fingerprint.Time.After(latestFP.Time) {
-			latestFP = fingerprint
-		}
+		trusted := v.consensus.trustedFingerprints(result.fingerprints)
+		unsigned = unsigned || len(trusted) < len(result.fingerprints)
+		fingerprints = append(fingerprints, trusted...)
 	}
 
 	// return error to the user if no VINs were found
-	if (latestFP == nil || latestFP.VIN == "") && fingerprintErr != nil {
+	if len(fingerprints) == 0 && fingerprintErr != nil {
 		return nil, fingerprintErr
 	}
+	if len(fingerprints) == 0 && unsigned {
+		return nil, richerrors.Error{
+			Err:         fmt.Errorf("only unsigned fingerprint messages for vehicle %s", vehicleInfo.DID.String()),
+			ExternalMsg: "Fingerprint messages are not signed by the paired device",
+			Code:        http.StatusBadRequest,
+		}
+	}
 
-	return latestFP, nil
+	consensus, err := v.consensus.selectVIN(fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	if !consensus.sourcesAgree {
+		v.logger.Warn().Str("vehicle", vehicleInfo.DID.String()).Str("vin", consensus.latest.VIN).
+			Strs("conflictingVins", consensus.conflictingVINs).Msg("Fingerprint messages report conflicting VINs")
+	}
+	return consensus, nil
 }
 
 func (v *Service) CreateManualVINAttestation(ctx context.Context, tokenID uint32, vin string, countryCode string) (*cloudevent.RawEvent, error) {
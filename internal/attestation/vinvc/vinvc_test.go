@@ -103,7 +103,7 @@ func TestVCController_GetVINVC(t *testing.T) {
 					},
 					VIN: "1HGCM82633A123456",
 				}
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice).Return(&validFP, nil)
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice, gomock.Any(), gomock.Any()).Return([]models.DecodedFingerprintData{validFP}, nil)
 
 				// Create a matcher to verify the expected VIN subject
 				expectedVINSubject := types.VINSubject{
@@ -157,7 +157,7 @@ func TestVCController_GetVINVC(t *testing.T) {
 					},
 				}
 				mocks.identityAPI.EXPECT().GetVehicleInfo(ctxType, vehicleInfo.DID).Return(vehicleInfo, nil)
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice).Return(nil, fmt.Errorf("no fingerprint messages"))
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice, gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("no fingerprint messages"))
 			},
 			expectedErrror: true,
 		},
@@ -210,8 +210,8 @@ func TestVCController_GetVINVC(t *testing.T) {
 					VIN: "1HGCM82633A654321",
 				}
 
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, device1).Return(&validFPEarliest, nil)
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, device2).Return(&validFPLatest, nil)
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, device1, gomock.Any(), gomock.Any()).Return([]models.DecodedFingerprintData{validFPEarliest}, nil)
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, device2, gomock.Any(), gomock.Any()).Return([]models.DecodedFingerprintData{validFPLatest}, nil)
 
 				// Create a matcher to verify the expected VIN subject (should use the latest fingerprint)
 				expectedVINSubject := types.VINSubject{
@@ -257,7 +257,7 @@ func TestVCController_GetVINVC(t *testing.T) {
 					},
 					VIN: "1HGCM82633A123456",
 				}
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice).Return(&validFP, nil)
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice, gomock.Any(), gomock.Any()).Return([]models.DecodedFingerprintData{validFP}, nil)
 
 				// Create a matcher to verify the expected VIN subject
 				expectedVINSubject := types.VINSubject{
@@ -304,7 +304,7 @@ func TestVCController_GetVINVC(t *testing.T) {
 					},
 					VIN: "1HGCM82633A123456",
 				}
-				mocks.fingerprintRepo.EXPECT().GetLatestFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice).Return(&validFP, nil)
+				mocks.fingerprintRepo.EXPECT().GetFingerprintMessages(ctxType, vehicleInfo.DID, pariedDevice, gomock.Any(), gomock.Any()).Return([]models.DecodedFingerprintData{validFP}, nil)
 
 				// Create a matcher to verify the expected VIN subject
 				expectedVINSubject := types.VINSubject{
@@ -343,6 +343,8 @@ func TestVCController_GetVINVC(t *testing.T) {
 				DIMORegistryChainID: polygonChainID,
 				VINDataVersion:      "vin/v1.0",
 				DevLicense:          testDevLicense,
+				// a single message is enough, consensus is covered by TestCreateVINAttestation_Consensus
+				VINConsensusMinMessages: 1,
 			}
 
 			// Create a new VCController instance for this test
@@ -399,3 +401,222 @@ func (m *vinSubjectMatcher) String() string {
 func matchVINSubject(expected types.VINSubject) gomock.Matcher {
 	return &vinSubjectMatcher{expected: expected}
 }
+
+func TestCreateVINAttestation_Consensus(t *testing.T) {
+	const (
+		vinA = "1HGCM82673A123456"
+		vinB = "1HGCM82693A654321"
+	)
+	now := time.Now().UTC().Truncate(time.Second)
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         polygonChainID,
+		TokenID:         big.NewInt(140),
+		ContractAddress: common.HexToAddress(defaultNFTAddress),
+	}
+	device1 := models.PairedDevice{
+		Type: models.DeviceTypeAftermarket,
+		DID:  cloudevent.ERC721DID{ChainID: polygonChainID, TokenID: big.NewInt(10), ContractAddress: common.HexToAddress(defaultNFTAddress)},
+	}
+	device2 := models.PairedDevice{
+		Type: models.DeviceTypeSynthetic,
+		DID:  cloudevent.ERC721DID{ChainID: polygonChainID, TokenID: big.NewInt(11), ContractAddress: common.HexToAddress(defaultNFTAddress)},
+	}
+	fingerprint := func(device models.PairedDevice, vin string, age time.Duration) models.DecodedFingerprintData {
+		return models.DecodedFingerprintData{
+			CloudEventHeader: cloudevent.CloudEventHeader{Time: now.Add(-age), Producer: device.DID.String()},
+			VIN:              vin,
+		}
+	}
+	withSignature := func(fingerprint models.DecodedFingerprintData, status string) models.DecodedFingerprintData {
+		fingerprint.SignatureStatus = status
+		return fingerprint
+	}
+
+	tests := []struct {
+		name             string
+		device1          []models.DecodedFingerprintData
+		device2          []models.DecodedFingerprintData
+		acceptUnsigned   bool
+		expectedError    bool
+		expectedVIN      string
+		expectedProducer string
+		expectedAt       time.Time
+		expectedCount    int
+		expectedAgree    bool
+		expectedConflict []string
+		expectedSigned   string
+	}{
+		{
+			name:             "devices agree",
+			device1:          []models.DecodedFingerprintData{fingerprint(device1, vinA, time.Hour), fingerprint(device1, vinA, 2*time.Hour)},
+			device2:          []models.DecodedFingerprintData{fingerprint(device2, vinA, 30*time.Minute)},
+			expectedVIN:      vinA,
+			expectedProducer: device2.DID.String(),
+			expectedAt:       now.Add(-30 * time.Minute),
+			expectedCount:    3,
+			expectedAgree:    true,
+		},
+		{
+			name: "a single corrupted read is outvoted",
+			device1: []models.DecodedFingerprintData{
+				fingerprint(device1, vinB, time.Minute),
+				fingerprint(device1, vinA, time.Hour),
+				fingerprint(device1, vinA, 2*time.Hour),
+				fingerprint(device1, vinA, 3*time.Hour),
+			},
+			expectedVIN:      vinA,
+			expectedProducer: device1.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    3,
+			expectedAgree:    false,
+			expectedConflict: []string{vinB},
+		},
+		{
+			name:             "few messages over several days",
+			device1:          []models.DecodedFingerprintData{fingerprint(device1, vinA, time.Hour), fingerprint(device1, vinA, 72*time.Hour)},
+			expectedVIN:      vinA,
+			expectedProducer: device1.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    2,
+			expectedAgree:    true,
+		},
+		{
+			name: "unsigned messages of a signing device are left out",
+			device1: []models.DecodedFingerprintData{
+				withSignature(fingerprint(device1, vinA, time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinA, time.Hour), types.SignatureStatusVerified),
+				withSignature(fingerprint(device1, vinA, 3*time.Hour), types.SignatureStatusVerified),
+			},
+			device2:          []models.DecodedFingerprintData{withSignature(fingerprint(device2, vinA, 2*time.Hour), types.SignatureStatusNotApplicable)},
+			expectedVIN:      vinA,
+			expectedProducer: device1.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    3,
+			expectedAgree:    true,
+			expectedSigned:   types.SignatureStatusVerified,
+		},
+		{
+			name: "unsigned messages outnumbering signed ones do not outvote them",
+			device1: []models.DecodedFingerprintData{
+				withSignature(fingerprint(device1, vinB, time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinB, 2*time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinB, 3*time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinB, 4*time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinA, time.Hour), types.SignatureStatusVerified),
+				withSignature(fingerprint(device1, vinA, 2*time.Hour), types.SignatureStatusVerified),
+				withSignature(fingerprint(device1, vinA, 3*time.Hour), types.SignatureStatusVerified),
+			},
+			expectedVIN:      vinA,
+			expectedProducer: device1.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    3,
+			expectedAgree:    true,
+			expectedSigned:   types.SignatureStatusVerified,
+		},
+		{
+			name:          "unsigned messages are not counted",
+			device1:       []models.DecodedFingerprintData{withSignature(fingerprint(device1, vinA, time.Hour), types.SignatureStatusUnsigned), withSignature(fingerprint(device1, vinA, 72*time.Hour), types.SignatureStatusUnsigned)},
+			expectedError: true,
+		},
+		{
+			name: "unsigned messages do not outvote a synthetic device",
+			device1: []models.DecodedFingerprintData{
+				withSignature(fingerprint(device1, vinB, time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinB, 2*time.Minute), types.SignatureStatusUnsigned),
+				withSignature(fingerprint(device1, vinB, 3*time.Minute), types.SignatureStatusUnsigned),
+			},
+			device2: []models.DecodedFingerprintData{
+				withSignature(fingerprint(device2, vinA, time.Hour), types.SignatureStatusNotApplicable),
+				withSignature(fingerprint(device2, vinA, 72*time.Hour), types.SignatureStatusNotApplicable),
+			},
+			expectedVIN:      vinA,
+			expectedProducer: device2.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    2,
+			expectedAgree:    true,
+			expectedSigned:   types.SignatureStatusNotApplicable,
+		},
+		{
+			name:             "unsigned messages are used when accepted and there is nothing else",
+			device1:          []models.DecodedFingerprintData{withSignature(fingerprint(device1, vinA, time.Hour), types.SignatureStatusUnsigned), withSignature(fingerprint(device1, vinA, 72*time.Hour), types.SignatureStatusUnsigned)},
+			acceptUnsigned:   true,
+			expectedVIN:      vinA,
+			expectedProducer: device1.DID.String(),
+			expectedAt:       now.Add(-time.Hour),
+			expectedCount:    2,
+			expectedAgree:    true,
+			expectedSigned:   types.SignatureStatusUnsigned,
+		},
+		{
+			name:          "single message is not enough",
+			device1:       []models.DecodedFingerprintData{fingerprint(device1, vinA, time.Hour)},
+			expectedError: true,
+		},
+		{
+			name:          "no messages",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vcRepo := NewMockVCRepo(ctrl)
+			identityAPI := NewMockIdentityAPI(ctrl)
+			fingerprintRepo := NewMockFingerprintRepo(ctrl)
+
+			vehicleInfo := &models.VehicleInfo{DID: vehicleDID, PairedDevices: []models.PairedDevice{device1, device2}}
+			identityAPI.EXPECT().GetVehicleInfo(gomock.Any(), vehicleDID).Return(vehicleInfo, nil)
+			fingerprintRepo.EXPECT().GetFingerprintMessages(gomock.Any(), vehicleDID, device1, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, vehicle cloudevent.ERC721DID, device models.PairedDevice, after time.Time, limit int32) ([]models.DecodedFingerprintData, error) {
+					require.WithinDuration(t, now.Add(-30*24*time.Hour), after, time.Minute)
+					return tt.device1, nil
+				})
+			fingerprintRepo.EXPECT().GetFingerprintMessages(gomock.Any(), vehicleDID, device2, gomock.Any(), gomock.Any()).Return(tt.device2, nil)
+
+			var uploaded *cloudevent.RawEvent
+			if !tt.expectedError {
+				vcRepo.EXPECT().UploadAttestation(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attestation *cloudevent.RawEvent) error {
+					uploaded = attestation
+					return nil
+				})
+			}
+
+			pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			require.NoError(t, err)
+			settings := &config.Settings{
+				VehicleNFTAddress:          defaultNFTAddress,
+				DIMORegistryChainID:        polygonChainID,
+				VINDataVersion:             "vin/v1.0",
+				DevLicense:                 testDevLicense,
+				AcceptUnsignedFingerprints: tt.acceptUnsigned,
+			}
+			logger := zerolog.Nop()
+			service := vinvc.NewService(&logger, vcRepo, identityAPI, fingerprintRepo, settings, pk)
+
+			_, err = service.CreateAndStoreVINAttestation(context.Background(), 140)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var credential types.Credential
+			require.NoError(t, json.Unmarshal(uploaded.Data, &credential))
+			var subject types.VINSubject
+			require.NoError(t, json.Unmarshal(credential.CredentialSubject, &subject))
+
+			require.Equal(t, tt.expectedVIN, subject.VehicleIdentificationNumber)
+			require.Equal(t, tt.expectedProducer, subject.RecordedBy)
+			require.True(t, tt.expectedAt.Equal(subject.RecordedAt))
+			require.Equal(t, tt.expectedCount, subject.EvidenceCount)
+			require.NotNil(t, subject.SourcesAgree)
+			require.Equal(t, tt.expectedAgree, *subject.SourcesAgree)
+			require.Equal(t, tt.expectedConflict, subject.ConflictingVINs)
+			require.Equal(t, tt.expectedSigned, subject.SignatureStatus)
+			require.Equal(t, "USA", subject.CountryCode)
+		})
+	}
+}
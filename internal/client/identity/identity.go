@@ -110,6 +110,7 @@ func (s *Service) GetVehicleInfo(ctx context.Context, vehicleDID cloudevent.ERC7
 			DID:              did,
 			Type:             models.DeviceTypeAftermarket,
 			ManufacturerName: respBody.Data.Vehicle.AftermarketDevice.Manufacturer.Name,
+			Address:          common.HexToAddress(respBody.Data.Vehicle.AftermarketDevice.Address),
 		})
 	}
 	if respBody.Data.Vehicle.SyntheticDevice != nil {
@@ -125,10 +126,21 @@ func (s *Service) GetVehicleInfo(ctx context.Context, vehicleDID cloudevent.ERC7
 	if respBody.Data.Vehicle.Definition == nil || respBody.Data.Vehicle.Definition.ID.value == nil {
 		return nil, fmt.Errorf("vehicle is missing definition ID")
 	}
+	var definitionAttributes map[string]string
+	for _, attribute := range respBody.Data.Vehicle.Definition.Attributes {
+		if definitionAttributes == nil {
+			definitionAttributes = make(map[string]string, len(respBody.Data.Vehicle.Definition.Attributes))
+		}
+		definitionAttributes[attribute.Name] = attribute.Value
+	}
 	vehicleInfo := &models.VehicleInfo{
-		DID:           vehicleDID,
-		PairedDevices: pairedDevices,
-		NameSlug:      *respBody.Data.Vehicle.Definition.ID.value,
+		DID:                  vehicleDID,
+		PairedDevices:        pairedDevices,
+		NameSlug:             *respBody.Data.Vehicle.Definition.ID.value,
+		DefinitionAttributes: definitionAttributes,
+	}
+	if respBody.Data.Vehicle.Manufacturer != nil {
+		vehicleInfo.ManufacturerName = respBody.Data.Vehicle.Manufacturer.Name
 	}
 	return vehicleInfo, nil
 }
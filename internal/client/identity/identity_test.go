@@ -50,11 +50,15 @@ func TestService_GetPairedDevices(t *testing.T) {
 			{
 				"data": {
 					"vehicle": {
+						"manufacturer": {
+							"name": "Toyota"
+						},
 						"definition": {
 							"id": "toyota_tacoma-4wd_2023"
 						},
 						"aftermarketDevice": {
-							"tokenDID": "%s"
+							"tokenDID": "%s",
+							"address": "0x5e31bBc786D7bEd95216383787deA1ab0f1c1897"
 						},
 						"syntheticDevice": {
 							"tokenDID": "%s"
@@ -64,10 +68,11 @@ func TestService_GetPairedDevices(t *testing.T) {
 			}`, deviceDID1.String(), deviceDID2.String()),
 			mockStatusCode: http.StatusOK,
 			expectedInfo: &models.VehicleInfo{
-				DID:      cloudevent.ERC721DID{TokenID: new(big.Int).SetInt64(123), ChainID: 137, ContractAddress: vehicleAddr},
-				NameSlug: testSlug,
+				DID:              cloudevent.ERC721DID{TokenID: new(big.Int).SetInt64(123), ChainID: 137, ContractAddress: vehicleAddr},
+				NameSlug:         testSlug,
+				ManufacturerName: "Toyota",
 				PairedDevices: []models.PairedDevice{
-					{DID: deviceDID1, Type: models.DeviceTypeAftermarket},
+					{DID: deviceDID1, Type: models.DeviceTypeAftermarket, Address: common.HexToAddress("0x5e31bBc786D7bEd95216383787deA1ab0f1c1897")},
 					{DID: deviceDID2, Type: models.DeviceTypeSynthetic},
 				},
 			},
@@ -93,6 +98,34 @@ func TestService_GetPairedDevices(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name:           "successful response with definition attributes",
+			vehicleTokenID: new(big.Int).SetInt64(131),
+			mockResponseBody: `
+			{
+				"data": {
+					"vehicle": {
+						"definition": {
+							"id": "toyota_tacoma-4wd_2023",
+							"attributes": [
+								{"name": "tire_pressure_front_kpa", "value": "241"},
+								{"name": "tire_pressure_rear_kpa", "value": "276"}
+							]
+						}
+					}
+				}
+			}`,
+			mockStatusCode: http.StatusOK,
+			expectedInfo: &models.VehicleInfo{
+				DID:      cloudevent.ERC721DID{TokenID: new(big.Int).SetInt64(131), ChainID: 137, ContractAddress: vehicleAddr},
+				NameSlug: testSlug,
+				DefinitionAttributes: map[string]string{
+					models.DefinitionAttributeFrontTirePressure: "241",
+					models.DefinitionAttributeRearTirePressure:  "276",
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name:           "GraphQL API error",
 			vehicleTokenID: new(big.Int).SetInt64(126),
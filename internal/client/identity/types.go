@@ -6,8 +6,12 @@ import "encoding/json"
 const query = `
 	query ($tokenId: Int!) {
 		vehicle(tokenId: $tokenId) {
+			manufacturer {
+				name
+			}
 			aftermarketDevice {
 				tokenDID
+				address
 				manufacturer {
 					name
 				}
@@ -17,6 +21,10 @@ const query = `
 			}
 			definition{
 				id
+				attributes {
+					name
+					value
+				}
 			}
 		}
 	}
@@ -35,6 +43,7 @@ type dataField struct {
 
 // vehicleField represents the vehicle field in the GraphQL response.
 type vehicleField struct {
+	Manufacturer      *manufacturer   `json:"manufacturer"`
 	AftermarketDevice *deviceResponse `json:"aftermarketDevice"`
 	SyntheticDevice   *deviceResponse `json:"syntheticDevice"`
 	Definition        *definitionResponse
@@ -43,6 +52,7 @@ type vehicleField struct {
 // deviceResponse represents the structure of the device response.
 type deviceResponse struct {
 	TokenDID     string       `json:"tokenDID"`
+	Address      string       `json:"address"`
 	Manufacturer manufacturer `json:"manufacturer"`
 }
 type manufacturer struct {
@@ -50,7 +60,14 @@ type manufacturer struct {
 }
 
 type definitionResponse struct {
-	ID nullableString `json:"id"`
+	ID         nullableString        `json:"id"`
+	Attributes []definitionAttribute `json:"attributes"`
+}
+
+// definitionAttribute is a name/value attribute of a device definition.
+type definitionAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // graphQLError represents an error returned from the GraphQL API.
@@ -149,6 +149,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldCurrentLocationCoordinates,
 			Value:     collection.CurrentLocationCoordinates.Value,
 			Timestamp: collection.CurrentLocationCoordinates.Timestamp,
+			Source:    collection.CurrentLocationCoordinates.Source,
+			Producer:  collection.CurrentLocationCoordinates.Producer,
 		})
 	}
 	if collection.CurrentLocationApproximateCoordinates != nil {
@@ -156,6 +158,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      "currentLocationApproximateCoordinates",
 			Value:     collection.CurrentLocationApproximateCoordinates.Value,
 			Timestamp: collection.CurrentLocationApproximateCoordinates.Timestamp,
+			Source:    collection.CurrentLocationApproximateCoordinates.Source,
+			Producer:  collection.CurrentLocationApproximateCoordinates.Producer,
 		})
 	}
 	if collection.PowertrainTransmissionTravelledDistance != nil {
@@ -163,6 +167,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldPowertrainTransmissionTravelledDistance,
 			Value:     collection.PowertrainTransmissionTravelledDistance.Value,
 			Timestamp: collection.PowertrainTransmissionTravelledDistance.Timestamp,
+			Source:    collection.PowertrainTransmissionTravelledDistance.Source,
+			Producer:  collection.PowertrainTransmissionTravelledDistance.Producer,
 		})
 	}
 	if collection.Speed != nil {
@@ -170,6 +176,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldSpeed,
 			Value:     collection.Speed.Value,
 			Timestamp: collection.Speed.Timestamp,
+			Source:    collection.Speed.Source,
+			Producer:  collection.Speed.Producer,
 		})
 	}
 	if collection.ObdDTCList != nil {
@@ -177,6 +185,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldOBDDTCList,
 			Value:     collection.ObdDTCList.Value,
 			Timestamp: collection.ObdDTCList.Timestamp,
+			Source:    collection.ObdDTCList.Source,
+			Producer:  collection.ObdDTCList.Producer,
 		})
 	}
 	if collection.ObdStatusDTCCount != nil {
@@ -184,6 +194,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldOBDStatusDTCCount,
 			Value:     collection.ObdStatusDTCCount.Value,
 			Timestamp: collection.ObdStatusDTCCount.Timestamp,
+			Source:    collection.ObdStatusDTCCount.Source,
+			Producer:  collection.ObdStatusDTCCount.Producer,
 		})
 	}
 	if collection.ChassisAxleRow1WheelLeftTirePressure != nil {
@@ -191,6 +203,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldChassisAxleRow1WheelLeftTirePressure,
 			Value:     collection.ChassisAxleRow1WheelLeftTirePressure.Value,
 			Timestamp: collection.ChassisAxleRow1WheelLeftTirePressure.Timestamp,
+			Source:    collection.ChassisAxleRow1WheelLeftTirePressure.Source,
+			Producer:  collection.ChassisAxleRow1WheelLeftTirePressure.Producer,
 		})
 	}
 	if collection.ChassisAxleRow1WheelRightTirePressure != nil {
@@ -198,6 +212,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldChassisAxleRow1WheelRightTirePressure,
 			Value:     collection.ChassisAxleRow1WheelRightTirePressure.Value,
 			Timestamp: collection.ChassisAxleRow1WheelRightTirePressure.Timestamp,
+			Source:    collection.ChassisAxleRow1WheelRightTirePressure.Source,
+			Producer:  collection.ChassisAxleRow1WheelRightTirePressure.Producer,
 		})
 	}
 	if collection.ChassisAxleRow2WheelLeftTirePressure != nil {
@@ -205,6 +221,8 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldChassisAxleRow2WheelLeftTirePressure,
 			Value:     collection.ChassisAxleRow2WheelLeftTirePressure.Value,
 			Timestamp: collection.ChassisAxleRow2WheelLeftTirePressure.Timestamp,
+			Source:    collection.ChassisAxleRow2WheelLeftTirePressure.Source,
+			Producer:  collection.ChassisAxleRow2WheelLeftTirePressure.Producer,
 		})
 	}
 	if collection.ChassisAxleRow2WheelRightTirePressure != nil {
@@ -212,6 +230,161 @@ func (s *Service) convertSignalCollectionToRecords(collection SignalCollection)
 			Name:      vss.FieldChassisAxleRow2WheelRightTirePressure,
 			Value:     collection.ChassisAxleRow2WheelRightTirePressure.Value,
 			Timestamp: collection.ChassisAxleRow2WheelRightTirePressure.Timestamp,
+			Source:    collection.ChassisAxleRow2WheelRightTirePressure.Source,
+			Producer:  collection.ChassisAxleRow2WheelRightTirePressure.Producer,
+		})
+	}
+	if collection.LowVoltageBatteryCurrentVoltage != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldLowVoltageBatteryCurrentVoltage,
+			Value:     collection.LowVoltageBatteryCurrentVoltage.Value,
+			Timestamp: collection.LowVoltageBatteryCurrentVoltage.Timestamp,
+			Source:    collection.LowVoltageBatteryCurrentVoltage.Source,
+			Producer:  collection.LowVoltageBatteryCurrentVoltage.Producer,
+		})
+	}
+	if collection.PowertrainCombustionEngineECT != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainCombustionEngineECT,
+			Value:     collection.PowertrainCombustionEngineECT.Value,
+			Timestamp: collection.PowertrainCombustionEngineECT.Timestamp,
+			Source:    collection.PowertrainCombustionEngineECT.Source,
+			Producer:  collection.PowertrainCombustionEngineECT.Producer,
+		})
+	}
+	if collection.PowertrainCombustionEngineEngineOilRelativeLevel != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel,
+			Value:     collection.PowertrainCombustionEngineEngineOilRelativeLevel.Value,
+			Timestamp: collection.PowertrainCombustionEngineEngineOilRelativeLevel.Timestamp,
+			Source:    collection.PowertrainCombustionEngineEngineOilRelativeLevel.Source,
+			Producer:  collection.PowertrainCombustionEngineEngineOilRelativeLevel.Producer,
+		})
+	}
+	if collection.ChassisAxleRow1WheelLeftBrakePadWear != nil {
+		signals = append(signals, Signal{
+			Name:      FieldChassisAxleRow1WheelLeftBrakePadWear,
+			Value:     collection.ChassisAxleRow1WheelLeftBrakePadWear.Value,
+			Timestamp: collection.ChassisAxleRow1WheelLeftBrakePadWear.Timestamp,
+			Source:    collection.ChassisAxleRow1WheelLeftBrakePadWear.Source,
+			Producer:  collection.ChassisAxleRow1WheelLeftBrakePadWear.Producer,
+		})
+	}
+	if collection.ChassisAxleRow1WheelRightBrakePadWear != nil {
+		signals = append(signals, Signal{
+			Name:      FieldChassisAxleRow1WheelRightBrakePadWear,
+			Value:     collection.ChassisAxleRow1WheelRightBrakePadWear.Value,
+			Timestamp: collection.ChassisAxleRow1WheelRightBrakePadWear.Timestamp,
+			Source:    collection.ChassisAxleRow1WheelRightBrakePadWear.Source,
+			Producer:  collection.ChassisAxleRow1WheelRightBrakePadWear.Producer,
+		})
+	}
+	if collection.ChassisAxleRow2WheelLeftBrakePadWear != nil {
+		signals = append(signals, Signal{
+			Name:      FieldChassisAxleRow2WheelLeftBrakePadWear,
+			Value:     collection.ChassisAxleRow2WheelLeftBrakePadWear.Value,
+			Timestamp: collection.ChassisAxleRow2WheelLeftBrakePadWear.Timestamp,
+			Source:    collection.ChassisAxleRow2WheelLeftBrakePadWear.Source,
+			Producer:  collection.ChassisAxleRow2WheelLeftBrakePadWear.Producer,
+		})
+	}
+	if collection.ChassisAxleRow2WheelRightBrakePadWear != nil {
+		signals = append(signals, Signal{
+			Name:      FieldChassisAxleRow2WheelRightBrakePadWear,
+			Value:     collection.ChassisAxleRow2WheelRightBrakePadWear.Value,
+			Timestamp: collection.ChassisAxleRow2WheelRightBrakePadWear.Timestamp,
+			Source:    collection.ChassisAxleRow2WheelRightBrakePadWear.Source,
+			Producer:  collection.ChassisAxleRow2WheelRightBrakePadWear.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryStateOfHealth != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryStateOfHealth,
+			Value:     collection.PowertrainTractionBatteryStateOfHealth.Value,
+			Timestamp: collection.PowertrainTractionBatteryStateOfHealth.Timestamp,
+			Source:    collection.PowertrainTractionBatteryStateOfHealth.Source,
+			Producer:  collection.PowertrainTractionBatteryStateOfHealth.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryCellVoltageMax != nil {
+		signals = append(signals, Signal{
+			Name:      FieldPowertrainTractionBatteryCellVoltageMax,
+			Value:     collection.PowertrainTractionBatteryCellVoltageMax.Value,
+			Timestamp: collection.PowertrainTractionBatteryCellVoltageMax.Timestamp,
+			Source:    collection.PowertrainTractionBatteryCellVoltageMax.Source,
+			Producer:  collection.PowertrainTractionBatteryCellVoltageMax.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryCellVoltageMin != nil {
+		signals = append(signals, Signal{
+			Name:      FieldPowertrainTractionBatteryCellVoltageMin,
+			Value:     collection.PowertrainTractionBatteryCellVoltageMin.Value,
+			Timestamp: collection.PowertrainTractionBatteryCellVoltageMin.Timestamp,
+			Source:    collection.PowertrainTractionBatteryCellVoltageMin.Source,
+			Producer:  collection.PowertrainTractionBatteryCellVoltageMin.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryGrossCapacity != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryGrossCapacity,
+			Value:     collection.PowertrainTractionBatteryGrossCapacity.Value,
+			Timestamp: collection.PowertrainTractionBatteryGrossCapacity.Timestamp,
+			Source:    collection.PowertrainTractionBatteryGrossCapacity.Source,
+			Producer:  collection.PowertrainTractionBatteryGrossCapacity.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryStateOfChargeCurrent != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryStateOfChargeCurrent,
+			Value:     collection.PowertrainTractionBatteryStateOfChargeCurrent.Value,
+			Timestamp: collection.PowertrainTractionBatteryStateOfChargeCurrent.Timestamp,
+			Source:    collection.PowertrainTractionBatteryStateOfChargeCurrent.Source,
+			Producer:  collection.PowertrainTractionBatteryStateOfChargeCurrent.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryStateOfChargeCurrentEnergy != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy,
+			Value:     collection.PowertrainTractionBatteryStateOfChargeCurrentEnergy.Value,
+			Timestamp: collection.PowertrainTractionBatteryStateOfChargeCurrentEnergy.Timestamp,
+			Source:    collection.PowertrainTractionBatteryStateOfChargeCurrentEnergy.Source,
+			Producer:  collection.PowertrainTractionBatteryStateOfChargeCurrentEnergy.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryRange != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryRange,
+			Value:     collection.PowertrainTractionBatteryRange.Value,
+			Timestamp: collection.PowertrainTractionBatteryRange.Timestamp,
+			Source:    collection.PowertrainTractionBatteryRange.Source,
+			Producer:  collection.PowertrainTractionBatteryRange.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryChargingIsCharging != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryChargingIsCharging,
+			Value:     collection.PowertrainTractionBatteryChargingIsCharging.Value,
+			Timestamp: collection.PowertrainTractionBatteryChargingIsCharging.Timestamp,
+			Source:    collection.PowertrainTractionBatteryChargingIsCharging.Source,
+			Producer:  collection.PowertrainTractionBatteryChargingIsCharging.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryChargingPower != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryChargingPower,
+			Value:     collection.PowertrainTractionBatteryChargingPower.Value,
+			Timestamp: collection.PowertrainTractionBatteryChargingPower.Timestamp,
+			Source:    collection.PowertrainTractionBatteryChargingPower.Source,
+			Producer:  collection.PowertrainTractionBatteryChargingPower.Producer,
+		})
+	}
+	if collection.PowertrainTractionBatteryChargingAddedEnergy != nil {
+		signals = append(signals, Signal{
+			Name:      vss.FieldPowertrainTractionBatteryChargingAddedEnergy,
+			Value:     collection.PowertrainTractionBatteryChargingAddedEnergy.Value,
+			Timestamp: collection.PowertrainTractionBatteryChargingAddedEnergy.Timestamp,
+			Source:    collection.PowertrainTractionBatteryChargingAddedEnergy.Source,
+			Producer:  collection.PowertrainTractionBatteryChargingAddedEnergy.Producer,
 		})
 	}
 
@@ -228,6 +401,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldCurrentLocationCoordinates,
 				Value:     *agg.CurrentLocationCoordinates,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.CurrentLocationApproximateCoordinates != nil {
@@ -235,6 +410,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      "currentLocationApproximateCoordinates",
 				Value:     *agg.CurrentLocationApproximateCoordinates,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.PowertrainTransmissionTravelledDistance != nil {
@@ -242,6 +419,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldPowertrainTransmissionTravelledDistance,
 				Value:     *agg.PowertrainTransmissionTravelledDistance,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.Speed != nil {
@@ -249,6 +428,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldSpeed,
 				Value:     *agg.Speed,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ObdDTCList != nil {
@@ -256,6 +437,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldOBDDTCList,
 				Value:     *agg.ObdDTCList,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ObdStatusDTCCount != nil {
@@ -263,6 +446,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldOBDStatusDTCCount,
 				Value:     *agg.ObdStatusDTCCount,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ChassisAxleRow1WheelLeftTirePressure != nil {
@@ -270,6 +455,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldChassisAxleRow1WheelLeftTirePressure,
 				Value:     *agg.ChassisAxleRow1WheelLeftTirePressure,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ChassisAxleRow1WheelRightTirePressure != nil {
@@ -277,6 +464,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldChassisAxleRow1WheelRightTirePressure,
 				Value:     *agg.ChassisAxleRow1WheelRightTirePressure,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ChassisAxleRow2WheelLeftTirePressure != nil {
@@ -284,6 +473,8 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldChassisAxleRow2WheelLeftTirePressure,
 				Value:     *agg.ChassisAxleRow2WheelLeftTirePressure,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 		if agg.ChassisAxleRow2WheelRightTirePressure != nil {
@@ -291,6 +482,161 @@ func (s *Service) convertSignalAggregationsToRecords(aggregations []SignalAggreg
 				Name:      vss.FieldChassisAxleRow2WheelRightTirePressure,
 				Value:     *agg.ChassisAxleRow2WheelRightTirePressure,
 				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.LowVoltageBatteryCurrentVoltage != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldLowVoltageBatteryCurrentVoltage,
+				Value:     *agg.LowVoltageBatteryCurrentVoltage,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainCombustionEngineECT != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainCombustionEngineECT,
+				Value:     *agg.PowertrainCombustionEngineECT,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainCombustionEngineEngineOilRelativeLevel != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainCombustionEngineEngineOilRelativeLevel,
+				Value:     *agg.PowertrainCombustionEngineEngineOilRelativeLevel,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.ChassisAxleRow1WheelLeftBrakePadWear != nil {
+			signals = append(signals, Signal{
+				Name:      FieldChassisAxleRow1WheelLeftBrakePadWear,
+				Value:     *agg.ChassisAxleRow1WheelLeftBrakePadWear,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.ChassisAxleRow1WheelRightBrakePadWear != nil {
+			signals = append(signals, Signal{
+				Name:      FieldChassisAxleRow1WheelRightBrakePadWear,
+				Value:     *agg.ChassisAxleRow1WheelRightBrakePadWear,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.ChassisAxleRow2WheelLeftBrakePadWear != nil {
+			signals = append(signals, Signal{
+				Name:      FieldChassisAxleRow2WheelLeftBrakePadWear,
+				Value:     *agg.ChassisAxleRow2WheelLeftBrakePadWear,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.ChassisAxleRow2WheelRightBrakePadWear != nil {
+			signals = append(signals, Signal{
+				Name:      FieldChassisAxleRow2WheelRightBrakePadWear,
+				Value:     *agg.ChassisAxleRow2WheelRightBrakePadWear,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryStateOfHealth != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryStateOfHealth,
+				Value:     *agg.PowertrainTractionBatteryStateOfHealth,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryCellVoltageMax != nil {
+			signals = append(signals, Signal{
+				Name:      FieldPowertrainTractionBatteryCellVoltageMax,
+				Value:     *agg.PowertrainTractionBatteryCellVoltageMax,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryCellVoltageMin != nil {
+			signals = append(signals, Signal{
+				Name:      FieldPowertrainTractionBatteryCellVoltageMin,
+				Value:     *agg.PowertrainTractionBatteryCellVoltageMin,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryGrossCapacity != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryGrossCapacity,
+				Value:     *agg.PowertrainTractionBatteryGrossCapacity,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryStateOfChargeCurrent != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryStateOfChargeCurrent,
+				Value:     *agg.PowertrainTractionBatteryStateOfChargeCurrent,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryStateOfChargeCurrentEnergy != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryStateOfChargeCurrentEnergy,
+				Value:     *agg.PowertrainTractionBatteryStateOfChargeCurrentEnergy,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryRange != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryRange,
+				Value:     *agg.PowertrainTractionBatteryRange,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryChargingIsCharging != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryChargingIsCharging,
+				Value:     *agg.PowertrainTractionBatteryChargingIsCharging,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryChargingPower != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryChargingPower,
+				Value:     *agg.PowertrainTractionBatteryChargingPower,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
+			})
+		}
+		if agg.PowertrainTractionBatteryChargingAddedEnergy != nil {
+			signals = append(signals, Signal{
+				Name:      vss.FieldPowertrainTractionBatteryChargingAddedEnergy,
+				Value:     *agg.PowertrainTractionBatteryChargingAddedEnergy,
+				Timestamp: agg.Timestamp,
+				Source:    agg.Source,
+				Producer:  agg.Producer,
 			})
 		}
 	}
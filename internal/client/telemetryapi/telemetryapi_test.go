@@ -132,6 +132,44 @@ func TestService_GetLatestSignals(t *testing.T) {
 	}
 }
 
+func TestService_GetLatestSignals_SourceAndProducer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := io.WriteString(w, `
+		{
+			"data": {
+				"signalsLatest": {
+					"lastSeen": "2024-01-15T10:30:00Z",
+					"powertrainTransmissionTravelledDistance": {
+						"timestamp": "2024-01-15T10:30:00Z",
+						"source": "0xF26421509Efe92861a587482100c6d728aBf1CD0",
+						"producer": "did:erc721:137:0x9c94C395cBcBDe662235E0A9d3bB87Ad708561BA:42",
+						"value": 12345.6
+					}
+				}
+			}
+		}`)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+
+	service, err := telemetryapi.NewService(server.URL, certPool)
+	require.NoError(t, err)
+
+	signals, err := service.GetLatestSignalsWithAuth(context.Background(), telemetryapi.TelemetryLatestOptions{
+		TokenID:  big.NewInt(123),
+		JWTToken: "test-jwt-token",
+		Signals:  []string{vss.FieldPowertrainTransmissionTravelledDistance},
+	})
+	require.NoError(t, err)
+	require.Len(t, signals, 1)
+	require.Equal(t, "0xF26421509Efe92861a587482100c6d728aBf1CD0", signals[0].Source)
+	require.Equal(t, "did:erc721:137:0x9c94C395cBcBDe662235E0A9d3bB87Ad708561BA:42", signals[0].Producer)
+}
+
 func TestService_GetHistoricalData(t *testing.T) {
 	ctx := context.Background()
 
@@ -179,3 +217,16 @@ func TestService_GetHistoricalData(t *testing.T) {
 	require.Equal(t, "speed", signals[1].Name)
 	require.Equal(t, 70.2, signals[1].Value)
 }
+
+func TestGenerateHistoricalQuery_Aggregations(t *testing.T) {
+	query := telemetryapi.GenerateHistoricalQuery([]string{
+		"speed",
+		"powertrainCombustionEngineECT",
+		"powertrainTractionBatteryChargingPower",
+		"currentLocationCoordinates",
+	})
+	require.Contains(t, query, "speed(agg:LAST)\n")
+	require.Contains(t, query, "powertrainCombustionEngineECT(agg:MAX)\n")
+	require.Contains(t, query, "powertrainTractionBatteryChargingPower(agg:MAX)\n")
+	require.Contains(t, query, "currentLocationCoordinates(agg:LAST) { latitude longitude hdop }\n")
+}
@@ -7,6 +7,22 @@ import (
 	"time"
 )
 
+// VSS fields supported by the telemetry API that are not yet generated in the model-garage vss package.
+const (
+	// FieldChassisAxleRow1WheelLeftBrakePadWear is the front left brake pad wear in percent, 0 is new and 100 is worn.
+	FieldChassisAxleRow1WheelLeftBrakePadWear = "chassisAxleRow1WheelLeftBrakePadWear"
+	// FieldChassisAxleRow1WheelRightBrakePadWear is the front right brake pad wear in percent, 0 is new and 100 is worn.
+	FieldChassisAxleRow1WheelRightBrakePadWear = "chassisAxleRow1WheelRightBrakePadWear"
+	// FieldChassisAxleRow2WheelLeftBrakePadWear is the rear left brake pad wear in percent, 0 is new and 100 is worn.
+	FieldChassisAxleRow2WheelLeftBrakePadWear = "chassisAxleRow2WheelLeftBrakePadWear"
+	// FieldChassisAxleRow2WheelRightBrakePadWear is the rear right brake pad wear in percent, 0 is new and 100 is worn.
+	FieldChassisAxleRow2WheelRightBrakePadWear = "chassisAxleRow2WheelRightBrakePadWear"
+	// FieldPowertrainTractionBatteryCellVoltageMax is the highest traction battery cell voltage in V.
+	FieldPowertrainTractionBatteryCellVoltageMax = "powertrainTractionBatteryCellVoltageMax"
+	// FieldPowertrainTractionBatteryCellVoltageMin is the lowest traction battery cell voltage in V.
+	FieldPowertrainTractionBatteryCellVoltageMin = "powertrainTractionBatteryCellVoltageMin"
+)
+
 // locationSignals is the set of signal names whose value is a Location object
 // rather than a scalar. They require a nested selection set in GraphQL queries.
 var locationSignals = map[string]bool{
@@ -14,6 +30,13 @@ var locationSignals = map[string]bool{
 	"currentLocationApproximateCoordinates": true,
 }
 
+// peakSignals is the set of signal names aggregated to the highest value of each interval rather than the last
+// value, since their peaks matter more than where they ended.
+var peakSignals = map[string]bool{
+	"powertrainCombustionEngineECT":          true,
+	"powertrainTractionBatteryChargingPower": true,
+}
+
 // GenerateLatestSignalsQuery generates a GraphQL query for latest signals based on requested signal names.
 func GenerateLatestSignalsQuery(signals []string) string {
 	if len(signals) == 0 {
@@ -28,9 +51,9 @@ func GenerateLatestSignalsQuery(signals []string) string {
 `)
 	for _, signal := range signals {
 		if locationSignals[signal] {
-			_, _ = fmt.Fprintf(&builder, "\t\t%s { timestamp value { latitude longitude hdop } }\n", signal)
+			_, _ = fmt.Fprintf(&builder, "\t\t%s { timestamp source producer value { latitude longitude hdop } }\n", signal)
 		} else {
-			_, _ = fmt.Fprintf(&builder, "\t\t%s { timestamp value }\n", signal)
+			_, _ = fmt.Fprintf(&builder, "\t\t%s { timestamp source producer value }\n", signal)
 		}
 	}
 
@@ -49,12 +72,17 @@ func GenerateHistoricalQuery(signals []string) string {
 	_, _ = builder.WriteString(`query ($tokenId: Int!, $from: Time!, $to: Time!, $interval: String!) {
 	signals(tokenId: $tokenId, from: $from, to: $to, interval: $interval) {
 		timestamp
+		source
+		producer
 `)
 
 	for _, signal := range signals {
-		if locationSignals[signal] {
+		switch {
+		case locationSignals[signal]:
 			_, _ = fmt.Fprintf(&builder, "\t\t%s(agg:LAST) { latitude longitude hdop }\n", signal)
-		} else {
+		case peakSignals[signal]:
+			_, _ = fmt.Fprintf(&builder, "\t\t%s(agg:MAX)\n", signal)
+		default:
 			_, _ = fmt.Fprintf(&builder, "\t\t%s(agg:LAST)\n", signal)
 		}
 	}
@@ -77,50 +105,93 @@ type dataField struct {
 
 // SignalCollection represents the latest signals collection from telemetry API.
 type SignalCollection struct {
-	LastSeen                                time.Time       `json:"lastSeen"`
-	CurrentLocationCoordinates              *SignalLocation `json:"currentLocationCoordinates"`
-	CurrentLocationApproximateCoordinates   *SignalLocation `json:"currentLocationApproximateCoordinates"`
-	PowertrainTransmissionTravelledDistance *SignalFloat    `json:"powertrainTransmissionTravelledDistance"`
-	Speed                                   *SignalFloat    `json:"speed"`
-	ObdDTCList                              *SignalString   `json:"obdDTCList"`
-	ObdStatusDTCCount                       *SignalFloat    `json:"obdStatusDTCCount"`
-	ChassisAxleRow1WheelLeftTirePressure    *SignalFloat    `json:"chassisAxleRow1WheelLeftTirePressure"`
-	ChassisAxleRow1WheelRightTirePressure   *SignalFloat    `json:"chassisAxleRow1WheelRightTirePressure"`
-	ChassisAxleRow2WheelLeftTirePressure    *SignalFloat    `json:"chassisAxleRow2WheelLeftTirePressure"`
-	ChassisAxleRow2WheelRightTirePressure   *SignalFloat    `json:"chassisAxleRow2WheelRightTirePressure"`
+	LastSeen                                            time.Time       `json:"lastSeen"`
+	CurrentLocationCoordinates                          *SignalLocation `json:"currentLocationCoordinates"`
+	CurrentLocationApproximateCoordinates               *SignalLocation `json:"currentLocationApproximateCoordinates"`
+	PowertrainTransmissionTravelledDistance             *SignalFloat    `json:"powertrainTransmissionTravelledDistance"`
+	Speed                                               *SignalFloat    `json:"speed"`
+	ObdDTCList                                          *SignalString   `json:"obdDTCList"`
+	ObdStatusDTCCount                                   *SignalFloat    `json:"obdStatusDTCCount"`
+	ChassisAxleRow1WheelLeftTirePressure                *SignalFloat    `json:"chassisAxleRow1WheelLeftTirePressure"`
+	ChassisAxleRow1WheelRightTirePressure               *SignalFloat    `json:"chassisAxleRow1WheelRightTirePressure"`
+	ChassisAxleRow2WheelLeftTirePressure                *SignalFloat    `json:"chassisAxleRow2WheelLeftTirePressure"`
+	ChassisAxleRow2WheelRightTirePressure               *SignalFloat    `json:"chassisAxleRow2WheelRightTirePressure"`
+	LowVoltageBatteryCurrentVoltage                     *SignalFloat    `json:"lowVoltageBatteryCurrentVoltage"`
+	PowertrainCombustionEngineECT                       *SignalFloat    `json:"powertrainCombustionEngineECT"`
+	PowertrainCombustionEngineEngineOilRelativeLevel    *SignalFloat    `json:"powertrainCombustionEngineEngineOilRelativeLevel"`
+	ChassisAxleRow1WheelLeftBrakePadWear                *SignalFloat    `json:"chassisAxleRow1WheelLeftBrakePadWear"`
+	ChassisAxleRow1WheelRightBrakePadWear               *SignalFloat    `json:"chassisAxleRow1WheelRightBrakePadWear"`
+	ChassisAxleRow2WheelLeftBrakePadWear                *SignalFloat    `json:"chassisAxleRow2WheelLeftBrakePadWear"`
+	ChassisAxleRow2WheelRightBrakePadWear               *SignalFloat    `json:"chassisAxleRow2WheelRightBrakePadWear"`
+	PowertrainTractionBatteryStateOfHealth              *SignalFloat    `json:"powertrainTractionBatteryStateOfHealth"`
+	PowertrainTractionBatteryCellVoltageMax             *SignalFloat    `json:"powertrainTractionBatteryCellVoltageMax"`
+	PowertrainTractionBatteryCellVoltageMin             *SignalFloat    `json:"powertrainTractionBatteryCellVoltageMin"`
+	PowertrainTractionBatteryGrossCapacity              *SignalFloat    `json:"powertrainTractionBatteryGrossCapacity"`
+	PowertrainTractionBatteryStateOfChargeCurrent       *SignalFloat    `json:"powertrainTractionBatteryStateOfChargeCurrent"`
+	PowertrainTractionBatteryStateOfChargeCurrentEnergy *SignalFloat    `json:"powertrainTractionBatteryStateOfChargeCurrentEnergy"`
+	PowertrainTractionBatteryRange                      *SignalFloat    `json:"powertrainTractionBatteryRange"`
+	PowertrainTractionBatteryChargingIsCharging         *SignalFloat    `json:"powertrainTractionBatteryChargingIsCharging"`
+	PowertrainTractionBatteryChargingPower              *SignalFloat    `json:"powertrainTractionBatteryChargingPower"`
+	PowertrainTractionBatteryChargingAddedEnergy        *SignalFloat    `json:"powertrainTractionBatteryChargingAddedEnergy"`
 }
 
 // SignalAggregations represents historical signal aggregations.
+// Source and Producer identify the origin of the last value in the interval.
 type SignalAggregations struct {
-	Timestamp                               time.Time `json:"timestamp"`
-	CurrentLocationCoordinates              *Location `json:"currentLocationCoordinates"`
-	CurrentLocationApproximateCoordinates   *Location `json:"currentLocationApproximateCoordinates"`
-	PowertrainTransmissionTravelledDistance *float64  `json:"powertrainTransmissionTravelledDistance"`
-	Speed                                   *float64  `json:"speed"`
-	ObdDTCList                              *string   `json:"obdDTCList"`
-	ObdStatusDTCCount                       *float64  `json:"obdStatusDTCCount"`
-	ChassisAxleRow1WheelLeftTirePressure    *float64  `json:"chassisAxleRow1WheelLeftTirePressure"`
-	ChassisAxleRow1WheelRightTirePressure   *float64  `json:"chassisAxleRow1WheelRightTirePressure"`
-	ChassisAxleRow2WheelLeftTirePressure    *float64  `json:"chassisAxleRow2WheelLeftTirePressure"`
-	ChassisAxleRow2WheelRightTirePressure   *float64  `json:"chassisAxleRow2WheelRightTirePressure"`
+	Timestamp                                           time.Time `json:"timestamp"`
+	Source                                              string    `json:"source"`
+	Producer                                            string    `json:"producer"`
+	CurrentLocationCoordinates                          *Location `json:"currentLocationCoordinates"`
+	CurrentLocationApproximateCoordinates               *Location `json:"currentLocationApproximateCoordinates"`
+	PowertrainTransmissionTravelledDistance             *float64  `json:"powertrainTransmissionTravelledDistance"`
+	Speed                                               *float64  `json:"speed"`
+	ObdDTCList                                          *string   `json:"obdDTCList"`
+	ObdStatusDTCCount                                   *float64  `json:"obdStatusDTCCount"`
+	ChassisAxleRow1WheelLeftTirePressure                *float64  `json:"chassisAxleRow1WheelLeftTirePressure"`
+	ChassisAxleRow1WheelRightTirePressure               *float64  `json:"chassisAxleRow1WheelRightTirePressure"`
+	ChassisAxleRow2WheelLeftTirePressure                *float64  `json:"chassisAxleRow2WheelLeftTirePressure"`
+	ChassisAxleRow2WheelRightTirePressure               *float64  `json:"chassisAxleRow2WheelRightTirePressure"`
+	LowVoltageBatteryCurrentVoltage                     *float64  `json:"lowVoltageBatteryCurrentVoltage"`
+	PowertrainCombustionEngineECT                       *float64  `json:"powertrainCombustionEngineECT"`
+	PowertrainCombustionEngineEngineOilRelativeLevel    *float64  `json:"powertrainCombustionEngineEngineOilRelativeLevel"`
+	ChassisAxleRow1WheelLeftBrakePadWear                *float64  `json:"chassisAxleRow1WheelLeftBrakePadWear"`
+	ChassisAxleRow1WheelRightBrakePadWear               *float64  `json:"chassisAxleRow1WheelRightBrakePadWear"`
+	ChassisAxleRow2WheelLeftBrakePadWear                *float64  `json:"chassisAxleRow2WheelLeftBrakePadWear"`
+	ChassisAxleRow2WheelRightBrakePadWear               *float64  `json:"chassisAxleRow2WheelRightBrakePadWear"`
+	PowertrainTractionBatteryStateOfHealth              *float64  `json:"powertrainTractionBatteryStateOfHealth"`
+	PowertrainTractionBatteryCellVoltageMax             *float64  `json:"powertrainTractionBatteryCellVoltageMax"`
+	PowertrainTractionBatteryCellVoltageMin             *float64  `json:"powertrainTractionBatteryCellVoltageMin"`
+	PowertrainTractionBatteryGrossCapacity              *float64  `json:"powertrainTractionBatteryGrossCapacity"`
+	PowertrainTractionBatteryStateOfChargeCurrent       *float64  `json:"powertrainTractionBatteryStateOfChargeCurrent"`
+	PowertrainTractionBatteryStateOfChargeCurrentEnergy *float64  `json:"powertrainTractionBatteryStateOfChargeCurrentEnergy"`
+	PowertrainTractionBatteryRange                      *float64  `json:"powertrainTractionBatteryRange"`
+	PowertrainTractionBatteryChargingIsCharging         *float64  `json:"powertrainTractionBatteryChargingIsCharging"`
+	PowertrainTractionBatteryChargingPower              *float64  `json:"powertrainTractionBatteryChargingPower"`
+	PowertrainTractionBatteryChargingAddedEnergy        *float64  `json:"powertrainTractionBatteryChargingAddedEnergy"`
 }
 
 // SignalFloat represents a float signal with timestamp.
 type SignalFloat struct {
 	Timestamp time.Time `json:"timestamp"`
 	Value     float64   `json:"value"`
+	Source    string    `json:"source"`
+	Producer  string    `json:"producer"`
 }
 
 // SignalString represents a string signal with timestamp.
 type SignalString struct {
 	Timestamp time.Time `json:"timestamp"`
 	Value     string    `json:"value"`
+	Source    string    `json:"source"`
+	Producer  string    `json:"producer"`
 }
 
 // SignalLocation represents a location signal with timestamp.
 type SignalLocation struct {
 	Timestamp time.Time `json:"timestamp"`
 	Value     Location  `json:"value"`
+	Source    string    `json:"source"`
+	Producer  string    `json:"producer"`
 }
 
 // Location is a WGS 84 coordinate as returned by the telemetry GraphQL API.
@@ -135,6 +206,10 @@ type Signal struct {
 	Name      string    `json:"name"`
 	Value     any       `json:"value"`
 	Timestamp time.Time `json:"timestamp"`
+	// Source is the address of the connection that ingested the value.
+	Source string `json:"source,omitempty"`
+	// Producer is the DID of the device that produced the value.
+	Producer string `json:"producer,omitempty"`
 }
 
 // graphQLError represents an error returned from the GraphQL API.
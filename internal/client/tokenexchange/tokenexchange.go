@@ -0,0 +1,132 @@
+// Package tokenexchange obtains vehicle tokens from token-exchange with the developer license of the service.
+package tokenexchange
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/tokencache"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const exchangeURI = "v1/tokens/exchange"
+
+// TokenGetter gets developer license tokens.
+type TokenGetter interface {
+	GetToken(ctx context.Context, devLicense string) (string, error)
+}
+
+type tokenRequest struct {
+	Asset       string   `json:"asset"`
+	Permissions []string `json:"permissions"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+// Client exchanges the developer license token of the service for vehicle tokens.
+type Client struct {
+	exchangeURL      string
+	devLicense       string
+	devLicenseTokens TokenGetter
+	vehicleAddr      common.Address
+	chainID          uint64
+	client           *http.Client
+	cache            *tokencache.Cache
+}
+
+// NewClient creates a new token-exchange client. Vehicle tokens are cached until shortly before they expire.
+func NewClient(settings *config.Settings, devLicenseTokens TokenGetter) (*Client, error) {
+	exchangeURL, err := url.JoinPath(settings.TokenExchangeURL, exchangeURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token exchange URL: %w", err)
+	}
+	c := &Client{
+		exchangeURL:      exchangeURL,
+		devLicense:       settings.DevLicense,
+		devLicenseTokens: devLicenseTokens,
+		vehicleAddr:      common.HexToAddress(settings.VehicleNFTAddress),
+		chainID:          uint64(settings.DIMORegistryChainID),
+		client:           &http.Client{Timeout: 30 * time.Second},
+	}
+	c.cache = tokencache.New(10*time.Minute, time.Hour, exchanger{c})
+	return c, nil
+}
+
+// GetVehicleToken returns a token with the permissions on the vehicle with the given token ID.
+func (c *Client) GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	permissions = slices.Sorted(slices.Values(permissions))
+	return c.cache.GetToken(ctx, strconv.FormatUint(uint64(tokenID), 10)+"/"+strings.Join(permissions, ","))
+}
+
+func (c *Client) exchange(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	devLicenseToken, err := c.devLicenseTokens.GetToken(ctx, c.devLicense)
+	if err != nil {
+		return "", fmt.Errorf("failed to get developer license token: %w", err)
+	}
+
+	asset := cloudevent.ERC721DID{
+		ChainID:         c.chainID,
+		ContractAddress: c.vehicleAddr,
+		TokenID:         new(big.Int).SetUint64(uint64(tokenID)),
+	}
+	body, err := json.Marshal(tokenRequest{Asset: asset.String(), Permissions: permissions})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.exchangeURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+devLicenseToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange token: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange returned non-200 status code: %d; %s", resp.StatusCode, string(respBody))
+	}
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+	if tokenResp.Token == "" {
+		return "", fmt.Errorf("token exchange returned an empty token")
+	}
+	return tokenResp.Token, nil
+}
+
+// exchanger gets the vehicle tokens of cache keys, the token ID and sorted permissions joined by "/".
+type exchanger struct {
+	client *Client
+}
+
+func (e exchanger) GetToken(ctx context.Context, key string) (string, error) {
+	tokenIDStr, permissions, _ := strings.Cut(key, "/")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid vehicle token key %q: %w", key, err)
+	}
+	return e.client.exchange(ctx, uint32(tokenID), strings.Split(permissions, ","))
+}
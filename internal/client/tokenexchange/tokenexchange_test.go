@@ -0,0 +1,71 @@
+package tokenexchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/tokenexchange"
+	"github.com/DIMO-Network/attestation-api/internal/config"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type staticTokens string
+
+func (s staticTokens) GetToken(context.Context, string) (string, error) {
+	return string(s), nil
+}
+
+func TestClient_GetVehicleToken(t *testing.T) {
+	vehicleToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	var requests []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/tokens/exchange", r.URL.Path)
+		require.Equal(t, "Bearer dev-license-token", r.Header.Get("Authorization"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+		if body["asset"] == "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:9" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": vehicleToken})
+	}))
+	defer server.Close()
+
+	client, err := tokenexchange.NewClient(&config.Settings{
+		TokenExchangeURL:    server.URL,
+		VehicleNFTAddress:   "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF",
+		DIMORegistryChainID: 137,
+	}, staticTokens("dev-license-token"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token, err := client.GetVehicleToken(ctx, 7, []string{tokenclaims.PermissionGetNonLocationHistory, tokenclaims.PermissionGetLocationHistory})
+	require.NoError(t, err)
+	require.Equal(t, vehicleToken, token)
+	require.Len(t, requests, 1)
+	require.Equal(t, "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:7", requests[0]["asset"])
+	require.ElementsMatch(t, []any{tokenclaims.PermissionGetNonLocationHistory, tokenclaims.PermissionGetLocationHistory}, requests[0]["permissions"])
+
+	// the same permissions in another order are served from the cache
+	_, err = client.GetVehicleToken(ctx, 7, []string{tokenclaims.PermissionGetLocationHistory, tokenclaims.PermissionGetNonLocationHistory})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	_, err = client.GetVehicleToken(ctx, 7, []string{tokenclaims.PermissionGetLocationHistory})
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	_, err = client.GetVehicleToken(ctx, 9, []string{tokenclaims.PermissionGetLocationHistory})
+	require.Error(t, err)
+}
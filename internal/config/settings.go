@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // Settings contains the application config.
 type Settings struct {
 	Port                      int    `env:"PORT"`
@@ -21,4 +23,84 @@ type Settings struct {
 	FetchGRPCAddr             string `env:"FETCH_GRPC_ADDR"`
 	RedirectURL               string `env:"DEV_LICENSE_REDIRECT_URL"`
 	VINDataVersion            string `env:"VIN_DATA_VERSION"`
+	// TirePressureFallback is a JSON object of placard tire pressures in kPa keyed by definition ID prefix,
+	// e.g. {"ford_f-250": {"front": 414, "rear": 552}}. It is used when a definition has no placard attributes.
+	TirePressureFallback string `env:"TIRE_PRESSURE_FALLBACK"`
+	// HealthRulesFile is the path of the health scoring rule file, the built-in rule sets are used when empty.
+	HealthRulesFile string `env:"HEALTH_RULES_FILE"`
+	// HealthQueryConcurrency is the number of telemetry queries run at once for long health time ranges, 4 when unset.
+	HealthQueryConcurrency int `env:"HEALTH_QUERY_CONCURRENCY"`
+	// VINConsensusWindowDays is how many days of fingerprint messages are considered when selecting a VIN, 30 when unset.
+	VINConsensusWindowDays int `env:"VIN_CONSENSUS_WINDOW_DAYS"`
+	// VINConsensusMinMessages is how many fingerprint messages must report a VIN before it is attested, 3 when unset.
+	VINConsensusMinMessages int `env:"VIN_CONSENSUS_MIN_MESSAGES"`
+	// VINConsensusMinDays is how many days apart the first and last report of a VIN suffice to attest it
+	// with fewer than VINConsensusMinMessages messages, 2 when unset.
+	VINConsensusMinDays int `env:"VIN_CONSENSUS_MIN_DAYS"`
+	// RequireFingerprintSignatures rejects unsigned fingerprint messages from aftermarket devices instead of
+	// recording them as unsigned. Either way, unsigned messages are not counted towards the VIN consensus
+	// unless AcceptUnsignedFingerprints is set.
+	RequireFingerprintSignatures bool `env:"REQUIRE_FINGERPRINT_SIGNATURES"`
+	// AcceptUnsignedFingerprints counts unsigned fingerprint messages of aftermarket devices that never signed
+	// a message towards the VIN consensus.
+	AcceptUnsignedFingerprints bool `env:"ACCEPT_UNSIGNED_FINGERPRINTS"`
+	// AdminJWKKeySetURL is the JWK set URL of the operator tokens of the admin endpoints, which are disabled when empty.
+	AdminJWKKeySetURL string `env:"ADMIN_JWK_KEY_SET_URL"`
+	// AdminRole is the role operator tokens must carry in their roles or groups claim, "attestation-admin" when unset.
+	AdminRole string `env:"ADMIN_ROLE"`
+	// ManualVINRequireApproval leaves manual VIN attestation requests pending until a second operator approves them.
+	ManualVINRequireApproval bool `env:"MANUAL_VIN_REQUIRE_APPROVAL"`
+	// DatabasePath is the path of the embedded database holding manual VIN requests and the audit log, which must be
+	// on a persistent volume. The database can only be opened by one process, so every feature storing data in it
+	// runs on a single replica. The service fails to start when such a feature is enabled without it.
+	DatabasePath string `env:"DATABASE_PATH"`
+	// GRPCTLSCertFile and GRPCTLSKeyFile are the PEM certificate and key of the gRPC server, which serves plaintext when unset.
+	GRPCTLSCertFile string `env:"GRPC_TLS_CERT_FILE"`
+	GRPCTLSKeyFile  string `env:"GRPC_TLS_KEY_FILE"`
+	// GRPCClientCAFile is the PEM bundle of the CAs verifying gRPC client certificates. Clients without a certificate
+	// must authenticate with a token-exchange JWT.
+	GRPCClientCAFile string `env:"GRPC_CLIENT_CA_FILE"`
+	// GRPCClientPermissions is a JSON object of gRPC client certificate identities, a URI, DNS name or common name,
+	// and their permissions, e.g. {"spiffe://dimo/ns/dimo/sa/vehicle-triggers-api": ["vin:create", "vin:force"]}.
+	GRPCClientPermissions string `env:"GRPC_CLIENT_PERMISSIONS"`
+	// TokenExchangeURL is the base URL of token-exchange. When set, the service exchanges its developer license token
+	// for vehicle tokens to read telemetry on behalf of internal callers and scheduled jobs.
+	TokenExchangeURL string `env:"TOKEN_EXCHANGE_URL"`
+	// AsyncJobWorkers is the number of workers running asynchronous attestation jobs, which are stored in the
+	// database at DatabasePath. Requests preferring an asynchronous response are answered synchronously when 0.
+	// Telemetry jobs get their vehicle token from TokenExchangeURL when they run and are rejected without it.
+	AsyncJobWorkers int `env:"ASYNC_JOB_WORKERS"`
+	// AsyncJobMaxQueued is how many jobs can wait for a worker before new jobs are rejected, 1000 when unset.
+	AsyncJobMaxQueued int `env:"ASYNC_JOB_MAX_QUEUED"`
+	// VINRenewalInterval is how often the VIN attestations about to expire are renewed, which is disabled when 0.
+	// The progress of a renewal is stored in the database at DatabasePath.
+	VINRenewalInterval time.Duration `env:"VIN_RENEWAL_INTERVAL"`
+	// VINRenewalWindow renews the VIN attestations expiring within it, 48h when unset.
+	VINRenewalWindow time.Duration `env:"VIN_RENEWAL_WINDOW"`
+	// VINRenewalRate is the maximum number of VIN attestations renewed per second, 2 when unset.
+	VINRenewalRate float64 `env:"VIN_RENEWAL_RATE"`
+	// FingerprintNATSURL is the NATS server the fingerprint events are received from to attest the VINs they report,
+	// which is disabled when empty. The attested VINs are stored in the database at DatabasePath.
+	FingerprintNATSURL string `env:"FINGERPRINT_NATS_URL"`
+	// FingerprintNATSStream is the JetStream stream of the fingerprint events.
+	FingerprintNATSStream string `env:"FINGERPRINT_NATS_STREAM"`
+	// FingerprintNATSSubject filters the subjects of the stream, every subject when empty.
+	FingerprintNATSSubject string `env:"FINGERPRINT_NATS_SUBJECT"`
+	// FingerprintNATSConsumer is the durable consumer shared by the replicas, attestation-api-auto-vin when unset.
+	FingerprintNATSConsumer string `env:"FINGERPRINT_NATS_CONSUMER"`
+	// AutoVINWorkers is the number of fingerprint events processed at once, 4 when unset.
+	AutoVINWorkers int `env:"AUTO_VIN_WORKERS"`
+	// IssuanceLedger records every attestation uploaded by the service and its requester in the database at
+	// LedgerDatabasePath. The ledger is queried through the admin endpoints.
+	IssuanceLedger bool `env:"ISSUANCE_LEDGER"`
+	// LedgerDatabasePath is the path of the SQLite database of the issuance ledger. Processes sharing the file, such as
+	// the API and the backfill command, record their attestations in it at once. It must be on a local volume, since
+	// SQLite cannot lock files on network file systems reliably.
+	LedgerDatabasePath string `env:"LEDGER_DATABASE_PATH"`
+	// RateLimits is a JSON object of attestation types, or "default", and the requests each dev license and vehicle
+	// can make per minute and per UTC day, e.g. {"default": {"clientPerMinute": 600, "vehiclePerDay": 100}}.
+	// Requests are unlimited when unset. Each replica counts the requests it serves, so the effective limits are the
+	// configured limits times the number of replicas. Daily quotas and usage are saved in the database at
+	// DatabasePath every minute and on shutdown and survive restarts, and they start over on restart without it.
+	RateLimits string `env:"RATE_LIMITS"`
 }
@@ -0,0 +1,191 @@
+package httphandlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/manualvin"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestIDParam is the parameter name for the manual VIN request id.
+const RequestIDParam = "requestId"
+
+// AdminController handles the operator requests of the admin endpoints.
+type AdminController struct {
+	manualVINService ManualVINService
+}
+
+// ManualVINService defines the interface for manual VIN attestation operations.
+type ManualVINService interface {
+	Submit(ctx context.Context, op *operator.Operator, req manualvin.Request) (*manualvin.Result, error)
+	Approve(ctx context.Context, op *operator.Operator, requestID string) (*manualvin.Result, error)
+	Reject(ctx context.Context, op *operator.Operator, requestID string, reason string) (*models.ManualVINRequest, error)
+	AuditLog(ctx context.Context, tokenID uint32) ([]models.AuditEntry, error)
+}
+
+// NewAdminController creates a new AdminController.
+func NewAdminController(manualVINService ManualVINService) *AdminController {
+	return &AdminController{manualVINService: manualVINService}
+}
+
+// CreateManualVINRequest represents the request body for creating a manual VIN attestation.
+type CreateManualVINRequest struct {
+	VIN string `json:"vin" validate:"required" example:"1HGCM82673A123456"`
+	// ISO 3166-1 alpha-3 country code, defaults to the country of manufacture decoded from the VIN.
+	CountryCode string `json:"countryCode" example:"USA"`
+	// Why the VIN is attested manually.
+	Reason string `json:"reason" validate:"required"`
+	// Reference to the supporting evidence, e.g. a support ticket or a document ID.
+	EvidenceRef string `json:"evidenceRef" validate:"required"`
+}
+
+// RejectManualVINRequest represents the request body for rejecting a manual VIN attestation request.
+type RejectManualVINRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type manualVINResponse struct {
+	Request *models.ManualVINRequest `json:"request"`
+	// Attestation is the issued attestation, absent while the request awaits approval.
+	Attestation *cloudevent.RawEvent `json:"attestation,omitempty"`
+}
+
+// @Summary Create Manual VIN Attestation
+// @Description Attest a VIN for a given token Id of a vehicle NFT without fingerprint evidence. Requires an operator token with the admin role.
+// @Description Returns 201 with the attestation, or 202 when the request must be approved by a second operator.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param  tokenId path int true "token Id of the vehicle NFT"
+// @Param  request body CreateManualVINRequest true "Request body"
+// @Success 201 {object} manualVINResponse
+// @Success 202 {object} manualVINResponse
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/vin/{tokenId}/manual [post]
+func (a *AdminController) CreateManualVINAttestation(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	tokenID, err := tokenIDFromParams(fiberCtx)
+	if err != nil {
+		return err
+	}
+
+	var req CreateManualVINRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	result, err := a.manualVINService.Submit(fiberCtx.Context(), op, manualvin.Request{
+		TokenID:     tokenID,
+		VIN:         req.VIN,
+		CountryCode: req.CountryCode,
+		Reason:      req.Reason,
+		EvidenceRef: req.EvidenceRef,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create manual VIN attestation: %w", err)
+	}
+	status := fiber.StatusCreated
+	if result.Attestation == nil {
+		status = fiber.StatusAccepted
+	}
+	return fiberCtx.Status(status).JSON(manualVINResponse{Request: result.Request, Attestation: result.Attestation})
+}
+
+// @Summary Approve Manual VIN Attestation Request
+// @Description Approve a pending manual VIN attestation request of another operator and issue the attestation.
+// @Tags Admin
+// @Produce json
+// @Param  requestId path string true "id of the manual VIN request"
+// @Success 201 {object} manualVINResponse
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/vin/requests/{requestId}/approve [post]
+func (a *AdminController) ApproveManualVINRequest(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	requestID := fiberCtx.Params(RequestIDParam)
+	if requestID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "request_id path parameter is required")
+	}
+
+	result, err := a.manualVINService.Approve(fiberCtx.Context(), op, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to approve manual VIN request: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusCreated).JSON(manualVINResponse{Request: result.Request, Attestation: result.Attestation})
+}
+
+// @Summary Reject Manual VIN Attestation Request
+// @Description Reject a pending manual VIN attestation request.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param  requestId path string true "id of the manual VIN request"
+// @Param  request body RejectManualVINRequest true "Request body"
+// @Success 200 {object} models.ManualVINRequest
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/vin/requests/{requestId}/reject [post]
+func (a *AdminController) RejectManualVINRequest(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	requestID := fiberCtx.Params(RequestIDParam)
+	if requestID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "request_id path parameter is required")
+	}
+
+	var req RejectManualVINRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	record, err := a.manualVINService.Reject(fiberCtx.Context(), op, requestID, req.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to reject manual VIN request: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(record)
+}
+
+// @Summary Get Manual VIN Audit Log
+// @Description List the manual VIN requests, approvals, rejections and issuances for a given token Id of a vehicle NFT, oldest first.
+// @Tags Admin
+// @Produce json
+// @Param  tokenId path int true "token Id of the vehicle NFT"
+// @Success 200 {array} models.AuditEntry
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/vin/{tokenId}/audit [get]
+func (a *AdminController) GetManualVINAuditLog(fiberCtx *fiber.Ctx) error {
+	tokenID, err := tokenIDFromParams(fiberCtx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := a.manualVINService.AuditLog(fiberCtx.Context(), tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get manual VIN audit log: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(entries)
+}
+
+// tokenIDFromParams parses the vehicle token id path parameter.
+func tokenIDFromParams(fiberCtx *fiber.Ctx) (uint32, error) {
+	tokenIDStr := fiberCtx.Params(TokenIDParam)
+	if tokenIDStr == "" {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "token_id path parameter is required")
+	}
+	tokenID64, err := strconv.ParseUint(tokenIDStr, 10, 32)
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid token_id format")
+	}
+	return uint32(tokenID64), nil
+}
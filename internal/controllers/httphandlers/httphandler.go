@@ -8,6 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
+	"github.com/DIMO-Network/attestation-api/internal/models"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/gofiber/fiber/v2"
 )
@@ -41,12 +47,15 @@ type HTTPController struct {
 	vehiclePositionService   VehiclePositionVCService
 	odometerStatementService OdometerStatementVCService
 	vehicleHealthService     VehicleHealthVCService
+	batteryHealthService     BatteryHealthVCService
+	jobs                     JobService
 	telemetryBaseURL         *url.URL
 }
 
 // VINVCService defines the interface for VIN VC operations.
 type VINVCService interface {
 	CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
+	CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error)
 }
 
 type POMVCService interface {
@@ -55,21 +64,27 @@ type POMVCService interface {
 
 // VehiclePositionVCService defines the interface for VehiclePositionVC operations.
 type VehiclePositionVCService interface {
-	CreateVehiclePositionVC(ctx context.Context, tokenID uint32, timestamp time.Time, jwtToken string) error
+	CreateVehiclePositionVC(ctx context.Context, tokenID uint32, timestamp time.Time, opts vehiclepositionvc.PositionOptions, jwtToken string) (*cloudevent.RawEvent, error)
 }
 
 // OdometerStatementVCService defines the interface for OdometerStatementVC operations.
 type OdometerStatementVCService interface {
-	CreateOdometerStatementVC(ctx context.Context, tokenID uint32, timestamp *time.Time, jwtToken string) error
+	CreateOdometerStatementVC(ctx context.Context, tokenID uint32, timestamp *time.Time, opts odometerstatementvc.StatementOptions, jwtToken string) (*cloudevent.RawEvent, error)
 }
 
 // VehicleHealthVCService defines the interface for VehicleHealthVC operations.
 type VehicleHealthVCService interface {
-	CreateVehicleHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, jwtToken string) error
+	CreateVehicleHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, opts vehiclehealthvc.HealthOptions, jwtToken string) (*cloudevent.RawEvent, error)
 }
 
-// NewVCController creates a new http VCController.
-func NewVCController(vinService VINVCService, pomService POMVCService, vehiclePositionService VehiclePositionVCService, odometerStatementService OdometerStatementVCService, vehicleHealthService VehicleHealthVCService, telemetryURL string) (*HTTPController, error) {
+// BatteryHealthVCService defines the interface for BatteryHealthVC operations.
+type BatteryHealthVCService interface {
+	CreateBatteryHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// NewVCController creates a new http VCController. Requests preferring an asynchronous response are answered
+// synchronously when jobs is nil.
+func NewVCController(vinService VINVCService, pomService POMVCService, vehiclePositionService VehiclePositionVCService, odometerStatementService OdometerStatementVCService, vehicleHealthService VehicleHealthVCService, batteryHealthService BatteryHealthVCService, jobs JobService, telemetryURL string) (*HTTPController, error) {
 	parsedURL, err := sanitizeTelemetryURL(telemetryURL)
 	if err != nil {
 		return nil, err
@@ -81,6 +96,8 @@ func NewVCController(vinService VINVCService, pomService POMVCService, vehiclePo
 		vehiclePositionService:   vehiclePositionService,
 		odometerStatementService: odometerStatementService,
 		vehicleHealthService:     vehicleHealthService,
+		batteryHealthService:     batteryHealthService,
+		jobs:                     jobs,
 		telemetryBaseURL:         parsedURL,
 	}, nil
 }
@@ -91,7 +108,10 @@ func NewVCController(vinService VINVCService, pomService POMVCService, vehiclePo
 // @Accept json
 // @Produce json
 // @Param  tokenId path int true "token Id of the vehicle NFT"
+// @Param  Prefer header string false "respond-async to create the attestation in the background"
+// @Param  webhookUrl query string false "HTTPS URL called with the job when an asynchronous request finishes"
 // @Success 200 {object} getVCResponse
+// @Success 202 {object} models.JobStatus
 // @Security     BearerAuth
 // @Router /v2/attestation/vin/{tokenId} [post]
 func (v *HTTPController) CreateVINAttestation(fiberCtx *fiber.Ctx) error {
@@ -107,6 +127,9 @@ func (v *HTTPController) CreateVINAttestation(fiberCtx *fiber.Ctx) error {
 	}
 
 	tokenID := uint32(tokenID64)
+	if v.preferAsync(fiberCtx) {
+		return v.submitJob(fiberCtx, models.JobTypeVIN, tokenID, nil)
+	}
 	_, err = v.vinService.CreateAndStoreVINAttestation(ctx, tokenID)
 	if err != nil {
 		return fmt.Errorf("failed to get or create VC: %w", err)
@@ -114,6 +137,34 @@ func (v *HTTPController) CreateVINAttestation(fiberCtx *fiber.Ctx) error {
 	return fiberCtx.Status(fiber.StatusOK).JSON(v.successResponse(tokenID, vinvcQuery))
 }
 
+// @Summary Get VIN Attestation Eligibility
+// @Description Run the VIN attestation checks for a given token Id of a vehicle NFT without creating an attestation.
+// @Description Reports the fingerprint messages of each paired device, the decoded VIN and the final decision with a reason code.
+// @Tags VINVC
+// @Produce json
+// @Param  tokenId path int true "token Id of the vehicle NFT"
+// @Success 200 {object} vinvc.EligibilityReport
+// @Security     BearerAuth
+// @Router /v2/attestation/vin/{tokenId}/eligibility [get]
+func (v *HTTPController) GetVINEligibility(fiberCtx *fiber.Ctx) error {
+	ctx := fiberCtx.Context()
+	tokenIDStr := fiberCtx.Params(TokenIDParam)
+	if tokenIDStr == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "token_id path parameter is required")
+	}
+
+	tokenID64, err := strconv.ParseUint(tokenIDStr, 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid token_id format")
+	}
+
+	report, err := v.vinService.CheckVINEligibility(ctx, uint32(tokenID64))
+	if err != nil {
+		return fmt.Errorf("failed to check VIN eligibility: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(report)
+}
+
 // successResponse generates a success response for the given token ID.
 func (v *HTTPController) successResponse(tokenID uint32, query string) *getVCResponse {
 	queryURL := v.telemetryBaseURL.JoinPath("query")
@@ -166,6 +217,8 @@ func sanitizeTelemetryURL(telemetryURL string) (*url.URL, error) {
 // CreateVehiclePositionVCRequest represents the request body for creating a VehiclePositionVC.
 type CreateVehiclePositionVCRequest struct {
 	Timestamp time.Time `json:"timestamp" validate:"required" example:"2021-01-01T00:00:00Z"`
+	// H3Resolution is the H3 resolution of the attested cell, from 1 to 8. Defaults to 8.
+	H3Resolution int `json:"h3Resolution,omitempty" example:"8"`
 }
 
 // @Summary Create Vehicle Position Attestation
@@ -175,7 +228,10 @@ type CreateVehiclePositionVCRequest struct {
 // @Produce json
 // @Param  tokenId path int true "token Id of the vehicle NFT"
 // @Param  request body CreateVehiclePositionVCRequest true "Request body"
+// @Param  Prefer header string false "respond-async to create the attestation in the background"
+// @Param  webhookUrl query string false "HTTPS URL called with the job when an asynchronous request finishes"
 // @Success 200 {object} successResponse
+// @Success 202 {object} models.JobStatus
 // @Security     BearerAuth
 // @Router /v2/attestation/vehicle-position/{tokenId} [post]
 func (v *HTTPController) CreateVehiclePositionAttestation(fiberCtx *fiber.Ctx) error {
@@ -206,7 +262,11 @@ func (v *HTTPController) CreateVehiclePositionAttestation(fiberCtx *fiber.Ctx) e
 	}
 
 	tokenID := uint32(tokenID64)
-	err = v.vehiclePositionService.CreateVehiclePositionVC(ctx, tokenID, req.Timestamp, jwtToken)
+	if v.preferAsync(fiberCtx) {
+		return v.submitJob(fiberCtx, models.JobTypeVehiclePosition, tokenID, req)
+	}
+	opts := vehiclepositionvc.PositionOptions{H3Resolution: req.H3Resolution}
+	_, err = v.vehiclePositionService.CreateVehiclePositionVC(ctx, tokenID, req.Timestamp, opts, jwtToken)
 	if err != nil {
 		return fmt.Errorf("failed to create VehiclePositionVC: %w", err)
 	}
@@ -217,16 +277,25 @@ func (v *HTTPController) CreateVehiclePositionAttestation(fiberCtx *fiber.Ctx) e
 // CreateOdometerStatementVCRequest represents the request body for creating an OdometerStatementVC.
 type CreateOdometerStatementVCRequest struct {
 	Timestamp *time.Time `json:"timestamp,omitempty" example:"2021-01-01T00:00:00Z"` // Optional timestamp
+	// Unit of the attested reading, "km" or "miles". Defaults to "km", or "miles" for the us-federal disclosure profile.
+	Unit string `json:"unit,omitempty" enums:"km,miles" example:"miles"`
+	// DisclosureProfile adds regional disclosure fields to the statement.
+	DisclosureProfile string `json:"disclosureProfile,omitempty" enums:"us-federal" example:"us-federal"`
 }
 
 // @Summary Create Odometer Statement Attestation
 // @Description Generate a new Odometer Statement attestation for a given token Id. If timestamp is not provided, uses the latest odometer reading.
+// @Description Miles are converted from kilometers using the international mile (1 mile = 1.609344 km).
+// @Description The us-federal disclosure profile requires an existing VIN attestation for the vehicle.
 // @Tags OdometerStatementVC
 // @Accept json
 // @Produce json
 // @Param  tokenId path int true "token Id of the vehicle NFT"
 // @Param  request body CreateOdometerStatementVCRequest false "Request body"
+// @Param  Prefer header string false "respond-async to create the attestation in the background"
+// @Param  webhookUrl query string false "HTTPS URL called with the job when an asynchronous request finishes"
 // @Success 200 {object} successResponse
+// @Success 202 {object} models.JobStatus
 // @Security     BearerAuth
 // @Router /v2/attestation/odometer-statement/{tokenId} [post]
 func (v *HTTPController) CreateOdometerStatementAttestation(fiberCtx *fiber.Ctx) error {
@@ -256,7 +325,14 @@ func (v *HTTPController) CreateOdometerStatementAttestation(fiberCtx *fiber.Ctx)
 	}
 
 	tokenID := uint32(tokenID64)
-	err = v.odometerStatementService.CreateOdometerStatementVC(ctx, tokenID, req.Timestamp, jwtToken)
+	if v.preferAsync(fiberCtx) {
+		return v.submitJob(fiberCtx, models.JobTypeOdometerStatement, tokenID, req)
+	}
+	opts := odometerstatementvc.StatementOptions{
+		Unit:              req.Unit,
+		DisclosureProfile: req.DisclosureProfile,
+	}
+	_, err = v.odometerStatementService.CreateOdometerStatementVC(ctx, tokenID, req.Timestamp, opts, jwtToken)
 	if err != nil {
 		return fmt.Errorf("failed to create OdometerStatementVC: %w", err)
 	}
@@ -268,16 +344,22 @@ func (v *HTTPController) CreateOdometerStatementAttestation(fiberCtx *fiber.Ctx)
 type CreateVehicleHealthVCRequest struct {
 	StartTime time.Time `json:"startTime" validate:"required" example:"2021-01-01T00:00:00Z"`
 	EndTime   time.Time `json:"endTime" validate:"required" example:"2021-01-15T00:00:00Z"`
+	// RuleSet is the name of the health rule set used to score the vehicle, e.g. "resale". Defaults to "default".
+	RuleSet string `json:"ruleSet,omitempty" example:"default"`
 }
 
 // @Summary Create Vehicle Health Attestation
-// @Description Generate a new Vehicle Health attestation for a given token Id and time range. The time range cannot exceed 30 days.
+// @Description Generate a new Vehicle Health attestation for a given token Id and time range. The time range cannot exceed 366 days; long ranges are evaluated at a coarser sample interval.
+// @Description The optional ruleSet selects the health definition used for the score; its name and version are recorded in the attestation.
 // @Tags VehicleHealthVC
 // @Accept json
 // @Produce json
 // @Param  tokenId path int true "token Id of the vehicle NFT"
 // @Param  request body CreateVehicleHealthVCRequest true "Request body"
+// @Param  Prefer header string false "respond-async to create the attestation in the background"
+// @Param  webhookUrl query string false "HTTPS URL called with the job when an asynchronous request finishes"
 // @Success 200 {object} successResponse
+// @Success 202 {object} models.JobStatus
 // @Security     BearerAuth
 // @Router /v2/attestation/vehicle-health/{tokenId} [post]
 func (v *HTTPController) CreateVehicleHealthAttestation(fiberCtx *fiber.Ctx) error {
@@ -302,10 +384,9 @@ func (v *HTTPController) CreateVehicleHealthAttestation(fiberCtx *fiber.Ctx) err
 		return fiber.NewError(fiber.StatusBadRequest, "startTime must be before endTime")
 	}
 
-	// Validate time range is not more than 30 days
-	maxDuration := 30 * 24 * time.Hour
-	if req.EndTime.Sub(req.StartTime) > maxDuration {
-		return fiber.NewError(fiber.StatusBadRequest, "time range cannot exceed 30 days")
+	// Validate time range is not more than a year
+	if req.EndTime.Sub(req.StartTime) > vehiclehealthvc.MaxTimeRange {
+		return fiber.NewError(fiber.StatusBadRequest, "time range cannot exceed 366 days")
 	}
 
 	// Extract JWT token from Authorization header
@@ -319,10 +400,81 @@ func (v *HTTPController) CreateVehicleHealthAttestation(fiberCtx *fiber.Ctx) err
 	}
 
 	tokenID := uint32(tokenID64)
-	err = v.vehicleHealthService.CreateVehicleHealthVC(ctx, tokenID, req.StartTime, req.EndTime, jwtToken)
+	if v.preferAsync(fiberCtx) {
+		return v.submitJob(fiberCtx, models.JobTypeVehicleHealth, tokenID, req)
+	}
+	opts := vehiclehealthvc.HealthOptions{RuleSet: req.RuleSet}
+	_, err = v.vehicleHealthService.CreateVehicleHealthVC(ctx, tokenID, req.StartTime, req.EndTime, opts, jwtToken)
 	if err != nil {
 		return fmt.Errorf("failed to create VehicleHealthVC: %w", err)
 	}
 
 	return fiberCtx.Status(fiber.StatusOK).JSON(successResponse{Message: successMessage})
 }
+
+// CreateBatteryHealthVCRequest represents the request body for creating a BatteryHealthVC.
+type CreateBatteryHealthVCRequest struct {
+	StartTime time.Time `json:"startTime" validate:"required" example:"2021-01-01T00:00:00Z"`
+	EndTime   time.Time `json:"endTime" validate:"required" example:"2021-03-01T00:00:00Z"`
+}
+
+// @Summary Create Battery Health Attestation
+// @Description Generate a new EV battery state of health attestation for a given token Id and time range. The time range cannot exceed 90 days.
+// @Description The attestation covers capacity, state of health, full charge range and DC fast charge share estimated from the charge sessions in the range.
+// @Tags BatteryHealthVC
+// @Accept json
+// @Produce json
+// @Param  tokenId path int true "token Id of the vehicle NFT"
+// @Param  request body CreateBatteryHealthVCRequest true "Request body"
+// @Param  Prefer header string false "respond-async to create the attestation in the background"
+// @Param  webhookUrl query string false "HTTPS URL called with the job when an asynchronous request finishes"
+// @Success 200 {object} successResponse
+// @Success 202 {object} models.JobStatus
+// @Security     BearerAuth
+// @Router /v2/attestation/battery-health/{tokenId} [post]
+func (v *HTTPController) CreateBatteryHealthAttestation(fiberCtx *fiber.Ctx) error {
+	ctx := fiberCtx.Context()
+	tokenIDStr := fiberCtx.Params(TokenIDParam)
+	if tokenIDStr == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "token_id path parameter is required")
+	}
+
+	tokenID64, err := strconv.ParseUint(tokenIDStr, 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid token_id format")
+	}
+
+	var req CreateBatteryHealthVCRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	// Validate time range
+	if req.StartTime.After(req.EndTime) {
+		return fiber.NewError(fiber.StatusBadRequest, "startTime must be before endTime")
+	}
+	if req.EndTime.Sub(req.StartTime) > batteryhealthvc.MaxTimeRange {
+		return fiber.NewError(fiber.StatusBadRequest, "time range cannot exceed 90 days")
+	}
+
+	// Extract JWT token from Authorization header
+	authHeader := fiberCtx.Get("Authorization")
+	jwtToken := ""
+	if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
+		jwtToken = strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if jwtToken == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "JWT token is required")
+	}
+
+	tokenID := uint32(tokenID64)
+	if v.preferAsync(fiberCtx) {
+		return v.submitJob(fiberCtx, models.JobTypeBatteryHealth, tokenID, req)
+	}
+	_, err = v.batteryHealthService.CreateBatteryHealthVC(ctx, tokenID, req.StartTime, req.EndTime, jwtToken)
+	if err != nil {
+		return fmt.Errorf("failed to create BatteryHealthVC: %w", err)
+	}
+
+	return fiberCtx.Status(fiber.StatusOK).JSON(successResponse{Message: successMessage})
+}
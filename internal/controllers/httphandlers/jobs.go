@@ -0,0 +1,182 @@
+package httphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/webhook"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/fibercommon/jwtmiddleware"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// JobIDParam is the parameter name for the asynchronous job id.
+	JobIDParam = "jobId"
+
+	// WebhookURLQuery is the query parameter of the webhook called when an asynchronous job finishes.
+	WebhookURLQuery = "webhookUrl"
+
+	respondAsync = "respond-async"
+)
+
+// JobService defines the interface for asynchronous attestation jobs.
+type JobService interface {
+	Submit(ctx context.Context, job *models.Job) (*models.Job, error)
+	Get(ctx context.Context, id string) (*models.Job, error)
+}
+
+// JobController handles asynchronous attestation job requests.
+type JobController struct {
+	jobs        JobService
+	vehicleAddr common.Address
+}
+
+// NewJobController creates a new JobController for jobs of vehicles of the vehicle contract.
+func NewJobController(jobs JobService, vehicleAddr common.Address) *JobController {
+	return &JobController{jobs: jobs, vehicleAddr: vehicleAddr}
+}
+
+// @Summary Get Attestation Job
+// @Description Get the state of an asynchronous attestation job and the signed attestation once it succeeded.
+// @Description The token must carry the privileges of the attestation on the vehicle of the job. Finished jobs are kept for 7 days.
+// @Tags Jobs
+// @Produce json
+// @Param  jobId path string true "job Id returned when the attestation was requested"
+// @Success 200 {object} models.JobStatus
+// @Security     BearerAuth
+// @Router /v2/attestation/jobs/{jobId} [get]
+func (j *JobController) GetJob(fiberCtx *fiber.Ctx) error {
+	job, err := j.jobs.Get(fiberCtx.Context(), fiberCtx.Params(JobIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	claims, err := jwtmiddleware.GetTokenClaim(fiberCtx)
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+	}
+	// jobs of other vehicles are reported as missing so their IDs cannot be probed
+	if !j.canReadJob(claims, job) {
+		return fiber.NewError(fiber.StatusNotFound, "Record not found")
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(job.Status())
+}
+
+// canReadJob reports whether the token grants the privileges of the job's attestation on the job's vehicle.
+func (j *JobController) canReadJob(claims *tokenclaims.Token, job *models.Job) bool {
+	assetDID, err := cloudevent.DecodeERC721DID(claims.Asset)
+	if err != nil || assetDID.ContractAddress != j.vehicleAddr || assetDID.TokenID.Cmp(new(big.Int).SetUint64(uint64(job.TokenID))) != 0 {
+		return false
+	}
+	permissions, ok := jobPermissions[job.Type]
+	if !ok {
+		return false
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(claims.Permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// jobPermissions are the privileges needed to request the attestation of each job type.
+var jobPermissions = map[string][]string{
+	models.JobTypeVIN:               {tokenclaims.PermissionGetVINCredential},
+	models.JobTypeVehiclePosition:   vehiclepositionvc.TelemetryPermissions,
+	models.JobTypeOdometerStatement: odometerstatementvc.TelemetryPermissions,
+	models.JobTypeVehicleHealth:     vehiclehealthvc.TelemetryPermissions,
+	models.JobTypeBatteryHealth:     batteryhealthvc.TelemetryPermissions,
+}
+
+// preferAsync reports whether the client prefers an asynchronous response and jobs are enabled.
+func (v *HTTPController) preferAsync(fiberCtx *fiber.Ctx) bool {
+	if v.jobs == nil {
+		return false
+	}
+	for _, preference := range strings.Split(fiberCtx.Get("Prefer"), ",") {
+		token, _, _ := strings.Cut(preference, ";")
+		if strings.EqualFold(strings.TrimSpace(token), respondAsync) {
+			return true
+		}
+	}
+	return false
+}
+
+// submitJob queues the attestation request as a job and responds with 202 and the job.
+func (v *HTTPController) submitJob(fiberCtx *fiber.Ctx, jobType string, tokenID uint32, req any) error {
+	webhookURL := fiberCtx.Query(WebhookURLQuery)
+	if webhookURL != "" {
+		if err := webhook.ValidateURL(webhookURL); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+	var request json.RawMessage
+	if req != nil {
+		var err error
+		request, err = json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("failed to marshal job request: %w", err)
+		}
+	}
+	job, err := v.jobs.Submit(fiberCtx.Context(), &models.Job{
+		Type:       jobType,
+		TokenID:    tokenID,
+		Request:    request,
+		WebhookURL: webhookURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue %s job: %w", jobType, err)
+	}
+	fiberCtx.Set(fiber.HeaderLocation, "/v2/attestation/jobs/"+job.ID)
+	fiberCtx.Set("Preference-Applied", respondAsync)
+	return fiberCtx.Status(fiber.StatusAccepted).JSON(job.Status())
+}
+
+// RunJob creates the attestation of an asynchronous job with the request it was queued with and its vehicle token.
+func (v *HTTPController) RunJob(ctx context.Context, job *models.Job) (*cloudevent.RawEvent, error) {
+	switch job.Type {
+	case models.JobTypeVIN:
+		return v.vinService.CreateAndStoreVINAttestation(ctx, job.TokenID)
+	case models.JobTypeVehiclePosition:
+		var req CreateVehiclePositionVCRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job request: %w", err)
+		}
+		opts := vehiclepositionvc.PositionOptions{H3Resolution: req.H3Resolution}
+		return v.vehiclePositionService.CreateVehiclePositionVC(ctx, job.TokenID, req.Timestamp, opts, job.JWTToken)
+	case models.JobTypeOdometerStatement:
+		var req CreateOdometerStatementVCRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job request: %w", err)
+		}
+		opts := odometerstatementvc.StatementOptions{Unit: req.Unit, DisclosureProfile: req.DisclosureProfile}
+		return v.odometerStatementService.CreateOdometerStatementVC(ctx, job.TokenID, req.Timestamp, opts, job.JWTToken)
+	case models.JobTypeVehicleHealth:
+		var req CreateVehicleHealthVCRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job request: %w", err)
+		}
+		opts := vehiclehealthvc.HealthOptions{RuleSet: req.RuleSet}
+		return v.vehicleHealthService.CreateVehicleHealthVC(ctx, job.TokenID, req.StartTime, req.EndTime, opts, job.JWTToken)
+	case models.JobTypeBatteryHealth:
+		var req CreateBatteryHealthVCRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job request: %w", err)
+		}
+		return v.batteryHealthService.CreateBatteryHealthVC(ctx, job.TokenID, req.StartTime, req.EndTime, job.JWTToken)
+	default:
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+}
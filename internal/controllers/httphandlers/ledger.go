@@ -0,0 +1,145 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/ledger"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EventIDParam is the parameter name for the attestation cloud event id.
+const EventIDParam = "eventId"
+
+// LedgerService defines the interface for querying the issuance ledger.
+type LedgerService interface {
+	Get(ctx context.Context, id string) (*models.LedgerEntry, error)
+	List(ctx context.Context, query models.LedgerQuery) (*models.LedgerPage, error)
+	Export(ctx context.Context, query models.LedgerQuery, format string, w io.Writer) error
+}
+
+// LedgerController handles the operator requests querying the issuance ledger.
+type LedgerController struct {
+	ledger LedgerService
+}
+
+// NewLedgerController creates a new LedgerController.
+func NewLedgerController(ledger LedgerService) *LedgerController {
+	return &LedgerController{ledger: ledger}
+}
+
+// @Summary List Issued Attestations
+// @Description List the attestations recorded in the issuance ledger, oldest first. Pass the next value of a page as the after parameter to get the following page.
+// @Tags Admin
+// @Produce json
+// @Param  tokenId query int false "token id of the vehicle NFT"
+// @Param  dataVersion query string false "data version of the attestations, e.g. vin/v1.0"
+// @Param  requester query string false "requester of the attestations, e.g. the dev license address"
+// @Param  status query string false "upload status" Enums(uploaded, failed)
+// @Param  from query string false "RFC 3339 time the attestations were recorded at or after"
+// @Param  to query string false "RFC 3339 time the attestations were recorded before"
+// @Param  after query string false "id of the last attestation of the previous page"
+// @Param  limit query int false "page size, 100 by default and at most 1000"
+// @Success 200 {object} models.LedgerPage
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/ledger [get]
+func (l *LedgerController) ListLedgerEntries(fiberCtx *fiber.Ctx) error {
+	query, err := ledgerQueryFromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	page, err := l.ledger.List(fiberCtx.Context(), query)
+	if err != nil {
+		return fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Get Issued Attestation
+// @Description Get the issuance ledger entry of an attestation.
+// @Tags Admin
+// @Produce json
+// @Param  eventId path string true "id of the attestation cloud event"
+// @Success 200 {object} models.LedgerEntry
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/ledger/{eventId} [get]
+func (l *LedgerController) GetLedgerEntry(fiberCtx *fiber.Ctx) error {
+	entry, err := l.ledger.Get(fiberCtx.Context(), fiberCtx.Params(EventIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to get ledger entry: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(entry)
+}
+
+// @Summary Export Issued Attestations
+// @Description Export every attestation in the issuance ledger matching the filters as CSV or Parquet, oldest first.
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/vnd.apache.parquet
+// @Param  format query string false "export format, csv by default" Enums(csv, parquet)
+// @Param  tokenId query int false "token id of the vehicle NFT"
+// @Param  dataVersion query string false "data version of the attestations, e.g. vin/v1.0"
+// @Param  requester query string false "requester of the attestations, e.g. the dev license address"
+// @Param  status query string false "upload status" Enums(uploaded, failed)
+// @Param  from query string false "RFC 3339 time the attestations were recorded at or after"
+// @Param  to query string false "RFC 3339 time the attestations were recorded before"
+// @Success 200 {file} file
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/ledger/export [get]
+func (l *LedgerController) ExportLedger(fiberCtx *fiber.Ctx) error {
+	query, err := ledgerQueryFromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	format := fiberCtx.Query("format", ledger.FormatCSV)
+	var out bytes.Buffer
+	if err := l.ledger.Export(fiberCtx.Context(), query, format, &out); err != nil {
+		return fmt.Errorf("failed to export ledger: %w", err)
+	}
+	contentType := "text/csv"
+	if format == ledger.FormatParquet {
+		contentType = "application/vnd.apache.parquet"
+	}
+	fiberCtx.Set(fiber.HeaderContentType, contentType)
+	fiberCtx.Attachment("attestations." + format)
+	return fiberCtx.Status(fiber.StatusOK).Send(out.Bytes())
+}
+
+// ledgerQueryFromFiber parses the filters and page of a ledger query from the query parameters.
+func ledgerQueryFromFiber(fiberCtx *fiber.Ctx) (models.LedgerQuery, error) {
+	query := models.LedgerQuery{
+		DataVersion: fiberCtx.Query("dataVersion"),
+		Requester:   fiberCtx.Query("requester"),
+		Status:      fiberCtx.Query("status"),
+		After:       fiberCtx.Query("after"),
+	}
+	if tokenIDStr := fiberCtx.Query(TokenIDParam); tokenIDStr != "" {
+		tokenID64, err := strconv.ParseUint(tokenIDStr, 10, 32)
+		if err != nil {
+			return query, fiber.NewError(fiber.StatusBadRequest, "invalid tokenId format")
+		}
+		query.TokenID = uint32(tokenID64)
+	}
+	for name, dst := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if value := fiberCtx.Query(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fiber.NewError(fiber.StatusBadRequest, "invalid "+name+" time, expected RFC 3339")
+			}
+			*dst = t
+		}
+	}
+	if limitStr := fiberCtx.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return query, fiber.NewError(fiber.StatusBadRequest, "invalid limit format")
+		}
+		query.Limit = limit
+	}
+	return query, nil
+}
@@ -0,0 +1,240 @@
+package httphandlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/recurring"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RecurringIDParam is the parameter name for the recurring subscription id.
+const RecurringIDParam = "recurringId"
+
+// RecurringService defines the interface for managing recurring attestation subscriptions.
+type RecurringService interface {
+	Create(ctx context.Context, op *operator.Operator, req recurring.Request) (*models.RecurringSubscription, error)
+	List(ctx context.Context, tokenID uint32, owner string) ([]models.RecurringSubscription, error)
+	Get(ctx context.Context, id string) (*models.RecurringSubscription, error)
+	Update(ctx context.Context, op *operator.Operator, id string, update recurring.Update) (*models.RecurringSubscription, error)
+	Delete(ctx context.Context, op *operator.Operator, id string) error
+	Pause(ctx context.Context, op *operator.Operator, id string) (*models.RecurringSubscription, error)
+	Resume(ctx context.Context, op *operator.Operator, id string) (*models.RecurringSubscription, error)
+}
+
+// RecurringController handles the operator requests managing recurring attestation subscriptions.
+type RecurringController struct {
+	recurring RecurringService
+}
+
+// NewRecurringController creates a new RecurringController.
+func NewRecurringController(recurring RecurringService) *RecurringController {
+	return &RecurringController{recurring: recurring}
+}
+
+// CreateRecurringRequest represents the request body for creating a recurring attestation subscription.
+type CreateRecurringRequest struct {
+	// Token Id of the vehicle NFT.
+	TokenID uint32 `json:"tokenId" validate:"required" example:"123"`
+	// Job type of the attestations.
+	Type string `json:"type" validate:"required" enums:"vin,vehicle-position,odometer-statement,vehicle-health,battery-health" example:"odometer-statement"`
+	// Time between due dates.
+	Interval string `json:"interval" validate:"required" enums:"daily,weekly,monthly" example:"monthly"`
+	// First due date, now when empty.
+	StartAt *time.Time `json:"startAt"`
+	// Options of the attestations.
+	Parameters models.RecurringParameters `json:"parameters"`
+	// Name of the partner the attestations are issued for.
+	Owner string `json:"owner" validate:"required" example:"Acme Lending"`
+	// HTTPS URL notified of failed attestations.
+	NotifyURL string `json:"notifyUrl" example:"https://example.com/hooks/recurring"`
+}
+
+// UpdateRecurringRequest represents the request body for updating a recurring attestation subscription.
+type UpdateRecurringRequest struct {
+	// Time between due dates.
+	Interval string `json:"interval" validate:"required" enums:"daily,weekly,monthly" example:"monthly"`
+	// Options of the attestations.
+	Parameters models.RecurringParameters `json:"parameters"`
+	// HTTPS URL notified of failed attestations, none when empty.
+	NotifyURL string `json:"notifyUrl" example:"https://example.com/hooks/recurring"`
+}
+
+// @Summary Create Recurring Attestation
+// @Description Issue an attestation of a vehicle on every due date of a schedule. Requires an operator token with the admin role.
+// @Description Attestations from telemetry are read with a vehicle token obtained by the service. A failed attestation is retried 3 times, 15 minutes apart, before its due date is skipped.
+// @Description The notify URL receives a signed models.RecurringFailure for every failure.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param  request body CreateRecurringRequest true "Request body"
+// @Success 201 {object} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring [post]
+func (r *RecurringController) CreateRecurring(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+
+	var req CreateRecurringRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	if req.TokenID == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "tokenId is required")
+	}
+
+	var startAt time.Time
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	}
+	sub, err := r.recurring.Create(fiberCtx.Context(), op, recurring.Request{
+		TokenID:    req.TokenID,
+		Type:       req.Type,
+		Interval:   req.Interval,
+		StartAt:    startAt,
+		Parameters: req.Parameters,
+		Owner:      req.Owner,
+		NotifyURL:  req.NotifyURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create recurring subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// @Summary List Recurring Attestations
+// @Description List the recurring attestation subscriptions, optionally of a vehicle or owner.
+// @Tags Admin
+// @Produce json
+// @Param  tokenId query int false "token id of the vehicle NFT"
+// @Param  owner query string false "owner of the subscriptions"
+// @Success 200 {array} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring [get]
+func (r *RecurringController) ListRecurring(fiberCtx *fiber.Ctx) error {
+	var tokenID uint32
+	if tokenIDStr := fiberCtx.Query(TokenIDParam); tokenIDStr != "" {
+		tokenID64, err := strconv.ParseUint(tokenIDStr, 10, 32)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid tokenId format")
+		}
+		tokenID = uint32(tokenID64)
+	}
+	subs, err := r.recurring.List(fiberCtx.Context(), tokenID, fiberCtx.Query("owner"))
+	if err != nil {
+		return fmt.Errorf("failed to list recurring subscriptions: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(subs)
+}
+
+// @Summary Get Recurring Attestation
+// @Description Get a recurring attestation subscription and the outcome of its last run.
+// @Tags Admin
+// @Produce json
+// @Param  recurringId path string true "id of the recurring subscription"
+// @Success 200 {object} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring/{recurringId} [get]
+func (r *RecurringController) GetRecurring(fiberCtx *fiber.Ctx) error {
+	sub, err := r.recurring.Get(fiberCtx.Context(), fiberCtx.Params(RecurringIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to get recurring subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
+
+// @Summary Update Recurring Attestation
+// @Description Replace the interval, parameters and notify URL of a recurring attestation subscription. The next due date is kept.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param  recurringId path string true "id of the recurring subscription"
+// @Param  request body UpdateRecurringRequest true "Request body"
+// @Success 200 {object} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring/{recurringId} [put]
+func (r *RecurringController) UpdateRecurring(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateRecurringRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	sub, err := r.recurring.Update(fiberCtx.Context(), op, fiberCtx.Params(RecurringIDParam), recurring.Update{
+		Interval:   req.Interval,
+		Parameters: req.Parameters,
+		NotifyURL:  req.NotifyURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update recurring subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
+
+// @Summary Delete Recurring Attestation
+// @Description Delete a recurring attestation subscription. Issued attestations are kept.
+// @Tags Admin
+// @Param  recurringId path string true "id of the recurring subscription"
+// @Success 204
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring/{recurringId} [delete]
+func (r *RecurringController) DeleteRecurring(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	if err := r.recurring.Delete(fiberCtx.Context(), op, fiberCtx.Params(RecurringIDParam)); err != nil {
+		return fmt.Errorf("failed to delete recurring subscription: %w", err)
+	}
+	return fiberCtx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Pause Recurring Attestation
+// @Description Stop issuing the attestations of a recurring subscription until it is resumed.
+// @Tags Admin
+// @Produce json
+// @Param  recurringId path string true "id of the recurring subscription"
+// @Success 200 {object} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring/{recurringId}/pause [post]
+func (r *RecurringController) PauseRecurring(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	sub, err := r.recurring.Pause(fiberCtx.Context(), op, fiberCtx.Params(RecurringIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to pause recurring subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
+
+// @Summary Resume Recurring Attestation
+// @Description Issue the attestations of a paused recurring subscription again. Due dates that passed while it was paused are skipped.
+// @Tags Admin
+// @Produce json
+// @Param  recurringId path string true "id of the recurring subscription"
+// @Success 200 {object} models.RecurringSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/recurring/{recurringId}/resume [post]
+func (r *RecurringController) ResumeRecurring(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	sub, err := r.recurring.Resume(fiberCtx.Context(), op, fiberCtx.Params(RecurringIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to resume recurring subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
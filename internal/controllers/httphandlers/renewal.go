@@ -0,0 +1,42 @@
+package httphandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RenewalService defines the interface for reading VIN attestation renewal runs.
+type RenewalService interface {
+	Latest(ctx context.Context) (*models.RenewalRun, error)
+}
+
+// RenewalController handles the operator requests about VIN attestation renewals.
+type RenewalController struct {
+	renewals RenewalService
+}
+
+// NewRenewalController creates a new RenewalController.
+func NewRenewalController(renewals RenewalService) *RenewalController {
+	return &RenewalController{renewals: renewals}
+}
+
+// @Summary Get Latest VIN Renewal
+// @Description Get the progress and outcome of the latest scheduled renewal of the VIN attestations about to expire.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} models.RenewalSummary
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/vin/renewals/latest [get]
+func (r *RenewalController) GetLatestRenewal(fiberCtx *fiber.Ctx) error {
+	run, err := r.renewals.Latest(fiberCtx.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get latest VIN renewal: %w", err)
+	}
+	if run == nil {
+		return fiber.NewError(fiber.StatusNotFound, "No VIN renewal has run yet")
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(run.Summary())
+}
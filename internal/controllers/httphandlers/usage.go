@@ -0,0 +1,33 @@
+package httphandlers
+
+import (
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UsageMeter defines the interface for reading the metered attestation requests.
+type UsageMeter interface {
+	Usage(client string) []models.Usage
+}
+
+// UsageController handles the operator requests reading the usage of each dev license.
+type UsageController struct {
+	meter UsageMeter
+}
+
+// NewUsageController creates a new UsageController.
+func NewUsageController(meter UsageMeter) *UsageController {
+	return &UsageController{meter: meter}
+}
+
+// @Summary Get Usage
+// @Description Get the attestation requests allowed and rejected by the rate limits for each dev license and attestation type on the UTC days of the last week. Usage is counted by each replica of the service, and survives restarts when DATABASE_PATH is set.
+// @Tags Admin
+// @Produce json
+// @Param  client query string false "dev license address of the requests, every client by default"
+// @Success 200 {array} models.Usage
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/usage [get]
+func (u *UsageController) GetUsage(fiberCtx *fiber.Ctx) error {
+	return fiberCtx.Status(fiber.StatusOK).JSON(u.meter.Usage(fiberCtx.Query("client")))
+}
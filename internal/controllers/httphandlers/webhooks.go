@@ -0,0 +1,249 @@
+package httphandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/notifications"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// SubscriptionIDParam is the parameter name for the webhook subscription id.
+	SubscriptionIDParam = "subscriptionId"
+
+	// DeliveryIDParam is the parameter name for the webhook delivery id.
+	DeliveryIDParam = "deliveryId"
+)
+
+// WebhookService defines the interface for managing webhook subscriptions.
+type WebhookService interface {
+	Subscribe(ctx context.Context, op *operator.Operator, req notifications.SubscriptionRequest) (*models.WebhookSubscription, error)
+	Subscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	Subscription(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	Unsubscribe(ctx context.Context, op *operator.Operator, id string) error
+	Deliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error)
+	Replay(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	SubscribeDevLicense(ctx context.Context, devLicense string, req notifications.SubscriptionRequest) (*models.WebhookSubscription, error)
+	DevLicenseSubscriptions(ctx context.Context, devLicense string) ([]models.WebhookSubscription, error)
+	DevLicenseSubscription(ctx context.Context, devLicense, id string) (*models.WebhookSubscription, error)
+	UnsubscribeDevLicense(ctx context.Context, devLicense, id string) error
+}
+
+// WebhookController handles the operator and dev license requests managing webhook subscriptions.
+type WebhookController struct {
+	webhooks WebhookService
+}
+
+// NewWebhookController creates a new WebhookController.
+func NewWebhookController(webhooks WebhookService) *WebhookController {
+	return &WebhookController{webhooks: webhooks}
+}
+
+// CreateWebhookSubscriptionRequest represents the request body for creating a webhook subscription.
+// Empty filters match every attestation.
+type CreateWebhookSubscriptionRequest struct {
+	// Name of the partner the webhook is registered for.
+	Partner string `json:"partner" validate:"required" example:"Acme Insurance"`
+	// HTTPS URL notified of the matching attestations.
+	URL string `json:"url" validate:"required" example:"https://example.com/hooks/attestations"`
+	// Token Ids of the vehicle NFTs.
+	TokenIDs []uint32 `json:"tokenIds"`
+	// Data versions of the attestations.
+	AttestationTypes []string `json:"attestationTypes" example:"vin/v1.0"`
+	// Addresses of the dev licenses requesting the attestations.
+	DevLicenses []string `json:"devLicenses"`
+}
+
+// @Summary Create Webhook Subscription
+// @Description Register a partner webhook notified of each issued attestation matching the filters. Requires an operator token with the admin role.
+// @Description Notifications are signed with an HMAC-SHA256 keyed by the secret of the subscription, which is only returned by this call.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param  request body CreateWebhookSubscriptionRequest true "Request body"
+// @Success 201 {object} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks [post]
+func (w *WebhookController) CreateSubscription(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+
+	var req CreateWebhookSubscriptionRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	sub, err := w.webhooks.Subscribe(fiberCtx.Context(), op, notifications.SubscriptionRequest{
+		Partner:          req.Partner,
+		URL:              req.URL,
+		TokenIDs:         req.TokenIDs,
+		AttestationTypes: req.AttestationTypes,
+		DevLicenses:      req.DevLicenses,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// @Summary List Webhook Subscriptions
+// @Description List the webhook subscriptions without their secrets.
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks [get]
+func (w *WebhookController) ListSubscriptions(fiberCtx *fiber.Ctx) error {
+	subs, err := w.webhooks.Subscriptions(fiberCtx.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(subs)
+}
+
+// @Summary Get Webhook Subscription
+// @Description Get a webhook subscription without its secret.
+// @Tags Admin
+// @Produce json
+// @Param  subscriptionId path string true "id of the webhook subscription"
+// @Success 200 {object} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks/{subscriptionId} [get]
+func (w *WebhookController) GetSubscription(fiberCtx *fiber.Ctx) error {
+	sub, err := w.webhooks.Subscription(fiberCtx.Context(), fiberCtx.Params(SubscriptionIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
+
+// @Summary Delete Webhook Subscription
+// @Description Delete a webhook subscription. Its delivery log is kept until it expires.
+// @Tags Admin
+// @Param  subscriptionId path string true "id of the webhook subscription"
+// @Success 204
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks/{subscriptionId} [delete]
+func (w *WebhookController) DeleteSubscription(fiberCtx *fiber.Ctx) error {
+	op, err := operator.FromFiber(fiberCtx)
+	if err != nil {
+		return err
+	}
+	if err := w.webhooks.Unsubscribe(fiberCtx.Context(), op, fiberCtx.Params(SubscriptionIDParam)); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return fiberCtx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary List Webhook Deliveries
+// @Description List the notifications sent to a webhook subscription in the last 30 days and their outcome, oldest first.
+// @Tags Admin
+// @Produce json
+// @Param  subscriptionId path string true "id of the webhook subscription"
+// @Success 200 {array} models.WebhookDelivery
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks/{subscriptionId}/deliveries [get]
+func (w *WebhookController) ListDeliveries(fiberCtx *fiber.Ctx) error {
+	deliveries, err := w.webhooks.Deliveries(fiberCtx.Context(), fiberCtx.Params(SubscriptionIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(deliveries)
+}
+
+// @Summary Replay Webhook Delivery
+// @Description Send the notification of a delivery to its webhook subscription again as a new delivery.
+// @Tags Admin
+// @Produce json
+// @Param  deliveryId path string true "id of the webhook delivery"
+// @Success 202 {object} models.WebhookDelivery
+// @Security     BearerAuth
+// @Router /v2/admin/attestation/webhooks/deliveries/{deliveryId}/replay [post]
+func (w *WebhookController) ReplayDelivery(fiberCtx *fiber.Ctx) error {
+	delivery, err := w.webhooks.Replay(fiberCtx.Context(), fiberCtx.Params(DeliveryIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusAccepted).JSON(delivery)
+}
+
+// @Summary Create Dev License Webhook Subscription
+// @Description Register a webhook notified of each attestation requested by the dev license of the token and matching the filters, and of the attestations issued by the service on its own, such as renewals, for vehicles the dev license requested before.
+// @Description Any token-exchange token issued to the dev license is accepted. The devLicenses filter may only hold the dev license itself.
+// @Description Notifications are signed with an HMAC-SHA256 keyed by the secret of the subscription, which is only returned by this call.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param  request body CreateWebhookSubscriptionRequest true "Request body"
+// @Success 201 {object} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/attestation/webhooks [post]
+func (w *WebhookController) CreateDevLicenseSubscription(fiberCtx *fiber.Ctx) error {
+	var req CreateWebhookSubscriptionRequest
+	if err := fiberCtx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	sub, err := w.webhooks.SubscribeDevLicense(fiberCtx.Context(), requester.FromContext(fiberCtx.Context()), notifications.SubscriptionRequest{
+		Partner:          req.Partner,
+		URL:              req.URL,
+		TokenIDs:         req.TokenIDs,
+		AttestationTypes: req.AttestationTypes,
+		DevLicenses:      req.DevLicenses,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// @Summary List Dev License Webhook Subscriptions
+// @Description List the webhook subscriptions of the dev license of the token without their secrets.
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/attestation/webhooks [get]
+func (w *WebhookController) ListDevLicenseSubscriptions(fiberCtx *fiber.Ctx) error {
+	subs, err := w.webhooks.DevLicenseSubscriptions(fiberCtx.Context(), requester.FromContext(fiberCtx.Context()))
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(subs)
+}
+
+// @Summary Get Dev License Webhook Subscription
+// @Description Get a webhook subscription of the dev license of the token without its secret.
+// @Tags Webhooks
+// @Produce json
+// @Param  subscriptionId path string true "id of the webhook subscription"
+// @Success 200 {object} models.WebhookSubscription
+// @Security     BearerAuth
+// @Router /v2/attestation/webhooks/{subscriptionId} [get]
+func (w *WebhookController) GetDevLicenseSubscription(fiberCtx *fiber.Ctx) error {
+	sub, err := w.webhooks.DevLicenseSubscription(fiberCtx.Context(), requester.FromContext(fiberCtx.Context()), fiberCtx.Params(SubscriptionIDParam))
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return fiberCtx.Status(fiber.StatusOK).JSON(sub)
+}
+
+// @Summary Delete Dev License Webhook Subscription
+// @Description Delete a webhook subscription of the dev license of the token.
+// @Tags Webhooks
+// @Param  subscriptionId path string true "id of the webhook subscription"
+// @Success 204
+// @Security     BearerAuth
+// @Router /v2/attestation/webhooks/{subscriptionId} [delete]
+func (w *WebhookController) DeleteDevLicenseSubscription(fiberCtx *fiber.Ctx) error {
+	if err := w.webhooks.UnsubscribeDevLicense(fiberCtx.Context(), requester.FromContext(fiberCtx.Context()), fiberCtx.Params(SubscriptionIDParam)); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return fiberCtx.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/manualvin"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/DIMO-Network/attestation-api/pkg/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type manualVINCtrl interface {
+	Submit(ctx context.Context, op *operator.Operator, req manualvin.Request) (*manualvin.Result, error)
+	Approve(ctx context.Context, op *operator.Operator, requestID string) (*manualvin.Result, error)
+	Reject(ctx context.Context, op *operator.Operator, requestID string, reason string) (*models.ManualVINRequest, error)
+	AuditLog(ctx context.Context, tokenID uint32) ([]models.AuditEntry, error)
+}
+
+type operatorVerifier interface {
+	FromIncomingContext(ctx context.Context) (*operator.Operator, error)
+}
+
+// ManualVinVcCreation creates a VIN VC for the given token ID on behalf of the operator, or leaves the request
+// pending when a second operator must approve it.
+func (s *Server) ManualVinVcCreation(ctx context.Context, req *grpc.ManualVinVcCreationRequest) (*grpc.ManualVinVcCreationResponse, error) {
+	op, err := s.authenticateOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.manualVIN.Submit(ctx, op, manualvin.Request{
+		TokenID:     req.GetTokenId(),
+		VIN:         req.GetVin(),
+		CountryCode: req.GetCountryCode(),
+		Reason:      req.GetReason(),
+		EvidenceRef: req.GetEvidenceRef(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate VIN VC: %w", err)
+	}
+	return manualVINResponse(result)
+}
+
+// ApproveManualVinVc approves a pending manual VIN request of another operator and creates the VIN VC.
+func (s *Server) ApproveManualVinVc(ctx context.Context, req *grpc.ApproveManualVinVcRequest) (*grpc.ManualVinVcCreationResponse, error) {
+	op, err := s.authenticateOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.manualVIN.Approve(ctx, op, req.GetRequestId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve manual VIN request: %w", err)
+	}
+	return manualVINResponse(result)
+}
+
+// RejectManualVinVc rejects a pending manual VIN request.
+func (s *Server) RejectManualVinVc(ctx context.Context, req *grpc.RejectManualVinVcRequest) (*grpc.RejectManualVinVcResponse, error) {
+	op, err := s.authenticateOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.manualVIN.Reject(ctx, op, req.GetRequestId(), req.GetReason())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject manual VIN request: %w", err)
+	}
+	return &grpc.RejectManualVinVcResponse{Request: manualVINRequestToProto(record)}, nil
+}
+
+// ListManualVinVcAudit lists the manual VIN audit log of the given token ID, oldest entry first.
+func (s *Server) ListManualVinVcAudit(ctx context.Context, req *grpc.ListManualVinVcAuditRequest) (*grpc.ListManualVinVcAuditResponse, error) {
+	if _, err := s.authenticateOperator(ctx); err != nil {
+		return nil, err
+	}
+	entries, err := s.manualVIN.AuditLog(ctx, req.GetTokenId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manual VIN audit log: %w", err)
+	}
+	resp := &grpc.ListManualVinVcAuditResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &grpc.ManualVinAuditEntry{
+			Id:            entry.ID,
+			TokenId:       entry.TokenID,
+			RequestId:     entry.RequestID,
+			Action:        entry.Action,
+			Operator:      entry.Operator,
+			Vin:           entry.VIN,
+			CountryCode:   entry.CountryCode,
+			Reason:        entry.Reason,
+			EvidenceRef:   entry.EvidenceRef,
+			AttestationId: entry.AttestationID,
+			Error:         entry.Error,
+			Time:          timestamppb.New(entry.Time),
+		})
+	}
+	return resp, nil
+}
+
+// authenticateOperator authenticates the operator of a manual VIN RPC.
+func (s *Server) authenticateOperator(ctx context.Context) (*operator.Operator, error) {
+	if s.manualVIN == nil || s.operators == nil {
+		return nil, status.Error(codes.Unimplemented, "manual VIN attestations are disabled")
+	}
+	return s.operators.FromIncomingContext(ctx)
+}
+
+func manualVINResponse(result *manualvin.Result) (*grpc.ManualVinVcCreationResponse, error) {
+	resp := &grpc.ManualVinVcCreationResponse{Request: manualVINRequestToProto(result.Request)}
+	if result.Attestation != nil {
+		raw, err := json.Marshal(result.Attestation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal VIN VC: %w", err)
+		}
+		resp.RawVc = string(raw)
+	}
+	return resp, nil
+}
+
+func manualVINRequestToProto(record *models.ManualVINRequest) *grpc.ManualVinRequest {
+	pbRequest := &grpc.ManualVinRequest{
+		Id:            record.ID,
+		TokenId:       record.TokenID,
+		Vin:           record.VIN,
+		CountryCode:   record.CountryCode,
+		Reason:        record.Reason,
+		EvidenceRef:   record.EvidenceRef,
+		Status:        record.Status,
+		RequestedBy:   record.RequestedBy,
+		RequestedAt:   timestamppb.New(record.RequestedAt),
+		ReviewedBy:    record.ReviewedBy,
+		ReviewReason:  record.ReviewReason,
+		AttestationId: record.AttestationID,
+	}
+	if record.ReviewedAt != nil {
+		pbRequest.ReviewedAt = timestamppb.New(*record.ReviewedAt)
+	}
+	return pbRequest
+}
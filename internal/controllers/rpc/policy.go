@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/internal/grpcauth"
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/pkg/grpc"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+)
+
+// MethodPolicies returns the authorization policies of the AttestationService methods. Vehicle methods mirror the
+// privileges of the HTTP endpoints, forcing a VIN VC and the manual VIN methods are limited to mTLS clients.
+func MethodPolicies() map[string]grpcauth.Policy {
+	vin := grpcauth.Policy{
+		ClientPermissions:  []string{grpcauth.PermissionCreateVIN},
+		VehiclePermissions: []string{tokenclaims.PermissionGetVINCredential},
+	}
+	ensure := vin
+	ensure.Elevated = func(req any) []string {
+		if ensureReq, ok := req.(*grpc.EnsureVinVcRequest); ok && ensureReq.GetForce() {
+			return []string{grpcauth.PermissionForceVIN}
+		}
+		return nil
+	}
+	// The manual VIN methods also authenticate the operator with the authorization metadata.
+	manual := grpcauth.Policy{ClientPermissions: []string{grpcauth.PermissionManualVIN}}
+	position := grpcauth.Policy{
+		ClientPermissions:  []string{grpcauth.PermissionCreatePosition},
+		VehiclePermissions: vehiclepositionvc.TelemetryPermissions,
+	}
+	odometer := grpcauth.Policy{
+		ClientPermissions:  []string{grpcauth.PermissionCreateOdometer},
+		VehiclePermissions: odometerstatementvc.TelemetryPermissions,
+	}
+	health := grpcauth.Policy{
+		ClientPermissions:  []string{grpcauth.PermissionCreateHealth},
+		VehiclePermissions: vehiclehealthvc.TelemetryPermissions,
+	}
+	battery := grpcauth.Policy{
+		ClientPermissions:  []string{grpcauth.PermissionCreateBattery},
+		VehiclePermissions: batteryhealthvc.TelemetryPermissions,
+	}
+
+	return map[string]grpcauth.Policy{
+		grpc.AttestationService_EnsureVinVc_FullMethodName:               ensure,
+		grpc.AttestationService_GetVinVcLatest_FullMethodName:            {Public: true},
+		grpc.AttestationService_TestVinVcCreation_FullMethodName:         vin,
+		grpc.AttestationService_GetVinVcEligibility_FullMethodName:       vin,
+		grpc.AttestationService_ManualVinVcCreation_FullMethodName:       manual,
+		grpc.AttestationService_ApproveManualVinVc_FullMethodName:        manual,
+		grpc.AttestationService_RejectManualVinVc_FullMethodName:         manual,
+		grpc.AttestationService_ListManualVinVcAudit_FullMethodName:      manual,
+		grpc.AttestationService_CreateVehiclePositionVc_FullMethodName:   position,
+		grpc.AttestationService_CreateOdometerStatementVc_FullMethodName: odometer,
+		grpc.AttestationService_CreateVehicleHealthVc_FullMethodName:     health,
+		grpc.AttestationService_CreateBatteryHealthVc_FullMethodName:     battery,
+	}
+}
+
+// LimitedMethods returns the attestation types of the AttestationService methods issuing attestations, which are
+// rate limited like the HTTP endpoints of the types.
+func LimitedMethods() map[string]string {
+	return map[string]string{
+		grpc.AttestationService_EnsureVinVc_FullMethodName:               models.JobTypeVIN,
+		grpc.AttestationService_CreateVehiclePositionVc_FullMethodName:   models.JobTypeVehiclePosition,
+		grpc.AttestationService_CreateOdometerStatementVc_FullMethodName: models.JobTypeOdometerStatement,
+		grpc.AttestationService_CreateVehicleHealthVc_FullMethodName:     models.JobTypeVehicleHealth,
+		grpc.AttestationService_CreateBatteryHealthVc_FullMethodName:     models.JobTypeBatteryHealth,
+	}
+}
@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/attestation/batteryhealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/odometerstatementvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclehealthvc"
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vehiclepositionvc"
+	"github.com/DIMO-Network/attestation-api/pkg/grpc"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TelemetryServices are the services of the attestations created from a vehicle's telemetry.
+type TelemetryServices struct {
+	Position PositionCtrl
+	Odometer OdometerCtrl
+	Health   HealthCtrl
+	Battery  BatteryCtrl
+	// VehicleTokens gets the service's own vehicle tokens for callers that do not send one.
+	// Callers must send a token when nil.
+	VehicleTokens VehicleTokenSource
+}
+
+// VehicleTokenSource gets vehicle tokens obtained by the service.
+type VehicleTokenSource interface {
+	GetVehicleToken(ctx context.Context, tokenID uint32, permissions []string) (string, error)
+}
+
+// PositionCtrl creates VehiclePositionVCs.
+type PositionCtrl interface {
+	CreateVehiclePositionVC(ctx context.Context, tokenID uint32, timestamp time.Time, opts vehiclepositionvc.PositionOptions, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// OdometerCtrl creates OdometerStatementVCs.
+type OdometerCtrl interface {
+	CreateOdometerStatementVC(ctx context.Context, tokenID uint32, timestamp *time.Time, opts odometerstatementvc.StatementOptions, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// HealthCtrl creates VehicleHealthVCs.
+type HealthCtrl interface {
+	CreateVehicleHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, opts vehiclehealthvc.HealthOptions, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// BatteryCtrl creates BatteryHealthVCs.
+type BatteryCtrl interface {
+	CreateBatteryHealthVC(ctx context.Context, tokenID uint32, startTime, endTime time.Time, jwtToken string) (*cloudevent.RawEvent, error)
+}
+
+// CreateVehiclePositionVc creates a VehiclePositionVC for the location closest to the requested time.
+func (s *Server) CreateVehiclePositionVc(ctx context.Context, req *grpc.CreateVehiclePositionVcRequest) (*grpc.CreateVehiclePositionVcResponse, error) {
+	if s.telemetry.Position == nil {
+		return nil, status.Error(codes.Unimplemented, "vehicle position attestations are disabled")
+	}
+	if req.GetTimestamp() == nil {
+		return nil, status.Error(codes.InvalidArgument, "timestamp is required")
+	}
+	jwtToken, err := s.telemetryToken(ctx, req.GetTokenId(), vehiclepositionvc.TelemetryPermissions)
+	if err != nil {
+		return nil, err
+	}
+	opts := vehiclepositionvc.PositionOptions{H3Resolution: int(req.GetH3Resolution())}
+	rawVC, err := s.telemetry.Position.CreateVehiclePositionVC(ctx, req.GetTokenId(), req.GetTimestamp().AsTime(), opts, jwtToken)
+	if err != nil {
+		return nil, statusError(err, "failed to create VehiclePositionVC")
+	}
+	raw, err := json.Marshal(rawVC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VehiclePositionVC: %w", err)
+	}
+	return &grpc.CreateVehiclePositionVcResponse{RawVc: string(raw)}, nil
+}
+
+// CreateOdometerStatementVc creates an OdometerStatementVC of the reading at the requested time, or of the latest reading.
+func (s *Server) CreateOdometerStatementVc(ctx context.Context, req *grpc.CreateOdometerStatementVcRequest) (*grpc.CreateOdometerStatementVcResponse, error) {
+	if s.telemetry.Odometer == nil {
+		return nil, status.Error(codes.Unimplemented, "odometer statement attestations are disabled")
+	}
+	jwtToken, err := s.telemetryToken(ctx, req.GetTokenId(), odometerstatementvc.TelemetryPermissions)
+	if err != nil {
+		return nil, err
+	}
+	var timestamp *time.Time
+	if req.GetTimestamp() != nil {
+		t := req.GetTimestamp().AsTime()
+		timestamp = &t
+	}
+	opts := odometerstatementvc.StatementOptions{
+		Unit:              req.GetUnit(),
+		DisclosureProfile: req.GetDisclosureProfile(),
+	}
+	rawVC, err := s.telemetry.Odometer.CreateOdometerStatementVC(ctx, req.GetTokenId(), timestamp, opts, jwtToken)
+	if err != nil {
+		return nil, statusError(err, "failed to create OdometerStatementVC")
+	}
+	raw, err := json.Marshal(rawVC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OdometerStatementVC: %w", err)
+	}
+	return &grpc.CreateOdometerStatementVcResponse{RawVc: string(raw)}, nil
+}
+
+// CreateVehicleHealthVc creates a VehicleHealthVC for the requested time range.
+func (s *Server) CreateVehicleHealthVc(ctx context.Context, req *grpc.CreateVehicleHealthVcRequest) (*grpc.CreateVehicleHealthVcResponse, error) {
+	if s.telemetry.Health == nil {
+		return nil, status.Error(codes.Unimplemented, "vehicle health attestations are disabled")
+	}
+	if req.GetStartTime() == nil || req.GetEndTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time and end_time are required")
+	}
+	jwtToken, err := s.telemetryToken(ctx, req.GetTokenId(), vehiclehealthvc.TelemetryPermissions)
+	if err != nil {
+		return nil, err
+	}
+	opts := vehiclehealthvc.HealthOptions{RuleSet: req.GetRuleSet()}
+	rawVC, err := s.telemetry.Health.CreateVehicleHealthVC(ctx, req.GetTokenId(), req.GetStartTime().AsTime(), req.GetEndTime().AsTime(), opts, jwtToken)
+	if err != nil {
+		return nil, statusError(err, "failed to create VehicleHealthVC")
+	}
+	raw, err := json.Marshal(rawVC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VehicleHealthVC: %w", err)
+	}
+	return &grpc.CreateVehicleHealthVcResponse{RawVc: string(raw)}, nil
+}
+
+// CreateBatteryHealthVc creates a BatteryHealthVC for the requested time range.
+func (s *Server) CreateBatteryHealthVc(ctx context.Context, req *grpc.CreateBatteryHealthVcRequest) (*grpc.CreateBatteryHealthVcResponse, error) {
+	if s.telemetry.Battery == nil {
+		return nil, status.Error(codes.Unimplemented, "battery health attestations are disabled")
+	}
+	if req.GetStartTime() == nil || req.GetEndTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time and end_time are required")
+	}
+	jwtToken, err := s.telemetryToken(ctx, req.GetTokenId(), batteryhealthvc.TelemetryPermissions)
+	if err != nil {
+		return nil, err
+	}
+	rawVC, err := s.telemetry.Battery.CreateBatteryHealthVC(ctx, req.GetTokenId(), req.GetStartTime().AsTime(), req.GetEndTime().AsTime(), jwtToken)
+	if err != nil {
+		return nil, statusError(err, "failed to create BatteryHealthVC")
+	}
+	raw, err := json.Marshal(rawVC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal BatteryHealthVC: %w", err)
+	}
+	return &grpc.CreateBatteryHealthVcResponse{RawVc: string(raw)}, nil
+}
+
+// telemetryToken returns the token passed to the telemetry API: the token-exchange token of the authorization
+// metadata, like the Authorization header of the HTTP endpoints, or else a token obtained by the service with the
+// permissions of the attestation. Calls without a token are only authorized for mTLS clients.
+func (s *Server) telemetryToken(ctx context.Context, tokenID uint32, permissions []string) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok && token != "" {
+			return token, nil
+		}
+	}
+	if s.telemetry.VehicleTokens == nil {
+		return "", status.Error(codes.Unauthenticated, "token-exchange token is required in the authorization metadata")
+	}
+	token, err := s.telemetry.VehicleTokens.GetVehicleToken(ctx, tokenID, permissions)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to get vehicle token: %v", err)
+	}
+	return token, nil
+}
+
+// statusError converts the HTTP code of a rich error to a gRPC status so clients can tell bad requests from failures.
+func statusError(err error, msg string) error {
+	var richErr richerrors.Error
+	if !errors.As(err, &richErr) {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	code := codes.Internal
+	switch richErr.Code {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	}
+	return status.Errorf(code, "%s: %s", msg, richErr.ExternalMsg)
+}
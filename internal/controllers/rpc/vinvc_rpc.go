@@ -5,26 +5,35 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/DIMO-Network/attestation-api/internal/attestation/vinvc"
 	"github.com/DIMO-Network/attestation-api/internal/config"
 	"github.com/DIMO-Network/attestation-api/pkg/grpc"
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/ethereum/go-ethereum/common"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Server implements the AttestationServiceServer interface.
 type Server struct {
 	grpc.UnimplementedAttestationServiceServer
 	ctrl              vinCtrl
+	manualVIN         manualVINCtrl
+	operators         operatorVerifier
+	telemetry         TelemetryServices
 	vehicleNFTAddress common.Address
 	chainID           uint64
 }
 
-// NewServer creates a new instance of the Server.
-func NewServer(ctrl vinCtrl, settings *config.Settings) *Server {
+// NewServer creates a new instance of the Server. The manual VIN RPCs are disabled when manualVIN or operators is nil,
+// and a telemetry RPC is disabled when its service is nil.
+func NewServer(ctrl vinCtrl, manualVIN manualVINCtrl, operators operatorVerifier, telemetry TelemetryServices, settings *config.Settings) *Server {
 	return &Server{
 		ctrl:              ctrl,
+		manualVIN:         manualVIN,
+		operators:         operators,
+		telemetry:         telemetry,
 		vehicleNFTAddress: common.HexToAddress(settings.VehicleNFTAddress),
 		chainID:           uint64(settings.DIMORegistryChainID),
 	}
@@ -33,7 +42,7 @@ func NewServer(ctrl vinCtrl, settings *config.Settings) *Server {
 type vinCtrl interface {
 	CreateAndStoreVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
 	CreateVINAttestation(ctx context.Context, tokenID uint32) (*cloudevent.RawEvent, error)
-	CreateManualVINAttestation(ctx context.Context, tokenID uint32, vin string, countryCode string) (*cloudevent.RawEvent, error)
+	CheckVINEligibility(ctx context.Context, tokenID uint32) (*vinvc.EligibilityReport, error)
 }
 
 // EnsureVinVc ensures that a VC exists for the given token ID.
@@ -70,17 +79,42 @@ func (s *Server) TestVinVcCreation(ctx context.Context, req *grpc.TestVinVcCreat
 	}, nil
 }
 
-// ManualVinVcCreation generates a VIN VC for the given token ID.
-func (s *Server) ManualVinVcCreation(ctx context.Context, req *grpc.ManualVinVcCreationRequest) (*grpc.ManualVinVcCreationResponse, error) {
-	rawVC, err := s.ctrl.CreateManualVINAttestation(ctx, req.GetTokenId(), req.GetVin(), req.GetCountryCode())
+// GetVinVcEligibility reports whether a VIN VC can be created for the given token ID and why.
+func (s *Server) GetVinVcEligibility(ctx context.Context, req *grpc.GetVinVcEligibilityRequest) (*grpc.GetVinVcEligibilityResponse, error) {
+	report, err := s.ctrl.CheckVINEligibility(ctx, req.GetTokenId())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate VIN VC: %w", err)
+		return nil, fmt.Errorf("failed to check VIN VC eligibility: %w", err)
 	}
-	raw, err := json.Marshal(rawVC)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal VIN VC: %w", err)
+	resp := &grpc.GetVinVcEligibilityResponse{
+		VehicleDid:      report.VehicleDID,
+		Eligible:        report.Eligible,
+		Reason:          report.Reason,
+		Message:         report.Message,
+		Vin:             report.VIN,
+		EvidenceCount:   int32(report.EvidenceCount),
+		ConflictingVins: report.ConflictingVINs,
 	}
-	return &grpc.ManualVinVcCreationResponse{
-		RawVc: string(raw),
-	}, nil
+	for _, device := range report.Devices {
+		pbDevice := &grpc.DeviceEligibility{
+			Did:               device.DID,
+			Type:              device.Type,
+			ManufacturerName:  device.ManufacturerName,
+			Status:            device.Status,
+			Error:             device.Error,
+			FingerprintCount:  int32(device.FingerprintCount),
+			Vin:               device.VIN,
+			VinValid:          device.VINValid,
+			SignatureStatus:   device.SignatureStatus,
+			VinModelYear:      int32(device.VINModelYear),
+			DefinitionMatch:   device.DefinitionMatch,
+			VinManufacturer:   device.VINManufacturer,
+			ManufacturerMatch: device.ManufacturerMatch,
+			CountryCode:       device.CountryCode,
+		}
+		if device.LatestFingerprintAt != nil {
+			pbDevice.LatestFingerprintAt = timestamppb.New(*device.LatestFingerprintAt)
+		}
+		resp.Devices = append(resp.Devices, pbDevice)
+	}
+	return resp, nil
 }
@@ -0,0 +1,182 @@
+// Package dtc provides an embedded dictionary of OBD-II diagnostic trouble codes (SAE J2012 / ISO 15031-6).
+package dtc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// SeverityCritical is a fault that affects safety or can cause damage if driving continues.
+	SeverityCritical = "critical"
+	// SeverityWarning is a fault that should be repaired soon.
+	SeverityWarning = "warning"
+	// SeverityInfo is a fault with little or no effect on drivability.
+	SeverityInfo = "info"
+)
+
+//go:embed dictionary.json
+var dictionaryJSON []byte
+
+// Entry describes a single diagnostic trouble code.
+type Entry struct {
+	// Code is the five character trouble code, e.g. "P0420".
+	Code string `json:"-"`
+	// Description is the standard or manufacturer description of the code.
+	Description string `json:"description"`
+	// System is the vehicle system the code belongs to.
+	System string `json:"system,omitempty"`
+	// Severity is one of "critical", "warning" or "info".
+	Severity string `json:"severity"`
+	// RecommendedAction is what the owner should do about the code.
+	RecommendedAction string `json:"recommendedAction,omitempty"`
+}
+
+// Dictionary resolves diagnostic trouble codes to their descriptions.
+type Dictionary struct {
+	// Version is the version of the dictionary data.
+	Version string `json:"version"`
+	// Standard is the standard the generic codes are taken from.
+	Standard string `json:"standard"`
+	// Codes holds the generic codes.
+	Codes map[string]Entry `json:"codes"`
+	// Manufacturers holds overrides keyed by vehicle definition ID (e.g. "ford_f-150_2021") or make (e.g. "ford").
+	Manufacturers map[string]map[string]Entry `json:"manufacturers"`
+}
+
+var defaultDictionary = sync.OnceValue(func() *Dictionary {
+	dict, err := Parse(dictionaryJSON)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded DTC dictionary: %v", err))
+	}
+	return dict
+})
+
+// Default returns the embedded DTC dictionary.
+func Default() *Dictionary {
+	return defaultDictionary()
+}
+
+// Parse parses a DTC dictionary document.
+func Parse(data []byte) (*Dictionary, error) {
+	var dict Dictionary
+	if err := json.Unmarshal(data, &dict); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dictionary: %w", err)
+	}
+	if dict.Version == "" {
+		return nil, errors.New("dictionary version is required")
+	}
+	if err := validateEntries(dict.Codes); err != nil {
+		return nil, err
+	}
+	for key, entries := range dict.Manufacturers {
+		if err := validateEntries(entries); err != nil {
+			return nil, fmt.Errorf("manufacturer %s: %w", key, err)
+		}
+	}
+	return &dict, nil
+}
+
+func validateEntries(entries map[string]Entry) error {
+	for code, entry := range entries {
+		if len(code) != 5 || systemName(code) == "" {
+			return fmt.Errorf("invalid code %q", code)
+		}
+		switch entry.Severity {
+		case SeverityCritical, SeverityWarning, SeverityInfo:
+		default:
+			return fmt.Errorf("code %s has invalid severity %q", code, entry.Severity)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the entry for a code as reported by the vehicle with the given definition ID.
+// Manufacturer overrides for the definition take precedence over overrides for its make, which
+// take precedence over the generic codes. Unknown codes get a severity inferred from their structure
+// and no description.
+func (d *Dictionary) Lookup(code, definitionID string) Entry {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	entry, ok := d.Manufacturers[definitionID][code]
+	if !ok {
+		entry, ok = d.Manufacturers[makeFromDefinition(definitionID)][code]
+	}
+	if !ok {
+		entry, ok = d.Codes[code]
+	}
+	if !ok {
+		entry = Entry{Severity: inferSeverity(code)}
+	}
+
+	entry.Code = code
+	if entry.System == "" {
+		entry.System = systemName(code)
+	}
+	return entry
+}
+
+// makeFromDefinition returns the make part of a definition ID such as "toyota_tacoma-4wd_2023".
+func makeFromDefinition(definitionID string) string {
+	vehicleMake, _, _ := strings.Cut(definitionID, "_")
+	return vehicleMake
+}
+
+// systemName returns the system a code belongs to from its first character and, for
+// generic powertrain codes, its subsystem digit.
+func systemName(code string) string {
+	if code == "" {
+		return ""
+	}
+	var system string
+	switch code[0] {
+	case 'P':
+		system = "Powertrain"
+	case 'C':
+		system = "Chassis"
+	case 'B':
+		system = "Body"
+	case 'U':
+		system = "Network"
+	default:
+		return ""
+	}
+	if code[0] != 'P' || len(code) < 3 || code[1] != '0' {
+		return system
+	}
+
+	switch code[2] {
+	case '0', '1', '2':
+		return system + " - Fuel and Air Metering"
+	case '3':
+		return system + " - Ignition System or Misfire"
+	case '4':
+		return system + " - Auxiliary Emission Controls"
+	case '5':
+		return system + " - Vehicle Speed, Idle Control and Auxiliary Inputs"
+	case '6':
+		return system + " - Computer Output Circuit"
+	case '7', '8', '9':
+		return system + " - Transmission"
+	case 'A', 'B', 'C':
+		return system + " - Hybrid Propulsion"
+	}
+	return system
+}
+
+// inferSeverity guesses the severity of a code that is not in the dictionary.
+func inferSeverity(code string) string {
+	switch {
+	case strings.HasPrefix(code, "P01"), strings.HasPrefix(code, "P02"):
+		return SeverityCritical // Fuel and air metering
+	case strings.HasPrefix(code, "P03"), strings.HasPrefix(code, "P04"):
+		return SeverityWarning // Ignition system or auxiliary emission
+	case strings.HasPrefix(code, "U"):
+		return SeverityWarning // Network communication codes
+	}
+	return SeverityInfo
+}
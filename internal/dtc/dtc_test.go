@@ -0,0 +1,111 @@
+package dtc_test
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/attestation-api/internal/dtc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	dict := dtc.Default()
+	require.NotNil(t, dict)
+	assert.NotEmpty(t, dict.Version)
+	assert.NotEmpty(t, dict.Codes)
+}
+
+func TestDictionary_Lookup(t *testing.T) {
+	dict, err := dtc.Parse([]byte(`{
+		"version": "test",
+		"codes": {
+			"P0420": {"description": "Catalyst System Efficiency Below Threshold (Bank 1)", "severity": "warning", "recommendedAction": "Inspect the catalytic converter."},
+			"P1000": {"description": "Generic P1000", "severity": "warning"}
+		},
+		"manufacturers": {
+			"ford": {
+				"P1000": {"description": "OBD-II Monitor Testing Not Complete", "severity": "info"}
+			},
+			"ford_f-150_2021": {
+				"P0420": {"description": "F-150 catalyst", "system": "Exhaust", "severity": "critical"}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		code         string
+		definitionID string
+		expected     dtc.Entry
+	}{
+		{
+			name:         "generic code",
+			code:         "P0420",
+			definitionID: "toyota_camry_2020",
+			expected: dtc.Entry{
+				Code:              "P0420",
+				Description:       "Catalyst System Efficiency Below Threshold (Bank 1)",
+				System:            "Powertrain - Auxiliary Emission Controls",
+				Severity:          dtc.SeverityWarning,
+				RecommendedAction: "Inspect the catalytic converter.",
+			},
+		},
+		{
+			name:         "make override",
+			code:         "P1000",
+			definitionID: "ford_escape_2019",
+			expected: dtc.Entry{
+				Code:        "P1000",
+				Description: "OBD-II Monitor Testing Not Complete",
+				System:      "Powertrain",
+				Severity:    dtc.SeverityInfo,
+			},
+		},
+		{
+			name:         "definition override takes precedence",
+			code:         "p0420 ",
+			definitionID: "ford_f-150_2021",
+			expected: dtc.Entry{
+				Code:        "P0420",
+				Description: "F-150 catalyst",
+				System:      "Exhaust",
+				Severity:    dtc.SeverityCritical,
+			},
+		},
+		{
+			name: "unknown code infers severity",
+			code: "U0999",
+			expected: dtc.Entry{
+				Code:     "U0999",
+				System:   "Network",
+				Severity: dtc.SeverityWarning,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dict.Lookup(tt.code, tt.definitionID))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed", data: `{`},
+		{name: "missing version", data: `{"codes": {}}`},
+		{name: "invalid code", data: `{"version": "test", "codes": {"X0420": {"severity": "info"}}}`},
+		{name: "invalid severity", data: `{"version": "test", "codes": {"P0420": {"severity": "bad"}}}`},
+		{name: "invalid manufacturer severity", data: `{"version": "test", "manufacturers": {"ford": {"P1000": {}}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dtc.Parse([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
@@ -3,15 +3,17 @@ package erc191
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // SignMessage signs the message with the configured private key.
 func SignMessage[T ~[]byte | ~string](message T, privateKey *ecdsa.PrivateKey) (string, error) {
-	msg := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
-	sign := crypto.Keccak256Hash([]byte(msg))
+	sign := hashMessage(message)
 	signature, err := crypto.Sign(sign.Bytes(), privateKey)
 	if err != nil {
 		return "", err
@@ -20,3 +22,27 @@ func SignMessage[T ~[]byte | ~string](message T, privateKey *ecdsa.PrivateKey) (
 	signature[64] += 27 // Support old Ethereum format
 	return "0x" + hex.EncodeToString(signature), nil
 }
+
+// RecoverAddress returns the address that produced the hex encoded signature of the message.
+func RecoverAddress[T ~[]byte | ~string](message T, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, errors.New("signature must be 65 bytes")
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27 // Support old Ethereum format
+	}
+	pubKey, err := crypto.SigToPub(hashMessage(message).Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover public key: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+func hashMessage[T ~[]byte | ~string](message T) common.Hash {
+	msg := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
+	return crypto.Keccak256Hash([]byte(msg))
+}
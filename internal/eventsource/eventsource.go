@@ -0,0 +1,31 @@
+// Package eventsource receives cloud events from a message broker. Consumers pull one message at a time, so a
+// consumer that is busy stops receiving until it is done.
+package eventsource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// ErrClosed is returned by Next when the source is closed.
+var ErrClosed = errors.New("event source closed")
+
+// Message is a cloud event received from a Source. It is redelivered when it is neither acknowledged nor
+// terminated.
+type Message interface {
+	Event() *cloudevent.RawEvent
+	// Ack acknowledges the message, it is not received again.
+	Ack() error
+	// Nak asks for the message to be received again after the delay.
+	Nak(delay time.Duration) error
+}
+
+// Source receives cloud events.
+type Source interface {
+	// Next blocks until a message is received, ctx is canceled or the source is closed.
+	Next(ctx context.Context) (Message, error)
+	Close() error
+}
@@ -0,0 +1,102 @@
+package eventsource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Memory is a Source of the events published to it, for tests and local development.
+type Memory struct {
+	mtx      sync.Mutex
+	messages chan *memoryMessage
+	closed   chan struct{}
+	acked    []string
+	naked    []string
+}
+
+// NewMemory creates a Memory holding up to size unreceived messages.
+func NewMemory(size int) *Memory {
+	return &Memory{
+		messages: make(chan *memoryMessage, size),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Publish adds the event to the source, blocking while it is full.
+func (m *Memory) Publish(ctx context.Context, event *cloudevent.RawEvent) error {
+	select {
+	case m.messages <- &memoryMessage{source: m, event: event}:
+		return nil
+	case <-m.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Next returns the next published event.
+func (m *Memory) Next(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-m.messages:
+		return msg, nil
+	case <-m.closed:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops Next and Publish.
+func (m *Memory) Close() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	select {
+	case <-m.closed:
+	default:
+		close(m.closed)
+	}
+	return nil
+}
+
+// Acked returns the IDs of the acknowledged events in the order they were acknowledged.
+func (m *Memory) Acked() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]string{}, m.acked...)
+}
+
+// Naked returns the IDs of the events asked to be received again, which are published again after the delay.
+func (m *Memory) Naked() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]string{}, m.naked...)
+}
+
+type memoryMessage struct {
+	source *Memory
+	event  *cloudevent.RawEvent
+}
+
+func (m *memoryMessage) Event() *cloudevent.RawEvent {
+	return m.event
+}
+
+func (m *memoryMessage) Ack() error {
+	m.source.mtx.Lock()
+	defer m.source.mtx.Unlock()
+	m.source.acked = append(m.source.acked, m.event.ID)
+	return nil
+}
+
+func (m *memoryMessage) Nak(delay time.Duration) error {
+	m.source.mtx.Lock()
+	m.source.naked = append(m.source.naked, m.event.ID)
+	m.source.mtx.Unlock()
+	time.AfterFunc(delay, func() {
+		_ = m.source.Publish(context.Background(), m.event)
+	})
+	return nil
+}
@@ -0,0 +1,120 @@
+package eventsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+)
+
+const defaultMaxPending = 64
+
+// NATSConfig configures a NATS JetStream source.
+type NATSConfig struct {
+	URL    string
+	Stream string
+	// Subject filters the subjects of the stream, every subject when empty.
+	Subject string
+	// Consumer is the name of the durable consumer, shared by the replicas of the service.
+	Consumer string
+	// MaxPending is the number of messages received and not yet acknowledged, 64 when not positive.
+	MaxPending int
+}
+
+// NATS is a Source of the cloud events of a NATS JetStream stream, read with a durable pull consumer. A new consumer
+// starts with the messages published after it is created.
+type NATS struct {
+	logger   *zerolog.Logger
+	conn     *nats.Conn
+	messages jetstream.MessagesContext
+}
+
+// NewNATS connects to NATS and creates or updates the durable consumer of the stream.
+func NewNATS(ctx context.Context, logger *zerolog.Logger, cfg NATSConfig) (*NATS, error) {
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = defaultMaxPending
+	}
+	conn, err := nats.Connect(cfg.URL, nats.Name("attestation-api"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	consumer, err := js.CreateOrUpdateConsumer(ctx, cfg.Stream, jetstream.ConsumerConfig{
+		Durable:       cfg.Consumer,
+		FilterSubject: cfg.Subject,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       5 * time.Minute,
+		MaxAckPending: cfg.MaxPending,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create consumer %s of stream %s: %w", cfg.Consumer, cfg.Stream, err)
+	}
+	messages, err := consumer.Messages(jetstream.PullMaxMessages(cfg.MaxPending))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to pull messages of stream %s: %w", cfg.Stream, err)
+	}
+	return &NATS{logger: logger, conn: conn, messages: messages}, nil
+}
+
+// Next returns the next message that holds a cloud event. Other messages are terminated, they would never be
+// processed.
+func (n *NATS) Next(ctx context.Context) (Message, error) {
+	for {
+		msg, err := n.messages.Next(jetstream.NextContext(ctx))
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return nil, ErrClosed
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("failed to receive NATS message: %w", err)
+		}
+		var event cloudevent.RawEvent
+		if err := json.Unmarshal(msg.Data(), &event); err != nil {
+			n.logger.Warn().Err(err).Str("subject", msg.Subject()).Msg("Dropping NATS message that is not a cloud event.")
+			if err := msg.Term(); err != nil {
+				n.logger.Warn().Err(err).Msg("Failed to terminate NATS message.")
+			}
+			continue
+		}
+		return &natsMessage{msg: msg, event: &event}, nil
+	}
+}
+
+// Close stops receiving messages and closes the connection.
+func (n *NATS) Close() error {
+	n.messages.Stop()
+	n.conn.Close()
+	return nil
+}
+
+type natsMessage struct {
+	msg   jetstream.Msg
+	event *cloudevent.RawEvent
+}
+
+func (m *natsMessage) Event() *cloudevent.RawEvent {
+	return m.event
+}
+
+func (m *natsMessage) Ack() error {
+	return m.msg.Ack()
+}
+
+func (m *natsMessage) Nak(delay time.Duration) error {
+	return m.msg.NakWithDelay(delay)
+}
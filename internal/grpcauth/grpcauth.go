@@ -0,0 +1,233 @@
+// Package grpcauth authenticates gRPC callers by their mTLS client certificate or a token-exchange JWT
+// and authorizes each call with a per-method policy.
+package grpcauth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Permissions granted to mTLS clients.
+const (
+	// PermissionCreateVIN allows creating and diagnosing VIN attestations of any vehicle.
+	PermissionCreateVIN = "vin:create"
+	// PermissionForceVIN allows creating a VIN attestation when a valid one exists.
+	PermissionForceVIN = "vin:force"
+	// PermissionManualVIN allows calling the manual VIN methods, which also require an operator token.
+	PermissionManualVIN = "vin:manual"
+	// PermissionCreatePosition allows creating vehicle position attestations of any vehicle.
+	PermissionCreatePosition = "position:create"
+	// PermissionCreateOdometer allows creating odometer statement attestations of any vehicle.
+	PermissionCreateOdometer = "odometer:create"
+	// PermissionCreateHealth allows creating vehicle health attestations of any vehicle.
+	PermissionCreateHealth = "health:create"
+	// PermissionCreateBattery allows creating battery health attestations of any vehicle.
+	PermissionCreateBattery = "battery:create"
+)
+
+// Policy authorizes the calls of a method.
+type Policy struct {
+	// Public methods need no caller identity.
+	Public bool
+	// ClientPermissions are the permissions an mTLS client needs to call the method.
+	ClientPermissions []string
+	// VehiclePermissions are the privileges a token-exchange token needs on the vehicle of the request.
+	// Token-exchange tokens cannot call the method when empty.
+	VehiclePermissions []string
+	// Elevated returns the additional client permissions a request needs, e.g. to force an attestation.
+	// Token-exchange tokens cannot make elevated requests.
+	Elevated func(req any) []string
+}
+
+// tokenIDRequest is a request for a single vehicle.
+type tokenIDRequest interface {
+	GetTokenId() uint32
+}
+
+// Authorizer authorizes gRPC calls.
+type Authorizer struct {
+	policies        map[string]Policy
+	clients         map[string][]string
+	keyFunc         jwt.Keyfunc
+	parser          *jwt.Parser
+	vehicleContract common.Address
+}
+
+// New creates an Authorizer of the methods in policies, keyed by full method name. Clients maps mTLS client
+// identities, a URI, DNS name or common name of the certificate, to their permissions. KeyFunc verifies
+// token-exchange tokens for vehicles of the vehicle contract.
+func New(policies map[string]Policy, clients map[string][]string, keyFunc jwt.Keyfunc, vehicleContract common.Address) *Authorizer {
+	return &Authorizer{
+		policies:        policies,
+		clients:         clients,
+		keyFunc:         keyFunc,
+		parser:          jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "ES256"}), jwt.WithExpirationRequired()),
+		vehicleContract: vehicleContract,
+	}
+}
+
+// ParseClientPermissions parses a JSON object of mTLS client identities and their permissions,
+// e.g. {"spiffe://dimo/ns/dimo/sa/vehicle-triggers-api": ["vin:create"]}.
+func ParseClientPermissions(data string) (map[string][]string, error) {
+	clients := map[string][]string{}
+	if strings.TrimSpace(data) == "" {
+		return clients, nil
+	}
+	if err := json.Unmarshal([]byte(data), &clients); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gRPC client permissions: %w", err)
+	}
+	return clients, nil
+}
+
+// UnaryInterceptor authorizes unary calls.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id, err := a.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(requester.NewContext(ctx, id), req)
+	}
+}
+
+// StreamInterceptor authorizes streaming calls. The request of a stream is not known up front,
+// so only public methods and mTLS clients are allowed.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, err := a.authorize(stream.Context(), info.FullMethod, nil); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// authorize authorizes the call and returns the identity of the caller, the client identity with the permissions
+// or the subject of the token. Public methods have no caller identity.
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string, req any) (string, error) {
+	policy, ok := a.policies[fullMethod]
+	if !ok {
+		return "", status.Errorf(codes.PermissionDenied, "method %s is not allowed", fullMethod)
+	}
+	if policy.Public {
+		return "", nil
+	}
+
+	required := slices.Clone(policy.ClientPermissions)
+	var elevated []string
+	if policy.Elevated != nil && req != nil {
+		elevated = policy.Elevated(req)
+		required = append(required, elevated...)
+	}
+
+	identities := clientIdentities(ctx)
+	if len(required) > 0 {
+		if identity, ok := a.clientWithPermissions(identities, required); ok {
+			return identity, nil
+		}
+	}
+
+	token := bearerToken(ctx)
+	if len(policy.VehiclePermissions) > 0 && len(elevated) == 0 && token != "" {
+		return a.authorizeToken(token, req, policy.VehiclePermissions)
+	}
+	if len(identities) == 0 && token == "" {
+		return "", status.Error(codes.Unauthenticated, "client certificate or token is required")
+	}
+	return "", status.Errorf(codes.PermissionDenied, "caller is not allowed to call %s", fullMethod)
+}
+
+// clientWithPermissions returns the first of the identities that has all the permissions.
+func (a *Authorizer) clientWithPermissions(identities, permissions []string) (string, bool) {
+	for _, identity := range identities {
+		granted, ok := a.clients[identity]
+		if !ok {
+			continue
+		}
+		if !slices.ContainsFunc(permissions, func(p string) bool { return !slices.Contains(granted, p) }) {
+			return identity, true
+		}
+	}
+	return "", false
+}
+
+// authorizeToken checks that the token-exchange token grants all privileges on the vehicle of the request and
+// returns the subject of the token.
+func (a *Authorizer) authorizeToken(token string, req any, permissions []string) (string, error) {
+	vehicleReq, ok := req.(tokenIDRequest)
+	if !ok {
+		return "", status.Error(codes.PermissionDenied, "method cannot be called with a token")
+	}
+	var claims tokenclaims.Token
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keyFunc); err != nil {
+		return "", status.Error(codes.Unauthenticated, "invalid token")
+	}
+	assetDID, err := cloudevent.DecodeERC721DID(claims.Asset)
+	if err != nil {
+		return "", status.Error(codes.PermissionDenied, "invalid token asset")
+	}
+	if assetDID.ContractAddress != a.vehicleContract || assetDID.TokenID.Cmp(big.NewInt(int64(vehicleReq.GetTokenId()))) != 0 {
+		return "", status.Error(codes.PermissionDenied, "token is not for the requested vehicle")
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(claims.Permissions, permission) {
+			return "", status.Error(codes.PermissionDenied, "token does not contain required privileges")
+		}
+	}
+	return claims.Subject, nil
+}
+
+// clientIdentities returns the URIs, DNS names and common name of the verified client certificate.
+func clientIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return certificateIdentities(tlsInfo.State.VerifiedChains[0][0])
+}
+
+func certificateIdentities(cert *x509.Certificate) []string {
+	var identities []string
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	identities = append(identities, cert.DNSNames...)
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	return identities
+}
+
+// bearerToken returns the bearer token of the authorization metadata.
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,252 @@
+package grpcauth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/controllers/rpc"
+	"github.com/DIMO-Network/attestation-api/internal/grpcauth"
+	attgrpc "github.com/DIMO-Network/attestation-api/pkg/grpc"
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const vehicleContract = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"
+
+func clientContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	clients, err := grpcauth.ParseClientPermissions(`{
+		"spiffe://dimo/ns/dimo/sa/vehicle-triggers-api": ["vin:create"],
+		"ops-console": ["vin:create", "vin:force", "vin:manual"],
+		"insurance-backend": ["position:create", "odometer:create"]
+	}`)
+	require.NoError(t, err)
+	authorizer := grpcauth.New(rpc.MethodPolicies(), clients, func(*jwt.Token) (any, error) { return &key.PublicKey, nil }, common.HexToAddress(vehicleContract))
+
+	vehicleToken := func(tokenID int64, contract string, permissions ...string) string {
+		claims := tokenclaims.Token{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+			CustomClaims: tokenclaims.CustomClaims{
+				Asset:       cloudevent.ERC721DID{ChainID: 137, ContractAddress: common.HexToAddress(contract), TokenID: big.NewInt(tokenID)}.String(),
+				Permissions: permissions,
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+	spiffeURI, err := url.Parse("spiffe://dimo/ns/dimo/sa/vehicle-triggers-api")
+	require.NoError(t, err)
+	triggers := clientContext(&x509.Certificate{URIs: []*url.URL{spiffeURI}})
+	ops := clientContext(&x509.Certificate{Subject: pkix.Name{CommonName: "ops-console"}})
+	insurance := clientContext(&x509.Certificate{DNSNames: []string{"insurance-backend"}})
+	unknown := clientContext(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}})
+	vinToken := vehicleToken(7, vehicleContract, tokenclaims.PermissionGetVINCredential)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		method       string
+		req          any
+		expectedCode codes.Code
+	}{
+		{
+			name:         "client with permission",
+			ctx:          triggers,
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "force needs the force permission",
+			ctx:          triggers,
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7, Force: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "client with force permission",
+			ctx:          ops,
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7, Force: true},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "manual needs the manual permission",
+			ctx:          triggers,
+			method:       attgrpc.AttestationService_ManualVinVcCreation_FullMethodName,
+			req:          &attgrpc.ManualVinVcCreationRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "client with manual permission",
+			ctx:          ops,
+			method:       attgrpc.AttestationService_ManualVinVcCreation_FullMethodName,
+			req:          &attgrpc.ManualVinVcCreationRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "unknown client",
+			ctx:          unknown,
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token",
+			ctx:          withToken(context.Background(), vinToken),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "vehicle token for eligibility",
+			ctx:          withToken(context.Background(), vinToken),
+			method:       attgrpc.AttestationService_GetVinVcEligibility_FullMethodName,
+			req:          &attgrpc.GetVinVcEligibilityRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "vehicle token cannot force",
+			ctx:          withToken(context.Background(), vinToken),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7, Force: true},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token for another vehicle",
+			ctx:          withToken(context.Background(), vinToken),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 8},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token for another contract",
+			ctx:          withToken(context.Background(), vehicleToken(7, "0x0000000000000000000000000000000000000001", tokenclaims.PermissionGetVINCredential)),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token without privilege",
+			ctx:          withToken(context.Background(), vehicleToken(7, vehicleContract, tokenclaims.PermissionGetNonLocationHistory)),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token cannot call manual methods",
+			ctx:          withToken(context.Background(), vinToken),
+			method:       attgrpc.AttestationService_ManualVinVcCreation_FullMethodName,
+			req:          &attgrpc.ManualVinVcCreationRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "client with position permission",
+			ctx:          insurance,
+			method:       attgrpc.AttestationService_CreateVehiclePositionVc_FullMethodName,
+			req:          &attgrpc.CreateVehiclePositionVcRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "health needs the health permission",
+			ctx:          insurance,
+			method:       attgrpc.AttestationService_CreateVehicleHealthVc_FullMethodName,
+			req:          &attgrpc.CreateVehicleHealthVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "vehicle token for position",
+			ctx:          withToken(context.Background(), vehicleToken(7, vehicleContract, tokenclaims.PermissionGetLocationHistory)),
+			method:       attgrpc.AttestationService_CreateVehiclePositionVc_FullMethodName,
+			req:          &attgrpc.CreateVehiclePositionVcRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "vehicle token for health needs all privileges",
+			ctx:          withToken(context.Background(), vehicleToken(7, vehicleContract, tokenclaims.PermissionGetNonLocationHistory)),
+			method:       attgrpc.AttestationService_CreateVehicleHealthVc_FullMethodName,
+			req:          &attgrpc.CreateVehicleHealthVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "invalid token",
+			ctx:          withToken(context.Background(), "not-a-jwt"),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "anonymous",
+			ctx:          context.Background(),
+			method:       attgrpc.AttestationService_EnsureVinVc_FullMethodName,
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "public method",
+			ctx:          context.Background(),
+			method:       attgrpc.AttestationService_GetVinVcLatest_FullMethodName,
+			req:          &attgrpc.GetLatestVinVcRequest{TokenId: 7},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "method without policy",
+			ctx:          ops,
+			method:       "/grpc.AttestationService/Unknown",
+			req:          &attgrpc.EnsureVinVcRequest{TokenId: 7},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			}
+			_, err := authorizer.UnaryInterceptor()(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			require.Equal(t, tt.expectedCode == codes.OK, called)
+		})
+	}
+}
+
+func TestParseClientPermissions(t *testing.T) {
+	clients, err := grpcauth.ParseClientPermissions("")
+	require.NoError(t, err)
+	require.Empty(t, clients)
+
+	_, err = grpcauth.ParseClientPermissions(`["vin:create"]`)
+	require.Error(t, err)
+}
@@ -0,0 +1,288 @@
+// Package healthscore scores vehicle health statuses with declarative, versioned rule sets.
+package healthscore
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+)
+
+const (
+	// KindDTC rules deduct points for each matching DTC.
+	KindDTC = "dtc"
+	// KindTirePressure rules deduct points for each tire outside its normal range.
+	KindTirePressure = "tirePressure"
+	// KindComponent rules deduct points for each vehicle component in a matching status.
+	KindComponent = "component"
+)
+
+// ErrUnknownRuleSet is returned when a requested rule set is not defined.
+var ErrUnknownRuleSet = errors.New("unknown health rule set")
+
+//go:embed rules.json
+var defaultRulesJSON []byte
+
+// Rule is a single deduction rule.
+type Rule struct {
+	// ID identifies the rule in the deductions of a scored status.
+	ID string `json:"id"`
+	// Kind is what the rule applies to, "dtc", "tirePressure" or "component".
+	Kind string `json:"kind"`
+
+	// Severity limits a DTC rule to codes of this severity.
+	Severity string `json:"severity,omitempty"`
+	// Codes limits a DTC rule to these codes.
+	Codes []string `json:"codes,omitempty"`
+	// Status limits a DTC rule to codes in this state, defaults to "active".
+	// A component rule requires the component status it applies to, "warning" or "critical".
+	Status string `json:"status,omitempty"`
+	// Components limits a component rule to these components.
+	Components []string `json:"components,omitempty"`
+
+	// Deduction is the points deducted for each match.
+	Deduction int `json:"deduction"`
+	// DeductionPerPercent adds points for each percent a tire deviates beyond its normal range.
+	DeductionPerPercent float64 `json:"deductionPerPercent,omitempty"`
+	// MaxDeductionPerTire caps the points deducted for a single tire.
+	MaxDeductionPerTire int `json:"maxDeductionPerTire,omitempty"`
+	// MaxDeduction caps the total points deducted by the rule.
+	MaxDeduction int `json:"maxDeduction,omitempty"`
+}
+
+// RuleSet is a named, versioned health definition.
+type RuleSet struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	// BaseScore is the score of a vehicle without deductions.
+	BaseScore int `json:"baseScore"`
+	// HealthyThreshold is the lowest score considered healthy.
+	HealthyThreshold int `json:"healthyThreshold"`
+	// SuppressMILWithCodes ignores the MIL_ON pseudo code when the vehicle reports the codes behind it,
+	// so the check engine light is not counted on top of them.
+	SuppressMILWithCodes bool   `json:"suppressMilWithCodes,omitempty"`
+	Rules                []Rule `json:"rules"`
+}
+
+// Engine holds the loaded rule sets.
+type Engine struct {
+	defaultName string
+	ruleSets    map[string]*RuleSet
+}
+
+type rulesFile struct {
+	Default  string     `json:"default"`
+	RuleSets []*RuleSet `json:"ruleSets"`
+}
+
+// Load loads the rule file at path, or the embedded rule sets when path is empty.
+func Load(path string) (*Engine, error) {
+	if path == "" {
+		return Parse(defaultRulesJSON)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read health rules file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates a rule file.
+func Parse(data []byte) (*Engine, error) {
+	var file rulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal health rules: %w", err)
+	}
+
+	engine := &Engine{
+		defaultName: file.Default,
+		ruleSets:    make(map[string]*RuleSet, len(file.RuleSets)),
+	}
+	for _, ruleSet := range file.RuleSets {
+		if err := ruleSet.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := engine.ruleSets[ruleSet.Name]; ok {
+			return nil, fmt.Errorf("duplicate health rule set %q", ruleSet.Name)
+		}
+		engine.ruleSets[ruleSet.Name] = ruleSet
+	}
+	if _, ok := engine.ruleSets[engine.defaultName]; !ok {
+		return nil, fmt.Errorf("default health rule set %q is not defined", engine.defaultName)
+	}
+	return engine, nil
+}
+
+func (r *RuleSet) validate() error {
+	if r.Name == "" || r.Version == "" {
+		return errors.New("health rule sets require a name and version")
+	}
+	if r.BaseScore <= 0 {
+		return fmt.Errorf("health rule set %s: baseScore must be positive", r.Name)
+	}
+	for _, rule := range r.Rules {
+		switch rule.Kind {
+		case KindDTC:
+			switch rule.Status {
+			case "", types.DTCStatusActive, types.DTCStatusCleared:
+			default:
+				return fmt.Errorf("health rule set %s: rule %s has invalid status %q", r.Name, rule.ID, rule.Status)
+			}
+		case KindTirePressure:
+		case KindComponent:
+			switch rule.Status {
+			case types.ComponentStatusWarning, types.ComponentStatusCritical:
+			default:
+				return fmt.Errorf("health rule set %s: rule %s has invalid component status %q", r.Name, rule.ID, rule.Status)
+			}
+		default:
+			return fmt.Errorf("health rule set %s: rule %s has invalid kind %q", r.Name, rule.ID, rule.Kind)
+		}
+		if rule.Deduction < 0 || rule.DeductionPerPercent < 0 || rule.MaxDeduction < 0 || rule.MaxDeductionPerTire < 0 {
+			return fmt.Errorf("health rule set %s: rule %s has negative deductions", r.Name, rule.ID)
+		}
+	}
+	return nil
+}
+
+// RuleSet returns the named rule set, or the default rule set when name is empty.
+func (e *Engine) RuleSet(name string) (*RuleSet, error) {
+	if name == "" {
+		name = e.defaultName
+	}
+	ruleSet, ok := e.ruleSets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRuleSet, name)
+	}
+	return ruleSet, nil
+}
+
+// Score sets the health score, healthy flag, deductions and rule set of the status.
+func (r *RuleSet) Score(status *types.VehicleHealthStatus) {
+	dtcs := r.scoredDTCs(status.DTCs)
+
+	score := r.BaseScore
+	var deductions []types.HealthDeduction
+	for _, rule := range r.Rules {
+		var points int
+		switch rule.Kind {
+		case KindDTC:
+			points = dtcDeduction(rule, dtcs)
+		case KindTirePressure:
+			points = tirePressureDeduction(rule, status.TirePressure)
+		case KindComponent:
+			points = componentDeduction(rule, status.Components)
+		}
+		if rule.MaxDeduction > 0 {
+			points = min(points, rule.MaxDeduction)
+		}
+		if points == 0 {
+			continue
+		}
+		score -= points
+		deductions = append(deductions, types.HealthDeduction{Rule: rule.ID, Points: points})
+	}
+
+	status.HealthScore = max(score, 0)
+	status.IsHealthy = status.HealthScore >= r.HealthyThreshold
+	status.Deductions = deductions
+	status.RuleSet = r.Name
+	status.RuleSetVersion = r.Version
+}
+
+// scoredDTCs returns the DTCs that take part in scoring.
+func (r *RuleSet) scoredDTCs(dtcs []types.DiagnosticTroubleCode) []types.DiagnosticTroubleCode {
+	if !r.SuppressMILWithCodes {
+		return dtcs
+	}
+	hasActiveCodes := slices.ContainsFunc(dtcs, func(dtc types.DiagnosticTroubleCode) bool {
+		return dtc.Code != types.DTCCodeMILOn && dtc.Status != types.DTCStatusCleared
+	})
+	if !hasActiveCodes {
+		return dtcs
+	}
+	return slices.DeleteFunc(slices.Clone(dtcs), func(dtc types.DiagnosticTroubleCode) bool {
+		return dtc.Code == types.DTCCodeMILOn
+	})
+}
+
+func dtcDeduction(rule Rule, dtcs []types.DiagnosticTroubleCode) int {
+	status := rule.Status
+	if status == "" {
+		status = types.DTCStatusActive
+	}
+	points := 0
+	for _, dtc := range dtcs {
+		if dtc.Status != "" && dtc.Status != status {
+			continue
+		}
+		if rule.Severity != "" && dtc.Severity != rule.Severity {
+			continue
+		}
+		if len(rule.Codes) > 0 && !slices.Contains(rule.Codes, dtc.Code) {
+			continue
+		}
+		points += rule.Deduction
+	}
+	return points
+}
+
+func componentDeduction(rule Rule, components []types.ComponentHealth) int {
+	points := 0
+	for _, component := range components {
+		if component.Status != rule.Status {
+			continue
+		}
+		if len(rule.Components) > 0 && !slices.Contains(rule.Components, component.Component) {
+			continue
+		}
+		points += rule.Deduction
+	}
+	return points
+}
+
+// tirePressureDeduction deducts for every tire outside its axle's normal range, growing with
+// how far the tire is beyond the range as a percentage of the placard pressure.
+func tirePressureDeduction(rule Rule, tp *types.TirePressureStatus) int {
+	if tp == nil || tp.Thresholds == nil {
+		return 0
+	}
+	th := tp.Thresholds
+	tires := []struct {
+		pressure          *float64
+		placard, min, max float64
+	}{
+		{tp.FrontLeft, th.FrontPlacard, th.FrontMin, th.FrontMax},
+		{tp.FrontRight, th.FrontPlacard, th.FrontMin, th.FrontMax},
+		{tp.RearLeft, th.RearPlacard, th.RearMin, th.RearMax},
+		{tp.RearRight, th.RearPlacard, th.RearMin, th.RearMax},
+	}
+
+	points := 0
+	for _, tire := range tires {
+		if tire.pressure == nil || tire.placard <= 0 {
+			continue
+		}
+		var beyond float64
+		switch {
+		case *tire.pressure < tire.min:
+			beyond = tire.min - *tire.pressure
+		case *tire.pressure > tire.max:
+			beyond = *tire.pressure - tire.max
+		default:
+			continue
+		}
+		tirePoints := rule.Deduction + int(math.Round(beyond/tire.placard*100*rule.DeductionPerPercent))
+		if rule.MaxDeductionPerTire > 0 {
+			tirePoints = min(tirePoints, rule.MaxDeductionPerTire)
+		}
+		points += tirePoints
+	}
+	return points
+}
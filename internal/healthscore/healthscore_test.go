@@ -0,0 +1,206 @@
+package healthscore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DIMO-Network/attestation-api/internal/healthscore"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `{
+	"default": "test",
+	"ruleSets": [
+		{
+			"name": "test",
+			"version": "test/v1",
+			"baseScore": 100,
+			"healthyThreshold": 70,
+			"suppressMilWithCodes": true,
+			"rules": [
+				{"id": "dtc-critical", "kind": "dtc", "severity": "critical", "deduction": 30},
+				{"id": "dtc-warning", "kind": "dtc", "severity": "warning", "deduction": 15},
+				{"id": "dtc-info", "kind": "dtc", "severity": "info", "deduction": 5, "maxDeduction": 5},
+				{"id": "tire-pressure", "kind": "tirePressure", "deduction": 5, "deductionPerPercent": 1, "maxDeductionPerTire": 20},
+				{"id": "component-critical", "kind": "component", "status": "critical", "deduction": 20},
+				{"id": "brake-pads-warning", "kind": "component", "status": "warning", "components": ["brakePads"], "deduction": 10}
+			]
+		},
+		{
+			"name": "no-dedupe",
+			"version": "no-dedupe/v1",
+			"baseScore": 100,
+			"healthyThreshold": 90,
+			"rules": [
+				{"id": "dtc-warning", "kind": "dtc", "severity": "warning", "deduction": 15}
+			]
+		}
+	]
+}`
+
+func ptr(v float64) *float64 { return &v }
+
+func TestDefaultRules(t *testing.T) {
+	engine, err := healthscore.Load("")
+	require.NoError(t, err)
+
+	ruleSet, err := engine.RuleSet("")
+	require.NoError(t, err)
+	assert.Equal(t, "default", ruleSet.Name)
+	assert.NotEmpty(t, ruleSet.Version)
+
+	for _, name := range []string{"resale", "leasing"} {
+		_, err := engine.RuleSet(name)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestRuleSet_Score(t *testing.T) {
+	engine, err := healthscore.Parse([]byte(testRules))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		ruleSet            string
+		status             types.VehicleHealthStatus
+		expectedScore      int
+		expectedHealthy    bool
+		expectedDeductions []types.HealthDeduction
+	}{
+		{
+			name:            "no findings",
+			expectedScore:   100,
+			expectedHealthy: true,
+		},
+		{
+			name: "MIL is suppressed when codes are reported and cleared codes are ignored",
+			status: types.VehicleHealthStatus{
+				DTCs: []types.DiagnosticTroubleCode{
+					{Code: "P0420", Severity: "warning", Status: types.DTCStatusActive},
+					{Code: "P0300", Severity: "critical", Status: types.DTCStatusCleared},
+					{Code: types.DTCCodeMILOn, Severity: "warning", Status: types.DTCStatusActive},
+				},
+			},
+			expectedScore:      85,
+			expectedHealthy:    true,
+			expectedDeductions: []types.HealthDeduction{{Rule: "dtc-warning", Points: 15}},
+		},
+		{
+			name:    "MIL counts without de-duplication",
+			ruleSet: "no-dedupe",
+			status: types.VehicleHealthStatus{
+				DTCs: []types.DiagnosticTroubleCode{
+					{Code: "P0420", Severity: "warning", Status: types.DTCStatusActive},
+					{Code: types.DTCCodeMILOn, Severity: "warning", Status: types.DTCStatusActive},
+				},
+			},
+			expectedScore:      70,
+			expectedHealthy:    false,
+			expectedDeductions: []types.HealthDeduction{{Rule: "dtc-warning", Points: 30}},
+		},
+		{
+			name: "rule caps and tire deviation",
+			status: types.VehicleHealthStatus{
+				DTCs: []types.DiagnosticTroubleCode{
+					{Code: "P0442", Severity: "info", Status: types.DTCStatusActive},
+					{Code: "P0455", Severity: "info", Status: types.DTCStatusActive},
+					{Code: "P0300", Severity: "critical", Status: types.DTCStatusActive},
+				},
+				TirePressure: &types.TirePressureStatus{
+					FrontLeft:  ptr(180), // 10% of placard below the normal range
+					FrontRight: ptr(240),
+					Thresholds: &types.TirePressureThresholds{
+						FrontPlacard: 240, FrontMin: 204, FrontMax: 276,
+						RearPlacard: 240, RearMin: 204, RearMax: 276,
+					},
+				},
+			},
+			expectedScore:   50,
+			expectedHealthy: false,
+			expectedDeductions: []types.HealthDeduction{
+				{Rule: "dtc-critical", Points: 30},
+				{Rule: "dtc-info", Points: 5},
+				{Rule: "tire-pressure", Points: 15},
+			},
+		},
+		{
+			name: "component statuses",
+			status: types.VehicleHealthStatus{
+				Components: []types.ComponentHealth{
+					{Component: types.ComponentLowVoltageBattery, Status: types.ComponentStatusCritical},
+					{Component: types.ComponentCoolant, Status: types.ComponentStatusOK},
+					{Component: types.ComponentEngineOil, Status: types.ComponentStatusWarning},
+					{Component: types.ComponentBrakePads, Status: types.ComponentStatusWarning},
+				},
+			},
+			expectedScore:   70,
+			expectedHealthy: true,
+			expectedDeductions: []types.HealthDeduction{
+				{Rule: "component-critical", Points: 20},
+				{Rule: "brake-pads-warning", Points: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSet, err := engine.RuleSet(tt.ruleSet)
+			require.NoError(t, err)
+
+			status := tt.status
+			ruleSet.Score(&status)
+			assert.Equal(t, tt.expectedScore, status.HealthScore)
+			assert.Equal(t, tt.expectedHealthy, status.IsHealthy)
+			assert.Equal(t, tt.expectedDeductions, status.Deductions)
+			assert.Equal(t, ruleSet.Name, status.RuleSet)
+			assert.Equal(t, ruleSet.Version, status.RuleSetVersion)
+		})
+	}
+}
+
+func TestEngine_UnknownRuleSet(t *testing.T) {
+	engine, err := healthscore.Parse([]byte(testRules))
+	require.NoError(t, err)
+
+	_, err = engine.RuleSet("missing")
+	require.ErrorIs(t, err, healthscore.ErrUnknownRuleSet)
+}
+
+func TestLoad_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(testRules), 0o600))
+
+	engine, err := healthscore.Load(path)
+	require.NoError(t, err)
+	ruleSet, err := engine.RuleSet("")
+	require.NoError(t, err)
+	assert.Equal(t, "test/v1", ruleSet.Version)
+
+	_, err = healthscore.Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed", data: `{`},
+		{name: "missing default", data: `{"default": "x", "ruleSets": []}`},
+		{name: "missing version", data: `{"default": "x", "ruleSets": [{"name": "x", "baseScore": 100}]}`},
+		{name: "duplicate", data: `{"default": "x", "ruleSets": [{"name": "x", "version": "1", "baseScore": 100}, {"name": "x", "version": "2", "baseScore": 100}]}`},
+		{name: "invalid kind", data: `{"default": "x", "ruleSets": [{"name": "x", "version": "1", "baseScore": 100, "rules": [{"id": "r", "kind": "other"}]}]}`},
+		{name: "invalid status", data: `{"default": "x", "ruleSets": [{"name": "x", "version": "1", "baseScore": 100, "rules": [{"id": "r", "kind": "dtc", "status": "pending"}]}]}`},
+		{name: "invalid component status", data: `{"default": "x", "ruleSets": [{"name": "x", "version": "1", "baseScore": 100, "rules": [{"id": "r", "kind": "component", "status": "ok"}]}]}`},
+		{name: "negative deduction", data: `{"default": "x", "ruleSets": [{"name": "x", "version": "1", "baseScore": 100, "rules": [{"id": "r", "kind": "dtc", "deduction": -5}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := healthscore.Parse([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
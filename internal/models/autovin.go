@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	// AutoVINStateAttested is the state of a vehicle whose reported VIN is attested.
+	AutoVINStateAttested = "attested"
+	// AutoVINStateIneligible is the state of a vehicle whose reported VIN could not be attested.
+	AutoVINStateIneligible = "ineligible"
+)
+
+// AutoVINState is the outcome of the latest fingerprint event that was processed for a vehicle.
+type AutoVINState struct {
+	TokenID uint32 `json:"tokenId"`
+	// VIN is the attested VIN, or the VIN reported by the fingerprint event when it could not be attested.
+	VIN   string `json:"vin"`
+	State string `json:"state" enums:"attested,ineligible"`
+	// Reason is why the VIN could not be attested.
+	Reason string `json:"reason,omitempty"`
+	// EventID is the ID of the fingerprint cloud event.
+	EventID string `json:"eventId"`
+	// AttestationID is the ID of the issued attestation cloud event, empty when an existing attestation already
+	// attested the VIN.
+	AttestationID string    `json:"attestationId,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+)
+
+// Types of asynchronous attestation jobs, named after the attestation endpoints.
+const (
+	JobTypeVIN               = "vin"
+	JobTypeVehiclePosition   = "vehicle-position"
+	JobTypeOdometerStatement = "odometer-statement"
+	JobTypeVehicleHealth     = "vehicle-health"
+	JobTypeBatteryHealth     = "battery-health"
+)
+
+// States of an asynchronous attestation job.
+const (
+	JobStateQueued    = "queued"
+	JobStateRunning   = "running"
+	JobStateSucceeded = "succeeded"
+	JobStateFailed    = "failed"
+)
+
+// States of the webhook call of a finished job.
+const (
+	WebhookStatePending   = "pending"
+	WebhookStateDelivered = "delivered"
+	WebhookStateFailed    = "failed"
+)
+
+// Job is an attestation request that runs in the background.
+type Job struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	TokenID uint32 `json:"tokenId"`
+	// Request is the JSON request body of the attestation endpoint.
+	Request json.RawMessage `json:"request,omitempty"`
+	// JWTToken is the vehicle token used to read telemetry. It is obtained when the job runs and is never stored.
+	JWTToken string `json:"-"`
+	// Requester identifies the caller the attestation is issued for.
+	Requester string `json:"requester,omitempty"`
+	// WebhookURL is called with the job when it finishes.
+	WebhookURL   string `json:"webhookUrl,omitempty"`
+	WebhookState string `json:"webhookState,omitempty"`
+	State        string `json:"state"`
+	// Result is the signed attestation of a succeeded job.
+	Result *cloudevent.RawEvent `json:"result,omitempty"`
+	// Error and ErrorCode are the message and HTTP status code of a failed job.
+	Error      string     `json:"error,omitempty"`
+	ErrorCode  int        `json:"errorCode,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Finished reports whether the job succeeded or failed.
+func (j *Job) Finished() bool {
+	return j.State == JobStateSucceeded || j.State == JobStateFailed
+}
+
+// JobStatus is the state and result of a job reported to its caller.
+type JobStatus struct {
+	ID           string               `json:"id"`
+	Type         string               `json:"type"`
+	TokenID      uint32               `json:"tokenId"`
+	State        string               `json:"state" enums:"queued,running,succeeded,failed"`
+	Result       *cloudevent.RawEvent `json:"result,omitempty"`
+	Error        string               `json:"error,omitempty"`
+	ErrorCode    int                  `json:"errorCode,omitempty"`
+	WebhookState string               `json:"webhookState,omitempty" enums:"pending,delivered,failed"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	StartedAt    *time.Time           `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time           `json:"finishedAt,omitempty"`
+}
+
+// Status returns the status of the job, leaving out the request.
+func (j *Job) Status() JobStatus {
+	return JobStatus{
+		ID:           j.ID,
+		Type:         j.Type,
+		TokenID:      j.TokenID,
+		State:        j.State,
+		Result:       j.Result,
+		Error:        j.Error,
+		ErrorCode:    j.ErrorCode,
+		WebhookState: j.WebhookState,
+		CreatedAt:    j.CreatedAt,
+		StartedAt:    j.StartedAt,
+		FinishedAt:   j.FinishedAt,
+	}
+}
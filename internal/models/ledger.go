@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Upload states of ledger entries.
+const (
+	LedgerStatusUploaded = "uploaded"
+	LedgerStatusFailed   = "failed"
+)
+
+// LedgerEntry records an attestation issued by the service and the outcome of its upload to DIS.
+type LedgerEntry struct {
+	// ID is the ID of the attestation cloud event.
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	DataVersion string `json:"dataVersion"`
+	// Subject is the DID of the vehicle.
+	Subject string `json:"subject"`
+	TokenID uint32 `json:"tokenId,omitempty"`
+	// Source is the dev license that issued the attestation.
+	Source string `json:"source"`
+	// Requester identifies the caller the attestation was issued for, the dev license of a token-exchange token,
+	// an mTLS client or an operator. It is empty for attestations the service issued on its own.
+	Requester string `json:"requester,omitempty"`
+	// Signer is the address of the key that signed the attestation.
+	Signer    string     `json:"signer"`
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+	ValidTo   *time.Time `json:"validTo,omitempty"`
+	Status    string     `json:"status" enums:"uploaded,failed"`
+	// UploadError is the error of a failed upload.
+	UploadError string `json:"uploadError,omitempty"`
+	// ContentHash is the hex SHA-256 of the uploaded cloud event JSON.
+	ContentHash string `json:"contentHash"`
+	// IssuedAt is the time of the cloud event.
+	IssuedAt time.Time `json:"issuedAt"`
+	// RecordedAt is when the upload was last attempted.
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// LedgerQuery filters ledger entries. Zero fields match every entry.
+type LedgerQuery struct {
+	TokenID     uint32
+	DataVersion string
+	Requester   string
+	Status      string
+	// From and To bound the time the entries were recorded at, To is exclusive.
+	From time.Time
+	To   time.Time
+	// After is the ID of the last entry of the previous page.
+	After string
+	// Limit is the maximum number of entries returned, every entry when 0.
+	Limit int
+}
+
+// LedgerPage is a page of ledger entries, oldest first.
+type LedgerPage struct {
+	Entries []LedgerEntry `json:"entries"`
+	// Next is the After of the next page, empty on the last page.
+	Next string `json:"next,omitempty"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Statuses of a manual VIN attestation request.
+const (
+	ManualVINStatusPending  = "pending"
+	ManualVINStatusApproved = "approved"
+	ManualVINStatusIssued   = "issued"
+	ManualVINStatusRejected = "rejected"
+	ManualVINStatusFailed   = "failed"
+)
+
+// Actions recorded in the manual VIN audit log.
+const (
+	AuditActionRequested = "requested"
+	AuditActionApproved  = "approved"
+	AuditActionRejected  = "rejected"
+	AuditActionIssued    = "issued"
+	AuditActionFailed    = "failed"
+)
+
+// ManualVINRequest is an operator request to attest a VIN without fingerprint evidence.
+type ManualVINRequest struct {
+	ID          string `json:"id"`
+	TokenID     uint32 `json:"tokenId"`
+	VIN         string `json:"vin"`
+	CountryCode string `json:"countryCode"`
+	// Reason is why the VIN is attested manually.
+	Reason string `json:"reason"`
+	// EvidenceRef references the supporting evidence, e.g. a support ticket or a document ID.
+	EvidenceRef string    `json:"evidenceRef"`
+	Status      string    `json:"status" enums:"pending,approved,issued,rejected,failed"`
+	RequestedBy string    `json:"requestedBy"`
+	RequestedAt time.Time `json:"requestedAt"`
+	// ReviewedBy is the operator who approved or rejected a request that requires approval.
+	ReviewedBy   string     `json:"reviewedBy,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty"`
+	ReviewReason string     `json:"reviewReason,omitempty"`
+	// AttestationID is the cloud event ID of the issued attestation.
+	AttestationID string `json:"attestationId,omitempty"`
+}
+
+// AuditEntry is an entry of the append-only manual VIN audit log.
+type AuditEntry struct {
+	ID          string `json:"id"`
+	TokenID     uint32 `json:"tokenId"`
+	RequestID   string `json:"requestId"`
+	Action      string `json:"action" enums:"requested,approved,rejected,issued,failed"`
+	Operator    string `json:"operator"`
+	VIN         string `json:"vin"`
+	CountryCode string `json:"countryCode"`
+	// Reason is the reason of the request, or of the rejection for rejected entries.
+	Reason      string `json:"reason"`
+	EvidenceRef string `json:"evidenceRef"`
+	// AttestationID is the cloud event ID of the issued attestation.
+	AttestationID string `json:"attestationId,omitempty"`
+	// Error describes why issuing the attestation failed.
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
@@ -1,7 +1,13 @@
 package models
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/attestation-api/internal/client/telemetryapi"
+	"github.com/DIMO-Network/attestation-api/pkg/types"
 	"github.com/DIMO-Network/cloudevent"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // DeviceType represents the type of device.
@@ -19,17 +25,78 @@ type PairedDevice struct {
 	DID              cloudevent.ERC721DID `json:"erc721Did"`
 	Type             DeviceType           `json:"type"`
 	ManufacturerName string               `json:"manufacturerName"`
+	// Address is the signing address of an aftermarket device, zero for synthetic devices.
+	Address common.Address `json:"address"`
 }
 
 // DecodedFingerprintData represents the decoded fingerprint data.
 type DecodedFingerprintData struct {
 	cloudevent.CloudEventHeader
 	VIN string `json:"vin"`
+	// SignatureStatus is the outcome of verifying the message signature against the paired device.
+	SignatureStatus string `json:"signatureStatus,omitempty"`
 }
 
+const (
+	// DefinitionAttributeFrontTirePressure is the device definition attribute holding the placard front tire pressure in kPa.
+	DefinitionAttributeFrontTirePressure = "tire_pressure_front_kpa"
+	// DefinitionAttributeRearTirePressure is the device definition attribute holding the placard rear tire pressure in kPa.
+	DefinitionAttributeRearTirePressure = "tire_pressure_rear_kpa"
+)
+
 // VehicleInfo contains information about a vehicle NFT.
 type VehicleInfo struct {
 	DID           cloudevent.ERC721DID
 	PairedDevices []PairedDevice
 	NameSlug      string
+	// ManufacturerName is the name of the manufacturer that minted the vehicle, e.g. "Ford".
+	ManufacturerName string
+	// DefinitionAttributes are the attributes of the vehicle's device definition keyed by name.
+	DefinitionAttributes map[string]string
+}
+
+// DataSource resolves the telemetry source and producer of a value against the paired devices of the vehicle.
+func (v *VehicleInfo) DataSource(source, producer string) types.DataSource {
+	dataSource := types.DataSource{
+		Source:   source,
+		Producer: producer,
+	}
+	for _, device := range v.PairedDevices {
+		if device.DID.String() == producer {
+			dataSource.DeviceType = string(device.Type)
+			dataSource.ManufacturerName = device.ManufacturerName
+			break
+		}
+	}
+	return dataSource
+}
+
+// DataSources resolves the distinct telemetry sources and producers of the signals, in order of first appearance.
+func (v *VehicleInfo) DataSources(signals []telemetryapi.Signal) []types.DataSource {
+	type origin struct{ source, producer string }
+	seen := make(map[origin]struct{})
+	var dataSources []types.DataSource
+	for _, signal := range signals {
+		key := origin{source: signal.Source, producer: signal.Producer}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		dataSources = append(dataSources, v.DataSource(signal.Source, signal.Producer))
+	}
+	return dataSources
+}
+
+// ModelYearFromSlug returns the model year at the end of a definition slug such as "toyota_tacoma-4wd_2023", or 0
+// when the slug has none.
+func ModelYearFromSlug(nameSlug string) int {
+	idx := strings.LastIndex(nameSlug, "_")
+	if idx == -1 {
+		return 0
+	}
+	year, err := strconv.Atoi(nameSlug[idx+1:])
+	if err != nil || year < 1900 {
+		return 0
+	}
+	return year
 }
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Intervals of recurring attestation subscriptions.
+const (
+	IntervalDaily   = "daily"
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+)
+
+// States of recurring attestation subscriptions.
+const (
+	RecurringStateActive = "active"
+	RecurringStatePaused = "paused"
+)
+
+// RecurringSubscription issues an attestation of a vehicle on every due date of its schedule.
+type RecurringSubscription struct {
+	ID      string `json:"id"`
+	TokenID uint32 `json:"tokenId"`
+	// Type is the job type of the attestations, e.g. odometer-statement.
+	Type string `json:"type" enums:"vin,vehicle-position,odometer-statement,vehicle-health,battery-health"`
+	// Interval is the time between due dates, months are calendar months.
+	Interval   string              `json:"interval" enums:"daily,weekly,monthly"`
+	Parameters RecurringParameters `json:"parameters"`
+	// Owner names the partner the attestations are issued for.
+	Owner string `json:"owner"`
+	// NotifyURL is called with a signed RecurringFailure when an attestation fails.
+	NotifyURL string `json:"notifyUrl,omitempty"`
+	State     string `json:"state" enums:"active,paused"`
+	// StartAt is the first due date of the interval, the due dates are counted from. Monthly due dates keep its
+	// day of the month, or fall on the last day of shorter months.
+	StartAt time.Time `json:"startAt"`
+	// NextDueAt is the due date of the next attestation.
+	NextDueAt time.Time `json:"nextDueAt"`
+	// NextRunAt is when the next attestation is attempted, after NextDueAt when it is retried. While an attestation
+	// is issued, it is the end of the run's lease, after which a run that did not finish is attempted again.
+	NextRunAt time.Time `json:"nextRunAt"`
+	// Attempts is the number of failed attempts for NextDueAt.
+	Attempts          int        `json:"attempts,omitempty"`
+	LastRunAt         *time.Time `json:"lastRunAt,omitempty"`
+	LastAttestationID string     `json:"lastAttestationId,omitempty"`
+	LastError         string     `json:"lastError,omitempty"`
+	CreatedBy         string     `json:"createdBy"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// RecurringParameters are the options of the attestations of a subscription, named like the request bodies of the
+// attestation endpoints. The time of each attestation is its due date.
+type RecurringParameters struct {
+	// H3Resolution is the H3 resolution of vehicle position attestations.
+	H3Resolution int `json:"h3Resolution,omitempty"`
+	// Unit and DisclosureProfile are the options of odometer statements.
+	Unit              string `json:"unit,omitempty"`
+	DisclosureProfile string `json:"disclosureProfile,omitempty"`
+	// RuleSet is the health rule set of vehicle health attestations.
+	RuleSet string `json:"ruleSet,omitempty"`
+	// PeriodDays is the number of days before the due date covered by vehicle and battery health attestations,
+	// the interval when 0.
+	PeriodDays int `json:"periodDays,omitempty"`
+}
+
+// RecurringFailure is the body posted to the notify URL of a subscription when an attestation fails.
+type RecurringFailure struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	TokenID        uint32    `json:"tokenId"`
+	Type           string    `json:"type"`
+	Owner          string    `json:"owner"`
+	DueAt          time.Time `json:"dueAt"`
+	Error          string    `json:"error"`
+	ErrorCode      int       `json:"errorCode"`
+	// Attempts is the number of failed attempts for the due date.
+	Attempts int `json:"attempts"`
+	// Skipped is true when the due date is given up and the next attestation is due on NextDueAt.
+	Skipped   bool      `json:"skipped"`
+	NextDueAt time.Time `json:"nextDueAt"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// States of a VIN attestation renewal run.
+const (
+	RenewalStateRunning   = "running"
+	RenewalStateCompleted = "completed"
+)
+
+// RenewalRun is the progress and outcome of renewing the VIN attestations about to expire.
+type RenewalRun struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	StartedAt time.Time `json:"startedAt"`
+	// ExpiringBefore is the time the renewed attestations expired before.
+	ExpiringBefore time.Time  `json:"expiringBefore"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+	// Candidates are the vehicle token IDs to renew, in the order they are renewed.
+	Candidates []uint32 `json:"candidates"`
+	// Next is the index of the next candidate to renew.
+	Next       int `json:"next"`
+	Renewed    int `json:"renewed"`
+	Ineligible int `json:"ineligible"`
+	Failed     int `json:"failed"`
+	// Reasons counts the candidates that were not renewed by the reason.
+	Reasons map[string]int `json:"reasons,omitempty"`
+}
+
+// RenewalSummary is the progress and outcome of a renewal run without its candidates.
+type RenewalSummary struct {
+	ID             string     `json:"id"`
+	State          string     `json:"state" enums:"running,completed"`
+	StartedAt      time.Time  `json:"startedAt"`
+	ExpiringBefore time.Time  `json:"expiringBefore"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+	// Candidates is the number of vehicles to renew.
+	Candidates int `json:"candidates"`
+	// Processed is the number of vehicles the renewal was attempted for.
+	Processed  int            `json:"processed"`
+	Renewed    int            `json:"renewed"`
+	Ineligible int            `json:"ineligible"`
+	Failed     int            `json:"failed"`
+	Reasons    map[string]int `json:"reasons,omitempty"`
+}
+
+// Summary returns the summary of the run.
+func (r *RenewalRun) Summary() RenewalSummary {
+	return RenewalSummary{
+		ID:             r.ID,
+		State:          r.State,
+		StartedAt:      r.StartedAt,
+		ExpiringBefore: r.ExpiringBefore,
+		FinishedAt:     r.FinishedAt,
+		Candidates:     len(r.Candidates),
+		Processed:      r.Next,
+		Renewed:        r.Renewed,
+		Ineligible:     r.Ineligible,
+		Failed:         r.Failed,
+		Reasons:        r.Reasons,
+	}
+}
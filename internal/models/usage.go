@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Usage counts the attestation requests of a client for an attestation type on a UTC day.
+type Usage struct {
+	// Date is the UTC day of the requests, e.g. 2026-01-02.
+	Date string `json:"date"`
+	// Client is the dev license of the requests.
+	Client string `json:"client"`
+	Type   string `json:"type" enums:"vin,vehicle-position,odometer-statement,vehicle-health,battery-health"`
+	// Allowed is the number of requests that were within the limits.
+	Allowed int `json:"allowed"`
+	// Rejected is the number of requests rejected by a rate limit or quota.
+	Rejected int `json:"rejected"`
+	// DailyQuota is the number of requests the client can make for the type per day, unlimited when 0.
+	DailyQuota int `json:"dailyQuota,omitempty"`
+}
+
+// RateLimitSnapshot is the state of the daily quotas and usage of the rate limits, saved so it survives restarts.
+type RateLimitSnapshot struct {
+	// DailyCounts are the requests counted towards the daily quotas of the current UTC day.
+	DailyCounts []DailyCount `json:"dailyCounts"`
+	Usage       []Usage      `json:"usage"`
+	SavedAt     time.Time    `json:"savedAt"`
+}
+
+// DailyCount is the number of requests counted towards the daily quota of a client or a vehicle.
+type DailyCount struct {
+	// Date is the UTC day of the requests, e.g. 2026-01-02.
+	Date   string `json:"date"`
+	Type   string `json:"type"`
+	Client string `json:"client,omitempty"`
+	// TokenID is the vehicle of the quota, 0 for the quota of the client.
+	TokenID uint32 `json:"tokenId,omitempty"`
+	Count   int    `json:"count"`
+}
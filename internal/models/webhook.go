@@ -0,0 +1,96 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookSubscription is a partner endpoint notified of the attestations matching its filters.
+// Empty filters match every attestation.
+type WebhookSubscription struct {
+	ID string `json:"id"`
+	// Partner names the partner the webhook is registered for.
+	Partner string `json:"partner"`
+	URL     string `json:"url"`
+	// Secret keys the HMAC signature of the notifications. It is only returned when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+	// TokenIDs are the vehicle token IDs of the attestations.
+	TokenIDs []uint32 `json:"tokenIds,omitempty"`
+	// AttestationTypes are the data versions of the attestations, e.g. vin/v1.0.
+	AttestationTypes []string `json:"attestationTypes,omitempty"`
+	// DevLicenses are the requesters of the attestations, the dev licenses that requested them.
+	DevLicenses []string `json:"devLicenses,omitempty"`
+	// Owner is the dev license managing the subscription, which only matches the attestations it requested and the
+	// attestations the service issues on its own for vehicles it requested before. It is empty for the subscriptions
+	// managed by operators.
+	Owner     string    `json:"owner,omitempty"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Matches reports whether the subscription is notified of the attestation.
+func (s *WebhookSubscription) Matches(notification *AttestationNotification) bool {
+	if len(s.DevLicenses) != 0 && !slices.Contains(s.DevLicenses, notification.Requester) {
+		return false
+	}
+	return s.matchesAttestation(notification)
+}
+
+// MatchesUnrequested reports whether the subscription of a dev license is notified of the attestation issued by the
+// service on its own, such as a renewal, provided the dev license requested an attestation of the vehicle before.
+func (s *WebhookSubscription) MatchesUnrequested(notification *AttestationNotification) bool {
+	return s.Owner != "" && notification.Requester == "" && notification.TokenID != 0 && s.matchesAttestation(notification)
+}
+
+// matchesAttestation reports whether the vehicle and type of the attestation match the filters.
+func (s *WebhookSubscription) matchesAttestation(notification *AttestationNotification) bool {
+	if len(s.TokenIDs) != 0 && !slices.Contains(s.TokenIDs, notification.TokenID) {
+		return false
+	}
+	if len(s.AttestationTypes) != 0 && !slices.Contains(s.AttestationTypes, notification.DataVersion) {
+		return false
+	}
+	return true
+}
+
+// AttestationNotification describes an issued attestation without its content.
+type AttestationNotification struct {
+	// EventID is the ID of the attestation cloud event.
+	EventID     string `json:"eventId"`
+	Type        string `json:"type"`
+	DataVersion string `json:"dataVersion"`
+	// Source is the dev license that issued the attestation.
+	Source string `json:"source"`
+	// Requester is the dev license or client that requested the attestation, empty for attestations the service
+	// issues on its own.
+	Requester string `json:"requester,omitempty"`
+	// Subject is the DID of the vehicle.
+	Subject   string     `json:"subject"`
+	TokenID   uint32     `json:"tokenId,omitempty"`
+	Time      time.Time  `json:"time"`
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+	ValidTo   *time.Time `json:"validTo,omitempty"`
+}
+
+// WebhookDelivery is a notification sent to a webhook subscription and its outcome.
+type WebhookDelivery struct {
+	ID             string                  `json:"id"`
+	SubscriptionID string                  `json:"subscriptionId"`
+	Notification   AttestationNotification `json:"notification"`
+	State          string                  `json:"state" enums:"pending,delivered,failed"`
+	// Attempts is the number of calls made to the webhook.
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"lastError,omitempty"`
+	// ReplayOf is the ID of the delivery this delivery replays.
+	ReplayOf   string     `json:"replayOf,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// WebhookPayload is the body posted to a webhook subscription.
+type WebhookPayload struct {
+	// DeliveryID identifies the delivery, replays of the same attestation have different delivery IDs.
+	DeliveryID     string `json:"deliveryId"`
+	SubscriptionID string `json:"subscriptionId"`
+	AttestationNotification
+}
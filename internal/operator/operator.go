@@ -0,0 +1,145 @@
+// Package operator authenticates the operators of the administrative endpoints with JWTs carrying an admin role.
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRole is the role required of operators when none is configured.
+const DefaultRole = "attestation-admin"
+
+const operatorLocalsKey = "operator"
+
+var (
+	// ErrUnauthenticated is returned for missing or invalid tokens.
+	ErrUnauthenticated = errors.New("operator token is missing or invalid")
+	// ErrForbidden is returned for valid tokens without the admin role.
+	ErrForbidden = errors.New("operator does not have the admin role")
+)
+
+// Operator is the authenticated operator of an administrative request.
+type Operator struct {
+	// ID identifies the operator in the audit log, the email of the token when present and the subject otherwise.
+	ID string
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Email  string   `json:"email"`
+	Roles  []string `json:"roles"`
+	Groups []string `json:"groups"`
+}
+
+// Verifier verifies operator tokens.
+type Verifier struct {
+	keyFunc jwt.Keyfunc
+	role    string
+	parser  *jwt.Parser
+}
+
+// NewVerifier creates a verifier of tokens signed by the keys of keyFunc that requires the role in the roles or groups claim.
+func NewVerifier(keyFunc jwt.Keyfunc, role string) *Verifier {
+	if role == "" {
+		role = DefaultRole
+	}
+	return &Verifier{
+		keyFunc: keyFunc,
+		role:    role,
+		parser:  jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "ES256"}), jwt.WithExpirationRequired()),
+	}
+}
+
+// NewJWKSVerifier creates a verifier of tokens signed by the keys of the JSON web key set at jwksURL.
+func NewJWKSVerifier(jwksURL, role string) (*Verifier, error) {
+	jwks, err := keyfunc.Get(jwksURL, keyfunc.Options{
+		RefreshInterval:   time.Hour,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator JWK set: %w", err)
+	}
+	return NewVerifier(jwks.Keyfunc, role), nil
+}
+
+// Verify verifies the token and returns the operator it was issued to.
+func (v *Verifier) Verify(token string) (*Operator, error) {
+	var tokenClaims claims
+	if _, err := v.parser.ParseWithClaims(token, &tokenClaims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+	id := tokenClaims.Email
+	if id == "" {
+		id = tokenClaims.Subject
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+	if !slices.Contains(tokenClaims.Roles, v.role) && !slices.Contains(tokenClaims.Groups, v.role) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, id)
+	}
+	return &Operator{ID: id}, nil
+}
+
+// Middleware authenticates the operator from the bearer token of the request and stores it for FromFiber.
+func (v *Verifier) Middleware() fiber.Handler {
+	return func(fiberCtx *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(fiberCtx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return fiber.NewError(fiber.StatusUnauthorized, "operator token is required")
+		}
+		op, err := v.Verify(token)
+		if err != nil {
+			if errors.Is(err, ErrForbidden) {
+				return fiber.NewError(fiber.StatusForbidden, "admin role is required")
+			}
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid operator token")
+		}
+		fiberCtx.Locals(operatorLocalsKey, op)
+		return fiberCtx.Next()
+	}
+}
+
+// FromFiber returns the operator authenticated by Middleware.
+func FromFiber(fiberCtx *fiber.Ctx) (*Operator, error) {
+	op, ok := fiberCtx.Locals(operatorLocalsKey).(*Operator)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "operator token is required")
+	}
+	return op, nil
+}
+
+// FromIncomingContext authenticates the operator from the bearer token in the authorization metadata of a gRPC call.
+func (v *Verifier) FromIncomingContext(ctx context.Context) (*Operator, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			if bearer, ok := strings.CutPrefix(value, "Bearer "); ok {
+				token = bearer
+				break
+			}
+		}
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "operator token is required")
+	}
+	op, err := v.Verify(token)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, "admin role is required")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid operator token")
+	}
+	return op, nil
+}
@@ -0,0 +1,126 @@
+package operator_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/operator"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	verifier := operator.NewVerifier(func(*jwt.Token) (any, error) { return &key.PublicKey, nil }, "")
+
+	sign := func(signer *ecdsa.PrivateKey, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(signer)
+		require.NoError(t, err)
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name        string
+		token       string
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name:       "admin role",
+			token:      sign(key, jwt.MapClaims{"sub": "op-1", "email": "ops@dimo.org", "roles": []string{operator.DefaultRole}, "exp": exp}),
+			expectedID: "ops@dimo.org",
+		},
+		{
+			name:       "admin group without email",
+			token:      sign(key, jwt.MapClaims{"sub": "op-1", "groups": []string{"support", operator.DefaultRole}, "exp": exp}),
+			expectedID: "op-1",
+		},
+		{
+			name:        "missing role",
+			token:       sign(key, jwt.MapClaims{"sub": "op-1", "roles": []string{"support"}, "exp": exp}),
+			expectedErr: operator.ErrForbidden,
+		},
+		{
+			name:        "expired",
+			token:       sign(key, jwt.MapClaims{"sub": "op-1", "roles": []string{operator.DefaultRole}, "exp": time.Now().Add(-time.Hour).Unix()}),
+			expectedErr: operator.ErrUnauthenticated,
+		},
+		{
+			name:        "no expiry",
+			token:       sign(key, jwt.MapClaims{"sub": "op-1", "roles": []string{operator.DefaultRole}}),
+			expectedErr: operator.ErrUnauthenticated,
+		},
+		{
+			name:        "signed by another key",
+			token:       sign(otherKey, jwt.MapClaims{"sub": "op-1", "roles": []string{operator.DefaultRole}, "exp": exp}),
+			expectedErr: operator.ErrUnauthenticated,
+		},
+		{
+			name:        "no subject",
+			token:       sign(key, jwt.MapClaims{"roles": []string{operator.DefaultRole}, "exp": exp}),
+			expectedErr: operator.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := verifier.Verify(tt.token)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedID, op.ID)
+		})
+	}
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	verifier := operator.NewVerifier(func(*jwt.Token) (any, error) { return &key.PublicKey, nil }, "ops")
+
+	sign := func(role string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"sub": "op-1", "roles": []string{role}, "exp": time.Now().Add(time.Hour).Unix(),
+		}).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name         string
+		md           metadata.MD
+		expectedCode codes.Code
+	}{
+		{name: "valid token", md: metadata.Pairs("authorization", "Bearer "+sign("ops")), expectedCode: codes.OK},
+		{name: "no metadata", expectedCode: codes.Unauthenticated},
+		{name: "not a bearer token", md: metadata.Pairs("authorization", sign("ops")), expectedCode: codes.Unauthenticated},
+		{name: "missing role", md: metadata.Pairs("authorization", "Bearer "+sign("support")), expectedCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			op, err := verifier.FromIncomingContext(ctx)
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				require.Equal(t, "op-1", op.ID)
+			}
+		})
+	}
+}
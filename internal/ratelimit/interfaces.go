@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+)
+
+// Store defines the interface for saving the daily quotas and usage of the rate limits across restarts.
+type Store interface {
+	SaveRateLimitSnapshot(ctx context.Context, snapshot *models.RateLimitSnapshot) error
+	RateLimitSnapshot(ctx context.Context) (*models.RateLimitSnapshot, error)
+}
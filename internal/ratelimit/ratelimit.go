@@ -0,0 +1,409 @@
+// Package ratelimit limits the attestation requests of each client and vehicle per minute and per UTC day, and
+// meters the requests of each client.
+//
+// Clients are the dev licenses of the token-exchange tokens, or the identities of the mTLS clients of gRPC calls.
+// Counters are kept in memory, so each replica enforces the limits on its own share of the requests. The daily
+// quotas and usage can be saved to a Store so they survive restarts.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultType holds the limits of the attestation types without their own limits.
+const DefaultType = "default"
+
+// Response headers of limited requests.
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+)
+
+// usageDays is how many days of usage are kept, including the current day.
+const usageDays = 7
+
+// persistInterval is how often Persist saves the daily quotas and usage.
+const persistInterval = time.Minute
+
+// unknownClient is the client of requests without a requester.
+const unknownClient = "unknown"
+
+// requestsTotal has no client label, since every dev license would add series. The usage of each client is read
+// with Usage instead.
+var requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "attestation_api_rate_limited_requests_total",
+	Help: "Attestation requests checked against the rate limits, by attestation type and result.",
+}, []string{"type", "result"})
+
+// Limits are the rate limits and daily quotas of an attestation type. Zero limits are unlimited.
+type Limits struct {
+	ClientPerMinute  int `json:"clientPerMinute"`
+	ClientPerDay     int `json:"clientPerDay"`
+	VehiclePerMinute int `json:"vehiclePerMinute"`
+	VehiclePerDay    int `json:"vehiclePerDay"`
+}
+
+// ParseLimits parses a JSON object of attestation types, or DefaultType, and their limits, e.g.
+// {"default": {"clientPerMinute": 600}, "vehicle-health": {"clientPerDay": 10000, "vehiclePerDay": 24}}.
+func ParseLimits(data string) (map[string]Limits, error) {
+	limits := map[string]Limits{}
+	if strings.TrimSpace(data) == "" {
+		return limits, nil
+	}
+	if err := json.Unmarshal([]byte(data), &limits); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rate limits: %w", err)
+	}
+	for typ, l := range limits {
+		if l.ClientPerMinute < 0 || l.ClientPerDay < 0 || l.VehiclePerMinute < 0 || l.VehiclePerDay < 0 {
+			return nil, fmt.Errorf("rate limits of %s cannot be negative", typ)
+		}
+	}
+	return limits, nil
+}
+
+// Decision is the outcome of checking a request against the limits.
+type Decision struct {
+	Allowed bool
+	// Limit, Remaining and Reset describe the limit closest to being exceeded, or the exceeded limit of a
+	// rejected request. Limit is 0 when the request is unlimited.
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// Reason names the exceeded limit of a rejected request.
+	Reason string
+}
+
+type counterKey struct {
+	typ    string
+	client string
+	// tokenID is 0 for the counters of the client.
+	tokenID uint32
+	daily   bool
+}
+
+type window struct {
+	end   time.Time
+	count int
+}
+
+type usageKey struct {
+	date   string
+	client string
+	typ    string
+}
+
+// check is a limit that applies to a request.
+type check struct {
+	key    counterKey
+	limit  int
+	end    time.Time
+	reason string
+}
+
+// Limiter enforces the rate limits and daily quotas of attestation requests.
+type Limiter struct {
+	limits map[string]Limits
+	now    func() time.Time
+
+	mu        sync.Mutex
+	counters  map[counterKey]*window
+	usage     map[usageKey]*models.Usage
+	lastPrune time.Time
+}
+
+// NewLimiter creates a new Limiter with the limits of each attestation type.
+func NewLimiter(limits map[string]Limits) *Limiter {
+	return &Limiter{
+		limits:   limits,
+		now:      time.Now,
+		counters: map[counterKey]*window{},
+		usage:    map[usageKey]*models.Usage{},
+	}
+}
+
+// limitsFor returns the limits of the attestation type.
+func (l *Limiter) limitsFor(typ string) Limits {
+	if limits, ok := l.limits[typ]; ok {
+		return limits
+	}
+	return l.limits[DefaultType]
+}
+
+// Allow checks a request of the client for an attestation of the vehicle and counts it when it is allowed.
+func (l *Limiter) Allow(typ, client string, tokenID uint32) Decision {
+	if client == "" {
+		client = unknownClient
+	}
+	limits := l.limitsFor(typ)
+	now := l.now().UTC()
+	minuteEnd := now.Truncate(time.Minute).Add(time.Minute)
+	dayEnd := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	checks := []check{
+		{key: counterKey{typ: typ, client: client}, limit: limits.ClientPerMinute, end: minuteEnd, reason: "client rate limit"},
+		{key: counterKey{typ: typ, client: client, daily: true}, limit: limits.ClientPerDay, end: dayEnd, reason: "client daily quota"},
+		{key: counterKey{typ: typ, tokenID: tokenID}, limit: limits.VehiclePerMinute, end: minuteEnd, reason: "vehicle rate limit"},
+		{key: counterKey{typ: typ, tokenID: tokenID, daily: true}, limit: limits.VehiclePerDay, end: dayEnd, reason: "vehicle daily quota"},
+	}
+	checks = slices.DeleteFunc(checks, func(c check) bool { return c.limit == 0 })
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	decision := Decision{Allowed: true, Remaining: math.MaxInt}
+	for _, c := range checks {
+		count := l.count(c.key, c.end)
+		if count >= c.limit {
+			decision = Decision{Limit: c.limit, Reset: c.end, Reason: c.reason}
+			break
+		}
+		// the request is counted below, so it uses one of the remaining requests
+		if remaining := c.limit - count - 1; remaining < decision.Remaining {
+			decision.Limit, decision.Remaining, decision.Reset = c.limit, remaining, c.end
+		}
+	}
+	if decision.Allowed {
+		for _, c := range checks {
+			l.counters[c.key].count++
+		}
+	}
+	if decision.Limit == 0 {
+		decision.Remaining = 0
+	}
+	l.meter(now, typ, client, limits, decision.Allowed)
+	return decision
+}
+
+// count returns the count of the current window of the counter, starting a new window when it ended.
+func (l *Limiter) count(key counterKey, end time.Time) int {
+	w, ok := l.counters[key]
+	if !ok || !w.end.Equal(end) {
+		w = &window{end: end}
+		l.counters[key] = w
+	}
+	return w.count
+}
+
+func (l *Limiter) meter(now time.Time, typ, client string, limits Limits, allowed bool) {
+	key := usageKey{date: now.Format(time.DateOnly), client: client, typ: typ}
+	usage, ok := l.usage[key]
+	if !ok {
+		usage = &models.Usage{Date: key.date, Client: client, Type: typ}
+		l.usage[key] = usage
+	}
+	usage.DailyQuota = limits.ClientPerDay
+	result := "allowed"
+	if allowed {
+		usage.Allowed++
+	} else {
+		usage.Rejected++
+		result = "rejected"
+	}
+	requestsTotal.WithLabelValues(typ, result).Inc()
+}
+
+// prune drops the counters of ended windows and the usage of old days, at most once a minute.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+	for key, w := range l.counters {
+		if !w.end.After(now) {
+			delete(l.counters, key)
+		}
+	}
+	oldest := now.AddDate(0, 0, 1-usageDays).Format(time.DateOnly)
+	for key := range l.usage {
+		if key.date < oldest {
+			delete(l.usage, key)
+		}
+	}
+}
+
+// Usage returns the usage of the client, or of every client when it is empty, on the UTC days of the last week,
+// ordered by date, client and type.
+func (l *Limiter) Usage(client string) []models.Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(l.now().UTC())
+	usage := []models.Usage{}
+	for key, u := range l.usage {
+		if client == "" || strings.EqualFold(key.client, client) {
+			usage = append(usage, *u)
+		}
+	}
+	slices.SortFunc(usage, func(a, b models.Usage) int {
+		return strings.Compare(a.Date+"\x00"+a.Client+"\x00"+a.Type, b.Date+"\x00"+b.Client+"\x00"+b.Type)
+	})
+	return usage
+}
+
+// Restore adds the daily quota counts of the current UTC day and the usage of the last week saved in the store.
+// It is called before the limiter checks requests.
+func (l *Limiter) Restore(ctx context.Context, store Store) error {
+	snapshot, err := store.RateLimitSnapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load rate limit snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return nil
+	}
+	now := l.now().UTC()
+	today := now.Format(time.DateOnly)
+	dayEnd := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	oldest := now.AddDate(0, 0, 1-usageDays).Format(time.DateOnly)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range snapshot.DailyCounts {
+		if c.Date != today {
+			continue
+		}
+		key := counterKey{typ: c.Type, client: c.Client, tokenID: c.TokenID, daily: true}
+		l.count(key, dayEnd)
+		l.counters[key].count += c.Count
+	}
+	for _, u := range snapshot.Usage {
+		if u.Date < oldest {
+			continue
+		}
+		key := usageKey{date: u.Date, client: u.Client, typ: u.Type}
+		if usage, ok := l.usage[key]; ok {
+			usage.Allowed += u.Allowed
+			usage.Rejected += u.Rejected
+			continue
+		}
+		l.usage[key] = &u
+	}
+	return nil
+}
+
+// Persist saves the daily quota counts and usage to the store every minute until ctx is canceled, and once more
+// when it is. Failures are logged, the limits are enforced regardless.
+func (l *Limiter) Persist(ctx context.Context, logger *zerolog.Logger, store Store) error {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := store.SaveRateLimitSnapshot(context.WithoutCancel(ctx), l.snapshot()); err != nil {
+				logger.Error().Err(err).Msg("Failed to save rate limit snapshot.")
+			}
+			return nil
+		case <-ticker.C:
+			if err := store.SaveRateLimitSnapshot(ctx, l.snapshot()); err != nil {
+				logger.Error().Err(err).Msg("Failed to save rate limit snapshot.")
+			}
+		}
+	}
+}
+
+// snapshot returns the daily quota counts of the current UTC day and the usage of the last week.
+func (l *Limiter) snapshot() *models.RateLimitSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UTC()
+	snapshot := &models.RateLimitSnapshot{DailyCounts: []models.DailyCount{}, Usage: []models.Usage{}, SavedAt: now}
+	for key, w := range l.counters {
+		if !key.daily || !w.end.After(now) || w.count == 0 {
+			continue
+		}
+		snapshot.DailyCounts = append(snapshot.DailyCounts, models.DailyCount{
+			Date:    w.end.AddDate(0, 0, -1).Format(time.DateOnly),
+			Type:    key.typ,
+			Client:  key.client,
+			TokenID: key.tokenID,
+			Count:   w.count,
+		})
+	}
+	for _, u := range l.usage {
+		snapshot.Usage = append(snapshot.Usage, *u)
+	}
+	return snapshot
+}
+
+// tokenIDRequest is a gRPC request for a vehicle.
+type tokenIDRequest interface {
+	GetTokenId() uint32
+}
+
+// UnaryInterceptor limits the gRPC calls of the methods, keyed by full method name, to their attestation types.
+// The client is the requester set by the authorization interceptor, the mTLS client identity or the subject of the
+// token, and the vehicle the token ID of the request. Limited calls return the rate limit headers in lower case,
+// and rejected calls ResourceExhausted with a retry-after header.
+func (l *Limiter) UnaryInterceptor(methods map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		typ, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		var tokenID uint32
+		if tokenReq, ok := req.(tokenIDRequest); ok {
+			tokenID = tokenReq.GetTokenId()
+		}
+		decision := l.Allow(typ, requester.FromContext(ctx), tokenID)
+		if decision.Limit != 0 {
+			reset := int(math.Ceil(decision.Reset.Sub(l.now()).Seconds()))
+			md := metadata.Pairs(
+				strings.ToLower(HeaderLimit), strconv.Itoa(decision.Limit),
+				strings.ToLower(HeaderRemaining), strconv.Itoa(decision.Remaining),
+				strings.ToLower(HeaderReset), strconv.Itoa(max(reset, 0)),
+			)
+			if !decision.Allowed {
+				md.Set("retry-after", strconv.Itoa(max(reset, 1)))
+			}
+			// the headers are informational, the call is limited without them
+			_ = grpc.SetHeader(ctx, md)
+			if !decision.Allowed {
+				return nil, status.Error(codes.ResourceExhausted, "Too many requests, "+decision.Reason+" exceeded")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Middleware limits the requests for attestations of the type. The client is the requester of the request and the
+// vehicle the token ID path parameter. Limited requests are answered with the rate limit headers, and rejected
+// requests with 429 and Retry-After.
+func (l *Limiter) Middleware(typ, tokenIDParam string) fiber.Handler {
+	return func(fiberCtx *fiber.Ctx) error {
+		tokenID, err := strconv.ParseUint(fiberCtx.Params(tokenIDParam), 10, 32)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid token_id format")
+		}
+		decision := l.Allow(typ, requester.FromContext(fiberCtx.Context()), uint32(tokenID))
+		if decision.Limit != 0 {
+			reset := int(math.Ceil(decision.Reset.Sub(l.now()).Seconds()))
+			fiberCtx.Set(HeaderLimit, strconv.Itoa(decision.Limit))
+			fiberCtx.Set(HeaderRemaining, strconv.Itoa(decision.Remaining))
+			fiberCtx.Set(HeaderReset, strconv.Itoa(max(reset, 0)))
+			if !decision.Allowed {
+				fiberCtx.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(reset, 1)))
+				return fiber.NewError(fiber.StatusTooManyRequests, "Too many requests, "+decision.Reason+" exceeded")
+			}
+		}
+		return fiberCtx.Next()
+	}
+}
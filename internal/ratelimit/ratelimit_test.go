@@ -0,0 +1,285 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/models"
+	"github.com/DIMO-Network/attestation-api/internal/requester"
+	"github.com/DIMO-Network/server-garage/pkg/fibercommon"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	clientA = "0x07B584f6a7125491C991ca2a45ab9e641B1CeE1b"
+	clientB = "0x9B7C1C2dA3b5cA8bF6C4E0D2d1a3F4E5c6B7a8D9"
+)
+
+func newTestLimiter(t *testing.T, limits map[string]Limits) (*Limiter, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 1, 2, 23, 59, 0, 0, time.UTC)
+	limiter := NewLimiter(limits)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits(`{"default": {"clientPerMinute": 600}, "vehicle-health": {"clientPerDay": 10000, "vehiclePerDay": 24}}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]Limits{
+		DefaultType:      {ClientPerMinute: 600},
+		"vehicle-health": {ClientPerDay: 10000, VehiclePerDay: 24},
+	}, limits)
+
+	limits, err = ParseLimits(" ")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	_, err = ParseLimits(`{"vin": {"clientPerDay": -1}}`)
+	require.Error(t, err)
+	_, err = ParseLimits(`[]`)
+	require.Error(t, err)
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   map[string]Limits
+		requests []struct {
+			client  string
+			tokenID uint32
+		}
+		wantAllowed   []bool
+		wantLimit     int
+		wantRemaining int
+		wantReason    string
+	}{
+		{
+			name:   "client rate limit",
+			limits: map[string]Limits{"vin": {ClientPerMinute: 2}},
+			requests: []struct {
+				client  string
+				tokenID uint32
+			}{{clientA, 1}, {clientA, 2}, {clientA, 3}, {clientB, 1}},
+			wantAllowed:   []bool{true, true, false, true},
+			wantLimit:     2,
+			wantRemaining: 1,
+		},
+		{
+			name:   "vehicle quota across clients",
+			limits: map[string]Limits{DefaultType: {VehiclePerDay: 2}},
+			requests: []struct {
+				client  string
+				tokenID uint32
+			}{{clientA, 1}, {clientB, 1}, {clientA, 1}},
+			wantAllowed: []bool{true, true, false},
+			wantLimit:   2,
+			wantReason:  "vehicle daily quota",
+		},
+		{
+			name:   "closest limit is reported",
+			limits: map[string]Limits{"vin": {ClientPerMinute: 10, ClientPerDay: 3}},
+			requests: []struct {
+				client  string
+				tokenID uint32
+			}{{clientA, 1}, {clientA, 2}},
+			wantAllowed:   []bool{true, true},
+			wantLimit:     3,
+			wantRemaining: 1,
+		},
+		{
+			name:   "other types are unlimited",
+			limits: map[string]Limits{"odometer-statement": {ClientPerMinute: 1}},
+			requests: []struct {
+				client  string
+				tokenID uint32
+			}{{clientA, 1}, {clientA, 1}},
+			wantAllowed: []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, _ := newTestLimiter(t, tt.limits)
+			var last Decision
+			for i, req := range tt.requests {
+				last = limiter.Allow("vin", req.client, req.tokenID)
+				require.Equal(t, tt.wantAllowed[i], last.Allowed, "request %d", i)
+			}
+			require.Equal(t, tt.wantLimit, last.Limit)
+			require.Equal(t, tt.wantRemaining, last.Remaining)
+			require.Equal(t, tt.wantReason, last.Reason)
+		})
+	}
+}
+
+func TestAllow_WindowsReset(t *testing.T) {
+	limiter, now := newTestLimiter(t, map[string]Limits{"vin": {ClientPerMinute: 1, ClientPerDay: 2}})
+
+	require.True(t, limiter.Allow("vin", clientA, 1).Allowed)
+	decision := limiter.Allow("vin", clientA, 1)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "client rate limit", decision.Reason)
+	require.Equal(t, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), decision.Reset)
+
+	// the next day resets both the minute and the daily window
+	*now = now.Add(time.Minute)
+	require.True(t, limiter.Allow("vin", clientA, 1).Allowed)
+	*now = now.Add(time.Minute)
+	require.True(t, limiter.Allow("vin", clientA, 1).Allowed)
+	*now = now.Add(time.Minute)
+	decision = limiter.Allow("vin", clientA, 1)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "client daily quota", decision.Reason)
+
+	require.Equal(t, []models.Usage{
+		{Date: "2026-01-02", Client: clientA, Type: "vin", Allowed: 1, Rejected: 1, DailyQuota: 2},
+		{Date: "2026-01-03", Client: clientA, Type: "vin", Allowed: 2, Rejected: 1, DailyQuota: 2},
+	}, limiter.Usage(""))
+	require.Empty(t, limiter.Usage(clientB))
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter, _ := newTestLimiter(t, map[string]Limits{"vin": {ClientPerMinute: 1}})
+	app := fiber.New(fiber.Config{ErrorHandler: fibercommon.ErrorHandler})
+	app.Post("/v2/attestation/vin/:tokenId", func(fiberCtx *fiber.Ctx) error {
+		// the token verified by the JWT middleware
+		claims := &tokenclaims.Token{}
+		claims.Subject = clientA
+		fiberCtx.Locals("user", &jwt.Token{Claims: claims})
+		return fiberCtx.Next()
+	}, requester.Middleware, limiter.Middleware("vin", "tokenId"), func(fiberCtx *fiber.Ctx) error {
+		require.Equal(t, clientA, requester.FromContext(fiberCtx.Context()))
+		return fiberCtx.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/v2/attestation/vin/1", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, "1", resp.Header.Get(HeaderLimit))
+	require.Equal(t, "0", resp.Header.Get(HeaderRemaining))
+	require.Equal(t, "60", resp.Header.Get(HeaderReset))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodPost, "/v2/attestation/vin/1", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, "60", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodPost, "/v2/attestation/vin/abc", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+// headerStream records the headers set by a gRPC interceptor.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
+
+type vehicleRequest struct {
+	tokenID uint32
+}
+
+func (r *vehicleRequest) GetTokenId() uint32 { return r.tokenID }
+
+func TestUnaryInterceptor(t *testing.T) {
+	limiter, _ := newTestLimiter(t, map[string]Limits{"vin": {VehiclePerMinute: 1}})
+	interceptor := limiter.UnaryInterceptor(map[string]string{"/AttestationService/EnsureVinVc": "vin"})
+	handler := func(ctx context.Context, _ any) (any, error) {
+		return "ok", nil
+	}
+	call := func(method, client string, tokenID uint32) (*headerStream, error) {
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(requester.NewContext(context.Background(), client), stream)
+		_, err := interceptor(ctx, &vehicleRequest{tokenID: tokenID}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return stream, err
+	}
+
+	stream, err := call("/AttestationService/EnsureVinVc", "spiffe://dimo/ns/dimo/sa/vehicle-triggers-api", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, stream.header.Get(HeaderLimit))
+	require.Equal(t, []string{"0"}, stream.header.Get(HeaderRemaining))
+
+	stream, err = call("/AttestationService/EnsureVinVc", clientA, 1)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"60"}, stream.header.Get("retry-after"))
+
+	_, err = call("/AttestationService/EnsureVinVc", clientA, 2)
+	require.NoError(t, err)
+	// methods that do not issue attestations are not limited
+	_, err = call("/AttestationService/GetVinVcLatest", clientA, 1)
+	require.NoError(t, err)
+
+	usage := limiter.Usage("spiffe://dimo/ns/dimo/sa/vehicle-triggers-api")
+	require.Len(t, usage, 1)
+	require.Equal(t, 1, usage[0].Allowed)
+}
+
+// memoryStore keeps the rate limit snapshot in memory.
+type memoryStore struct {
+	snapshot *models.RateLimitSnapshot
+}
+
+func (s *memoryStore) SaveRateLimitSnapshot(_ context.Context, snapshot *models.RateLimitSnapshot) error {
+	s.snapshot = snapshot
+	return nil
+}
+
+func (s *memoryStore) RateLimitSnapshot(context.Context) (*models.RateLimitSnapshot, error) {
+	return s.snapshot, nil
+}
+
+func TestPersistRestore(t *testing.T) {
+	limits := map[string]Limits{"vin": {ClientPerMinute: 10, ClientPerDay: 2, VehiclePerDay: 5}}
+	limiter, now := newTestLimiter(t, limits)
+	require.True(t, limiter.Allow("vin", clientA, 1).Allowed)
+	require.True(t, limiter.Allow("vin", clientA, 2).Allowed)
+	require.False(t, limiter.Allow("vin", clientA, 3).Allowed)
+
+	// Persist saves once more when it stops
+	store := &memoryStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	logger := zerolog.Nop()
+	require.NoError(t, limiter.Persist(ctx, &logger, store))
+	require.NotNil(t, store.snapshot)
+
+	// the daily quota of the client is still used up after a restart, while the minute limit starts over
+	restarted, restartedNow := newTestLimiter(t, limits)
+	*restartedNow = now.Add(30 * time.Second)
+	require.NoError(t, restarted.Restore(context.Background(), store))
+	decision := restarted.Allow("vin", clientA, 4)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "client daily quota", decision.Reason)
+	require.True(t, restarted.Allow("vin", clientB, 1).Allowed)
+	usage := restarted.Usage(clientA)
+	require.Len(t, usage, 1)
+	require.Equal(t, 2, usage[0].Allowed)
+	require.Equal(t, 2, usage[0].Rejected)
+
+	// quotas of a previous day are not restored, its usage is
+	next, nextNow := newTestLimiter(t, limits)
+	*nextNow = now.Add(time.Hour)
+	require.NoError(t, next.Restore(context.Background(), store))
+	require.True(t, next.Allow("vin", clientA, 4).Allowed)
+	require.Len(t, next.Usage(clientA), 2)
+}
@@ -0,0 +1,51 @@
+// Package requester carries the identity of the caller an attestation is issued for through the context of the
+// request, so it can be recorded with the attestation.
+//
+// The identity is the subject of a token-exchange token, which is the address of the caller's dev license, the
+// identity of an mTLS client certificate or the ID of an operator. Attestations the service issues on its own,
+// e.g. renewals, have no requester.
+package requester
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/server-garage/pkg/fibercommon/jwtmiddleware"
+	"github.com/DIMO-Network/token-exchange-api/pkg/tokenclaims"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the requester ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the requester ID carried by ctx, or an empty string when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware stores the subject of the token-exchange token verified by the JWT middleware as the requester of
+// the request, returned by FromContext for the context of the fiber request.
+func Middleware(fiberCtx *fiber.Ctx) error {
+	if claims, err := jwtmiddleware.GetTokenClaim(fiberCtx); err == nil && claims.Subject != "" {
+		fiberCtx.Locals(contextKey{}, claims.Subject)
+	}
+	return fiberCtx.Next()
+}
+
+// FromToken returns the subject of a token-exchange token without verifying it, for tokens verified when they
+// were received.
+func FromToken(token string) string {
+	var claims tokenclaims.Token
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
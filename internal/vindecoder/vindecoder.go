@@ -0,0 +1,280 @@
+// Package vindecoder decodes VINs offline from an embedded table of world manufacturer identifiers (ISO 3779 / ISO 3780).
+package vindecoder
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/vin"
+)
+
+// Regions of the world as assigned by the first characters of the VIN.
+const (
+	RegionAfrica       = "Africa"
+	RegionAsia         = "Asia"
+	RegionEurope       = "Europe"
+	RegionNorthAmerica = "North America"
+	RegionOceania      = "Oceania"
+	RegionSouthAmerica = "South America"
+)
+
+// vinCharacters is the order of the characters in ISO 3780 country ranges.
+const vinCharacters = "ABCDEFGHJKLMNPRSTUVWXYZ1234567890"
+
+// checkDigitWeights are the position weights of the check digit calculation (49 CFR 565.15).
+var checkDigitWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// ErrCheckDigit is returned for North American VINs whose check digit does not match.
+var ErrCheckDigit = errors.New("VIN check digit does not match")
+
+//go:embed wmi.json
+var wmiJSON []byte
+
+// Manufacturer is the manufacturer assigned a world manufacturer identifier.
+type Manufacturer struct {
+	// Name is the name of the manufacturer.
+	Name string `json:"name"`
+	// Makes are the vehicle makes built under the identifier, defaults to the name.
+	Makes []string `json:"makes,omitempty"`
+	// EncodesModelYear is set for manufacturers known to encode the model year in position 10 of VINs built
+	// outside North America, where ISO 3779 does not require it.
+	EncodesModelYear bool `json:"encodesModelYear,omitempty"`
+}
+
+// CountryRange assigns the VINs whose first two characters fall within the range to a country.
+type CountryRange struct {
+	// From and To are the first and last two characters of the range, in ISO 3780 order.
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Country is the ISO 3166-1 alpha-3 country code.
+	Country string `json:"country"`
+}
+
+// Decoder decodes VINs.
+type Decoder struct {
+	// Version is the version of the decoder data.
+	Version string `json:"version"`
+	// Countries are the country ranges of the VIN prefixes.
+	Countries []CountryRange `json:"countries"`
+	// Manufacturers are keyed by their world manufacturer identifier, the first three characters of the VIN.
+	Manufacturers map[string]Manufacturer `json:"manufacturers"`
+}
+
+// Decoded is what a VIN reveals about the vehicle.
+type Decoded struct {
+	VIN string
+	// WMI is the world manufacturer identifier, empty for Japanese chassis numbers.
+	WMI string
+	// Manufacturer is empty when the identifier is unknown.
+	Manufacturer Manufacturer
+	Region       string
+	// CountryCode is the ISO 3166-1 alpha-3 code of the country of manufacture.
+	CountryCode string
+	// ModelYear is 0 when the VIN does not encode it, or is built outside North America by a manufacturer not known
+	// to encode it.
+	ModelYear int
+	// CheckDigitValid is nil for VINs without a check digit.
+	CheckDigitValid *bool
+}
+
+var defaultDecoder = sync.OnceValue(func() *Decoder {
+	decoder, err := Parse(wmiJSON)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded WMI table: %v", err))
+	}
+	return decoder
+})
+
+// Default returns the decoder of the embedded WMI table.
+func Default() *Decoder {
+	return defaultDecoder()
+}
+
+// Parse parses a WMI table document.
+func Parse(data []byte) (*Decoder, error) {
+	var decoder Decoder
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal WMI table: %w", err)
+	}
+	if decoder.Version == "" {
+		return nil, errors.New("WMI table version is required")
+	}
+	for _, country := range decoder.Countries {
+		if len(country.From) != 2 || len(country.To) != 2 || country.From[0] != country.To[0] ||
+			position(country.From[1]) < 0 || position(country.To[1]) < position(country.From[1]) {
+			return nil, fmt.Errorf("invalid country range %s-%s", country.From, country.To)
+		}
+		if len(country.Country) != 3 {
+			return nil, fmt.Errorf("country range %s-%s has invalid country %q", country.From, country.To, country.Country)
+		}
+	}
+	for wmi, manufacturer := range decoder.Manufacturers {
+		if len(wmi) != 3 || manufacturer.Name == "" {
+			return nil, fmt.Errorf("invalid manufacturer %q", wmi)
+		}
+	}
+	return &decoder, nil
+}
+
+// Decode decodes a 17 character VIN or a Japanese chassis number. North American VINs must have a valid check digit.
+func (d *Decoder) Decode(vinValue string) (*Decoded, error) {
+	vinObj := vin.VIN(strings.ToUpper(strings.TrimSpace(vinValue)))
+	if !vinObj.IsValidVIN() {
+		if vinObj.IsValidJapanChassis() {
+			return &Decoded{VIN: vinObj.String(), Region: RegionAsia, CountryCode: "JPN"}, nil
+		}
+		return nil, fmt.Errorf("invalid VIN %s", vinValue)
+	}
+
+	value := vinObj.String()
+	country := d.country(value)
+	decoded := &Decoded{
+		VIN:          value,
+		WMI:          vinObj.Wmi(),
+		Manufacturer: d.Manufacturers[vinObj.Wmi()],
+		Region:       region(value[0], country),
+		CountryCode:  country,
+	}
+	if decoded.Region == RegionNorthAmerica {
+		valid := value[8] == checkDigit(value)
+		decoded.CheckDigitValid = &valid
+		if !valid {
+			return nil, fmt.Errorf("%w: %s", ErrCheckDigit, value)
+		}
+	}
+	if decoded.Region == RegionNorthAmerica || decoded.Manufacturer.EncodesModelYear {
+		decoded.ModelYear = modelYear(value, decoded.Region == RegionNorthAmerica, time.Now().Year())
+	}
+	return decoded, nil
+}
+
+// MatchesManufacturer reports whether the manufacturer name, e.g. from the vehicle record, builds the decoded make.
+// It returns nil when either is unknown.
+func (d *Decoded) MatchesManufacturer(name string) *bool {
+	if d.Manufacturer.Name == "" || name == "" {
+		return nil
+	}
+	makes := d.Manufacturer.Makes
+	if len(makes) == 0 {
+		makes = []string{d.Manufacturer.Name}
+	}
+	match := false
+	for _, vehicleMake := range makes {
+		if normalize(vehicleMake) == normalize(name) {
+			match = true
+			break
+		}
+	}
+	return &match
+}
+
+// country returns the country of the range containing the first two characters of the VIN.
+func (d *Decoder) country(value string) string {
+	second := position(value[1])
+	for _, country := range d.Countries {
+		if country.From[0] == value[0] && second >= position(country.From[1]) && second <= position(country.To[1]) {
+			return country.Country
+		}
+	}
+	return ""
+}
+
+func position(char byte) int {
+	return strings.IndexByte(vinCharacters, char)
+}
+
+// region returns the region of the country of manufacture, or the region assigned the first character of the VIN
+// when the country is not North American. Ranges of other regions are also assigned to North American countries,
+// such as 7F-70 to the United States.
+func region(first byte, country string) string {
+	switch country {
+	case "USA", "CAN", "MEX":
+		return RegionNorthAmerica
+	}
+	switch {
+	case first >= 'A' && first <= 'G':
+		return RegionAfrica
+	case first >= 'H' && first <= 'R':
+		return RegionAsia
+	case first >= 'S' && first <= 'Z':
+		return RegionEurope
+	case first >= '1' && first <= '5':
+		return RegionNorthAmerica
+	case first == '6' || first == '7':
+		return RegionOceania
+	case first == '8' || first == '9':
+		return RegionSouthAmerica
+	}
+	return ""
+}
+
+// checkDigit calculates the check digit of the VIN.
+func checkDigit(value string) byte {
+	sum := 0
+	for i := range len(value) {
+		sum += transliterate(value[i]) * checkDigitWeights[i]
+	}
+	if rem := sum % 11; rem != 10 {
+		return byte('0' + rem)
+	}
+	return 'X'
+}
+
+// transliterate returns the numeric value of a VIN character.
+func transliterate(char byte) int {
+	switch {
+	case char >= '0' && char <= '9':
+		return int(char - '0')
+	case char >= 'A' && char <= 'H':
+		return int(char-'A') + 1
+	case char >= 'J' && char <= 'N':
+		return int(char-'J') + 1
+	case char == 'P':
+		return 7
+	case char == 'R':
+		return 9
+	case char >= 'S' && char <= 'Z':
+		return int(char-'S') + 2
+	}
+	return 0
+}
+
+// modelYear decodes the model year character of the VIN, which repeats every 30 years. North American VINs have
+// a letter in position 7 from 2010 on; VINs of other manufacturers encoding the year are assumed to be of the most
+// recent year not after next year.
+func modelYear(value string, northAmerican bool, currentYear int) int {
+	const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+	offset := strings.IndexByte(yearCodes, value[9])
+	if offset < 0 {
+		return 0
+	}
+	year := 1980 + offset
+	if northAmerican {
+		if position7 := value[6]; position7 < '0' || position7 > '9' {
+			year += 30
+		}
+		return year
+	}
+	for year+30 <= currentYear+1 {
+		year += 30
+	}
+	return year
+}
+
+// normalize lowercases the name and drops everything but letters and digits.
+func normalize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return -1
+	}, name)
+}
@@ -0,0 +1,244 @@
+package vindecoder_test
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/attestation-api/internal/vindecoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	decoder := vindecoder.Default()
+	require.NotNil(t, decoder)
+	assert.NotEmpty(t, decoder.Version)
+	assert.NotEmpty(t, decoder.Countries)
+	assert.NotEmpty(t, decoder.Manufacturers)
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name        string
+		vin         string
+		expected    *vindecoder.Decoded
+		expectError bool
+		expectedErr error
+	}{
+		{
+			name: "North American VIN before 2010",
+			vin:  "1HGCM82633A004352",
+			expected: &vindecoder.Decoded{
+				VIN:             "1HGCM82633A004352",
+				WMI:             "1HG",
+				Manufacturer:    vindecoder.Manufacturer{Name: "Honda"},
+				Region:          vindecoder.RegionNorthAmerica,
+				CountryCode:     "USA",
+				ModelYear:       2003,
+				CheckDigitValid: ptr(true),
+			},
+		},
+		{
+			name: "North American VIN from 2010 on",
+			vin:  "1hgcv1f34pa123456",
+			expected: &vindecoder.Decoded{
+				VIN:             "1HGCV1F34PA123456",
+				WMI:             "1HG",
+				Manufacturer:    vindecoder.Manufacturer{Name: "Honda"},
+				Region:          vindecoder.RegionNorthAmerica,
+				CountryCode:     "USA",
+				ModelYear:       2023,
+				CheckDigitValid: ptr(true),
+			},
+		},
+		{
+			name: "Mexican VIN",
+			vin:  "3FMTK3SU5MMA12345",
+			expected: &vindecoder.Decoded{
+				VIN:             "3FMTK3SU5MMA12345",
+				WMI:             "3FM",
+				Manufacturer:    vindecoder.Manufacturer{Name: "Ford"},
+				Region:          vindecoder.RegionNorthAmerica,
+				CountryCode:     "MEX",
+				ModelYear:       2021,
+				CheckDigitValid: ptr(true),
+			},
+		},
+		{
+			name: "United States VIN in a range of Oceania",
+			vin:  "7SAYGDEE3PA000001",
+			expected: &vindecoder.Decoded{
+				VIN:             "7SAYGDEE3PA000001",
+				WMI:             "7SA",
+				Manufacturer:    vindecoder.Manufacturer{Name: "Tesla"},
+				Region:          vindecoder.RegionNorthAmerica,
+				CountryCode:     "USA",
+				ModelYear:       2023,
+				CheckDigitValid: ptr(true),
+			},
+		},
+		{
+			name: "United States Honda VIN in a range of Oceania",
+			vin:  "7FARW2H52NE000001",
+			expected: &vindecoder.Decoded{
+				VIN:             "7FARW2H52NE000001",
+				WMI:             "7FA",
+				Manufacturer:    vindecoder.Manufacturer{Name: "Honda"},
+				Region:          vindecoder.RegionNorthAmerica,
+				CountryCode:     "USA",
+				ModelYear:       2022,
+				CheckDigitValid: ptr(true),
+			},
+		},
+		{
+			name:        "United States VIN in a range of Oceania with wrong check digit",
+			vin:         "7SAYGDEE1PA000001",
+			expectError: true,
+			expectedErr: vindecoder.ErrCheckDigit,
+		},
+		{
+			name:        "North American VIN with wrong check digit",
+			vin:         "1HGCM82634A004352",
+			expectError: true,
+			expectedErr: vindecoder.ErrCheckDigit,
+		},
+		{
+			name: "European VIN without check digit",
+			vin:  "WBA8E9G59GNU12345",
+			expected: &vindecoder.Decoded{
+				VIN:          "WBA8E9G59GNU12345",
+				WMI:          "WBA",
+				Manufacturer: vindecoder.Manufacturer{Name: "BMW", EncodesModelYear: true},
+				Region:       vindecoder.RegionEurope,
+				CountryCode:  "DEU",
+				ModelYear:    2016,
+			},
+		},
+		{
+			name: "European VIN of a manufacturer not encoding the model year",
+			vin:  "WDD2050421F123456",
+			expected: &vindecoder.Decoded{
+				VIN:          "WDD2050421F123456",
+				WMI:          "WDD",
+				Manufacturer: vindecoder.Manufacturer{Name: "Mercedes-Benz"},
+				Region:       vindecoder.RegionEurope,
+				CountryCode:  "DEU",
+			},
+		},
+		{
+			name: "unknown manufacturer",
+			vin:  "KPTA0B1SSDP123456",
+			expected: &vindecoder.Decoded{
+				VIN:         "KPTA0B1SSDP123456",
+				WMI:         "KPT",
+				Region:      vindecoder.RegionAsia,
+				CountryCode: "KOR",
+			},
+		},
+		{
+			name: "Japanese chassis number",
+			vin:  "SNT33-042261",
+			expected: &vindecoder.Decoded{
+				VIN:         "SNT33-042261",
+				Region:      vindecoder.RegionAsia,
+				CountryCode: "JPN",
+			},
+		},
+		{
+			name:        "invalid VIN",
+			vin:         "1HGCM82633A00435I",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := vindecoder.Default().Decode(tt.vin)
+			if tt.expectError {
+				require.Error(t, err)
+				if tt.expectedErr != nil {
+					require.ErrorIs(t, err, tt.expectedErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, decoded)
+		})
+	}
+}
+
+func TestDecoder_DecodeRegion(t *testing.T) {
+	tests := []struct {
+		vin             string
+		expectedRegion  string
+		expectedCountry string
+	}{
+		{vin: "AAVZZZ6RZ9U012345", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "ZAF"},
+		{vin: "DA1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "EGY"},
+		{vin: "DF1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "MAR"},
+		{vin: "DL1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "ZMB"},
+		{vin: "EA1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "ETH"},
+		{vin: "EF1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "MOZ"},
+		{vin: "FA1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "GHA"},
+		{vin: "FK1AB2CD3EF456789", expectedRegion: vindecoder.RegionAfrica, expectedCountry: "NGA"},
+		{vin: "LVGBV3FK0PG123456", expectedRegion: vindecoder.RegionAsia, expectedCountry: "CHN"},
+		{vin: "6FPAAAJGCM8A12345", expectedRegion: vindecoder.RegionOceania, expectedCountry: "AUS"},
+		{vin: "7A8GA0CC6PA000001", expectedRegion: vindecoder.RegionOceania, expectedCountry: "NZL"},
+		{vin: "7SAYGDEE3PA000001", expectedRegion: vindecoder.RegionNorthAmerica, expectedCountry: "USA"},
+		{vin: "7FARW2H52NE000001", expectedRegion: vindecoder.RegionNorthAmerica, expectedCountry: "USA"},
+		{vin: "9BWZZZ377VT004251", expectedRegion: vindecoder.RegionSouthAmerica, expectedCountry: "BRA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vin, func(t *testing.T) {
+			decoded, err := vindecoder.Default().Decode(tt.vin)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRegion, decoded.Region)
+			assert.Equal(t, tt.expectedCountry, decoded.CountryCode)
+		})
+	}
+}
+
+func TestDecoded_MatchesManufacturer(t *testing.T) {
+	decoder, err := vindecoder.Parse([]byte(`{
+		"version": "test",
+		"countries": [{"from": "1A", "to": "10", "country": "USA"}],
+		"manufacturers": {
+			"1HG": {"name": "Honda"},
+			"1C4": {"name": "FCA US", "makes": ["Chrysler", "Dodge", "Jeep"]}
+		}
+	}`))
+	require.NoError(t, err)
+
+	honda, err := decoder.Decode("1HGCM82633A004352")
+	require.NoError(t, err)
+	fca, err := decoder.Decode("1C4RJFAG6FC123456")
+	require.NoError(t, err)
+	unknown, err := decoder.Decode("1M8GDM9AXKP042788")
+	require.NoError(t, err)
+
+	assert.Equal(t, ptr(true), honda.MatchesManufacturer("honda"))
+	assert.Equal(t, ptr(false), honda.MatchesManufacturer("Toyota"))
+	assert.Equal(t, ptr(true), fca.MatchesManufacturer("Jeep"))
+	assert.Nil(t, honda.MatchesManufacturer(""))
+	assert.Nil(t, unknown.MatchesManufacturer("Honda"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing version", data: `{"countries": []}`},
+		{name: "range across first characters", data: `{"version": "test", "countries": [{"from": "1A", "to": "2A", "country": "USA"}]}`},
+		{name: "reversed range", data: `{"version": "test", "countries": [{"from": "1Z", "to": "1A", "country": "USA"}]}`},
+		{name: "invalid country", data: `{"version": "test", "countries": [{"from": "1A", "to": "10", "country": "US"}]}`},
+		{name: "invalid manufacturer", data: `{"version": "test", "manufacturers": {"1H": {"name": "Honda"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vindecoder.Parse([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func ptr(v bool) *bool { return &v }
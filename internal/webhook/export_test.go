@@ -0,0 +1,8 @@
+package webhook
+
+import "net/http"
+
+// AllowPrivateAddresses lets the sender call webhooks on private addresses, such as test servers on loopback.
+func AllowPrivateAddresses(s *Sender) {
+	s.client = &http.Client{Timeout: requestTimeout}
+}
@@ -0,0 +1,223 @@
+// Package webhook calls webhooks with payloads signed by the attestation signer of the service or by an HMAC
+// keyed with a secret shared with the receiver.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/erc191"
+)
+
+const (
+	// SignatureHeader is the header of the ERC-191 signature of the request body, made with the key that signs
+	// the attestations so receivers can verify it against the issuer address.
+	SignatureHeader = "X-Attestation-Signature"
+
+	// HMACSignatureHeader is the header of the hex HMAC-SHA256 of the timestamp, a dot and the request body,
+	// prefixed with "sha256=".
+	HMACSignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader is the header of the Unix time the request was signed at, so receivers can reject replays.
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = 2 * time.Second
+	requestTimeout     = 10 * time.Second
+	lookupTimeout      = 5 * time.Second
+)
+
+var (
+	// ErrInvalidURL is returned for webhook URLs that are not absolute HTTPS URLs.
+	ErrInvalidURL = errors.New("webhook URL must be an absolute https URL")
+	// ErrForbiddenAddress is returned for webhook URLs whose host is not a public address, so webhooks cannot be
+	// used to reach the network of the service.
+	ErrForbiddenAddress = errors.New("webhook URL must not point to a private, loopback or link-local address")
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which netip does not report as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Sender calls webhooks.
+type Sender struct {
+	privateKey  *ecdsa.PrivateKey
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewSender creates a Sender signing payloads with the private key. Failed calls are retried up to maxAttempts
+// times in total, waiting backoff before the first retry and doubling it after each. Defaults apply when zero.
+func NewSender(privateKey *ecdsa.PrivateKey, maxAttempts int, backoff time.Duration) *Sender {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	// the address is checked when connecting, after the host is resolved, so a host cannot be pointed at a
+	// private address after its URL was validated
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: checkDialAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Sender{
+		privateKey:  privateKey,
+		client:      &http.Client{Timeout: requestTimeout, Transport: transport},
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// ValidateURL checks that the webhook URL is an absolute HTTPS URL whose host resolves to public addresses only.
+// Hosts that do not resolve are accepted, since the Sender checks the address again when it calls the webhook.
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublic(addr) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !isPublic(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// checkDialAddress rejects connections to addresses that are not public.
+func checkDialAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid webhook address %q: %w", address, err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid webhook address %q: %w", address, err)
+	}
+	if !isPublic(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	return nil
+}
+
+// isPublic reports whether the address is a globally routable unicast address.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// Send posts the JSON payload to the webhook URL, retrying until it answers with a 2xx status or the attempts
+// are exhausted.
+func (s *Sender) Send(ctx context.Context, webhookURL string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	signature, err := erc191.SignMessage(body, s.privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign webhook payload: %w", err)
+	}
+	_, err = s.send(ctx, webhookURL, body, func() map[string]string {
+		return map[string]string{SignatureHeader: signature}
+	})
+	return err
+}
+
+// SendHMAC posts the JSON payload to the webhook URL like Send, signed with an HMAC keyed by secret instead of the
+// attestation signer. It returns the number of calls made.
+func (s *Sender) SendHMAC(ctx context.Context, webhookURL, secret string, payload any) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	// every attempt is signed with its own timestamp
+	return s.send(ctx, webhookURL, body, func() map[string]string {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		return map[string]string{
+			TimestampHeader:     timestamp,
+			HMACSignatureHeader: SignHMAC(secret, timestamp, body),
+		}
+	})
+}
+
+// SignHMAC returns the HMACSignatureHeader value of the body sent at the timestamp.
+func SignHMAC(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send posts the body with the headers until the webhook answers with a 2xx status or the attempts are exhausted,
+// and returns the number of calls made.
+func (s *Sender) send(ctx context.Context, webhookURL string, body []byte, headers func() map[string]string) (int, error) {
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err := s.post(ctx, webhookURL, body, headers())
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt == s.maxAttempts {
+			return attempt, fmt.Errorf("webhook failed after %d attempts: %w", attempt, err)
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, fmt.Errorf("webhook canceled after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *Sender) post(ctx context.Context, webhookURL string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/attestation-api/internal/erc191"
+	"github.com/DIMO-Network/attestation-api/internal/webhook"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	tests := []struct {
+		name          string
+		failures      int
+		expectedCalls int
+		expectedError bool
+	}{
+		{name: "delivered", failures: 0, expectedCalls: 1},
+		{name: "delivered after retries", failures: 2, expectedCalls: 3},
+		{name: "attempts exhausted", failures: 5, expectedCalls: 3, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"id":"job-1"}`, string(body))
+				addr, err := erc191.RecoverAddress(body, r.Header.Get(webhook.SignatureHeader))
+				require.NoError(t, err)
+				require.Equal(t, signer, addr)
+				if calls <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+
+			sender := webhook.NewSender(privateKey, 3, time.Millisecond)
+			webhook.AllowPrivateAddresses(sender)
+			err := sender.Send(context.Background(), server.URL, map[string]string{"id": "job-1"})
+			if tt.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	require.NoError(t, webhook.ValidateURL("https://example.com/hooks/attestation"))
+	require.ErrorIs(t, webhook.ValidateURL("http://example.com/hooks"), webhook.ErrInvalidURL)
+	require.ErrorIs(t, webhook.ValidateURL("/hooks"), webhook.ErrInvalidURL)
+	require.ErrorIs(t, webhook.ValidateURL("https://"), webhook.ErrInvalidURL)
+
+	require.NoError(t, webhook.ValidateURL("https://93.184.215.14/hooks"))
+	for _, rawURL := range []string{
+		"https://localhost/hooks",
+		"https://api.localhost./hooks",
+		"https://127.0.0.1:8443/hooks",
+		"https://10.0.0.5/hooks",
+		"https://172.16.3.4/hooks",
+		"https://192.168.1.1/hooks",
+		"https://100.64.0.1/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://0.0.0.0/hooks",
+		"https://[::1]/hooks",
+		"https://[fd00::1]/hooks",
+		"https://[fe80::1%25eth0]/hooks",
+		"https://[::ffff:127.0.0.1]/hooks",
+	} {
+		require.ErrorIs(t, webhook.ValidateURL(rawURL), webhook.ErrForbiddenAddress, rawURL)
+	}
+}
+
+func TestSender_ForbiddenAddress(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	sender := webhook.NewSender(privateKey, 1, time.Millisecond)
+	err = sender.Send(context.Background(), server.URL, map[string]string{"id": "job-1"})
+	require.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+	require.Zero(t, calls)
+}
+
+func TestSender_SendHMAC(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp := r.Header.Get(webhook.TimestampHeader)
+		require.NotEmpty(t, timestamp)
+		require.Equal(t, webhook.SignHMAC("secret", timestamp, body), r.Header.Get(webhook.HMACSignatureHeader))
+		require.NotEqual(t, webhook.SignHMAC("other", timestamp, body), r.Header.Get(webhook.HMACSignatureHeader))
+		require.Empty(t, r.Header.Get(webhook.SignatureHeader))
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	sender := webhook.NewSender(privateKey, 3, time.Millisecond)
+	webhook.AllowPrivateAddresses(sender)
+	attempts, err := sender.SendHMAC(context.Background(), server.URL, "secret", map[string]string{"eventId": "vc-1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 2, calls)
+}
@@ -306,10 +306,15 @@ func (x *TestVinVcCreationResponse) GetRawVc() string {
 }
 
 type ManualVinVcCreationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TokenId       uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
-	Vin           string                 `protobuf:"bytes,2,opt,name=vin,proto3" json:"vin,omitempty"`
-	CountryCode   string                 `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Vin     string                 `protobuf:"bytes,2,opt,name=vin,proto3" json:"vin,omitempty"`
+	// Defaults to the country of manufacture decoded from the VIN.
+	CountryCode string `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// Why the VIN is attested manually.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Reference to the supporting evidence, e.g. a support ticket or a document ID.
+	EvidenceRef   string `protobuf:"bytes,5,opt,name=evidence_ref,json=evidenceRef,proto3" json:"evidence_ref,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -365,9 +370,25 @@ func (x *ManualVinVcCreationRequest) GetCountryCode() string {
 	return ""
 }
 
+func (x *ManualVinVcCreationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ManualVinVcCreationRequest) GetEvidenceRef() string {
+	if x != nil {
+		return x.EvidenceRef
+	}
+	return ""
+}
+
 type ManualVinVcCreationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RawVc         string                 `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty while the request awaits approval.
+	RawVc         string            `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	Request       *ManualVinRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -409,36 +430,1409 @@ func (x *ManualVinVcCreationResponse) GetRawVc() string {
 	return ""
 }
 
-var File_pkg_grpc_atttestation_api_proto protoreflect.FileDescriptor
+func (x *ManualVinVcCreationResponse) GetRequest() *ManualVinRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
 
-const file_pkg_grpc_atttestation_api_proto_rawDesc = "" +
-	"\n" +
-	"\x1fpkg/grpc/atttestation-api.proto\x12\x04grpc\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
-	"\x12EnsureVinVcRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\rR\atokenId\x12\x14\n" +
-	"\x05force\x18\x02 \x01(\bR\x05force\x122\n" +
-	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\",\n" +
-	"\x13EnsureVinVcResponse\x12\x15\n" +
-	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"2\n" +
-	"\x15GetLatestVinVcRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\rR\atokenId\"/\n" +
-	"\x16GetLatestVinVcResponse\x12\x15\n" +
-	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"5\n" +
-	"\x18TestVinVcCreationRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\rR\atokenId\"2\n" +
-	"\x19TestVinVcCreationResponse\x12\x15\n" +
-	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"l\n" +
-	"\x1aManualVinVcCreationRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\rR\atokenId\x12\x10\n" +
-	"\x03vin\x18\x02 \x01(\tR\x03vin\x12!\n" +
-	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\"4\n" +
-	"\x1bManualVinVcCreationResponse\x12\x15\n" +
-	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc2\xd7\x02\n" +
+type ManualVinRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TokenId     uint32                 `protobuf:"varint,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Vin         string                 `protobuf:"bytes,3,opt,name=vin,proto3" json:"vin,omitempty"`
+	CountryCode string                 `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Reason      string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	EvidenceRef string                 `protobuf:"bytes,6,opt,name=evidence_ref,json=evidenceRef,proto3" json:"evidence_ref,omitempty"`
+	// One of "pending", "approved", "issued", "rejected" or "failed".
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,8,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,10,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ReviewReason  string                 `protobuf:"bytes,12,opt,name=review_reason,json=reviewReason,proto3" json:"review_reason,omitempty"`
+	AttestationId string                 `protobuf:"bytes,13,opt,name=attestation_id,json=attestationId,proto3" json:"attestation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManualVinRequest) Reset() {
+	*x = ManualVinRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManualVinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManualVinRequest) ProtoMessage() {}
+
+func (x *ManualVinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManualVinRequest.ProtoReflect.Descriptor instead.
+func (*ManualVinRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ManualVinRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *ManualVinRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetEvidenceRef() string {
+	if x != nil {
+		return x.EvidenceRef
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *ManualVinRequest) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *ManualVinRequest) GetReviewReason() string {
+	if x != nil {
+		return x.ReviewReason
+	}
+	return ""
+}
+
+func (x *ManualVinRequest) GetAttestationId() string {
+	if x != nil {
+		return x.AttestationId
+	}
+	return ""
+}
+
+type ApproveManualVinVcRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveManualVinVcRequest) Reset() {
+	*x = ApproveManualVinVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveManualVinVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveManualVinVcRequest) ProtoMessage() {}
+
+func (x *ApproveManualVinVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveManualVinVcRequest.ProtoReflect.Descriptor instead.
+func (*ApproveManualVinVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApproveManualVinVcRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type RejectManualVinVcRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectManualVinVcRequest) Reset() {
+	*x = RejectManualVinVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectManualVinVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectManualVinVcRequest) ProtoMessage() {}
+
+func (x *RejectManualVinVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectManualVinVcRequest.ProtoReflect.Descriptor instead.
+func (*RejectManualVinVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RejectManualVinVcRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RejectManualVinVcRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RejectManualVinVcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *ManualVinRequest      `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectManualVinVcResponse) Reset() {
+	*x = RejectManualVinVcResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectManualVinVcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectManualVinVcResponse) ProtoMessage() {}
+
+func (x *RejectManualVinVcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectManualVinVcResponse.ProtoReflect.Descriptor instead.
+func (*RejectManualVinVcResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RejectManualVinVcResponse) GetRequest() *ManualVinRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type ListManualVinVcAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListManualVinVcAuditRequest) Reset() {
+	*x = ListManualVinVcAuditRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListManualVinVcAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListManualVinVcAuditRequest) ProtoMessage() {}
+
+func (x *ListManualVinVcAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListManualVinVcAuditRequest.ProtoReflect.Descriptor instead.
+func (*ListManualVinVcAuditRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListManualVinVcAuditRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+type ListManualVinVcAuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ManualVinAuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListManualVinVcAuditResponse) Reset() {
+	*x = ListManualVinVcAuditResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListManualVinVcAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListManualVinVcAuditResponse) ProtoMessage() {}
+
+func (x *ListManualVinVcAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListManualVinVcAuditResponse.ProtoReflect.Descriptor instead.
+func (*ListManualVinVcAuditResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListManualVinVcAuditResponse) GetEntries() []*ManualVinAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ManualVinAuditEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TokenId   uint32                 `protobuf:"varint,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	RequestId string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// One of "requested", "approved", "rejected", "issued" or "failed".
+	Action      string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Operator    string `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Vin         string `protobuf:"bytes,6,opt,name=vin,proto3" json:"vin,omitempty"`
+	CountryCode string `protobuf:"bytes,7,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// The reason of the request, or of the rejection for rejected entries.
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	EvidenceRef   string                 `protobuf:"bytes,9,opt,name=evidence_ref,json=evidenceRef,proto3" json:"evidence_ref,omitempty"`
+	AttestationId string                 `protobuf:"bytes,10,opt,name=attestation_id,json=attestationId,proto3" json:"attestation_id,omitempty"`
+	Error         string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManualVinAuditEntry) Reset() {
+	*x = ManualVinAuditEntry{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManualVinAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManualVinAuditEntry) ProtoMessage() {}
+
+func (x *ManualVinAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManualVinAuditEntry.ProtoReflect.Descriptor instead.
+func (*ManualVinAuditEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ManualVinAuditEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *ManualVinAuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetEvidenceRef() string {
+	if x != nil {
+		return x.EvidenceRef
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetAttestationId() string {
+	if x != nil {
+		return x.AttestationId
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ManualVinAuditEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type GetVinVcEligibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVinVcEligibilityRequest) Reset() {
+	*x = GetVinVcEligibilityRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVinVcEligibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVinVcEligibilityRequest) ProtoMessage() {}
+
+func (x *GetVinVcEligibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVinVcEligibilityRequest.ProtoReflect.Descriptor instead.
+func (*GetVinVcEligibilityRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetVinVcEligibilityRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+type GetVinVcEligibilityResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	VehicleDid string                 `protobuf:"bytes,1,opt,name=vehicle_did,json=vehicleDid,proto3" json:"vehicle_did,omitempty"`
+	// True when a VIN VC would be created.
+	Eligible bool `protobuf:"varint,2,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	// The reason code of the decision, e.g. "eligible" or "noFingerprints".
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// The VIN that would be attested.
+	Vin string `protobuf:"bytes,5,opt,name=vin,proto3" json:"vin,omitempty"`
+	// The number of fingerprint messages reporting the VIN.
+	EvidenceCount   int32                `protobuf:"varint,6,opt,name=evidence_count,json=evidenceCount,proto3" json:"evidence_count,omitempty"`
+	ConflictingVins []string             `protobuf:"bytes,7,rep,name=conflicting_vins,json=conflictingVins,proto3" json:"conflicting_vins,omitempty"`
+	Devices         []*DeviceEligibility `protobuf:"bytes,8,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVinVcEligibilityResponse) Reset() {
+	*x = GetVinVcEligibilityResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVinVcEligibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVinVcEligibilityResponse) ProtoMessage() {}
+
+func (x *GetVinVcEligibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVinVcEligibilityResponse.ProtoReflect.Descriptor instead.
+func (*GetVinVcEligibilityResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetVinVcEligibilityResponse) GetVehicleDid() string {
+	if x != nil {
+		return x.VehicleDid
+	}
+	return ""
+}
+
+func (x *GetVinVcEligibilityResponse) GetEligible() bool {
+	if x != nil {
+		return x.Eligible
+	}
+	return false
+}
+
+func (x *GetVinVcEligibilityResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GetVinVcEligibilityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetVinVcEligibilityResponse) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *GetVinVcEligibilityResponse) GetEvidenceCount() int32 {
+	if x != nil {
+		return x.EvidenceCount
+	}
+	return 0
+}
+
+func (x *GetVinVcEligibilityResponse) GetConflictingVins() []string {
+	if x != nil {
+		return x.ConflictingVins
+	}
+	return nil
+}
+
+func (x *GetVinVcEligibilityResponse) GetDevices() []*DeviceEligibility {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type DeviceEligibility struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Did              string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ManufacturerName string                 `protobuf:"bytes,3,opt,name=manufacturer_name,json=manufacturerName,proto3" json:"manufacturer_name,omitempty"`
+	// The outcome of the fingerprint check, e.g. "ok" or "invalidFingerprint".
+	Status              string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error               string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	FingerprintCount    int32                  `protobuf:"varint,6,opt,name=fingerprint_count,json=fingerprintCount,proto3" json:"fingerprint_count,omitempty"`
+	LatestFingerprintAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=latest_fingerprint_at,json=latestFingerprintAt,proto3" json:"latest_fingerprint_at,omitempty"`
+	Vin                 string                 `protobuf:"bytes,8,opt,name=vin,proto3" json:"vin,omitempty"`
+	VinValid            bool                   `protobuf:"varint,9,opt,name=vin_valid,json=vinValid,proto3" json:"vin_valid,omitempty"`
+	SignatureStatus     string                 `protobuf:"bytes,10,opt,name=signature_status,json=signatureStatus,proto3" json:"signature_status,omitempty"`
+	VinModelYear        int32                  `protobuf:"varint,11,opt,name=vin_model_year,json=vinModelYear,proto3" json:"vin_model_year,omitempty"`
+	// Unset when the model year of the VIN or the vehicle definition is unknown.
+	DefinitionMatch *bool  `protobuf:"varint,12,opt,name=definition_match,json=definitionMatch,proto3,oneof" json:"definition_match,omitempty"`
+	VinManufacturer string `protobuf:"bytes,13,opt,name=vin_manufacturer,json=vinManufacturer,proto3" json:"vin_manufacturer,omitempty"`
+	// Unset when the manufacturer of the VIN or the vehicle is unknown.
+	ManufacturerMatch *bool `protobuf:"varint,14,opt,name=manufacturer_match,json=manufacturerMatch,proto3,oneof" json:"manufacturer_match,omitempty"`
+	// ISO 3166-1 alpha-3 code of the country of manufacture.
+	CountryCode   string `protobuf:"bytes,15,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceEligibility) Reset() {
+	*x = DeviceEligibility{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceEligibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceEligibility) ProtoMessage() {}
+
+func (x *DeviceEligibility) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceEligibility.ProtoReflect.Descriptor instead.
+func (*DeviceEligibility) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeviceEligibility) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetManufacturerName() string {
+	if x != nil {
+		return x.ManufacturerName
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetFingerprintCount() int32 {
+	if x != nil {
+		return x.FingerprintCount
+	}
+	return 0
+}
+
+func (x *DeviceEligibility) GetLatestFingerprintAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestFingerprintAt
+	}
+	return nil
+}
+
+func (x *DeviceEligibility) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetVinValid() bool {
+	if x != nil {
+		return x.VinValid
+	}
+	return false
+}
+
+func (x *DeviceEligibility) GetSignatureStatus() string {
+	if x != nil {
+		return x.SignatureStatus
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetVinModelYear() int32 {
+	if x != nil {
+		return x.VinModelYear
+	}
+	return 0
+}
+
+func (x *DeviceEligibility) GetDefinitionMatch() bool {
+	if x != nil && x.DefinitionMatch != nil {
+		return *x.DefinitionMatch
+	}
+	return false
+}
+
+func (x *DeviceEligibility) GetVinManufacturer() string {
+	if x != nil {
+		return x.VinManufacturer
+	}
+	return ""
+}
+
+func (x *DeviceEligibility) GetManufacturerMatch() bool {
+	if x != nil && x.ManufacturerMatch != nil {
+		return *x.ManufacturerMatch
+	}
+	return false
+}
+
+func (x *DeviceEligibility) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type CreateVehiclePositionVcRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// The location closest to this time is attested.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Resolution of the attested H3 cell, 1 to 8. Defaults to 8.
+	H3Resolution  int32 `protobuf:"varint,3,opt,name=h3_resolution,json=h3Resolution,proto3" json:"h3_resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehiclePositionVcRequest) Reset() {
+	*x = CreateVehiclePositionVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehiclePositionVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehiclePositionVcRequest) ProtoMessage() {}
+
+func (x *CreateVehiclePositionVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehiclePositionVcRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehiclePositionVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateVehiclePositionVcRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *CreateVehiclePositionVcRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CreateVehiclePositionVcRequest) GetH3Resolution() int32 {
+	if x != nil {
+		return x.H3Resolution
+	}
+	return 0
+}
+
+type CreateVehiclePositionVcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawVc         string                 `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehiclePositionVcResponse) Reset() {
+	*x = CreateVehiclePositionVcResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehiclePositionVcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehiclePositionVcResponse) ProtoMessage() {}
+
+func (x *CreateVehiclePositionVcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehiclePositionVcResponse.ProtoReflect.Descriptor instead.
+func (*CreateVehiclePositionVcResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateVehiclePositionVcResponse) GetRawVc() string {
+	if x != nil {
+		return x.RawVc
+	}
+	return ""
+}
+
+type CreateOdometerStatementVcRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// Defaults to the latest odometer reading.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Unit of the attested reading, "km" or "miles". Defaults to "km", or "miles" for the us-federal disclosure profile.
+	Unit string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	// Adds regional disclosure fields to the statement, e.g. "us-federal".
+	DisclosureProfile string `protobuf:"bytes,4,opt,name=disclosure_profile,json=disclosureProfile,proto3" json:"disclosure_profile,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateOdometerStatementVcRequest) Reset() {
+	*x = CreateOdometerStatementVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOdometerStatementVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOdometerStatementVcRequest) ProtoMessage() {}
+
+func (x *CreateOdometerStatementVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOdometerStatementVcRequest.ProtoReflect.Descriptor instead.
+func (*CreateOdometerStatementVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateOdometerStatementVcRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *CreateOdometerStatementVcRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CreateOdometerStatementVcRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CreateOdometerStatementVcRequest) GetDisclosureProfile() string {
+	if x != nil {
+		return x.DisclosureProfile
+	}
+	return ""
+}
+
+type CreateOdometerStatementVcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawVc         string                 `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOdometerStatementVcResponse) Reset() {
+	*x = CreateOdometerStatementVcResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOdometerStatementVcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOdometerStatementVcResponse) ProtoMessage() {}
+
+func (x *CreateOdometerStatementVcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOdometerStatementVcResponse.ProtoReflect.Descriptor instead.
+func (*CreateOdometerStatementVcResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateOdometerStatementVcResponse) GetRawVc() string {
+	if x != nil {
+		return x.RawVc
+	}
+	return ""
+}
+
+type CreateVehicleHealthVcRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// The range cannot exceed 366 days.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Name of the health rule set used to score the vehicle. Defaults to "default".
+	RuleSet       string `protobuf:"bytes,4,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehicleHealthVcRequest) Reset() {
+	*x = CreateVehicleHealthVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehicleHealthVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleHealthVcRequest) ProtoMessage() {}
+
+func (x *CreateVehicleHealthVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleHealthVcRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehicleHealthVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateVehicleHealthVcRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *CreateVehicleHealthVcRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateVehicleHealthVcRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CreateVehicleHealthVcRequest) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+type CreateVehicleHealthVcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawVc         string                 `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehicleHealthVcResponse) Reset() {
+	*x = CreateVehicleHealthVcResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehicleHealthVcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleHealthVcResponse) ProtoMessage() {}
+
+func (x *CreateVehicleHealthVcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleHealthVcResponse.ProtoReflect.Descriptor instead.
+func (*CreateVehicleHealthVcResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateVehicleHealthVcResponse) GetRawVc() string {
+	if x != nil {
+		return x.RawVc
+	}
+	return ""
+}
+
+type CreateBatteryHealthVcRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint32                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// The range cannot exceed 90 days.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBatteryHealthVcRequest) Reset() {
+	*x = CreateBatteryHealthVcRequest{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBatteryHealthVcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatteryHealthVcRequest) ProtoMessage() {}
+
+func (x *CreateBatteryHealthVcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatteryHealthVcRequest.ProtoReflect.Descriptor instead.
+func (*CreateBatteryHealthVcRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateBatteryHealthVcRequest) GetTokenId() uint32 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *CreateBatteryHealthVcRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateBatteryHealthVcRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type CreateBatteryHealthVcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawVc         string                 `protobuf:"bytes,1,opt,name=raw_vc,json=rawVc,proto3" json:"raw_vc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBatteryHealthVcResponse) Reset() {
+	*x = CreateBatteryHealthVcResponse{}
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBatteryHealthVcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatteryHealthVcResponse) ProtoMessage() {}
+
+func (x *CreateBatteryHealthVcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_atttestation_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatteryHealthVcResponse.ProtoReflect.Descriptor instead.
+func (*CreateBatteryHealthVcResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_atttestation_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateBatteryHealthVcResponse) GetRawVc() string {
+	if x != nil {
+		return x.RawVc
+	}
+	return ""
+}
+
+var File_pkg_grpc_atttestation_api_proto protoreflect.FileDescriptor
+
+const file_pkg_grpc_atttestation_api_proto_rawDesc = "" +
+	"\n" +
+	"\x1fpkg/grpc/atttestation-api.proto\x12\x04grpc\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
+	"\x12EnsureVinVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x122\n" +
+	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\",\n" +
+	"\x13EnsureVinVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"2\n" +
+	"\x15GetLatestVinVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\"/\n" +
+	"\x16GetLatestVinVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"5\n" +
+	"\x18TestVinVcCreationRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\"2\n" +
+	"\x19TestVinVcCreationResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"\xa7\x01\n" +
+	"\x1aManualVinVcCreationRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x12\x10\n" +
+	"\x03vin\x18\x02 \x01(\tR\x03vin\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\fevidence_ref\x18\x05 \x01(\tR\vevidenceRef\"f\n" +
+	"\x1bManualVinVcCreationResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\x120\n" +
+	"\arequest\x18\x02 \x01(\v2\x16.grpc.ManualVinRequestR\arequest\"\xd1\x03\n" +
+	"\x10ManualVinRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\rR\atokenId\x12\x10\n" +
+	"\x03vin\x18\x03 \x01(\tR\x03vin\x12!\n" +
+	"\fcountry_code\x18\x04 \x01(\tR\vcountryCode\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12!\n" +
+	"\fevidence_ref\x18\x06 \x01(\tR\vevidenceRef\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12!\n" +
+	"\frequested_by\x18\b \x01(\tR\vrequestedBy\x12=\n" +
+	"\frequested_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12\x1f\n" +
+	"\vreviewed_by\x18\n" +
+	" \x01(\tR\n" +
+	"reviewedBy\x12;\n" +
+	"\vreviewed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x12#\n" +
+	"\rreview_reason\x18\f \x01(\tR\freviewReason\x12%\n" +
+	"\x0eattestation_id\x18\r \x01(\tR\rattestationId\":\n" +
+	"\x19ApproveManualVinVcRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"Q\n" +
+	"\x18RejectManualVinVcRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"M\n" +
+	"\x19RejectManualVinVcResponse\x120\n" +
+	"\arequest\x18\x01 \x01(\v2\x16.grpc.ManualVinRequestR\arequest\"8\n" +
+	"\x1bListManualVinVcAuditRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\"S\n" +
+	"\x1cListManualVinVcAuditResponse\x123\n" +
+	"\aentries\x18\x01 \x03(\v2\x19.grpc.ManualVinAuditEntryR\aentries\"\xf0\x02\n" +
+	"\x13ManualVinAuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\rR\atokenId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x10\n" +
+	"\x03vin\x18\x06 \x01(\tR\x03vin\x12!\n" +
+	"\fcountry_code\x18\a \x01(\tR\vcountryCode\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12!\n" +
+	"\fevidence_ref\x18\t \x01(\tR\vevidenceRef\x12%\n" +
+	"\x0eattestation_id\x18\n" +
+	" \x01(\tR\rattestationId\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x12.\n" +
+	"\x04time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"7\n" +
+	"\x1aGetVinVcEligibilityRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\"\xa3\x02\n" +
+	"\x1bGetVinVcEligibilityResponse\x12\x1f\n" +
+	"\vvehicle_did\x18\x01 \x01(\tR\n" +
+	"vehicleDid\x12\x1a\n" +
+	"\beligible\x18\x02 \x01(\bR\beligible\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x10\n" +
+	"\x03vin\x18\x05 \x01(\tR\x03vin\x12%\n" +
+	"\x0eevidence_count\x18\x06 \x01(\x05R\revidenceCount\x12)\n" +
+	"\x10conflicting_vins\x18\a \x03(\tR\x0fconflictingVins\x121\n" +
+	"\adevices\x18\b \x03(\v2\x17.grpc.DeviceEligibilityR\adevices\"\xef\x04\n" +
+	"\x11DeviceEligibility\x12\x10\n" +
+	"\x03did\x18\x01 \x01(\tR\x03did\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12+\n" +
+	"\x11manufacturer_name\x18\x03 \x01(\tR\x10manufacturerName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12+\n" +
+	"\x11fingerprint_count\x18\x06 \x01(\x05R\x10fingerprintCount\x12N\n" +
+	"\x15latest_fingerprint_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x13latestFingerprintAt\x12\x10\n" +
+	"\x03vin\x18\b \x01(\tR\x03vin\x12\x1b\n" +
+	"\tvin_valid\x18\t \x01(\bR\bvinValid\x12)\n" +
+	"\x10signature_status\x18\n" +
+	" \x01(\tR\x0fsignatureStatus\x12$\n" +
+	"\x0evin_model_year\x18\v \x01(\x05R\fvinModelYear\x12.\n" +
+	"\x10definition_match\x18\f \x01(\bH\x00R\x0fdefinitionMatch\x88\x01\x01\x12)\n" +
+	"\x10vin_manufacturer\x18\r \x01(\tR\x0fvinManufacturer\x122\n" +
+	"\x12manufacturer_match\x18\x0e \x01(\bH\x01R\x11manufacturerMatch\x88\x01\x01\x12!\n" +
+	"\fcountry_code\x18\x0f \x01(\tR\vcountryCodeB\x13\n" +
+	"\x11_definition_matchB\x15\n" +
+	"\x13_manufacturer_match\"\x9a\x01\n" +
+	"\x1eCreateVehiclePositionVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12#\n" +
+	"\rh3_resolution\x18\x03 \x01(\x05R\fh3Resolution\"8\n" +
+	"\x1fCreateVehiclePositionVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"\xba\x01\n" +
+	" CreateOdometerStatementVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12-\n" +
+	"\x12disclosure_profile\x18\x04 \x01(\tR\x11disclosureProfile\":\n" +
+	"!CreateOdometerStatementVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"\xc6\x01\n" +
+	"\x1cCreateVehicleHealthVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x19\n" +
+	"\brule_set\x18\x04 \x01(\tR\aruleSet\"6\n" +
+	"\x1dCreateVehicleHealthVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc\"\xab\x01\n" +
+	"\x1cCreateBatteryHealthVcRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"6\n" +
+	"\x1dCreateBatteryHealthVcResponse\x12\x15\n" +
+	"\x06raw_vc\x18\x01 \x01(\tR\x05rawVc2\xdc\b\n" +
 	"\x12AttestationService\x12B\n" +
 	"\vEnsureVinVc\x12\x18.grpc.EnsureVinVcRequest\x1a\x19.grpc.EnsureVinVcResponse\x12K\n" +
 	"\x0eGetVinVcLatest\x12\x1b.grpc.GetLatestVinVcRequest\x1a\x1c.grpc.GetLatestVinVcResponse\x12T\n" +
 	"\x11TestVinVcCreation\x12\x1e.grpc.TestVinVcCreationRequest\x1a\x1f.grpc.TestVinVcCreationResponse\x12Z\n" +
-	"\x13ManualVinVcCreation\x12 .grpc.ManualVinVcCreationRequest\x1a!.grpc.ManualVinVcCreationResponseB2Z0github.com/DIMO-Network/attestation-api/pkg/grpcb\x06proto3"
+	"\x13ManualVinVcCreation\x12 .grpc.ManualVinVcCreationRequest\x1a!.grpc.ManualVinVcCreationResponse\x12Z\n" +
+	"\x13GetVinVcEligibility\x12 .grpc.GetVinVcEligibilityRequest\x1a!.grpc.GetVinVcEligibilityResponse\x12X\n" +
+	"\x12ApproveManualVinVc\x12\x1f.grpc.ApproveManualVinVcRequest\x1a!.grpc.ManualVinVcCreationResponse\x12T\n" +
+	"\x11RejectManualVinVc\x12\x1e.grpc.RejectManualVinVcRequest\x1a\x1f.grpc.RejectManualVinVcResponse\x12]\n" +
+	"\x14ListManualVinVcAudit\x12!.grpc.ListManualVinVcAuditRequest\x1a\".grpc.ListManualVinVcAuditResponse\x12f\n" +
+	"\x17CreateVehiclePositionVc\x12$.grpc.CreateVehiclePositionVcRequest\x1a%.grpc.CreateVehiclePositionVcResponse\x12l\n" +
+	"\x19CreateOdometerStatementVc\x12&.grpc.CreateOdometerStatementVcRequest\x1a'.grpc.CreateOdometerStatementVcResponse\x12`\n" +
+	"\x15CreateVehicleHealthVc\x12\".grpc.CreateVehicleHealthVcRequest\x1a#.grpc.CreateVehicleHealthVcResponse\x12`\n" +
+	"\x15CreateBatteryHealthVc\x12\".grpc.CreateBatteryHealthVcRequest\x1a#.grpc.CreateBatteryHealthVcResponseB2Z0github.com/DIMO-Network/attestation-api/pkg/grpcb\x06proto3"
 
 var (
 	file_pkg_grpc_atttestation_api_proto_rawDescOnce sync.Once
@@ -452,33 +1846,81 @@ func file_pkg_grpc_atttestation_api_proto_rawDescGZIP() []byte {
 	return file_pkg_grpc_atttestation_api_proto_rawDescData
 }
 
-var file_pkg_grpc_atttestation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pkg_grpc_atttestation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_pkg_grpc_atttestation_api_proto_goTypes = []any{
-	(*EnsureVinVcRequest)(nil),          // 0: grpc.EnsureVinVcRequest
-	(*EnsureVinVcResponse)(nil),         // 1: grpc.EnsureVinVcResponse
-	(*GetLatestVinVcRequest)(nil),       // 2: grpc.GetLatestVinVcRequest
-	(*GetLatestVinVcResponse)(nil),      // 3: grpc.GetLatestVinVcResponse
-	(*TestVinVcCreationRequest)(nil),    // 4: grpc.TestVinVcCreationRequest
-	(*TestVinVcCreationResponse)(nil),   // 5: grpc.TestVinVcCreationResponse
-	(*ManualVinVcCreationRequest)(nil),  // 6: grpc.ManualVinVcCreationRequest
-	(*ManualVinVcCreationResponse)(nil), // 7: grpc.ManualVinVcCreationResponse
-	(*timestamppb.Timestamp)(nil),       // 8: google.protobuf.Timestamp
+	(*EnsureVinVcRequest)(nil),                // 0: grpc.EnsureVinVcRequest
+	(*EnsureVinVcResponse)(nil),               // 1: grpc.EnsureVinVcResponse
+	(*GetLatestVinVcRequest)(nil),             // 2: grpc.GetLatestVinVcRequest
+	(*GetLatestVinVcResponse)(nil),            // 3: grpc.GetLatestVinVcResponse
+	(*TestVinVcCreationRequest)(nil),          // 4: grpc.TestVinVcCreationRequest
+	(*TestVinVcCreationResponse)(nil),         // 5: grpc.TestVinVcCreationResponse
+	(*ManualVinVcCreationRequest)(nil),        // 6: grpc.ManualVinVcCreationRequest
+	(*ManualVinVcCreationResponse)(nil),       // 7: grpc.ManualVinVcCreationResponse
+	(*ManualVinRequest)(nil),                  // 8: grpc.ManualVinRequest
+	(*ApproveManualVinVcRequest)(nil),         // 9: grpc.ApproveManualVinVcRequest
+	(*RejectManualVinVcRequest)(nil),          // 10: grpc.RejectManualVinVcRequest
+	(*RejectManualVinVcResponse)(nil),         // 11: grpc.RejectManualVinVcResponse
+	(*ListManualVinVcAuditRequest)(nil),       // 12: grpc.ListManualVinVcAuditRequest
+	(*ListManualVinVcAuditResponse)(nil),      // 13: grpc.ListManualVinVcAuditResponse
+	(*ManualVinAuditEntry)(nil),               // 14: grpc.ManualVinAuditEntry
+	(*GetVinVcEligibilityRequest)(nil),        // 15: grpc.GetVinVcEligibilityRequest
+	(*GetVinVcEligibilityResponse)(nil),       // 16: grpc.GetVinVcEligibilityResponse
+	(*DeviceEligibility)(nil),                 // 17: grpc.DeviceEligibility
+	(*CreateVehiclePositionVcRequest)(nil),    // 18: grpc.CreateVehiclePositionVcRequest
+	(*CreateVehiclePositionVcResponse)(nil),   // 19: grpc.CreateVehiclePositionVcResponse
+	(*CreateOdometerStatementVcRequest)(nil),  // 20: grpc.CreateOdometerStatementVcRequest
+	(*CreateOdometerStatementVcResponse)(nil), // 21: grpc.CreateOdometerStatementVcResponse
+	(*CreateVehicleHealthVcRequest)(nil),      // 22: grpc.CreateVehicleHealthVcRequest
+	(*CreateVehicleHealthVcResponse)(nil),     // 23: grpc.CreateVehicleHealthVcResponse
+	(*CreateBatteryHealthVcRequest)(nil),      // 24: grpc.CreateBatteryHealthVcRequest
+	(*CreateBatteryHealthVcResponse)(nil),     // 25: grpc.CreateBatteryHealthVcResponse
+	(*timestamppb.Timestamp)(nil),             // 26: google.protobuf.Timestamp
 }
 var file_pkg_grpc_atttestation_api_proto_depIdxs = []int32{
-	8, // 0: grpc.EnsureVinVcRequest.before:type_name -> google.protobuf.Timestamp
-	0, // 1: grpc.AttestationService.EnsureVinVc:input_type -> grpc.EnsureVinVcRequest
-	2, // 2: grpc.AttestationService.GetVinVcLatest:input_type -> grpc.GetLatestVinVcRequest
-	4, // 3: grpc.AttestationService.TestVinVcCreation:input_type -> grpc.TestVinVcCreationRequest
-	6, // 4: grpc.AttestationService.ManualVinVcCreation:input_type -> grpc.ManualVinVcCreationRequest
-	1, // 5: grpc.AttestationService.EnsureVinVc:output_type -> grpc.EnsureVinVcResponse
-	3, // 6: grpc.AttestationService.GetVinVcLatest:output_type -> grpc.GetLatestVinVcResponse
-	5, // 7: grpc.AttestationService.TestVinVcCreation:output_type -> grpc.TestVinVcCreationResponse
-	7, // 8: grpc.AttestationService.ManualVinVcCreation:output_type -> grpc.ManualVinVcCreationResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	26, // 0: grpc.EnsureVinVcRequest.before:type_name -> google.protobuf.Timestamp
+	8,  // 1: grpc.ManualVinVcCreationResponse.request:type_name -> grpc.ManualVinRequest
+	26, // 2: grpc.ManualVinRequest.requested_at:type_name -> google.protobuf.Timestamp
+	26, // 3: grpc.ManualVinRequest.reviewed_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: grpc.RejectManualVinVcResponse.request:type_name -> grpc.ManualVinRequest
+	14, // 5: grpc.ListManualVinVcAuditResponse.entries:type_name -> grpc.ManualVinAuditEntry
+	26, // 6: grpc.ManualVinAuditEntry.time:type_name -> google.protobuf.Timestamp
+	17, // 7: grpc.GetVinVcEligibilityResponse.devices:type_name -> grpc.DeviceEligibility
+	26, // 8: grpc.DeviceEligibility.latest_fingerprint_at:type_name -> google.protobuf.Timestamp
+	26, // 9: grpc.CreateVehiclePositionVcRequest.timestamp:type_name -> google.protobuf.Timestamp
+	26, // 10: grpc.CreateOdometerStatementVcRequest.timestamp:type_name -> google.protobuf.Timestamp
+	26, // 11: grpc.CreateVehicleHealthVcRequest.start_time:type_name -> google.protobuf.Timestamp
+	26, // 12: grpc.CreateVehicleHealthVcRequest.end_time:type_name -> google.protobuf.Timestamp
+	26, // 13: grpc.CreateBatteryHealthVcRequest.start_time:type_name -> google.protobuf.Timestamp
+	26, // 14: grpc.CreateBatteryHealthVcRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 15: grpc.AttestationService.EnsureVinVc:input_type -> grpc.EnsureVinVcRequest
+	2,  // 16: grpc.AttestationService.GetVinVcLatest:input_type -> grpc.GetLatestVinVcRequest
+	4,  // 17: grpc.AttestationService.TestVinVcCreation:input_type -> grpc.TestVinVcCreationRequest
+	6,  // 18: grpc.AttestationService.ManualVinVcCreation:input_type -> grpc.ManualVinVcCreationRequest
+	15, // 19: grpc.AttestationService.GetVinVcEligibility:input_type -> grpc.GetVinVcEligibilityRequest
+	9,  // 20: grpc.AttestationService.ApproveManualVinVc:input_type -> grpc.ApproveManualVinVcRequest
+	10, // 21: grpc.AttestationService.RejectManualVinVc:input_type -> grpc.RejectManualVinVcRequest
+	12, // 22: grpc.AttestationService.ListManualVinVcAudit:input_type -> grpc.ListManualVinVcAuditRequest
+	18, // 23: grpc.AttestationService.CreateVehiclePositionVc:input_type -> grpc.CreateVehiclePositionVcRequest
+	20, // 24: grpc.AttestationService.CreateOdometerStatementVc:input_type -> grpc.CreateOdometerStatementVcRequest
+	22, // 25: grpc.AttestationService.CreateVehicleHealthVc:input_type -> grpc.CreateVehicleHealthVcRequest
+	24, // 26: grpc.AttestationService.CreateBatteryHealthVc:input_type -> grpc.CreateBatteryHealthVcRequest
+	1,  // 27: grpc.AttestationService.EnsureVinVc:output_type -> grpc.EnsureVinVcResponse
+	3,  // 28: grpc.AttestationService.GetVinVcLatest:output_type -> grpc.GetLatestVinVcResponse
+	5,  // 29: grpc.AttestationService.TestVinVcCreation:output_type -> grpc.TestVinVcCreationResponse
+	7,  // 30: grpc.AttestationService.ManualVinVcCreation:output_type -> grpc.ManualVinVcCreationResponse
+	16, // 31: grpc.AttestationService.GetVinVcEligibility:output_type -> grpc.GetVinVcEligibilityResponse
+	7,  // 32: grpc.AttestationService.ApproveManualVinVc:output_type -> grpc.ManualVinVcCreationResponse
+	11, // 33: grpc.AttestationService.RejectManualVinVc:output_type -> grpc.RejectManualVinVcResponse
+	13, // 34: grpc.AttestationService.ListManualVinVcAudit:output_type -> grpc.ListManualVinVcAuditResponse
+	19, // 35: grpc.AttestationService.CreateVehiclePositionVc:output_type -> grpc.CreateVehiclePositionVcResponse
+	21, // 36: grpc.AttestationService.CreateOdometerStatementVc:output_type -> grpc.CreateOdometerStatementVcResponse
+	23, // 37: grpc.AttestationService.CreateVehicleHealthVc:output_type -> grpc.CreateVehicleHealthVcResponse
+	25, // 38: grpc.AttestationService.CreateBatteryHealthVc:output_type -> grpc.CreateBatteryHealthVcResponse
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_pkg_grpc_atttestation_api_proto_init() }
@@ -486,13 +1928,14 @@ func file_pkg_grpc_atttestation_api_proto_init() {
 	if File_pkg_grpc_atttestation_api_proto != nil {
 		return
 	}
+	file_pkg_grpc_atttestation_api_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_grpc_atttestation_api_proto_rawDesc), len(file_pkg_grpc_atttestation_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AttestationService_EnsureVinVc_FullMethodName         = "/grpc.AttestationService/EnsureVinVc"
-	AttestationService_GetVinVcLatest_FullMethodName      = "/grpc.AttestationService/GetVinVcLatest"
-	AttestationService_TestVinVcCreation_FullMethodName   = "/grpc.AttestationService/TestVinVcCreation"
-	AttestationService_ManualVinVcCreation_FullMethodName = "/grpc.AttestationService/ManualVinVcCreation"
+	AttestationService_EnsureVinVc_FullMethodName               = "/grpc.AttestationService/EnsureVinVc"
+	AttestationService_GetVinVcLatest_FullMethodName            = "/grpc.AttestationService/GetVinVcLatest"
+	AttestationService_TestVinVcCreation_FullMethodName         = "/grpc.AttestationService/TestVinVcCreation"
+	AttestationService_ManualVinVcCreation_FullMethodName       = "/grpc.AttestationService/ManualVinVcCreation"
+	AttestationService_GetVinVcEligibility_FullMethodName       = "/grpc.AttestationService/GetVinVcEligibility"
+	AttestationService_ApproveManualVinVc_FullMethodName        = "/grpc.AttestationService/ApproveManualVinVc"
+	AttestationService_RejectManualVinVc_FullMethodName         = "/grpc.AttestationService/RejectManualVinVc"
+	AttestationService_ListManualVinVcAudit_FullMethodName      = "/grpc.AttestationService/ListManualVinVcAudit"
+	AttestationService_CreateVehiclePositionVc_FullMethodName   = "/grpc.AttestationService/CreateVehiclePositionVc"
+	AttestationService_CreateOdometerStatementVc_FullMethodName = "/grpc.AttestationService/CreateOdometerStatementVc"
+	AttestationService_CreateVehicleHealthVc_FullMethodName     = "/grpc.AttestationService/CreateVehicleHealthVc"
+	AttestationService_CreateBatteryHealthVc_FullMethodName     = "/grpc.AttestationService/CreateBatteryHealthVc"
 )
 
 // AttestationServiceClient is the client API for AttestationService service.
@@ -33,6 +41,16 @@ type AttestationServiceClient interface {
 	GetVinVcLatest(ctx context.Context, in *GetLatestVinVcRequest, opts ...grpc.CallOption) (*GetLatestVinVcResponse, error)
 	TestVinVcCreation(ctx context.Context, in *TestVinVcCreationRequest, opts ...grpc.CallOption) (*TestVinVcCreationResponse, error)
 	ManualVinVcCreation(ctx context.Context, in *ManualVinVcCreationRequest, opts ...grpc.CallOption) (*ManualVinVcCreationResponse, error)
+	GetVinVcEligibility(ctx context.Context, in *GetVinVcEligibilityRequest, opts ...grpc.CallOption) (*GetVinVcEligibilityResponse, error)
+	// The manual VIN RPCs require an operator token with the admin role in the authorization metadata.
+	ApproveManualVinVc(ctx context.Context, in *ApproveManualVinVcRequest, opts ...grpc.CallOption) (*ManualVinVcCreationResponse, error)
+	RejectManualVinVc(ctx context.Context, in *RejectManualVinVcRequest, opts ...grpc.CallOption) (*RejectManualVinVcResponse, error)
+	ListManualVinVcAudit(ctx context.Context, in *ListManualVinVcAuditRequest, opts ...grpc.CallOption) (*ListManualVinVcAuditResponse, error)
+	// The telemetry RPCs read the vehicle's telemetry with the token-exchange token in the authorization metadata.
+	CreateVehiclePositionVc(ctx context.Context, in *CreateVehiclePositionVcRequest, opts ...grpc.CallOption) (*CreateVehiclePositionVcResponse, error)
+	CreateOdometerStatementVc(ctx context.Context, in *CreateOdometerStatementVcRequest, opts ...grpc.CallOption) (*CreateOdometerStatementVcResponse, error)
+	CreateVehicleHealthVc(ctx context.Context, in *CreateVehicleHealthVcRequest, opts ...grpc.CallOption) (*CreateVehicleHealthVcResponse, error)
+	CreateBatteryHealthVc(ctx context.Context, in *CreateBatteryHealthVcRequest, opts ...grpc.CallOption) (*CreateBatteryHealthVcResponse, error)
 }
 
 type attestationServiceClient struct {
@@ -83,6 +101,86 @@ func (c *attestationServiceClient) ManualVinVcCreation(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *attestationServiceClient) GetVinVcEligibility(ctx context.Context, in *GetVinVcEligibilityRequest, opts ...grpc.CallOption) (*GetVinVcEligibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVinVcEligibilityResponse)
+	err := c.cc.Invoke(ctx, AttestationService_GetVinVcEligibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) ApproveManualVinVc(ctx context.Context, in *ApproveManualVinVcRequest, opts ...grpc.CallOption) (*ManualVinVcCreationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ManualVinVcCreationResponse)
+	err := c.cc.Invoke(ctx, AttestationService_ApproveManualVinVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) RejectManualVinVc(ctx context.Context, in *RejectManualVinVcRequest, opts ...grpc.CallOption) (*RejectManualVinVcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectManualVinVcResponse)
+	err := c.cc.Invoke(ctx, AttestationService_RejectManualVinVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) ListManualVinVcAudit(ctx context.Context, in *ListManualVinVcAuditRequest, opts ...grpc.CallOption) (*ListManualVinVcAuditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListManualVinVcAuditResponse)
+	err := c.cc.Invoke(ctx, AttestationService_ListManualVinVcAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) CreateVehiclePositionVc(ctx context.Context, in *CreateVehiclePositionVcRequest, opts ...grpc.CallOption) (*CreateVehiclePositionVcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVehiclePositionVcResponse)
+	err := c.cc.Invoke(ctx, AttestationService_CreateVehiclePositionVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) CreateOdometerStatementVc(ctx context.Context, in *CreateOdometerStatementVcRequest, opts ...grpc.CallOption) (*CreateOdometerStatementVcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOdometerStatementVcResponse)
+	err := c.cc.Invoke(ctx, AttestationService_CreateOdometerStatementVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) CreateVehicleHealthVc(ctx context.Context, in *CreateVehicleHealthVcRequest, opts ...grpc.CallOption) (*CreateVehicleHealthVcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVehicleHealthVcResponse)
+	err := c.cc.Invoke(ctx, AttestationService_CreateVehicleHealthVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestationServiceClient) CreateBatteryHealthVc(ctx context.Context, in *CreateBatteryHealthVcRequest, opts ...grpc.CallOption) (*CreateBatteryHealthVcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBatteryHealthVcResponse)
+	err := c.cc.Invoke(ctx, AttestationService_CreateBatteryHealthVc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AttestationServiceServer is the server API for AttestationService service.
 // All implementations must embed UnimplementedAttestationServiceServer
 // for forward compatibility.
@@ -91,6 +189,16 @@ type AttestationServiceServer interface {
 	GetVinVcLatest(context.Context, *GetLatestVinVcRequest) (*GetLatestVinVcResponse, error)
 	TestVinVcCreation(context.Context, *TestVinVcCreationRequest) (*TestVinVcCreationResponse, error)
 	ManualVinVcCreation(context.Context, *ManualVinVcCreationRequest) (*ManualVinVcCreationResponse, error)
+	GetVinVcEligibility(context.Context, *GetVinVcEligibilityRequest) (*GetVinVcEligibilityResponse, error)
+	// The manual VIN RPCs require an operator token with the admin role in the authorization metadata.
+	ApproveManualVinVc(context.Context, *ApproveManualVinVcRequest) (*ManualVinVcCreationResponse, error)
+	RejectManualVinVc(context.Context, *RejectManualVinVcRequest) (*RejectManualVinVcResponse, error)
+	ListManualVinVcAudit(context.Context, *ListManualVinVcAuditRequest) (*ListManualVinVcAuditResponse, error)
+	// The telemetry RPCs read the vehicle's telemetry with the token-exchange token in the authorization metadata.
+	CreateVehiclePositionVc(context.Context, *CreateVehiclePositionVcRequest) (*CreateVehiclePositionVcResponse, error)
+	CreateOdometerStatementVc(context.Context, *CreateOdometerStatementVcRequest) (*CreateOdometerStatementVcResponse, error)
+	CreateVehicleHealthVc(context.Context, *CreateVehicleHealthVcRequest) (*CreateVehicleHealthVcResponse, error)
+	CreateBatteryHealthVc(context.Context, *CreateBatteryHealthVcRequest) (*CreateBatteryHealthVcResponse, error)
 	mustEmbedUnimplementedAttestationServiceServer()
 }
 
@@ -113,6 +221,30 @@ func (UnimplementedAttestationServiceServer) TestVinVcCreation(context.Context,
 func (UnimplementedAttestationServiceServer) ManualVinVcCreation(context.Context, *ManualVinVcCreationRequest) (*ManualVinVcCreationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ManualVinVcCreation not implemented")
 }
+func (UnimplementedAttestationServiceServer) GetVinVcEligibility(context.Context, *GetVinVcEligibilityRequest) (*GetVinVcEligibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVinVcEligibility not implemented")
+}
+func (UnimplementedAttestationServiceServer) ApproveManualVinVc(context.Context, *ApproveManualVinVcRequest) (*ManualVinVcCreationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveManualVinVc not implemented")
+}
+func (UnimplementedAttestationServiceServer) RejectManualVinVc(context.Context, *RejectManualVinVcRequest) (*RejectManualVinVcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectManualVinVc not implemented")
+}
+func (UnimplementedAttestationServiceServer) ListManualVinVcAudit(context.Context, *ListManualVinVcAuditRequest) (*ListManualVinVcAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListManualVinVcAudit not implemented")
+}
+func (UnimplementedAttestationServiceServer) CreateVehiclePositionVc(context.Context, *CreateVehiclePositionVcRequest) (*CreateVehiclePositionVcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehiclePositionVc not implemented")
+}
+func (UnimplementedAttestationServiceServer) CreateOdometerStatementVc(context.Context, *CreateOdometerStatementVcRequest) (*CreateOdometerStatementVcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOdometerStatementVc not implemented")
+}
+func (UnimplementedAttestationServiceServer) CreateVehicleHealthVc(context.Context, *CreateVehicleHealthVcRequest) (*CreateVehicleHealthVcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicleHealthVc not implemented")
+}
+func (UnimplementedAttestationServiceServer) CreateBatteryHealthVc(context.Context, *CreateBatteryHealthVcRequest) (*CreateBatteryHealthVcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatteryHealthVc not implemented")
+}
 func (UnimplementedAttestationServiceServer) mustEmbedUnimplementedAttestationServiceServer() {}
 func (UnimplementedAttestationServiceServer) testEmbeddedByValue()                            {}
 
@@ -206,6 +338,150 @@ func _AttestationService_ManualVinVcCreation_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttestationService_GetVinVcEligibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVinVcEligibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).GetVinVcEligibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_GetVinVcEligibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).GetVinVcEligibility(ctx, req.(*GetVinVcEligibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_ApproveManualVinVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveManualVinVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).ApproveManualVinVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_ApproveManualVinVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).ApproveManualVinVc(ctx, req.(*ApproveManualVinVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_RejectManualVinVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectManualVinVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).RejectManualVinVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_RejectManualVinVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).RejectManualVinVc(ctx, req.(*RejectManualVinVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_ListManualVinVcAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListManualVinVcAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).ListManualVinVcAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_ListManualVinVcAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).ListManualVinVcAudit(ctx, req.(*ListManualVinVcAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_CreateVehiclePositionVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehiclePositionVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).CreateVehiclePositionVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_CreateVehiclePositionVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).CreateVehiclePositionVc(ctx, req.(*CreateVehiclePositionVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_CreateOdometerStatementVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOdometerStatementVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).CreateOdometerStatementVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_CreateOdometerStatementVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).CreateOdometerStatementVc(ctx, req.(*CreateOdometerStatementVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_CreateVehicleHealthVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehicleHealthVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).CreateVehicleHealthVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_CreateVehicleHealthVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).CreateVehicleHealthVc(ctx, req.(*CreateVehicleHealthVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestationService_CreateBatteryHealthVc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBatteryHealthVcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestationServiceServer).CreateBatteryHealthVc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestationService_CreateBatteryHealthVc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestationServiceServer).CreateBatteryHealthVc(ctx, req.(*CreateBatteryHealthVcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AttestationService_ServiceDesc is the grpc.ServiceDesc for AttestationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +505,38 @@ var AttestationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ManualVinVcCreation",
 			Handler:    _AttestationService_ManualVinVcCreation_Handler,
 		},
+		{
+			MethodName: "GetVinVcEligibility",
+			Handler:    _AttestationService_GetVinVcEligibility_Handler,
+		},
+		{
+			MethodName: "ApproveManualVinVc",
+			Handler:    _AttestationService_ApproveManualVinVc_Handler,
+		},
+		{
+			MethodName: "RejectManualVinVc",
+			Handler:    _AttestationService_RejectManualVinVc_Handler,
+		},
+		{
+			MethodName: "ListManualVinVcAudit",
+			Handler:    _AttestationService_ListManualVinVcAudit_Handler,
+		},
+		{
+			MethodName: "CreateVehiclePositionVc",
+			Handler:    _AttestationService_CreateVehiclePositionVc_Handler,
+		},
+		{
+			MethodName: "CreateOdometerStatementVc",
+			Handler:    _AttestationService_CreateOdometerStatementVc_Handler,
+		},
+		{
+			MethodName: "CreateVehicleHealthVc",
+			Handler:    _AttestationService_CreateVehicleHealthVc_Handler,
+		},
+		{
+			MethodName: "CreateBatteryHealthVc",
+			Handler:    _AttestationService_CreateBatteryHealthVc_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/grpc/atttestation-api.proto",
@@ -30,8 +30,26 @@ type VINSubject struct {
 	RecordedAt time.Time `json:"recordedAt,omitempty"`
 	// CountryCode that VIN belongs to.
 	CountryCode string `json:"countryCode,omitempty"`
+	// EvidenceCount is the number of fingerprint messages that reported the VIN.
+	EvidenceCount int `json:"evidenceCount,omitempty"`
+	// SourcesAgree reports whether every fingerprint message considered reported the same VIN.
+	// It is not set for manually recorded VINs.
+	SourcesAgree *bool `json:"sourcesAgree,omitempty"`
+	// ConflictingVINs are the other VINs reported by fingerprint messages.
+	ConflictingVINs []string `json:"conflictingVins,omitempty"`
+	// SignatureStatus is the outcome of verifying the signature of the fingerprint message the VIN is recorded from.
+	SignatureStatus string `json:"signatureStatus,omitempty"`
 }
 
+const (
+	// SignatureStatusVerified is a message signed by the paired device it was produced by.
+	SignatureStatusVerified = "verified"
+	// SignatureStatusUnsigned is a message without a signature from a device that signs its messages.
+	SignatureStatusUnsigned = "unsigned"
+	// SignatureStatusNotApplicable is a message from a source that does not sign its messages, such as a synthetic device.
+	SignatureStatusNotApplicable = "notApplicable"
+)
+
 const (
 	// LocationTypeCellID represents the cell ID location type.
 	LocationTypeCellID = "cellId"
@@ -135,6 +153,20 @@ type VehiclePositionVCSubject struct {
 	RequestedTimestamp time.Time `json:"requestedTimestamp"`
 	// Producer is the entity that produced the location data.
 	Producer string `json:"producer,omitempty"`
+	// DataSource is the integration that produced the location data.
+	DataSource *DataSource `json:"dataSource,omitempty"`
+}
+
+// DataSource identifies the integration that produced attested telemetry values.
+type DataSource struct {
+	// Source is the address of the connection that ingested the values.
+	Source string `json:"source"`
+	// Producer is the DID of the device that produced the values.
+	Producer string `json:"producer,omitempty"`
+	// DeviceType is the type of the producing device, "aftermarket" or "synthetic".
+	DeviceType string `json:"deviceType,omitempty"`
+	// ManufacturerName is the manufacturer of the producing aftermarket device.
+	ManufacturerName string `json:"manufacturerName,omitempty"`
 }
 
 // TimeRange represents a time range.
@@ -155,8 +187,20 @@ type OdometerStatementVCSubject struct {
 	RequestedTimestamp *time.Time `json:"requestedTimestamp,omitempty"`
 	// Producer is the entity that produced the odometer data.
 	Producer string `json:"producer,omitempty"`
+	// DataSource is the integration that produced the odometer reading.
+	DataSource *DataSource `json:"dataSource,omitempty"`
+	// Disclosure is the regional odometer disclosure, present only when a disclosure profile was requested.
+	Disclosure *OdometerDisclosure `json:"disclosure,omitempty"`
 }
 
+const (
+	// OdometerUnitKilometers is the kilometers odometer unit.
+	OdometerUnitKilometers = "km"
+	// OdometerUnitMiles is the miles odometer unit.
+	// Values are converted from kilometers using the international mile, exactly 1.609344 km.
+	OdometerUnitMiles = "miles"
+)
+
 // OdometerReading represents an odometer reading with metadata.
 type OdometerReading struct {
 	Value     float64   `json:"value"`
@@ -164,6 +208,37 @@ type OdometerReading struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+const (
+	// DisclosureProfileUSFederal is the US federal odometer disclosure profile (49 CFR Part 580).
+	DisclosureProfileUSFederal = "us-federal"
+
+	// MileageStatusActual certifies the reading reflects the actual mileage of the vehicle.
+	MileageStatusActual = "actualMileage"
+	// MileageStatusExceedsMechanicalLimits indicates the mileage is in excess of the odometer's mechanical limits.
+	MileageStatusExceedsMechanicalLimits = "exceedsMechanicalLimits"
+	// MileageStatusNotActual indicates the reading is not the actual mileage of the vehicle.
+	MileageStatusNotActual = "notActual"
+
+	// ExemptionModelYearAge marks a vehicle exempt from disclosure due to its model year age.
+	ExemptionModelYearAge = "modelYearAge"
+)
+
+// OdometerDisclosure represents the regional disclosure fields of an odometer statement.
+type OdometerDisclosure struct {
+	// Profile is the disclosure profile, e.g. "us-federal".
+	Profile string `json:"profile"`
+	// VehicleIdentificationNumber is the VIN taken from the latest VIN attestation of the vehicle.
+	VehicleIdentificationNumber string `json:"vehicleIdentificationNumber"`
+	// ModelYear is the model year of the vehicle, if known.
+	ModelYear int `json:"modelYear,omitempty"`
+	// MileageStatus is one of "actualMileage", "exceedsMechanicalLimits" or "notActual".
+	MileageStatus string `json:"mileageStatus"`
+	// Exempt indicates the vehicle is exempt from odometer disclosure requirements.
+	Exempt bool `json:"exempt"`
+	// ExemptionReasons lists why the vehicle is exempt.
+	ExemptionReasons []string `json:"exemptionReasons,omitempty"`
+}
+
 // VehicleHealthVCSubject represents the subject of the VehicleHealthVC.
 type VehicleHealthVCSubject struct {
 	VehicleDID cloudevent.ERC721DID `json:"vehicleDID,omitempty"`
@@ -171,8 +246,39 @@ type VehicleHealthVCSubject struct {
 	HealthStatus VehicleHealthStatus `json:"healthStatus"`
 	// SearchedTimeRange is the time range that was searched.
 	SearchedTimeRange TimeRange `json:"searchedTimeRange"`
-	// Producer is the entity that produced the health data.
+	// Producer is the entity that produced the most recent health data.
+	Producer string `json:"producer,omitempty"`
+	// DataSources are the integrations that produced the health data.
+	DataSources []DataSource `json:"dataSources,omitempty"`
+	// DTCDictionaryVersion is the version of the DTC dictionary used to describe the DTCs.
+	DTCDictionaryVersion string `json:"dtcDictionaryVersion,omitempty"`
+}
+
+// BatteryHealthVCSubject represents the subject of the BatteryHealthVC, the state of an EV traction battery.
+type BatteryHealthVCSubject struct {
+	VehicleDID cloudevent.ERC721DID `json:"vehicleDID,omitempty"`
+	// SearchedTimeRange is the time range that was searched.
+	SearchedTimeRange TimeRange `json:"searchedTimeRange"`
+	// GrossCapacity is the gross capacity of the battery in kWh as reported by the vehicle.
+	GrossCapacity *float64 `json:"grossCapacity,omitempty"`
+	// NetCapacity is the usable capacity of the battery in kWh, estimated from the energy stored at each state of charge.
+	NetCapacity *float64 `json:"netCapacity,omitempty"`
+	// StateOfHealth is the capacity of the battery relative to new in percent as reported by the vehicle.
+	StateOfHealth *float64 `json:"stateOfHealth,omitempty"`
+	// FullChargeRange is the battery range in km at 100% state of charge, estimated from the end of charge sessions.
+	FullChargeRange *float64 `json:"fullChargeRange,omitempty"`
+	// ChargeSessions is the number of charge sessions found in the time range.
+	ChargeSessions int `json:"chargeSessions"`
+	// DCFastChargeShare is the percentage of the charged energy that was added by DC fast charging.
+	DCFastChargeShare *float64 `json:"dcFastChargeShare,omitempty"`
+	// EvidenceSampleCount is the number of telemetry samples the attestation is derived from.
+	EvidenceSampleCount int `json:"evidenceSampleCount"`
+	// MethodVersion is the version of the method used to derive the estimates.
+	MethodVersion string `json:"methodVersion"`
+	// Producer is the entity that produced the most recent battery data.
 	Producer string `json:"producer,omitempty"`
+	// DataSources are the integrations that produced the battery data.
+	DataSources []DataSource `json:"dataSources,omitempty"`
 }
 
 // VehicleHealthStatus represents the health status of a vehicle.
@@ -181,20 +287,107 @@ type VehicleHealthStatus struct {
 	DTCs []DiagnosticTroubleCode `json:"dtcs"`
 	// TirePressure contains tire pressure readings.
 	TirePressure *TirePressureStatus `json:"tirePressure,omitempty"`
+	// Components contains the status of the monitored vehicle components, such as the 12V battery and coolant.
+	Components []ComponentHealth `json:"components,omitempty"`
+	// Statistics summarizes each numeric signal over the searched time range.
+	Statistics []SignalStatistics `json:"statistics,omitempty"`
+	// SampleInterval is the aggregation interval of the telemetry the status was derived from, e.g. "5m".
+	SampleInterval string `json:"sampleInterval,omitempty"`
 	// HealthScore is an overall health score (0-100).
 	HealthScore int `json:"healthScore"`
 	// IsHealthy indicates if the vehicle is considered healthy.
 	IsHealthy bool `json:"isHealthy"`
 	// LastUpdated is when this health status was last updated.
 	LastUpdated time.Time `json:"lastUpdated"`
+	// RuleSet is the name of the health rule set that produced the score.
+	RuleSet string `json:"ruleSet,omitempty"`
+	// RuleSetVersion is the version of the health rule set that produced the score.
+	RuleSetVersion string `json:"ruleSetVersion,omitempty"`
+	// Deductions are the points each rule deducted from the score.
+	Deductions []HealthDeduction `json:"deductions,omitempty"`
 }
 
+// SignalStatistics summarizes the samples of a numeric signal.
+type SignalStatistics struct {
+	Signal string  `json:"signal"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Avg    float64 `json:"avg"`
+	Count  int     `json:"count"`
+}
+
+// ComponentHealth is the evaluated status of a single vehicle component.
+type ComponentHealth struct {
+	// Component identifies the component, e.g. "lowVoltageBattery".
+	Component string `json:"component"`
+	// Status is one of "ok", "warning" or "critical".
+	Status string `json:"status"`
+	// Value is the reading the status was evaluated from.
+	Value float64 `json:"value"`
+	// Unit is the unit of Value.
+	Unit string `json:"unit"`
+	// Timestamp is when the evidence reading was taken.
+	Timestamp time.Time `json:"timestamp"`
+	// Signals are the VSS signals the reading was derived from.
+	Signals []string `json:"signals"`
+}
+
+const (
+	// ComponentLowVoltageBattery is the 12V battery, evaluated from its voltage.
+	ComponentLowVoltageBattery = "lowVoltageBattery"
+	// ComponentCoolant is the engine cooling system, evaluated from the highest coolant temperature.
+	ComponentCoolant = "coolant"
+	// ComponentEngineOil is the engine oil, evaluated from its level.
+	ComponentEngineOil = "engineOil"
+	// ComponentBrakePads is the most worn brake pad.
+	ComponentBrakePads = "brakePads"
+	// ComponentTractionBatteryStateOfHealth is the EV traction battery capacity relative to new.
+	ComponentTractionBatteryStateOfHealth = "tractionBatteryStateOfHealth"
+	// ComponentTractionBatteryCellImbalance is the spread between the highest and lowest traction battery cell voltages.
+	ComponentTractionBatteryCellImbalance = "tractionBatteryCellImbalance"
+
+	// ComponentStatusOK is a component reading within its normal range.
+	ComponentStatusOK = "ok"
+	// ComponentStatusWarning is a component reading that needs attention soon.
+	ComponentStatusWarning = "warning"
+	// ComponentStatusCritical is a component reading that needs immediate attention.
+	ComponentStatusCritical = "critical"
+)
+
+// HealthDeduction is the number of points a health rule deducted from the score.
+type HealthDeduction struct {
+	Rule   string `json:"rule"`
+	Points int    `json:"points"`
+}
+
+const (
+	// DTCStatusActive marks a DTC that is still reported by the vehicle.
+	DTCStatusActive = "active"
+	// DTCStatusCleared marks a DTC that the vehicle stopped reporting.
+	DTCStatusCleared = "cleared"
+
+	// DTCCodeMILOn is the pseudo DTC reported when the DTC count indicates the check engine light is on.
+	DTCCodeMILOn = "MIL_ON"
+)
+
 // DiagnosticTroubleCode represents a DTC.
 type DiagnosticTroubleCode struct {
-	Code        string    `json:"code"`
-	Description string    `json:"description,omitempty"`
-	Severity    string    `json:"severity"` // "info", "warning", "critical"
-	Timestamp   time.Time `json:"timestamp"`
+	Code              string    `json:"code"`
+	Description       string    `json:"description,omitempty"`
+	System            string    `json:"system,omitempty"`
+	Severity          string    `json:"severity"` // "info", "warning", "critical"
+	RecommendedAction string    `json:"recommendedAction,omitempty"`
+	Timestamp         time.Time `json:"timestamp"`
+	// FirstSeen is when the code was first reported in the searched time range.
+	FirstSeen time.Time `json:"firstSeen"`
+	// LastSeen is when the code was last reported in the searched time range.
+	LastSeen time.Time `json:"lastSeen"`
+	// Occurrences is the number of separate periods the code was active in, so 1 plus the number of recurrences.
+	Occurrences int `json:"occurrences"`
+	// Status is "active" or "cleared".
+	Status string `json:"status"`
+	// ClearedAt is when the code was first missing from a later DTC sample, set only for cleared codes.
+	ClearedAt *time.Time `json:"clearedAt,omitempty"`
 }
 
 // TirePressureStatus represents tire pressure readings.
@@ -206,4 +399,40 @@ type TirePressureStatus struct {
 	Unit       string    `json:"unit"` // "psi" or "kpa"
 	IsNormal   bool      `json:"isNormal"`
 	Timestamp  time.Time `json:"timestamp"`
+	// LowTires lists the positions below their axle's normal range, e.g. "frontLeft".
+	LowTires []string `json:"lowTires,omitempty"`
+	// HighTires lists the positions above their axle's normal range, e.g. "rearRight".
+	HighTires []string `json:"highTires,omitempty"`
+	// Thresholds are the per-axle thresholds the readings were evaluated against.
+	Thresholds *TirePressureThresholds `json:"thresholds,omitempty"`
+}
+
+const (
+	// TirePositionFrontLeft is the front left tire.
+	TirePositionFrontLeft = "frontLeft"
+	// TirePositionFrontRight is the front right tire.
+	TirePositionFrontRight = "frontRight"
+	// TirePositionRearLeft is the rear left tire.
+	TirePositionRearLeft = "rearLeft"
+	// TirePositionRearRight is the rear right tire.
+	TirePositionRearRight = "rearRight"
+
+	// TireThresholdSourceDeviceDefinition marks placard pressures taken from the vehicle's device definition.
+	TireThresholdSourceDeviceDefinition = "deviceDefinition"
+	// TireThresholdSourceFallback marks placard pressures taken from the configured fallback table.
+	TireThresholdSourceFallback = "fallback"
+	// TireThresholdSourceDefault marks the generic placard pressure used when nothing else is known.
+	TireThresholdSourceDefault = "default"
+)
+
+// TirePressureThresholds are the placard pressures and normal ranges of each axle, in the unit of the readings.
+type TirePressureThresholds struct {
+	// Source is where the placard pressures came from: "deviceDefinition", "fallback" or "default".
+	Source       string  `json:"source"`
+	FrontPlacard float64 `json:"frontPlacard"`
+	FrontMin     float64 `json:"frontMin"`
+	FrontMax     float64 `json:"frontMax"`
+	RearPlacard  float64 `json:"rearPlacard"`
+	RearMin      float64 `json:"rearMin"`
+	RearMax      float64 `json:"rearMax"`
 }